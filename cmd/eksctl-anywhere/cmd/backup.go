@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backup resources",
+	Long:  "Use eksctl anywhere backup to take backups of cluster resources, such as etcd or a whole management cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+)
+
+type clusterBackupOptions struct {
+	clusterOptions
+	wConfig   string
+	backupDir string
+}
+
+var bc = &clusterBackupOptions{}
+
+var backupClusterCmd = &cobra.Command{
+	Use:          "cluster -f <config-file>",
+	Short:        "Backup a management cluster",
+	Long:         "This command saves the cluster-api objects and the EKS-A resources of a management cluster and all the workload clusters it manages, so it can be rebuilt with eksctl anywhere restore cluster",
+	PreRunE:      preRunClusterBackup,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := bc.backupCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to backup cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunClusterBackup(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	backupCmd.AddCommand(backupClusterCmd)
+	backupClusterCmd.Flags().StringVarP(&bc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	backupClusterCmd.Flags().StringVarP(&bc.wConfig, "w-config", "w", "", "Kubeconfig file of the management cluster")
+	backupClusterCmd.Flags().StringVar(&bc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	backupClusterCmd.Flags().StringVar(&bc.backupDir, "backup-dir", "", "Folder to save the backup in. Defaults to <cluster-name>/backups/<timestamp>")
+	if err := backupClusterCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *clusterBackupOptions) backupCluster(ctx context.Context) error {
+	clusterConfig, err := commonValidation(ctx, o.fileName)
+	if err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+	if !clusterConfig.IsSelfManaged() {
+		return fmt.Errorf("cluster %s is not a management cluster, only management clusters can be backed up", clusterConfig.Name)
+	}
+
+	kubeconfigPath := getKubeconfigPath(clusterConfig.Name, o.wConfig)
+	if !validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
+		return kubeconfig.NewMissingFileError(kubeconfigPath)
+	}
+
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	backupDir := o.backupDir
+	if backupDir == "" {
+		backupDir = filepath.Join(clusterSpec.Cluster.Name, "backups", time.Now().UTC().Format("20060102150405"))
+	}
+	writer, err := filewriter.NewWriter(backupDir)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(append(o.mountDirs(), backupDir)...).
+		WithClusterManager(clusterSpec.Cluster).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: kubeconfigPath,
+	}
+
+	return workflows.NewBackup(deps.ClusterManager, writer).Run(ctx, managementCluster)
+}
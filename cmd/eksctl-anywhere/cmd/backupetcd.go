@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+type etcdSnapshotOptions struct {
+	clusterMachinesOptions
+	snapshotName string
+	snapshotsDir string
+	s3Bucket     string
+	s3Prefix     string
+	s3Region     string
+	s3Endpoint   string
+}
+
+var be = &etcdSnapshotOptions{}
+
+var backupEtcdCmd = &cobra.Command{
+	Use:          "etcd",
+	Short:        "Take an etcd snapshot of a cluster",
+	Long:         "This command takes a snapshot of the etcd data of a cluster, with stacked or external etcd, and saves it locally or in an S3 compatible store",
+	PreRunE:      preRunClusterMachines,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := be.backupEtcd(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to backup etcd: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	backupCmd.AddCommand(backupEtcdCmd)
+	be.addFlags(backupEtcdCmd)
+	backupEtcdCmd.Flags().StringVar(&be.snapshotName, "snapshot-name", "", "Name of the etcd snapshot. Defaults to <cluster-name>-etcd-<timestamp>.db")
+}
+
+func (o *etcdSnapshotOptions) addFlags(cmd *cobra.Command) {
+	o.clusterMachinesOptions.addFlags(cmd)
+	cmd.Flags().StringVar(&o.snapshotsDir, "snapshots-dir", "", "Local folder for etcd snapshots. Defaults to <cluster-name>/etcd-snapshots")
+	cmd.Flags().StringVar(&o.s3Bucket, "s3-bucket", "", "S3 bucket for etcd snapshots. When set, snapshots are stored in it instead of the local folder")
+	cmd.Flags().StringVar(&o.s3Prefix, "s3-prefix", "", "Prefix for the etcd snapshot objects in the S3 bucket")
+	cmd.Flags().StringVar(&o.s3Region, "s3-region", "us-east-1", "Region of the S3 bucket")
+	cmd.Flags().StringVar(&o.s3Endpoint, "s3-endpoint", "", "Endpoint of an S3 compatible store, like MinIO")
+}
+
+func (o *etcdSnapshotOptions) backupEtcd(ctx context.Context) error {
+	clusterSpec, err := o.clusterSpec(ctx)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(o.mountDirs()...).
+		WithKubectl().
+		WithDocker().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	etcdBackup, err := o.newEtcdBackup(clusterSpec, deps)
+	if err != nil {
+		return err
+	}
+
+	snapshotName := o.snapshotName
+	if snapshotName == "" {
+		snapshotName = fmt.Sprintf("%s-etcd-%s.db", clusterSpec.Cluster.Name, time.Now().UTC().Format("20060102150405"))
+	}
+
+	return etcdBackup.Backup(ctx, o.managementCluster(clusterSpec), clusterSpec, snapshotName)
+}
+
+func (o *etcdSnapshotOptions) newEtcdBackup(clusterSpec *cluster.Spec, deps *dependencies.Dependencies) (*etcdbackup.EtcdBackup, error) {
+	store, err := o.store(clusterSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	runner, err := o.runner(clusterSpec, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	return etcdbackup.New(deps.Kubectl, runner, store), nil
+}
+
+func (o *etcdSnapshotOptions) store(clusterSpec *cluster.Spec) (etcdbackup.Store, error) {
+	if o.s3Bucket != "" {
+		return etcdbackup.NewS3Store(etcdbackup.S3Config{
+			Bucket:   o.s3Bucket,
+			Prefix:   o.s3Prefix,
+			Region:   o.s3Region,
+			Endpoint: o.s3Endpoint,
+		})
+	}
+
+	dir := o.snapshotsDir
+	if dir == "" {
+		dir = filepath.Join(clusterSpec.Cluster.Name, "etcd-snapshots")
+	}
+	return etcdbackup.NewLocalStore(dir), nil
+}
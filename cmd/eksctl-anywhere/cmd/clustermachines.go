@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const defaultSSHPrivateKeyFileName = "eks-a-id_rsa"
+
+// clusterMachinesOptions are the options shared by the commands that run in the etcd and control plane
+// machines of an existing cluster
+type clusterMachinesOptions struct {
+	clusterOptions
+	wConfig       string
+	sshUsername   string
+	sshKey        string
+	sshKnownHosts string
+	// sshInsecureIgnoreHostKeys skips the verification of the machine host keys
+	sshInsecureIgnoreHostKeys bool
+}
+
+func preRunClusterMachines(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func (o *clusterMachinesOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	cmd.Flags().StringVarP(&o.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster")
+	cmd.Flags().StringVar(&o.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	cmd.Flags().StringVar(&o.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	cmd.Flags().StringVar(&o.sshUsername, "ssh-username", "", "Username to ssh into the cluster machines. Not needed for the docker provider")
+	cmd.Flags().StringVar(&o.sshKey, "ssh-key", "", "Private key to ssh into the cluster machines. Defaults to <cluster-name>/"+defaultSSHPrivateKeyFileName)
+	cmd.Flags().StringVar(&o.sshKnownHosts, "ssh-known-hosts", "", "Known hosts file to verify the cluster machines host keys. Required for providers other than docker unless --ssh-insecure-ignore-host-keys is set")
+	cmd.Flags().BoolVar(&o.sshInsecureIgnoreHostKeys, "ssh-insecure-ignore-host-keys", false, "Connect to the cluster machines without verifying their host keys (not recommended)")
+	if err := cmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *clusterMachinesOptions) clusterSpec(ctx context.Context) (*cluster.Spec, error) {
+	clusterConfig, err := commonValidation(ctx, o.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("common validations failed due to: %v", err)
+	}
+
+	kubeconfigPath := getKubeconfigPath(clusterConfig.Name, o.wConfig)
+	if !validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
+		return nil, kubeconfig.NewMissingFileError(kubeconfigPath)
+	}
+
+	return newClusterSpec(o.clusterOptions)
+}
+
+// managementCluster returns the cluster holding the CAPI objects for the cluster, which is the cluster itself
+// when it's self managed
+func (o *clusterMachinesOptions) managementCluster(clusterSpec *cluster.Spec) *types.Cluster {
+	if clusterSpec.ManagementCluster != nil {
+		return clusterSpec.ManagementCluster
+	}
+	return o.workloadCluster(clusterSpec)
+}
+
+func (o *clusterMachinesOptions) workloadCluster(clusterSpec *cluster.Spec) *types.Cluster {
+	return &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, o.wConfig),
+	}
+}
+
+func (o *clusterMachinesOptions) runner(clusterSpec *cluster.Spec, deps *dependencies.Dependencies) (etcdbackup.NodeRunner, error) {
+	if clusterSpec.Cluster.Spec.DatacenterRef.Kind == v1alpha1.DockerDatacenterKind {
+		return etcdbackup.NewDockerRunner(deps.DockerClient), nil
+	}
+
+	if o.sshUsername == "" {
+		return nil, fmt.Errorf("--ssh-username is required for provider %s", clusterSpec.Cluster.Spec.DatacenterRef.Kind)
+	}
+
+	sshKey := o.sshKey
+	if sshKey == "" {
+		sshKey = filepath.Join(clusterSpec.Cluster.Name, defaultSSHPrivateKeyFileName)
+	}
+
+	if o.sshKnownHosts == "" && !o.sshInsecureIgnoreHostKeys {
+		return nil, fmt.Errorf("--ssh-known-hosts is required for provider %s, use --ssh-insecure-ignore-host-keys to skip the host keys verification", clusterSpec.Cluster.Spec.DatacenterRef.Kind)
+	}
+
+	return etcdbackup.NewSSHRunner(o.sshUsername, sshKey, o.sshKnownHosts, o.sshInsecureIgnoreHostKeys)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
@@ -23,10 +24,12 @@ import (
 
 type createClusterOptions struct {
 	clusterOptions
+	eventsOptions
 	forceClean       bool
 	skipIpCheck      bool
 	hardwareFileName string
 	skipPowerActions bool
+	resume           bool
 }
 
 var cc = &createClusterOptions{}
@@ -51,6 +54,8 @@ func init() {
 	createClusterCmd.Flags().BoolVar(&cc.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
 	createClusterCmd.Flags().StringVar(&cc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	createClusterCmd.Flags().StringVar(&cc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	createClusterCmd.Flags().BoolVar(&cc.resume, "resume", false, "Resume a failed cluster create from the last completed task")
+	cc.eventsOptions.addFlags(createClusterCmd.Flags())
 
 	if err := createClusterCmd.MarkFlagRequired("filename"); err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -112,8 +117,12 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 
 	validations.CheckDockerAllocatedMemory(ctx, docker)
 
+	if cc.resume && cc.forceClean {
+		return fmt.Errorf("--resume and --force-cleanup can't be used together")
+	}
+
 	kubeconfigPath := kubeconfig.FromClusterName(clusterConfig.Name)
-	if validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
+	if !cc.resume && validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
 		return fmt.Errorf(
 			"old cluster config file exists under %s, please use a different clusterName to proceed",
 			clusterConfig.Name,
@@ -128,7 +137,8 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(cc.mountDirs()...).
 		WithBootstrapper().
 		WithClusterManager(clusterSpec.Cluster).
-		WithProvider(cc.fileName, clusterSpec.Cluster, cc.skipIpCheck, cc.hardwareFileName, cc.skipPowerActions).
+		// The control plane ip is expected to be in use when resuming
+		WithProvider(cc.fileName, clusterSpec.Cluster, cc.skipIpCheck || cc.resume, cc.hardwareFileName, cc.skipPowerActions).
 		WithFluxAddonClient(ctx, clusterSpec.Cluster, clusterSpec.GitOpsConfig).
 		WithWriter().
 		Build(ctx)
@@ -149,12 +159,26 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		return fmt.Errorf("provider snow is not supported in this release")
 	}
 
+	if !features.IsActive(features.CalicoCNI()) && clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig != nil && clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Calico != nil {
+		return fmt.Errorf("cni calico is not supported in this release")
+	}
+
+	events, eventsCloser, err := cc.eventSink()
+	if err != nil {
+		return err
+	}
+	if eventsCloser != nil {
+		defer eventsCloser.Close()
+	}
+
 	createCluster := workflows.NewCreate(
 		deps.Bootstrapper,
 		deps.Provider,
 		deps.ClusterManager,
 		deps.FluxAddonClient,
 		deps.Writer,
+		workflows.WithCreateCheckpoints(task.NewFileCheckpointStore(deps.Writer)),
+		workflows.WithCreateTaskEvents(events),
 	)
 
 	var cluster *types.Cluster
@@ -182,5 +206,8 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	}
 	createValidations := createvalidations.New(validationOpts)
 
+	if cc.resume {
+		return createCluster.Resume(ctx, clusterSpec, createValidations)
+	}
 	return createCluster.Run(ctx, clusterSpec, createValidations, cc.forceClean)
 }
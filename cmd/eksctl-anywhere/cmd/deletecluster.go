@@ -20,6 +20,7 @@ import (
 
 type deleteClusterOptions struct {
 	clusterOptions
+	eventsOptions
 	wConfig          string
 	forceCleanup     bool
 	hardwareFileName string
@@ -61,6 +62,7 @@ func init() {
 	deleteClusterCmd.Flags().BoolVar(&dc.forceCleanup, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	deleteClusterCmd.Flags().StringVar(&dc.managementKubeconfig, "kubeconfig", "", "kubeconfig file pointing to a management cluster")
 	deleteClusterCmd.Flags().StringVar(&dc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	dc.eventsOptions.addFlags(deleteClusterCmd.Flags())
 }
 
 func (dc *deleteClusterOptions) validate(ctx context.Context, args []string) error {
@@ -113,11 +115,20 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 		return fmt.Errorf("Error: provider tinkerbell is not supported in this release")
 	}
 
+	events, eventsCloser, err := dc.eventSink()
+	if err != nil {
+		return err
+	}
+	if eventsCloser != nil {
+		defer eventsCloser.Close()
+	}
+
 	deleteCluster := workflows.NewDelete(
 		deps.Bootstrapper,
 		deps.Provider,
 		deps.ClusterManager,
 		deps.FluxAddonClient,
+		workflows.WithDeleteTaskEvents(events),
 	)
 
 	var cluster *types.Cluster
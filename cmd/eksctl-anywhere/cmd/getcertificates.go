@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+)
+
+type certificatesOptions struct {
+	clusterMachinesOptions
+	rotationMethod string
+}
+
+var gco = &certificatesOptions{}
+
+var getCertificatesCmd = &cobra.Command{
+	Use:          "certificates",
+	Short:        "Get the certificates of a cluster",
+	Long:         "This command shows when the control plane, etcd and aws-iam-authenticator certificates of a cluster expire",
+	PreRunE:      preRunClusterMachines,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gco.getCertificates(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to get certificates: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getCertificatesCmd)
+	gco.addFlags(getCertificatesCmd)
+}
+
+func (o *certificatesOptions) getCertificates(ctx context.Context) error {
+	clusterSpec, err := o.clusterSpec(ctx)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(o.mountDirs()...).
+		WithKubectl().
+		WithDocker().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	runner, err := o.runner(clusterSpec, deps)
+	if err != nil {
+		return err
+	}
+
+	certs, err := certificates.New(deps.Kubectl, runner, nil).Get(ctx, o.managementCluster(clusterSpec), clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	table, err := serializeCertificates(certs, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Print(table)
+
+	return nil
+}
+
+func serializeCertificates(certs []certificates.Certificate, now time.Time) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tCERTIFICATE\tEXPIRES\tRESIDUAL TIME")
+	for _, cert := range certs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", cert.Node, cert.Path, cert.NotAfter.UTC().Format(time.RFC3339), residualTime(cert.NotAfter, now))
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+func residualTime(notAfter, now time.Time) string {
+	if !notAfter.After(now) {
+		return "expired"
+	}
+	return fmt.Sprintf("%dd", int(notAfter.Sub(now).Hours()/24))
+}
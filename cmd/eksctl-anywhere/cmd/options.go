@@ -2,9 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 
+	"github.com/spf13/pflag"
+
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
 
@@ -43,3 +48,40 @@ func newClusterSpec(options clusterOptions) (*cluster.Spec, error) {
 
 	return clusterSpec, nil
 }
+
+type eventsOptions struct {
+	eventsFile string
+	eventsFd   int
+}
+
+func (e *eventsOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&e.eventsFile, "events-file", "", "File to write task events to as json lines")
+	flags.IntVar(&e.eventsFd, "events-fd", -1, "Open file descriptor to write task events to as json lines")
+}
+
+// eventSink opens the destination selected with --events-file or --events-fd. It returns a nil sink
+// when neither is set. The returned closer must be called once the workflow is done
+func (e *eventsOptions) eventSink() (task.EventSink, io.Closer, error) {
+	if e.eventsFile != "" && e.eventsFd >= 0 {
+		return nil, nil, fmt.Errorf("--events-file and --events-fd can't be used together")
+	}
+
+	var f *os.File
+	switch {
+	case e.eventsFile != "":
+		var err error
+		f, err = os.OpenFile(e.eventsFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("opening events file: %v", err)
+		}
+	case e.eventsFd >= 0:
+		f = os.NewFile(uintptr(e.eventsFd), fmt.Sprintf("fd%d", e.eventsFd))
+		if f == nil {
+			return nil, nil, fmt.Errorf("invalid events file descriptor %d", e.eventsFd)
+		}
+	default:
+		return nil, nil, nil
+	}
+
+	return task.NewJSONLinesSink(f), f, nil
+}
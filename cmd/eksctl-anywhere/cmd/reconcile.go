@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Reconcile resources",
+	Long:  "Use eksctl anywhere reconcile to make existing resources match their source of truth, such as the hardware registered with Tinkerbell",
+}
+
+func init() {
+	rootCmd.AddCommand(reconcileCmd)
+}
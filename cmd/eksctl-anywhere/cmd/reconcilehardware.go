@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type reconcileHardwareOptions struct {
+	hardwareOptions
+	kubeconfig    string
+	deleteRemoved bool
+	dryRun        bool
+}
+
+var rhOpts = &reconcileHardwareOptions{}
+
+var reconcileHardwareCmd = &cobra.Command{
+	Use:   "hardware",
+	Short: "Reconcile the hardware registered with Tinkerbell",
+	Long: `
+Compare a hardware CSV inventory with the hardware registered in a Tinkerbell
+stack, matching machines by ID and MAC address. New machines are registered and
+machines with a different network configuration are updated. Registered hardware
+missing from the inventory is only deregistered with --delete-removed. Hardware
+bound to a pending or running workflow is never changed.
+
+Tinkerbell doesn't store BMC configuration, so the BMC configuration is compared
+with the hardware YAML manifest written by the previous run in the output directory.
+When anything changed, the manifest is regenerated for the inventory, with the IDs of the
+registered hardware and without the skipped hardware, and, with --kubeconfig, applied to
+the management cluster. Changes to the BMC configuration
+require --kubeconfig.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rhOpts.reconcileHardware(cmd.Context())
+	},
+}
+
+func init() {
+	reconcileCmd.AddCommand(reconcileHardwareCmd)
+
+	flags := reconcileHardwareCmd.Flags()
+
+	flags.StringVarP(&rhOpts.csvPath, generateHardwareFilenameFlagName, "f", "", "CSV file path")
+	if err := reconcileHardwareCmd.MarkFlagRequired(generateHardwareFilenameFlagName); err != nil {
+		panic(err)
+	}
+
+	flags.StringVar(&rhOpts.tinkerbellIp, generateHardwareTinkerbellIpFlagName, "", "Tinkerbell stack IP address")
+	if err := reconcileHardwareCmd.MarkFlagRequired(generateHardwareTinkerbellIpFlagName); err != nil {
+		panic(err)
+	}
+
+	flags.StringVarP(&rhOpts.outputPath, "output", "o", "", "directory path to output the hardware YAML manifest")
+	flags.StringVar(&rhOpts.kubeconfig, "kubeconfig", "", "Management cluster kubeconfig file to apply the hardware YAML manifest to")
+	flags.StringVar(&rhOpts.grpcPort, "grpc-port", defaultGrpcPort, "Tinkerbell GRPC Authority port")
+	flags.StringVar(&rhOpts.certPort, "cert-port", defaultCertPort, "Tinkerbell Cert URL port")
+	flags.BoolVar(&rhOpts.deleteRemoved, "delete-removed", false, "deregister the hardware that is not in the CSV inventory")
+	flags.BoolVar(&rhOpts.dryRun, "dry-run", false, "print the changes without applying them")
+}
+
+func (rhOpts *reconcileHardwareOptions) reconcileHardware(ctx context.Context) error {
+	if err := validateOptions(&rhOpts.hardwareOptions); err != nil {
+		return err
+	}
+
+	csvFile, err := os.Open(rhOpts.csvPath)
+	if err != nil {
+		return fmt.Errorf("csv: %v", err)
+	}
+	defer csvFile.Close()
+
+	reader, err := hardware.NewCsvReader(csvFile)
+	if err != nil {
+		return fmt.Errorf("csv: %v", err)
+	}
+
+	var inventory hardware.MachineCollector
+	if err := hardware.TranslateAll(reader, &inventory, hardware.NewDefaultMachineValidator()); err != nil {
+		return err
+	}
+
+	outputDir := rhOpts.outputPath
+	if outputDir == "" {
+		outputDir = hardware.DefaultManifestDir
+	}
+	manifestPath := filepath.Join(outputDir, hardware.DefaultHardwareManifestYamlFilename)
+
+	appliedBmc, err := readAppliedBmcConfigs(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	if !rhOpts.dryRun {
+		if _, err := hardware.CreateManifestDir(outputDir); err != nil {
+			return err
+		}
+	}
+
+	tink, kubectl, closer, err := rhOpts.executables(ctx, outputDir)
+	if err != nil {
+		return err
+	}
+	defer closer.Close(ctx)
+
+	registered, err := tink.GetHardware(ctx)
+	if err != nil {
+		return err
+	}
+
+	workflows, err := tink.GetWorkflow(ctx)
+	if err != nil {
+		return err
+	}
+
+	plan, err := hardware.NewReconcilePlan(inventory, registered, workflows, appliedBmc, rhOpts.deleteRemoved)
+	if err != nil {
+		return err
+	}
+	printReconcilePlan(plan)
+
+	if rhOpts.dryRun || plan.IsEmpty() {
+		return nil
+	}
+
+	if len(plan.UpdateBmc) > 0 && rhOpts.kubeconfig == "" {
+		return fmt.Errorf("the BMC configuration of %d machines changed, --kubeconfig is required to apply the hardware YAML manifest", len(plan.UpdateBmc))
+	}
+
+	if err := hardware.ReconcileTinkerbellHardware(ctx, tink, plan); err != nil {
+		return err
+	}
+
+	manifest, err := writeHardwareManifest(outputDir, plan.Machines)
+	if err != nil {
+		return err
+	}
+
+	if rhOpts.kubeconfig != "" {
+		if err := kubectl.ApplyHardware(ctx, manifest, rhOpts.kubeconfig); err != nil {
+			return err
+		}
+	}
+
+	logger.MarkSuccess("Hardware reconciled", "manifest", manifest)
+	return nil
+}
+
+// executables builds the tink and kubectl executables, mounting the manifest output directory and the kubeconfig
+// directory so kubectl can apply the manifest
+func (rhOpts *reconcileHardwareOptions) executables(ctx context.Context, outputDir string) (*executables.Tink, *executables.Kubectl, types.Closer, error) {
+	var mountDirs []string
+	if !rhOpts.dryRun && rhOpts.kubeconfig != "" {
+		for _, dir := range []string{outputDir, filepath.Dir(rhOpts.kubeconfig)} {
+			absDir, err := filepath.Abs(dir)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("mount directory: %v", err)
+			}
+			mountDirs = append(mountDirs, absDir)
+		}
+	}
+
+	executableBuilder, close, err := executables.NewExecutableBuilder(ctx, executables.DefaultEksaImage(), mountDirs...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("initialize executables: %v", err)
+	}
+
+	cert := fmt.Sprintf("http://%s:%s/cert", rhOpts.tinkerbellIp, rhOpts.certPort)
+	grpc := fmt.Sprintf("%s:%s", rhOpts.tinkerbellIp, rhOpts.grpcPort)
+
+	return executableBuilder.BuildTinkExecutable(cert, grpc), executableBuilder.BuildKubectlExecutable(), close, nil
+}
+
+// readAppliedBmcConfigs reads the BMC configuration in the hardware YAML manifest of the previous run.
+// It returns nil when there is no previous manifest
+func readAppliedBmcConfigs(manifestPath string) (map[string]hardware.Machine, error) {
+	manifest, err := os.Open(manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tinkerbell manifest yaml: %v", err)
+	}
+	defer manifest.Close()
+
+	return hardware.ReadBmcConfigs(manifest)
+}
+
+func printReconcilePlan(plan *hardware.ReconcilePlan) {
+	for _, m := range plan.Create {
+		logger.Info("Registering new hardware", "id", m.Id, "hostname", m.Hostname, "mac", m.MacAddress)
+	}
+	for _, m := range plan.Update {
+		logger.Info("Updating hardware", "id", m.Id, "hostname", m.Hostname, "mac", m.MacAddress)
+	}
+	for _, m := range plan.UpdateBmc {
+		logger.Info("Updating BMC configuration", "id", m.Id, "hostname", m.Hostname, "bmcIp", m.BmcIpAddress)
+	}
+	for _, h := range plan.Delete {
+		logger.Info("Deregistering hardware", "id", h.Id)
+	}
+	for _, id := range plan.Skipped {
+		logger.Info("Warning: skipping hardware bound to an active workflow", "id", id)
+	}
+	if plan.IsEmpty() {
+		logger.Info("Registered hardware is up to date")
+	}
+}
+
+// writeHardwareManifest regenerates the hardware YAML manifest, with the BMC configuration, for machines
+func writeHardwareManifest(outputDir string, machines []hardware.Machine) (string, error) {
+	path := filepath.Join(outputDir, hardware.DefaultHardwareManifestYamlFilename)
+	hardwareYaml, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("tinkerbell manifest yaml: %v", err)
+	}
+	defer hardwareYaml.Close()
+
+	yamlWriter := hardware.NewTinkerbellManifestYaml(hardwareYaml)
+	for _, m := range machines {
+		if err := yamlWriter.Write(m); err != nil {
+			return "", err
+		}
+	}
+
+	return path, nil
+}
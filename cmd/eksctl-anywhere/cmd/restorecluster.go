@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+)
+
+var rc = &clusterBackupOptions{}
+
+var restoreClusterCmd = &cobra.Command{
+	Use:          "cluster -f <config-file> --backup-dir <backup-dir>",
+	Short:        "Restore a management cluster from a backup",
+	Long:         "This command rebuilds a management cluster saved with eksctl anywhere backup cluster on a new bootstrap cluster, which takes over the management of the workload clusters",
+	PreRunE:      preRunClusterBackup,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rc.restoreCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to restore cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreClusterCmd)
+	restoreClusterCmd.Flags().StringVarP(&rc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	restoreClusterCmd.Flags().StringVar(&rc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	restoreClusterCmd.Flags().StringVar(&rc.backupDir, "backup-dir", "", "Folder with the backup taken with eksctl anywhere backup cluster")
+	for _, flag := range []string{"filename", "backup-dir"} {
+		if err := restoreClusterCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (o *clusterBackupOptions) restoreCluster(ctx context.Context) error {
+	clusterConfig, err := commonValidation(ctx, o.fileName)
+	if err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+	if !clusterConfig.IsSelfManaged() {
+		return fmt.Errorf("cluster %s is not a management cluster, only management clusters can be restored", clusterConfig.Name)
+	}
+
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(append(o.mountDirs(), o.backupDir)...).
+		WithBootstrapper().
+		WithClusterManager(clusterSpec.Cluster).
+		WithProvider(o.fileName, clusterSpec.Cluster, true, "", true).
+		WithWriter().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup(ctx, deps, &err)
+
+	err = workflows.NewRestore(deps.Bootstrapper, deps.Provider, deps.ClusterManager).Run(ctx, clusterSpec, o.backupDir)
+	return err
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+)
+
+var re = &etcdSnapshotOptions{}
+
+var restoreEtcdCmd = &cobra.Command{
+	Use:          "etcd",
+	Short:        "Restore the etcd data of a cluster from a snapshot",
+	Long:         "This command rebuilds all the etcd members of a cluster, with stacked or external etcd, from a snapshot taken with eksctl anywhere backup etcd",
+	PreRunE:      preRunClusterMachines,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := re.restoreEtcd(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to restore etcd: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreEtcdCmd)
+	re.addFlags(restoreEtcdCmd)
+	restoreEtcdCmd.Flags().StringVar(&re.snapshotName, "snapshot-name", "", "Name of the etcd snapshot to restore")
+	if err := restoreEtcdCmd.MarkFlagRequired("snapshot-name"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *etcdSnapshotOptions) restoreEtcd(ctx context.Context) error {
+	clusterSpec, err := o.clusterSpec(ctx)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(o.mountDirs()...).
+		WithKubectl().
+		WithDocker().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	etcdBackup, err := o.newEtcdBackup(clusterSpec, deps)
+	if err != nil {
+		return err
+	}
+
+	return etcdBackup.Restore(ctx, o.managementCluster(clusterSpec), clusterSpec, o.snapshotName)
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var rotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Rotate resources",
+	Long:  "Use eksctl anywhere rotate to issue new credentials for a cluster, such as its certificates",
+}
+
+func init() {
+	rootCmd.AddCommand(rotateCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+)
+
+var rco = &certificatesOptions{}
+
+var rotateCertificatesCmd = &cobra.Command{
+	Use:          "certificates",
+	Short:        "Rotate the certificates of a cluster",
+	Long:         "This command issues new control plane, external etcd and aws-iam-authenticator certificates for a cluster, replacing its control plane machines or renewing the certificates in place, and refreshes its kubeconfig",
+	PreRunE:      preRunClusterMachines,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rco.rotateCertificates(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to rotate certificates: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rotateCmd.AddCommand(rotateCertificatesCmd)
+	rco.addFlags(rotateCertificatesCmd)
+	rotateCertificatesCmd.Flags().StringVar(&rco.rotationMethod, "method", string(certificates.RolloutRotation),
+		fmt.Sprintf("How to rotate the certificates: %s replaces the control plane machines, %s renews the certificates in the existing ones",
+			certificates.RolloutRotation, certificates.InPlaceRotation))
+}
+
+func (o *certificatesOptions) rotateCertificates(ctx context.Context) error {
+	clusterSpec, err := o.clusterSpec(ctx)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(o.mountDirs()...).
+		WithClusterManager(clusterSpec.Cluster).
+		WithProvider(o.fileName, clusterSpec.Cluster, false, "", false).
+		WithKubectl().
+		WithDocker().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	runner, err := o.runner(clusterSpec, deps)
+	if err != nil {
+		return err
+	}
+
+	rotation := certificates.New(deps.Kubectl, runner, deps.ClusterManager)
+	return rotation.Rotate(ctx, o.managementCluster(clusterSpec), o.workloadCluster(clusterSpec), clusterSpec, deps.Provider, certificates.RotationMethod(o.rotationMethod))
+}
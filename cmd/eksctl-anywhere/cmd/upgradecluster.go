@@ -12,6 +12,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
@@ -20,9 +21,13 @@ import (
 
 type upgradeClusterOptions struct {
 	clusterOptions
+	eventsOptions
 	wConfig          string
 	forceClean       bool
 	hardwareFileName string
+	resume           bool
+	dryRun           bool
+	rollback         bool
 }
 
 var uc = &upgradeClusterOptions{}
@@ -58,6 +63,11 @@ func init() {
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	upgradeClusterCmd.Flags().StringVar(&uc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	upgradeClusterCmd.Flags().StringVar(&uc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	upgradeClusterCmd.Flags().BoolVar(&uc.resume, "resume", false, "Resume a failed cluster upgrade from the last completed task")
+	uc.eventsOptions.addFlags(upgradeClusterCmd.Flags())
+	upgradeClusterCmd.Flags().BoolVar(&uc.dryRun, "dry-run", false, "Print the changes the upgrade would make to the cluster objects without applying them")
+	upgradeClusterCmd.Flags().BoolVar(&uc.rollback, "rollback", false, "Roll back a failed cluster upgrade to the cluster state saved before the upgrade")
+	upgradeClusterCmd.Flags().StringVarP(&output, outputFlagName, "o", outputDefault, "Output format for --dry-run: text|json")
 	err := upgradeClusterCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -65,6 +75,12 @@ func init() {
 }
 
 func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
+	if uc.resume && uc.forceClean {
+		return fmt.Errorf("--resume and --force-cleanup can't be used together")
+	}
+	if uc.rollback && (uc.resume || uc.dryRun || uc.forceClean) {
+		return fmt.Errorf("--rollback can't be used with --resume, --dry-run or --force-cleanup")
+	}
 	if _, err := uc.commonValidations(ctx); err != nil {
 		return fmt.Errorf("common validations failed due to: %v", err)
 	}
@@ -91,6 +107,19 @@ func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
 		return fmt.Errorf("Error: upgrade operation is not supported for provider tinkerbell")
 	}
 
+	if uc.dryRun {
+		err = uc.upgradeClusterDryRun(ctx, deps, clusterSpec)
+		return err
+	}
+
+	events, eventsCloser, err := uc.eventSink()
+	if err != nil {
+		return err
+	}
+	if eventsCloser != nil {
+		defer eventsCloser.Close()
+	}
+
 	upgradeCluster := workflows.NewUpgrade(
 		deps.Bootstrapper,
 		deps.Provider,
@@ -98,6 +127,8 @@ func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
 		deps.ClusterManager,
 		deps.FluxAddonClient,
 		deps.Writer,
+		workflows.WithUpgradeCheckpoints(task.NewFileCheckpointStore(deps.Writer)),
+		workflows.WithUpgradeTaskEvents(events),
 	)
 
 	workloadCluster := &types.Cluster{
@@ -118,6 +149,11 @@ func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
 		}
 	}
 
+	if uc.rollback {
+		err = workflows.NewRollback(deps.Provider, deps.ClusterManager, deps.FluxAddonClient).Run(ctx, clusterSpec, cluster)
+		return err
+	}
+
 	validationOpts := &validations.Opts{
 		Kubectl:           deps.Kubectl,
 		Spec:              clusterSpec,
@@ -127,6 +163,10 @@ func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
 	}
 	upgradeValidations := upgradevalidations.New(validationOpts)
 
+	if uc.resume {
+		err = upgradeCluster.Resume(ctx, clusterSpec, cluster, upgradeValidations)
+		return err
+	}
 	err = upgradeCluster.Run(ctx, clusterSpec, cluster, upgradeValidations, uc.forceClean)
 	return err
 }
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/manifestdiff"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func (uc *upgradeClusterOptions) upgradeClusterDryRun(ctx context.Context, deps *dependencies.Dependencies, clusterSpec *cluster.Spec) error {
+	// The management cluster holds both the CAPI and the EKS-A objects, for self-managed clusters it's the cluster itself
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, uc.wConfig),
+	}
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	if err := deps.Provider.SetupAndValidateUpgradeCluster(ctx, managementCluster, clusterSpec); err != nil {
+		return err
+	}
+
+	diffs, err := deps.ClusterManager.DryRunUpgradeCluster(ctx, managementCluster, managementCluster, clusterSpec, deps.Provider)
+	if err != nil {
+		return err
+	}
+
+	serializedDiffs, err := serializeObjectDiffs(diffs, output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(serializedDiffs)
+
+	return nil
+}
+
+func serializeObjectDiffs(diffs []manifestdiff.ObjectDiff, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeObjectDiffsToText(diffs), nil
+	case outputJson:
+		if diffs == nil {
+			diffs = []manifestdiff.ObjectDiff{}
+		}
+		jsonDiffs, err := json.Marshal(diffs)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the objects diff to json: %v", err)
+		}
+		return string(jsonDiffs), nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeObjectDiffsToText(diffs []manifestdiff.ObjectDiff) string {
+	buffer := bytes.Buffer{}
+	var changed, rollouts int
+	for _, diff := range diffs {
+		if diff.Operation == manifestdiff.Unchanged {
+			continue
+		}
+		changed++
+
+		fmt.Fprintf(&buffer, "%s %s/%s: %s", diff.Kind, diff.Namespace, diff.Name, diff.Operation)
+		if diff.RollingReplacement {
+			rollouts++
+			fmt.Fprint(&buffer, " (triggers rolling replacement)")
+		}
+		fmt.Fprintln(&buffer)
+
+		for _, change := range diff.Changes {
+			fmt.Fprintf(&buffer, "    %s: %s -> %s\n", change.Path, formatValue(change.Current), formatValue(change.Desired))
+		}
+		for _, reason := range diff.RolloutReasons {
+			fmt.Fprintf(&buffer, "    rollout: %s\n", reason)
+		}
+	}
+
+	if changed == 0 {
+		return "No changes to cluster objects\n"
+	}
+	fmt.Fprintf(&buffer, "\n%d objects changed, %d trigger a rolling replacement of machines\n", changed, rollouts)
+
+	return buffer.String()
+}
+
+func formatValue(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		content, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprintf("%v", value)
+		}
+		return string(content)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
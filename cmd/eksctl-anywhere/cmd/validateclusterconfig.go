@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type validateClusterConfigOptions struct {
+	fileName string
+	output   string
+}
+
+var vcc = &validateClusterConfigOptions{}
+
+var validateClusterConfigCmd = &cobra.Command{
+	Use:          "cluster-config -f <cluster-config-file> [flags]",
+	Short:        "Validate a cluster config file",
+	Long:         "This command parses, defaults and validates all the objects in a cluster config file without accessing any infrastructure, and reports the errors found for each object",
+	PreRunE:      preRunValidateClusterConfig,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return vcc.validateClusterConfig()
+	},
+}
+
+func preRunValidateClusterConfig(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	validateCmd.AddCommand(validateClusterConfigCmd)
+	validateClusterConfigCmd.Flags().StringVarP(&vcc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	validateClusterConfigCmd.Flags().StringVarP(&vcc.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	if err := validateClusterConfigCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (vcc *validateClusterConfigOptions) validateClusterConfig() error {
+	if !validations.FileExists(vcc.fileName) {
+		return fmt.Errorf("the cluster config file %s does not exist", vcc.fileName)
+	}
+
+	content, err := ioutil.ReadFile(vcc.fileName)
+	if err != nil {
+		return fmt.Errorf("reading cluster config file: %v", err)
+	}
+
+	report := cluster.ValidateConfigManifest(content)
+	serializedReport, err := serializeValidationReport(report, vcc.output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(serializedReport)
+
+	if !report.Valid {
+		return fmt.Errorf("cluster config file %s is invalid: found %d error(s)", vcc.fileName, len(report.Errors))
+	}
+
+	return nil
+}
+
+func serializeValidationReport(report *cluster.ValidationReport, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return validationReportToText(report), nil
+	case outputJson:
+		jsonReport, err := json.Marshal(report)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the validation report to json: %v", err)
+		}
+		return string(jsonReport) + "\n", nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func validationReportToText(report *cluster.ValidationReport) string {
+	if report.Valid {
+		return "Cluster config is valid\n"
+	}
+
+	buffer := bytes.Buffer{}
+	for _, e := range report.Errors {
+		fmt.Fprintf(&buffer, "%s\n", e)
+	}
+
+	return buffer.String()
+}
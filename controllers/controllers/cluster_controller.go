@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -11,16 +12,20 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/aws/eks-anywhere/controllers/controllers/clusters"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 )
@@ -32,39 +37,100 @@ const (
 
 // ClusterReconciler reconciles a Cluster object
 type ClusterReconciler struct {
-	client    client.Client
-	log       logr.Logger
-	validator *vsphere.Validator
-	defaulter *vsphere.Defaulter
-	tracker   *remote.ClusterCacheTracker
+	client     client.Client
+	log        logr.Logger
+	validator  *vsphere.Validator
+	defaulter  *vsphere.Defaulter
+	cmkBuilder clusters.CmkBuilder
+	helm       cilium.Helm
+	tracker    *remote.ClusterCacheTracker
 }
 
-func NewClusterReconciler(client client.Client, log logr.Logger, scheme *runtime.Scheme, govc *executables.Govc, tracker *remote.ClusterCacheTracker) *ClusterReconciler {
+func NewClusterReconciler(client client.Client, log logr.Logger, scheme *runtime.Scheme, govc *executables.Govc, cmkBuilder clusters.CmkBuilder, helm cilium.Helm, tracker *remote.ClusterCacheTracker) *ClusterReconciler {
 	validator := vsphere.NewValidator(govc, &networkutils.DefaultNetClient{})
 	defaulter := vsphere.NewDefaulter(govc)
 
 	return &ClusterReconciler{
-		client:    client,
-		log:       log,
-		validator: validator,
-		defaulter: defaulter,
-		tracker:   tracker,
+		client:     client,
+		log:        log,
+		validator:  validator,
+		defaulter:  defaulter,
+		cmkBuilder: cmkBuilder,
+		helm:       helm,
+		tracker:    tracker,
 	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(context.Background(), &anywherev1.Cluster{}, clusters.DatacenterRefIndex, clusters.IndexDatacenterRef); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(context.Background(), &anywherev1.Cluster{}, clusters.MachineGroupRefsIndex, clusters.IndexMachineGroupRefs); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(context.Background(), &anywherev1.TinkerbellMachineConfig{}, clusters.TemplateRefIndex, clusters.IndexTemplateRef); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&anywherev1.Cluster{}).
-		// Watches(&source.Kind{Type: &anywherev1.VSphereDatacenterConfig{}}, &handler.EnqueueRequestForObject{}).
-		// Watches(&source.Kind{Type: &anywherev1.VSphereMachineConfig{}}, &handler.EnqueueRequestForObject{}).
+		Watches(&source.Kind{Type: &anywherev1.VSphereDatacenterConfig{}}, r.enqueueClustersForConfig(anywherev1.VSphereDatacenterKind, clusters.DatacenterRefIndex)).
+		Watches(&source.Kind{Type: &anywherev1.VSphereMachineConfig{}}, r.enqueueClustersForConfig(anywherev1.VSphereMachineConfigKind, clusters.MachineGroupRefsIndex)).
+		Watches(&source.Kind{Type: &anywherev1.DockerDatacenterConfig{}}, r.enqueueClustersForConfig(anywherev1.DockerDatacenterKind, clusters.DatacenterRefIndex)).
+		Watches(&source.Kind{Type: &anywherev1.CloudStackDatacenterConfig{}}, r.enqueueClustersForConfig(anywherev1.CloudStackDatacenterKind, clusters.DatacenterRefIndex)).
+		Watches(&source.Kind{Type: &anywherev1.CloudStackMachineConfig{}}, r.enqueueClustersForConfig(anywherev1.CloudStackMachineConfigKind, clusters.MachineGroupRefsIndex)).
+		Watches(&source.Kind{Type: &anywherev1.SnowDatacenterConfig{}}, r.enqueueClustersForConfig(anywherev1.SnowDatacenterKind, clusters.DatacenterRefIndex)).
+		Watches(&source.Kind{Type: &anywherev1.SnowMachineConfig{}}, r.enqueueClustersForConfig(anywherev1.SnowMachineConfigKind, clusters.MachineGroupRefsIndex)).
+		Watches(&source.Kind{Type: &anywherev1.TinkerbellDatacenterConfig{}}, r.enqueueClustersForConfig(anywherev1.TinkerbellDatacenterKind, clusters.DatacenterRefIndex)).
+		Watches(&source.Kind{Type: &anywherev1.TinkerbellMachineConfig{}}, r.enqueueClustersForConfig(anywherev1.TinkerbellMachineConfigKind, clusters.MachineGroupRefsIndex)).
+		Watches(&source.Kind{Type: &anywherev1.TinkerbellTemplateConfig{}}, handler.EnqueueRequestsFromMapFunc(r.clustersForTemplateConfig)).
 		Complete(r)
 }
 
-// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;bundles;awsiamconfigs,verbs=get;list;watch;create;update;patch;delete
+// enqueueClustersForConfig enqueues the clusters referencing a config object of the given kind
+func (r *ClusterReconciler) enqueueClustersForConfig(kind, index string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(r.clustersForConfig(kind, index))
+}
+
+// clustersForConfig maps a config object of the given kind to the clusters referencing it, found through
+// the Cluster field index for that kind of reference
+func (r *ClusterReconciler) clustersForConfig(kind, index string) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		clusterList := &anywherev1.ClusterList{}
+		if err := r.client.List(context.Background(), clusterList, client.InNamespace(o.GetNamespace()), client.MatchingFields{index: clusters.ConfigRefKey(kind, o.GetName())}); err != nil {
+			r.log.Error(err, "Failed listing clusters for config", "kind", kind, "name", o.GetName())
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(clusterList.Items))
+		for _, cluster := range clusterList.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}})
+		}
+		return requests
+	}
+}
+
+// clustersForTemplateConfig maps a TinkerbellTemplateConfig to the clusters referencing the machine configs that use it
+func (r *ClusterReconciler) clustersForTemplateConfig(o client.Object) []reconcile.Request {
+	machineConfigs := &anywherev1.TinkerbellMachineConfigList{}
+	if err := r.client.List(context.Background(), machineConfigs, client.InNamespace(o.GetNamespace()), client.MatchingFields{clusters.TemplateRefIndex: o.GetName()}); err != nil {
+		r.log.Error(err, "Failed listing machine configs for template config", "name", o.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range machineConfigs.Items {
+		requests = append(requests, r.clustersForConfig(anywherev1.TinkerbellMachineConfigKind, clusters.MachineGroupRefsIndex)(&machineConfigs.Items[i])...)
+	}
+	return requests
+}
+
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;cloudstackdatacenterconfigs;cloudstackmachineconfigs;snowdatacenterconfigs;snowmachineconfigs;tinkerbelldatacenterconfigs;tinkerbellmachineconfigs;tinkerbelltemplateconfigs;bundles;awsiamconfigs;gitopsconfigs;fluxconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=oidcconfigs,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/status;vspheredatacenterconfigs/status;vspheremachineconfigs/status;dockerdatacenterconfigs/status;bundles/status;awsiamconfigs/status,verbs=;get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/finalizers;vspheredatacenterconfigs/finalizers;vspheremachineconfigs/finalizers;dockerdatacenterconfigs/finalizers;bundles/finalizers;awsiamconfigs/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/status;vspheredatacenterconfigs/status;vspheremachineconfigs/status;dockerdatacenterconfigs/status;cloudstackdatacenterconfigs/status;cloudstackmachineconfigs/status;snowdatacenterconfigs/status;snowmachineconfigs/status;tinkerbelldatacenterconfigs/status;tinkerbellmachineconfigs/status;tinkerbelltemplateconfigs/status;bundles/status;awsiamconfigs/status,verbs=;get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/finalizers;vspheredatacenterconfigs/finalizers;vspheremachineconfigs/finalizers;dockerdatacenterconfigs/finalizers;cloudstackdatacenterconfigs/finalizers;cloudstackmachineconfigs/finalizers;snowdatacenterconfigs/finalizers;snowmachineconfigs/finalizers;tinkerbelldatacenterconfigs/finalizers;tinkerbellmachineconfigs/finalizers;tinkerbelltemplateconfigs/finalizers;bundles/finalizers;awsiamconfigs/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=test,resources=test,verbs=get;list;watch;create;update;patch;delete;kill
@@ -123,16 +189,37 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 }
 
 func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *anywherev1.Cluster, log logr.Logger) (ctrl.Result, error) {
-	clusterProviderReconciler, err := clusters.BuildProviderReconciler(cluster.Spec.DatacenterRef.Kind, r.client, r.log, r.validator, r.defaulter, r.tracker)
+	clusterProviderReconciler, err := clusters.BuildProviderReconciler(cluster.Spec.DatacenterRef.Kind, r.client, r.log, r.validator, r.defaulter, r.cmkBuilder, r.helm, r.tracker)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	configGenerations, err := clusters.ConfigGenerations(ctx, r.client, cluster)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	clusters.ResetSpecsForNewGeneration(cluster)
+	clusters.ResetSpecsForChangedConfigs(cluster, configGenerations)
 
 	reconcileResult, err := clusterProviderReconciler.Reconcile(ctx, cluster)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	return reconcileResult.ToCtrlResult(), nil
+	clusters.RecordAppliedConfigGenerations(cluster, configGenerations)
+
+	if err := clusters.UpdateClusterStatus(ctx, r.client, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+	cluster.Status.FailureMessage = nil
+
+	// The generation is only observed once all its spec has been applied, the provider reconcilers requeue
+	// while they wait for the cluster to be ready before applying the rest of it
+	result := reconcileResult.ToCtrlResult()
+	if result.IsZero() && clusters.SpecsApplied(cluster) {
+		cluster.Status.ObservedGeneration = cluster.Generation
+	}
+
+	return result, nil
 }
 
 func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *anywherev1.Cluster) (ctrl.Result, error) {
@@ -152,7 +239,16 @@ func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *anywhe
 	case apierrors.IsNotFound(err):
 		r.log.Info("Deleting EKS Anywhere cluster", "name", capiCluster.Name, "cluster.DeletionTimestamp", cluster.DeletionTimestamp, "finalizer", cluster.Finalizers)
 
-		// TODO delete GitOps,Datacenter and MachineConfig objects
+		pending, err := clusters.DeleteConfigObjects(ctx, r.client, r.log, cluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(pending) > 0 {
+			r.log.Info("Waiting for config objects to be deleted", "name", cluster.Name, "objects", pending)
+			conditions.MarkFalse(cluster, clusterv1.ReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "Waiting for %s to be deleted", strings.Join(pending, ", "))
+			return ctrl.Result{RequeueAfter: defaultRequeueTime}, nil
+		}
+
 		controllerutil.RemoveFinalizer(cluster, clusterFinalizerName)
 	default:
 		return ctrl.Result{}, err
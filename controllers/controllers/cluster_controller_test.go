@@ -11,17 +11,30 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrlruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/aws/eks-anywhere/controllers/controllers/clusters"
 	_ "github.com/aws/eks-anywhere/internal/test/envtest"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	ciliummocks "github.com/aws/eks-anywhere/pkg/networking/cilium/mocks"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	cloudstackmocks "github.com/aws/eks-anywhere/pkg/providers/cloudstack/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -331,6 +344,937 @@ func TestClusterReconcilerDeleteNoCAPIClusterSuccess(t *testing.T) {
 	}
 }
 
+func TestClusterReconcilerDeleteConfigObjects(t *testing.T) {
+	g := NewWithT(t)
+	cluster := createCluster()
+	cluster.Spec.IdentityProviderRefs = []anywherev1.Ref{{Kind: anywherev1.OIDCConfigKind, Name: "oidc"}}
+	cluster.Spec.GitOpsRef = &anywherev1.Ref{Kind: anywherev1.GitOpsConfigKind, Name: "gitops"}
+	now := metav1.Now()
+	cluster.DeletionTimestamp = &now
+	controllerutil.AddFinalizer(cluster, clusterFinalizerName)
+
+	datacenterConfig := createDataCenter(cluster)
+	machineConfigCP := createCPMachineConfig()
+	machineConfigWN := createWNMachineConfig()
+	oidcConfig := &anywherev1.OIDCConfig{ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: namespace}}
+	gitOpsConfig := &anywherev1.GitOpsConfig{ObjectMeta: metav1.ObjectMeta{Name: "gitops", Namespace: namespace}}
+
+	objs := []runtime.Object{cluster, datacenterConfig, machineConfigCP, machineConfigWN, oidcConfig, gitOpsConfig}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	ctx := context.Background()
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrlruntime.Result{}))
+
+	for _, obj := range []client.Object{datacenterConfig, machineConfigCP, machineConfigWN, oidcConfig, gitOpsConfig} {
+		err := cl.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s should be deleted", obj.GetName())
+	}
+
+	// The cluster is gone once its finalizer is removed
+	err = cl.Get(ctx, req.NamespacedName, &anywherev1.Cluster{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestClusterReconcilerDeleteKeepsSharedConfigObjects(t *testing.T) {
+	g := NewWithT(t)
+	cluster := createCluster()
+	now := metav1.Now()
+	cluster.DeletionTimestamp = &now
+	controllerutil.AddFinalizer(cluster, clusterFinalizerName)
+
+	otherCluster := createCluster()
+	otherCluster.Name = "other-cluster"
+	otherCluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name = "other-cluster-cp"
+	otherCluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name = "other-cluster-wn"
+
+	datacenterConfig := createDataCenter(cluster)
+	machineConfigCP := createCPMachineConfig()
+	machineConfigWN := createWNMachineConfig()
+
+	objs := []runtime.Object{cluster, otherCluster, datacenterConfig, machineConfigCP, machineConfigWN}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(datacenterConfig), datacenterConfig)).To(Succeed())
+	for _, obj := range []client.Object{machineConfigCP, machineConfigWN} {
+		err := cl.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s should be deleted", obj.GetName())
+	}
+}
+
+func TestClusterReconcilerDeleteSharedConfigObjectsWithLastCluster(t *testing.T) {
+	g := NewWithT(t)
+	now := metav1.Now()
+	cluster := createCluster()
+	cluster.DeletionTimestamp = &now
+	controllerutil.AddFinalizer(cluster, clusterFinalizerName)
+
+	otherCluster := createCluster()
+	otherCluster.Name = "other-cluster"
+	otherCluster.DeletionTimestamp = &now
+	controllerutil.AddFinalizer(otherCluster, clusterFinalizerName)
+
+	datacenterConfig := createDataCenter(cluster)
+	machineConfigCP := createCPMachineConfig()
+	machineConfigWN := createWNMachineConfig()
+
+	objs := []runtime.Object{cluster, otherCluster, datacenterConfig, machineConfigCP, machineConfigWN}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	otherReq := reconcile.Request{NamespacedName: types.NamespacedName{Name: otherCluster.Name, Namespace: namespace}}
+
+	ctx := context.Background()
+	// The clusters deleted together release the shared objects one at a time, by name
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrlruntime.Result{RequeueAfter: defaultRequeueTime}))
+	for _, obj := range []client.Object{datacenterConfig, machineConfigCP, machineConfigWN} {
+		g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed(), "%s should be kept", obj.GetName())
+	}
+	g.Expect(cl.Get(ctx, req.NamespacedName, &anywherev1.Cluster{})).To(Succeed())
+
+	result, err = r.Reconcile(ctx, otherReq)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrlruntime.Result{}))
+	for _, obj := range []client.Object{datacenterConfig, machineConfigCP, machineConfigWN} {
+		g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed(), "%s should be kept", obj.GetName())
+	}
+	err = cl.Get(ctx, otherReq.NamespacedName, &anywherev1.Cluster{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// The last cluster deletes them
+	result, err = r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrlruntime.Result{}))
+	for _, obj := range []client.Object{datacenterConfig, machineConfigCP, machineConfigWN} {
+		err := cl.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s should be deleted", obj.GetName())
+	}
+	err = cl.Get(ctx, req.NamespacedName, &anywherev1.Cluster{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestClusterReconcilerDeleteWaitsForConfigObjects(t *testing.T) {
+	g := NewWithT(t)
+	cluster := createCluster()
+	now := metav1.Now()
+	cluster.DeletionTimestamp = &now
+	controllerutil.AddFinalizer(cluster, clusterFinalizerName)
+
+	datacenterConfig := createDataCenter(cluster)
+	machineConfigCP := createCPMachineConfig()
+	machineConfigCP.Finalizers = []string{"test/finalizer"}
+	machineConfigWN := createWNMachineConfig()
+
+	objs := []runtime.Object{cluster, datacenterConfig, machineConfigCP, machineConfigWN}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	ctx := context.Background()
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrlruntime.Result{RequeueAfter: defaultRequeueTime}))
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(apiCluster.Finalizers).To(ContainElement(clusterFinalizerName))
+	g.Expect(conditions.GetReason(apiCluster, clusterv1.ReadyCondition)).To(Equal(clusterv1.DeletingReason))
+	g.Expect(conditions.GetMessage(apiCluster, clusterv1.ReadyCondition)).To(Equal("Waiting for VSphereMachineConfig test-cluster-cp to be deleted"))
+}
+
+func TestClusterReconcilerClustersForConfig(t *testing.T) {
+	g := NewWithT(t)
+	cluster := createCluster()
+	otherNamespaceCluster := createCluster()
+	otherNamespaceCluster.Namespace = "other"
+	machineConfig := createCPMachineConfig()
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(cluster, otherNamespaceCluster).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	requests := r.clustersForConfig(anywherev1.VSphereMachineConfigKind, clusters.MachineGroupRefsIndex)(machineConfig)
+	g.Expect(requests).To(ConsistOf(reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}))
+}
+
+func TestClusterReconcilerDockerWaitForControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createDockerCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+	// The specs are already applied, the fake client doesn't support server side apply
+	conditions.MarkTrue(cluster, "ControlPlaneSpecApplied")
+	conditions.MarkTrue(cluster, "WorkerNodeSpecApplied")
+
+	datacenterConfig := createDockerDataCenter(cluster)
+	bundle := createBundle(managementCluster)
+	eksd := createEksdRelease()
+	capiCluster := newCAPICluster(cluster.Name, cluster.Namespace)
+
+	objs := []runtime.Object{cluster, datacenterConfig, bundle, eksd, managementCluster, capiCluster}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	result, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrlruntime.Result{RequeueAfter: defaultRequeueTime}))
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(r.client.Get(context.TODO(), req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(apiCluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestClusterReconcilerDockerStatusWaitingForControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createDockerCluster()
+	cluster.Generation = 2
+	cluster.Status.ObservedGeneration = 2
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+	// The specs are already applied, the fake client doesn't support server side apply
+	conditions.MarkTrue(cluster, "ControlPlaneSpecApplied")
+	conditions.MarkTrue(cluster, "WorkerNodeSpecApplied")
+
+	datacenterConfig := createDockerDataCenter(cluster)
+	bundle := createBundle(managementCluster)
+	eksd := createEksdRelease()
+	capiCluster := newCAPICluster(cluster.Name, cluster.Namespace)
+	kcp := newKubeadmControlPlane(cluster.Name)
+	kcp.Status = controlplanev1.KubeadmControlPlaneStatus{
+		Replicas:        1,
+		UpdatedReplicas: 1,
+	}
+	md := newMachineDeployment(cluster.Name + "-md-0")
+
+	objs := []runtime.Object{cluster, datacenterConfig, bundle, eksd, managementCluster, capiCluster, kcp, md}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	result, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrlruntime.Result{RequeueAfter: defaultRequeueTime}))
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(r.client.Get(context.TODO(), req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(apiCluster.Status.ObservedGeneration).To(Equal(int64(2)))
+	g.Expect(apiCluster.Status.ControlPlane).To(Equal(&anywherev1.NodeGroupStatus{Replicas: 1, UpdatedReplicas: 1}))
+	g.Expect(apiCluster.Status.WorkerNodeGroups).To(Equal([]anywherev1.NodeGroupStatus{{Name: "md-0"}}))
+	g.Expect(conditions.GetReason(apiCluster, anywherev1.ControlPlaneReadyCondition)).To(Equal(anywherev1.ControlPlaneInitializingReason))
+	g.Expect(conditions.GetReason(apiCluster, anywherev1.WorkersReadyCondition)).To(Equal(anywherev1.ScalingUpReason))
+	g.Expect(conditions.GetReason(apiCluster, anywherev1.DefaultCNIConfiguredCondition)).To(Equal(anywherev1.WaitingForControlPlaneReason))
+	g.Expect(conditions.IsFalse(apiCluster, clusterv1.ReadyCondition)).To(BeTrue())
+}
+
+func TestClusterReconcilerDockerNewGenerationReappliesSpecs(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createDockerCluster()
+	cluster.Generation = 3
+	cluster.Status.ObservedGeneration = 2
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+	conditions.MarkTrue(cluster, "ControlPlaneSpecApplied")
+	conditions.MarkTrue(cluster, "WorkerNodeSpecApplied")
+
+	datacenterConfig := createDockerDataCenter(cluster)
+	bundle := createBundle(managementCluster)
+	eksd := createEksdRelease()
+	capiCluster := newCAPICluster(cluster.Name, cluster.Namespace)
+
+	objs := []runtime.Object{cluster, datacenterConfig, bundle, eksd, managementCluster, capiCluster}
+	cl := &applyRecorder{Client: fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()}
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	appliedKinds := make([]string, 0, len(cl.applied))
+	for _, obj := range cl.applied {
+		appliedKinds = append(appliedKinds, obj.GetObjectKind().GroupVersionKind().Kind)
+	}
+	g.Expect(appliedKinds).To(ContainElements("KubeadmControlPlane", "MachineDeployment"))
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(r.client.Get(context.TODO(), req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(conditions.IsTrue(apiCluster, "ControlPlaneSpecApplied")).To(BeTrue())
+	g.Expect(conditions.IsTrue(apiCluster, "WorkerNodeSpecApplied")).To(BeTrue())
+	// The CNI is only applied once the control plane is ready, so the new generation isn't observed yet
+	g.Expect(conditions.IsTrue(apiCluster, "CNISpecApplied")).To(BeFalse())
+	g.Expect(apiCluster.Status.ObservedGeneration).To(Equal(int64(2)))
+}
+
+func TestClusterReconcilerDockerInstallKindnetd(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createDockerCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+	cluster.Generation = 2
+	cluster.Status.ObservedGeneration = 1
+	cluster.Spec.ClusterNetwork = anywherev1.ClusterNetwork{
+		Pods:      anywherev1.Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+		CNIConfig: &anywherev1.CNIConfig{Kindnetd: &anywherev1.KindnetdConfig{}},
+	}
+
+	datacenterConfig := createDockerDataCenter(cluster)
+	bundle := createBundle(managementCluster)
+	bundle.Spec.VersionsBundles[0].Kindnetd.Manifest.URI = "testdata/kindnetd_manifest.yaml"
+	eksd := createEksdRelease()
+	capiCluster := newCAPICluster(cluster.Name, cluster.Namespace)
+	conditions.MarkTrue(capiCluster, "ControlPlaneReady")
+
+	objs := []runtime.Object{cluster, datacenterConfig, bundle, eksd, managementCluster, capiCluster}
+	cl := &applyRecorder{Client: fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()}
+	remoteClient := &applyRecorder{Client: fake.NewClientBuilder().Build()}
+
+	r := &ClusterReconciler{
+		client:  cl,
+		log:     logf.Log,
+		tracker: remote.NewTestClusterCacheTracker(logf.Log, remoteClient, scheme.Scheme, client.ObjectKeyFromObject(capiCluster)),
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(remoteClient.applied).To(HaveLen(2))
+	g.Expect(remoteClient.applied[0].GetName()).To(Equal("kindnet"))
+	daemonSet := remoteClient.applied[1].(*unstructured.Unstructured)
+	g.Expect(daemonSet.GetKind()).To(Equal("DaemonSet"))
+	containers, _, _ := unstructured.NestedSlice(daemonSet.Object, "spec", "template", "spec", "containers")
+	g.Expect(containers[0]).To(HaveKeyWithValue("env", ContainElement(map[string]interface{}{"name": "POD_SUBNET", "value": "192.168.0.0/16"})))
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(r.client.Get(context.TODO(), req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(conditions.IsTrue(apiCluster, anywherev1.DefaultCNIConfiguredCondition)).To(BeTrue())
+	g.Expect(apiCluster.Status.ObservedGeneration).To(Equal(int64(2)))
+}
+
+func TestClusterReconcilerDockerInstallCilium(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	helm := ciliummocks.NewMockHelm(ctrl)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createDockerCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+	// Clusters created before CNIConfig was introduced only have the deprecated CNI field
+	cluster.Spec.ClusterNetwork = anywherev1.ClusterNetwork{CNI: anywherev1.Cilium}
+	conditions.MarkTrue(cluster, "ControlPlaneSpecApplied")
+	conditions.MarkTrue(cluster, "WorkerNodeSpecApplied")
+
+	datacenterConfig := createDockerDataCenter(cluster)
+	bundle := createBundle(managementCluster)
+	bundle.Spec.VersionsBundles[0].Cilium.HelmChart = v1alpha1.Image{URI: "public.ecr.aws/isovalent/cilium:1.10.11"}
+	eksd := createEksdRelease()
+	capiCluster := newCAPICluster(cluster.Name, cluster.Namespace)
+	conditions.MarkTrue(capiCluster, "ControlPlaneReady")
+
+	objs := []runtime.Object{cluster, datacenterConfig, bundle, eksd, managementCluster, capiCluster}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	remoteClient := &applyRecorder{Client: fake.NewClientBuilder().Build()}
+
+	r := &ClusterReconciler{
+		client:  cl,
+		log:     logf.Log,
+		helm:    helm,
+		tracker: remote.NewTestClusterCacheTracker(logf.Log, remoteClient, scheme.Scheme, client.ObjectKeyFromObject(capiCluster)),
+	}
+
+	ctx := context.Background()
+	helm.EXPECT().Template(ctx, "oci://public.ecr.aws/isovalent/cilium", "1.10.11", "kube-system", gomock.Any()).Return([]byte(`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: cilium
+  namespace: kube-system
+`), nil)
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(remoteClient.applied).To(HaveLen(1))
+	g.Expect(remoteClient.applied[0].GetName()).To(Equal("cilium"))
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(r.client.Get(context.TODO(), req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(conditions.IsTrue(apiCluster, anywherev1.DefaultCNIConfiguredCondition)).To(BeTrue())
+	g.Expect(apiCluster.Spec.ClusterNetwork.CNIConfig).To(BeNil())
+}
+
+func TestClusterReconcilerDockerMissingDatacenter(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createDockerCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+
+	objs := []runtime.Object{cluster, managementCluster}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "expected a not found error but got: %v", err)
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(r.client.Get(context.TODO(), req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(apiCluster.Status.FailureMessage).NotTo(BeNil())
+}
+
+func TestClusterReconcilerSnowWaitForControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createSnowCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+	// The specs are already applied, the fake client doesn't support server side apply
+	conditions.MarkTrue(cluster, "ControlPlaneSpecApplied")
+	conditions.MarkTrue(cluster, "WorkerNodeSpecApplied")
+
+	datacenterConfig := createSnowDataCenter(cluster)
+	cpMachineConfig := createSnowMachineConfig(cluster, name+"-cp")
+	wnMachineConfig := createSnowMachineConfig(cluster, name+"-wn")
+	bundle := createBundle(managementCluster)
+	eksd := createEksdRelease()
+	capiCluster := newCAPICluster(cluster.Name, cluster.Namespace)
+
+	objs := []runtime.Object{cluster, datacenterConfig, cpMachineConfig, wnMachineConfig, bundle, eksd, managementCluster, capiCluster}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	result, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrlruntime.Result{RequeueAfter: defaultRequeueTime}))
+}
+
+func TestClusterReconcilerSnowKeepsUnchangedMachineTemplates(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createSnowCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+
+	datacenterConfig := createSnowDataCenter(cluster)
+	cpMachineConfig := createSnowMachineConfig(cluster, name+"-cp")
+	wnMachineConfig := createSnowMachineConfig(cluster, name+"-wn")
+	oldCPTemplate := snow.SnowMachineTemplate(cpMachineConfig)
+	oldCPTemplate.SetName(name + "-control-plane-template-1")
+	oldWorkerTemplate := snow.SnowMachineTemplate(wnMachineConfig)
+	oldWorkerTemplate.SetName(name + "-md-0-1")
+	// Only the control plane AMI changes. The templates point to the AMI of the machine configs they were built from
+	cpMachineConfig = cpMachineConfig.DeepCopy()
+	cpMachineConfig.Spec.AMIID = "ami-2"
+
+	kcp := newKubeadmControlPlane(cluster.Name)
+	kcp.Spec.MachineTemplate.InfrastructureRef.Name = oldCPTemplate.Name
+	md := newMachineDeployment("md-0")
+	md.Spec.Template.Spec.InfrastructureRef.Name = oldWorkerTemplate.Name
+	bundle := createBundle(managementCluster)
+	eksd := createEksdRelease()
+	capiCluster := newCAPICluster(cluster.Name, cluster.Namespace)
+
+	objs := []runtime.Object{cluster, datacenterConfig, cpMachineConfig, wnMachineConfig, bundle, eksd, managementCluster, capiCluster, kcp, md, oldCPTemplate, oldWorkerTemplate}
+	cl := &applyRecorder{Client: fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()}
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	templateNames := appliedNames(cl.applied, snow.SnowMachineTemplateKind)
+	g.Expect(templateNames).To(HaveLen(2))
+	g.Expect(templateNames).To(ContainElement(oldWorkerTemplate.Name), "the unchanged worker template should be reused")
+	g.Expect(templateNames).NotTo(ContainElement(oldCPTemplate.Name), "the changed control plane template should be renamed")
+	g.Expect(templateNames).To(ContainElement(HavePrefix(name + "-control-plane-template-")))
+}
+
+func TestClusterReconcilerSnowInvalidMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createSnowCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+
+	datacenterConfig := createSnowDataCenter(cluster)
+	cpMachineConfig := createSnowMachineConfig(cluster, name+"-cp")
+	cpMachineConfig.Spec.AMIID = ""
+	wnMachineConfig := createSnowMachineConfig(cluster, name+"-wn")
+
+	objs := []runtime.Object{cluster, datacenterConfig, cpMachineConfig, wnMachineConfig, managementCluster}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).To(MatchError(ContainSubstring("SnowMachineConfig AMIID is a required field")))
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(r.client.Get(context.TODO(), req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(apiCluster.Status.FailureMessage).NotTo(BeNil())
+}
+
+func TestClusterReconcilerTinkerbellWaitForControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createTinkerbellCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+
+	datacenterConfig := createTinkerbellDataCenter(cluster)
+	cpMachineConfig := createTinkerbellMachineConfig(cluster, name+"-cp")
+	wnMachineConfig := createTinkerbellMachineConfig(cluster, name+"-wn")
+	templateConfig := createTinkerbellTemplateConfig(cluster)
+	bundle := createBundle(managementCluster)
+	eksd := createEksdRelease()
+	capiCluster := newCAPICluster(cluster.Name, cluster.Namespace)
+
+	objs := []runtime.Object{cluster, datacenterConfig, cpMachineConfig, wnMachineConfig, templateConfig, bundle, eksd, managementCluster, capiCluster}
+	cl := &applyRecorder{Client: fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()}
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	result, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrlruntime.Result{RequeueAfter: defaultRequeueTime}))
+
+	g.Expect(appliedNames(cl.applied, "KubeadmControlPlane")).To(ConsistOf(cluster.Name))
+	g.Expect(appliedNames(cl.applied, "MachineDeployment")).To(ConsistOf(cluster.Name + "-md-0"))
+	g.Expect(appliedNames(cl.applied, "TinkerbellMachineTemplate")).To(ConsistOf(
+		HavePrefix(cluster.Name+"-control-plane-template-"),
+		HavePrefix(cluster.Name+"-md-0-"),
+	))
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(r.client.Get(context.TODO(), req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(conditions.IsTrue(apiCluster, "ControlPlaneSpecApplied")).To(BeTrue())
+	g.Expect(conditions.IsTrue(apiCluster, "WorkerNodeSpecApplied")).To(BeTrue())
+}
+
+func TestClusterReconcilerTinkerbellMissingSshAuthorizedKey(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createTinkerbellCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+
+	datacenterConfig := createTinkerbellDataCenter(cluster)
+	cpMachineConfig := createTinkerbellMachineConfig(cluster, name+"-cp")
+	wnMachineConfig := createTinkerbellMachineConfig(cluster, name+"-wn")
+	wnMachineConfig.Spec.Users = nil
+	templateConfig := createTinkerbellTemplateConfig(cluster)
+
+	objs := []runtime.Object{cluster, datacenterConfig, cpMachineConfig, wnMachineConfig, templateConfig, managementCluster}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).To(MatchError(ContainSubstring("TinkerbellMachineConfig " + name + "-wn: a user with an ssh authorized key is required")))
+}
+
+func TestClusterReconcilerDockerKeepsUnchangedWorkerTemplates(t *testing.T) {
+	tests := []struct {
+		name                 string
+		datacenterGeneration int64
+		wantTemplateReused   bool
+	}{
+		{
+			name:                 "configs unchanged",
+			datacenterGeneration: 1,
+			wantTemplateReused:   true,
+		},
+		{
+			name:                 "datacenter changed",
+			datacenterGeneration: 2,
+			wantTemplateReused:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			managementCluster := createCluster()
+			managementCluster.Name = "management-cluster"
+			cluster := createDockerCluster()
+			cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+			cluster.Status.AppliedConfigGenerations = map[string]int64{"DockerDatacenterConfig/datacenter": 1}
+			conditions.MarkTrue(cluster, "ControlPlaneSpecApplied")
+
+			datacenterConfig := createDockerDataCenter(cluster)
+			datacenterConfig.Generation = tt.datacenterGeneration
+			md := newMachineDeployment(cluster.Name + "-md-0")
+			md.Spec.Template.Spec.InfrastructureRef.Name = cluster.Name + "-md-0-1"
+			bundle := createBundle(managementCluster)
+			eksd := createEksdRelease()
+			capiCluster := newCAPICluster(cluster.Name, cluster.Namespace)
+
+			objs := []runtime.Object{cluster, datacenterConfig, md, bundle, eksd, managementCluster, capiCluster}
+			cl := &applyRecorder{Client: fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()}
+
+			r := &ClusterReconciler{
+				client: cl,
+				log:    logf.Log,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+			_, err := r.Reconcile(context.Background(), req)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			templateNames := appliedNames(cl.applied, "DockerMachineTemplate")
+			if tt.wantTemplateReused {
+				g.Expect(templateNames).To(ContainElement(cluster.Name + "-md-0-1"))
+			} else {
+				g.Expect(templateNames).NotTo(ContainElement(cluster.Name + "-md-0-1"))
+				g.Expect(templateNames).To(ContainElement(HavePrefix(cluster.Name + "-md-0-")))
+			}
+		})
+	}
+}
+
+func TestClusterReconcilerClustersForTemplateConfig(t *testing.T) {
+	g := NewWithT(t)
+	cluster := createTinkerbellCluster()
+	machineConfig := createTinkerbellMachineConfig(cluster, name+"-wn")
+	templateConfig := createTinkerbellTemplateConfig(cluster)
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster, machineConfig).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	g.Expect(r.clustersForTemplateConfig(templateConfig)).To(ConsistOf(reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}))
+}
+
+func TestClusterReconcilerTinkerbellMissingTemplateConfig(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createTinkerbellCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+
+	datacenterConfig := createTinkerbellDataCenter(cluster)
+	cpMachineConfig := createTinkerbellMachineConfig(cluster, name+"-cp")
+	wnMachineConfig := createTinkerbellMachineConfig(cluster, name+"-wn")
+
+	objs := []runtime.Object{cluster, datacenterConfig, cpMachineConfig, wnMachineConfig, managementCluster}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "expected a not found error but got: %v", err)
+}
+
+func TestClusterReconcilerCloudStackMissingCredentials(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createCloudStackCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+
+	datacenterConfig := createCloudStackDataCenter(cluster)
+	cpMachineConfig := createCloudStackMachineConfig(cluster, name+"-cp")
+	wnMachineConfig := createCloudStackMachineConfig(cluster, name+"-wn")
+
+	objs := []runtime.Object{cluster, datacenterConfig, cpMachineConfig, wnMachineConfig, managementCluster}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).To(MatchError(ContainSubstring("failed getting cloudstack credentials secret")))
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(r.client.Get(context.TODO(), req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(apiCluster.Status.FailureMessage).NotTo(BeNil())
+}
+
+func TestClusterReconcilerCloudStackWaitForControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createCloudStackCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+
+	datacenterConfig := createCloudStackDataCenter(cluster)
+	cpMachineConfig := createCloudStackMachineConfig(cluster, name+"-cp")
+	wnMachineConfig := createCloudStackMachineConfig(cluster, name+"-wn")
+	secret := createCloudStackSecret()
+	bundle := createBundle(managementCluster)
+	eksd := createEksdRelease()
+	capiCluster := newCAPICluster(cluster.Name, cluster.Namespace)
+
+	objs := []runtime.Object{cluster, datacenterConfig, cpMachineConfig, wnMachineConfig, secret, bundle, eksd, managementCluster, capiCluster}
+	cl := &applyRecorder{Client: fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()}
+
+	mockCtrl := gomock.NewController(t)
+	cmk := cloudstackmocks.NewMockProviderCmkClient(mockCtrl)
+	domain := anywherev1.CloudStackResourceIdentifier{Id: "domain-id", Name: "domain"}
+	zones := []anywherev1.CloudStackResourceIdentifier{{Id: "zone-id", Name: "zone"}}
+	cmk.EXPECT().ValidateDomainPresent(ctx, "domain").Return(domain, nil).AnyTimes()
+	cmk.EXPECT().ValidateZonesPresent(ctx, datacenterConfig.Spec.Zones).Return(zones, nil).AnyTimes()
+	cmk.EXPECT().ValidateNetworkPresent(ctx, "domain-id", datacenterConfig.Spec.Zones[0], zones, "", false).Return(nil)
+	cmk.EXPECT().ValidateTemplatePresent(ctx, "domain-id", "zone-id", "", cpMachineConfig.Spec.Template).Return(nil).Times(2)
+	cmk.EXPECT().ValidateServiceOfferingPresent(ctx, "zone-id", cpMachineConfig.Spec.ComputeOffering).Return(nil).Times(2)
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+		cmkBuilder: func(execConfig decoder.CloudStackExecConfig) cloudstack.ProviderCmkClient {
+			return cmk
+		},
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	result, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrlruntime.Result{RequeueAfter: defaultRequeueTime}))
+
+	g.Expect(appliedNames(cl.applied, "KubeadmControlPlane")).To(ConsistOf(cluster.Name))
+	g.Expect(appliedNames(cl.applied, "MachineDeployment")).To(ConsistOf(cluster.Name + "-md-0"))
+	g.Expect(appliedNames(cl.applied, "CloudStackMachineTemplate")).To(ConsistOf(
+		HavePrefix(cluster.Name+"-control-plane-template-"),
+		HavePrefix(cluster.Name+"-md-0-"),
+	))
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(r.client.Get(context.TODO(), req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(conditions.IsTrue(apiCluster, "ControlPlaneSpecApplied")).To(BeTrue())
+	g.Expect(conditions.IsTrue(apiCluster, "WorkerNodeSpecApplied")).To(BeTrue())
+}
+
+func TestClusterReconcilerCloudStackMissingSshAuthorizedKey(t *testing.T) {
+	g := NewWithT(t)
+	managementCluster := createCluster()
+	managementCluster.Name = "management-cluster"
+	cluster := createCloudStackCluster()
+	cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+
+	datacenterConfig := createCloudStackDataCenter(cluster)
+	cpMachineConfig := createCloudStackMachineConfig(cluster, name+"-cp")
+	cpMachineConfig.Spec.Users[0].SshAuthorizedKeys = nil
+	wnMachineConfig := createCloudStackMachineConfig(cluster, name+"-wn")
+	secret := createCloudStackSecret()
+
+	objs := []runtime.Object{cluster, datacenterConfig, cpMachineConfig, wnMachineConfig, secret, managementCluster}
+
+	cb := fake.NewClientBuilder()
+	cl := cb.WithRuntimeObjects(objs...).Build()
+
+	r := &ClusterReconciler{
+		client: cl,
+		log:    logf.Log,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).To(MatchError(ContainSubstring("CloudStackMachineConfig " + name + "-cp: a user with an ssh authorized key is required")))
+}
+
 func createWNMachineConfig() *anywherev1.VSphereMachineConfig {
 	return &anywherev1.VSphereMachineConfig{
 		TypeMeta: metav1.TypeMeta{
@@ -375,6 +1319,57 @@ func newCAPICluster(name, namespace string) *clusterv1.Cluster {
 	}
 }
 
+func newKubeadmControlPlane(name string) *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "eksa-system",
+			Name:      name,
+		},
+	}
+}
+
+func newMachineDeployment(name string) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MachineDeployment",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "eksa-system",
+			Name:      name,
+		},
+	}
+}
+
+// appliedNames returns the names of the applied objects of a kind
+func appliedNames(applied []client.Object, kind string) []string {
+	var names []string
+	for _, obj := range applied {
+		if obj.GetObjectKind().GroupVersionKind().Kind == kind {
+			names = append(names, obj.GetName())
+		}
+	}
+	return names
+}
+
+// applyRecorder records the objects applied with server side apply, which the fake client doesn't support
+type applyRecorder struct {
+	client.Client
+	applied []client.Object
+}
+
+func (a *applyRecorder) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch == client.Apply {
+		a.applied = append(a.applied, obj)
+		return nil
+	}
+	return a.Client.Patch(ctx, obj, patch, opts...)
+}
+
 func createCPMachineConfig() *anywherev1.VSphereMachineConfig {
 	return &anywherev1.VSphereMachineConfig{
 		TypeMeta: metav1.TypeMeta{
@@ -567,3 +1562,191 @@ func createSecret() *apiv1.Secret {
 		},
 	}
 }
+
+func createDockerDataCenter(cluster *anywherev1.Cluster) *anywherev1.DockerDatacenterConfig {
+	return &anywherev1.DockerDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.DockerDatacenterKind,
+			APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: cluster.Namespace,
+		},
+	}
+}
+
+func createDockerCluster() *anywherev1.Cluster {
+	cluster := createCluster()
+	cluster.Spec.DatacenterRef.Kind = anywherev1.DockerDatacenterKind
+	cluster.Spec.ControlPlaneConfiguration.Endpoint = nil
+	cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = nil
+	cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef = nil
+	return cluster
+}
+
+func withMachineGroupKind(cluster *anywherev1.Cluster, datacenterKind, machineConfigKind string) *anywherev1.Cluster {
+	cluster.Spec.DatacenterRef.Kind = datacenterKind
+	cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Kind = machineConfigKind
+	cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Kind = machineConfigKind
+	return cluster
+}
+
+func createSnowCluster() *anywherev1.Cluster {
+	return withMachineGroupKind(createCluster(), anywherev1.SnowDatacenterKind, anywherev1.SnowMachineConfigKind)
+}
+
+func createSnowDataCenter(cluster *anywherev1.Cluster) *anywherev1.SnowDatacenterConfig {
+	return &anywherev1.SnowDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.SnowDatacenterKind,
+			APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: cluster.Namespace,
+		},
+	}
+}
+
+func createSnowMachineConfig(cluster *anywherev1.Cluster, name string) *anywherev1.SnowMachineConfig {
+	return &anywherev1.SnowMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.SnowMachineConfigKind,
+			APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.Namespace,
+		},
+		Spec: anywherev1.SnowMachineConfigSpec{
+			AMIID:        "ami-1",
+			InstanceType: anywherev1.SbeCLarge,
+			SshKeyName:   "default",
+		},
+	}
+}
+
+func createTinkerbellCluster() *anywherev1.Cluster {
+	return withMachineGroupKind(createCluster(), anywherev1.TinkerbellDatacenterKind, anywherev1.TinkerbellMachineConfigKind)
+}
+
+func createTinkerbellDataCenter(cluster *anywherev1.Cluster) *anywherev1.TinkerbellDatacenterConfig {
+	return &anywherev1.TinkerbellDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.TinkerbellDatacenterKind,
+			APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: cluster.Namespace,
+		},
+		Spec: anywherev1.TinkerbellDatacenterConfigSpec{
+			TinkerbellIP:           "1.1.1.2",
+			TinkerbellCertURL:      "http://1.1.1.2:42114/cert",
+			TinkerbellGRPCAuth:     "1.1.1.2:42113",
+			TinkerbellPBnJGRPCAuth: "1.1.1.2:50051",
+		},
+	}
+}
+
+func createTinkerbellMachineConfig(cluster *anywherev1.Cluster, name string) *anywherev1.TinkerbellMachineConfig {
+	return &anywherev1.TinkerbellMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.TinkerbellMachineConfigKind,
+			APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.Namespace,
+		},
+		Spec: anywherev1.TinkerbellMachineConfigSpec{
+			OSFamily: anywherev1.Ubuntu,
+			TemplateRef: anywherev1.Ref{
+				Kind: anywherev1.TinkerbellTemplateConfigKind,
+				Name: "tink-template",
+			},
+			Users: []anywherev1.UserConfiguration{
+				{
+					Name:              "ec2-user",
+					SshAuthorizedKeys: []string{"ssh-rsa AAAA"},
+				},
+			},
+		},
+	}
+}
+
+func createTinkerbellTemplateConfig(cluster *anywherev1.Cluster) *anywherev1.TinkerbellTemplateConfig {
+	return &anywherev1.TinkerbellTemplateConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.TinkerbellTemplateConfigKind,
+			APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tink-template",
+			Namespace: cluster.Namespace,
+		},
+	}
+}
+
+func createCloudStackCluster() *anywherev1.Cluster {
+	return withMachineGroupKind(createCluster(), anywherev1.CloudStackDatacenterKind, anywherev1.CloudStackMachineConfigKind)
+}
+
+func createCloudStackDataCenter(cluster *anywherev1.Cluster) *anywherev1.CloudStackDatacenterConfig {
+	return &anywherev1.CloudStackDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.CloudStackDatacenterKind,
+			APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: cluster.Namespace,
+		},
+		Spec: anywherev1.CloudStackDatacenterConfigSpec{
+			Domain:                "domain",
+			ManagementApiEndpoint: "http://1.1.1.3:8080/client/api",
+			Zones: []anywherev1.CloudStackZone{
+				{
+					Name:    "zone",
+					Network: anywherev1.CloudStackResourceIdentifier{Name: "network"},
+				},
+			},
+		},
+	}
+}
+
+func createCloudStackMachineConfig(cluster *anywherev1.Cluster, name string) *anywherev1.CloudStackMachineConfig {
+	return &anywherev1.CloudStackMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.CloudStackMachineConfigKind,
+			APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.Namespace,
+		},
+		Spec: anywherev1.CloudStackMachineConfigSpec{
+			Template:        anywherev1.CloudStackResourceIdentifier{Name: "kubernetes-1-20"},
+			ComputeOffering: anywherev1.CloudStackResourceIdentifier{Name: "m4-large"},
+			Users: []anywherev1.UserConfiguration{
+				{
+					Name:              "capc",
+					SshAuthorizedKeys: []string{"ssh-rsa AAAA"},
+				},
+			},
+		},
+	}
+}
+
+func createCloudStackSecret() *apiv1.Secret {
+	return &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "eksa-system",
+			Name:      "cloudstack-credentials",
+		},
+		Data: map[string][]byte{
+			"cloud-config": []byte("[Global]\napi-key = test-key\nsecret-key = test-secret\napi-url = http://1.1.1.3:8080/client/api\n"),
+		},
+	}
+}
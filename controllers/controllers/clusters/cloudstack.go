@@ -0,0 +1,164 @@
+package clusters
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/reconciler"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+)
+
+// CmkBuilder builds a cmk client for the CloudStack credentials stored in the cluster
+type CmkBuilder func(execConfig decoder.CloudStackExecConfig) cloudstack.ProviderCmkClient
+
+type CloudStackReconciler struct {
+	*providerClusterReconciler
+	cmkBuilder CmkBuilder
+}
+
+func NewCloudStackReconciler(client client.Client, log logr.Logger, cmkBuilder CmkBuilder, helm cilium.Helm, tracker *remote.ClusterCacheTracker) *CloudStackReconciler {
+	return &CloudStackReconciler{
+		providerClusterReconciler: newProviderClusterReconciler(client, log, helm, tracker),
+		cmkBuilder:                cmkBuilder,
+	}
+}
+
+func CloudStackCredentials(ctx context.Context, cli client.Client) (*apiv1.Secret, error) {
+	secret := &apiv1.Secret{}
+	secretKey := client.ObjectKey{
+		Namespace: constants.EksaSystemNamespace,
+		Name:      constants.CloudStackCredentialsName,
+	}
+	if err := cli.Get(ctx, secretKey, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// SetupCloudStackEnvVars exports the CloudStack credentials stored in the cluster the same way the CLI
+// receives them, since the CloudStack template builder reads the exec config from the environment
+func SetupCloudStackEnvVars(ctx context.Context, cli client.Client) (*decoder.CloudStackExecConfig, error) {
+	secret, err := CloudStackCredentials(ctx, cli)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting cloudstack credentials secret: %v", err)
+	}
+
+	cloudConfig := base64.StdEncoding.EncodeToString(secret.Data[cloudstack.CloudConfigSecretKey])
+	if err := os.Setenv(decoder.EksacloudStackCloudConfigB64SecretKey, cloudConfig); err != nil {
+		return nil, fmt.Errorf("failed setting env %s: %v", decoder.EksacloudStackCloudConfigB64SecretKey, err)
+	}
+
+	execConfig, err := decoder.ParseCloudStackSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing cloudstack credentials: %v", err)
+	}
+
+	return execConfig, nil
+}
+
+func (s *CloudStackReconciler) Reconcile(ctx context.Context, cluster *anywherev1.Cluster) (reconciler.Result, error) {
+	dataCenterConfig := &anywherev1.CloudStackDatacenterConfig{}
+	dataCenterName := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Spec.DatacenterRef.Name}
+	if err := s.providerClient.Get(ctx, dataCenterName, dataCenterConfig); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	machineConfigMap := map[string]*anywherev1.CloudStackMachineConfig{}
+	for _, ref := range cluster.MachineConfigRefs() {
+		machineConfig := &anywherev1.CloudStackMachineConfig{}
+		machineConfigName := types.NamespacedName{Namespace: cluster.Namespace, Name: ref.Name}
+		if err := s.providerClient.Get(ctx, machineConfigName, machineConfig); err != nil {
+			return reconciler.Result{}, err
+		}
+		// Checked before the validator, which defaults missing users and keys to empty ones
+		if err := validateSshAuthorizedKey(anywherev1.CloudStackMachineConfigKind, ref.Name, machineConfig.Spec.Users); err != nil {
+			return reconciler.Result{}, err
+		}
+		machineConfigMap[ref.Name] = machineConfig
+	}
+
+	execConfig, err := SetupCloudStackEnvVars(ctx, s.providerClient)
+	if err != nil {
+		s.log.Error(err, "Failed to set up env vars for CloudStackDatacenterConfig")
+		return reconciler.Result{}, err
+	}
+
+	specWithBundles, err := s.specWithBundles(ctx, cluster)
+	if err != nil {
+		return reconciler.Result{}, err
+	}
+
+	validator := cloudstack.NewValidator(s.cmkBuilder(*execConfig))
+	if err := validator.ValidateCloudStackDatacenterConfig(ctx, dataCenterConfig); err != nil {
+		return reconciler.Result{}, err
+	}
+	if err := validator.ValidateClusterMachineConfigs(ctx, cloudstack.NewSpec(specWithBundles, machineConfigMap, dataCenterConfig)); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	cp := machineConfigMap[cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+	worker := machineConfigMap[cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name]
+	var etcdSpec *anywherev1.CloudStackMachineConfigSpec
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdSpec = &machineConfigMap[cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name].Spec
+	}
+
+	templateBuilder := &cloudStackTemplateBuilder{
+		CloudStackTemplateBuilder: cloudstack.NewCloudStackTemplateBuilder(&dataCenterConfig.Spec, &cp.Spec, &worker.Spec, etcdSpec, time.Now),
+		workerSshAuthorizedKey:    worker.Spec.Users[0].SshAuthorizedKeys[0],
+	}
+	clusterName := cluster.ObjectMeta.Name
+
+	kubeadmconfigTemplateNames := make(map[string]string, len(cluster.Spec.WorkerNodeGroupConfigurations))
+	workloadTemplateNames := make(map[string]string, len(cluster.Spec.WorkerNodeGroupConfigurations))
+
+	for _, wnConfig := range cluster.Spec.WorkerNodeGroupConfigurations {
+		kubeadmconfigTemplateNames[wnConfig.Name] = common.KubeadmConfigTemplateName(clusterName, wnConfig.Name, time.Now)
+		workloadTemplateNames[wnConfig.Name] = common.WorkerMachineTemplateName(clusterName, wnConfig.Name, time.Now)
+	}
+
+	cpOpt := func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = common.CPMachineTemplateName(clusterName, time.Now)
+		values["cloudstackControlPlaneSshAuthorizedKey"] = cp.Spec.Users[0].SshAuthorizedKeys[0]
+		if etcdSpec != nil {
+			values["cloudstackEtcdSshAuthorizedKey"] = etcdSpec.Users[0].SshAuthorizedKeys[0]
+		}
+		values["etcdTemplateName"] = common.EtcdMachineTemplateName(clusterName, time.Now)
+	}
+	s.log.Info("cluster", "name", cluster.Name)
+
+	return s.reconcileCAPISpecs(ctx, cluster, templateBuilder, specWithBundles, cpOpt, workloadTemplateNames, kubeadmconfigTemplateNames)
+}
+
+// cloudStackTemplateBuilder adapts the CloudStack template builder, which only generates the first
+// worker node group, to the template names the controller picks for each group
+type cloudStackTemplateBuilder struct {
+	*cloudstack.CloudStackTemplateBuilder
+	workerSshAuthorizedKey string
+}
+
+func (b *cloudStackTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *c.Spec, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string) (content []byte, err error) {
+	workersOpt := func(values map[string]interface{}) {
+		values["workloadTemplateName"] = workloadTemplateNames[clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name]
+		values["cloudstackWorkerSshAuthorizedKey"] = b.workerSshAuthorizedKey
+	}
+	return b.CloudStackTemplateBuilder.GenerateCAPISpecWorkers(clusterSpec, workersOpt)
+}
+
+var _ providers.TemplateBuilder = &cloudStackTemplateBuilder{}
@@ -0,0 +1,192 @@
+package clusters
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// Field indexes of the Cluster objects by the configs they reference, with the values built by ConfigRefKey
+const (
+	DatacenterRefIndex    = "spec.datacenterRef"
+	MachineGroupRefsIndex = "spec.machineGroupRefs"
+)
+
+// TemplateRefIndex is the field index of the TinkerbellMachineConfig objects by the name of the template config they reference
+const TemplateRefIndex = "spec.templateRef"
+
+const (
+	configChangedReason = "ConfigChanged"
+	specChangedReason   = "SpecChanged"
+)
+
+// ConfigRefKey identifies a config object of a cluster by its kind and name
+func ConfigRefKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+// IndexDatacenterRef returns the DatacenterRefIndex values of a Cluster
+func IndexDatacenterRef(o client.Object) []string {
+	cluster, ok := o.(*anywherev1.Cluster)
+	if !ok || cluster.Spec.DatacenterRef.Name == "" {
+		return nil
+	}
+	return []string{ConfigRefKey(cluster.Spec.DatacenterRef.Kind, cluster.Spec.DatacenterRef.Name)}
+}
+
+// IndexMachineGroupRefs returns the MachineGroupRefsIndex values of a Cluster
+func IndexMachineGroupRefs(o client.Object) []string {
+	cluster, ok := o.(*anywherev1.Cluster)
+	if !ok {
+		return nil
+	}
+	refs := cluster.MachineConfigRefs()
+	keys := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		keys = append(keys, ConfigRefKey(ref.Kind, ref.Name))
+	}
+	return keys
+}
+
+// IndexTemplateRef returns the TemplateRefIndex values of a TinkerbellMachineConfig
+func IndexTemplateRef(o client.Object) []string {
+	machineConfig, ok := o.(*anywherev1.TinkerbellMachineConfig)
+	if !ok || machineConfig.Spec.TemplateRef.Name == "" {
+		return nil
+	}
+	return []string{machineConfig.Spec.TemplateRef.Name}
+}
+
+// templateConfigKey identifies the template config of the machine config with key machineConfigKey in the config
+// generations, so a change in the template config is attributed to the node groups using the machine config
+func templateConfigKey(machineConfigKey string) string {
+	return machineConfigKey + "/" + anywherev1.TinkerbellTemplateConfigKind
+}
+
+// ConfigGenerations returns the current generations of the datacenter and machine configs of a cluster,
+// keyed by ConfigRefKey, and of the template configs referenced by the machine configs
+func ConfigGenerations(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) (map[string]int64, error) {
+	refs := append([]anywherev1.Ref{cluster.Spec.DatacenterRef}, cluster.MachineConfigRefs()...)
+	generations := make(map[string]int64, len(refs))
+	for _, ref := range refs {
+		obj, err := getConfig(ctx, c, cluster.Namespace, ref.Kind, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		key := ConfigRefKey(ref.Kind, ref.Name)
+		generations[key] = obj.GetGeneration()
+
+		if ref.Kind != anywherev1.TinkerbellMachineConfigKind {
+			continue
+		}
+		templateName, _, _ := unstructured.NestedString(obj.Object, "spec", "templateRef", "name")
+		if templateName == "" {
+			continue
+		}
+		template, err := getConfig(ctx, c, cluster.Namespace, anywherev1.TinkerbellTemplateConfigKind, templateName)
+		if err != nil {
+			return nil, err
+		}
+		generations[templateConfigKey(key)] = template.GetGeneration()
+	}
+	return generations, nil
+}
+
+func getConfig(ctx context.Context, c client.Client, namespace, kind, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(anywherev1.GroupVersion.WithKind(kind))
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// configsChanged returns true if any of the configs referenced by refs, or their template configs, changed
+// between the applied and the current generations
+func configsChanged(applied, generations map[string]int64, refs ...*anywherev1.Ref) bool {
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+		key := ConfigRefKey(ref.Kind, ref.Name)
+		for _, k := range []string{key, templateConfigKey(key)} {
+			appliedGeneration, wasApplied := applied[k]
+			generation, exists := generations[k]
+			if wasApplied != exists || appliedGeneration != generation {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResetSpecsForChangedConfigs marks the control plane and worker specs as not applied when the configs they are
+// generated from changed since the cluster AppliedConfigGenerations, so the provider reconcilers generate new
+// machine templates and the nodes are rolled out. Clusters without AppliedConfigGenerations are left as they are
+func ResetSpecsForChangedConfigs(cluster *anywherev1.Cluster, generations map[string]int64) {
+	applied := cluster.Status.AppliedConfigGenerations
+	if applied == nil {
+		return
+	}
+
+	controlPlaneRefs := []*anywherev1.Ref{&cluster.Spec.DatacenterRef, cluster.Spec.ControlPlaneConfiguration.MachineGroupRef}
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		controlPlaneRefs = append(controlPlaneRefs, cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef)
+	}
+	if configsChanged(applied, generations, controlPlaneRefs...) {
+		conditions.MarkFalse(cluster, controlSpecPlaneAppliedCondition, configChangedReason, clusterv1.ConditionSeverityInfo, "Control plane configs changed")
+	}
+
+	workerRefs := []*anywherev1.Ref{&cluster.Spec.DatacenterRef}
+	for i := range cluster.Spec.WorkerNodeGroupConfigurations {
+		workerRefs = append(workerRefs, cluster.Spec.WorkerNodeGroupConfigurations[i].MachineGroupRef)
+	}
+	if configsChanged(applied, generations, workerRefs...) {
+		conditions.MarkFalse(cluster, workerNodeSpecPlaneAppliedCondition, configChangedReason, clusterv1.ConditionSeverityInfo, "Worker node configs changed")
+	}
+}
+
+// specAppliedConditions are the conditions the provider reconcilers set once they applied each part of the cluster spec
+var specAppliedConditions = []clusterv1.ConditionType{
+	controlSpecPlaneAppliedCondition,
+	workerNodeSpecPlaneAppliedCondition,
+	extraObjectsSpecPlaneAppliedCondition,
+	cniSpecAppliedCondition,
+}
+
+// ResetSpecsForNewGeneration marks the control plane, worker, extra objects and CNI specs as not applied when the
+// cluster spec changed since the generation last processed by the controller, so the provider reconcilers apply the
+// new spec before the cluster Status.ObservedGeneration is advanced
+func ResetSpecsForNewGeneration(cluster *anywherev1.Cluster) {
+	if cluster.Generation == cluster.Status.ObservedGeneration {
+		return
+	}
+
+	for _, condition := range specAppliedConditions {
+		conditions.MarkFalse(cluster, condition, specChangedReason, clusterv1.ConditionSeverityInfo, "Cluster spec changed")
+	}
+}
+
+// SpecsApplied returns true once the provider reconcilers applied every part of the cluster spec
+func SpecsApplied(cluster *anywherev1.Cluster) bool {
+	for _, condition := range specAppliedConditions {
+		if !conditions.IsTrue(cluster, condition) {
+			return false
+		}
+	}
+	return true
+}
+
+// RecordAppliedConfigGenerations stores the generations of the configs the CAPI specs were generated from,
+// once both the control plane and worker specs are applied
+func RecordAppliedConfigGenerations(cluster *anywherev1.Cluster, generations map[string]int64) {
+	if conditions.IsTrue(cluster, controlSpecPlaneAppliedCondition) && conditions.IsTrue(cluster, workerNodeSpecPlaneAppliedCondition) {
+		cluster.Status.AppliedConfigGenerations = generations
+	}
+}
@@ -0,0 +1,301 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/clusters"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestIndexDatacenterRef(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusters.IndexDatacenterRef(configsTestCluster())).To(Equal([]string{"VSphereDatacenterConfig/datacenter"}))
+	g.Expect(clusters.IndexDatacenterRef(&anywherev1.Cluster{})).To(BeEmpty())
+	g.Expect(clusters.IndexDatacenterRef(&anywherev1.VSphereMachineConfig{})).To(BeEmpty())
+}
+
+func TestIndexMachineGroupRefs(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusters.IndexMachineGroupRefs(configsTestCluster())).To(ConsistOf(
+		"VSphereMachineConfig/test-cluster-cp",
+		"VSphereMachineConfig/test-cluster-wn",
+	))
+	g.Expect(clusters.IndexMachineGroupRefs(&anywherev1.VSphereMachineConfig{})).To(BeEmpty())
+}
+
+func TestConfigGenerations(t *testing.T) {
+	g := NewWithT(t)
+	cluster := configsTestCluster()
+	datacenter := &anywherev1.VSphereDatacenterConfig{ObjectMeta: metav1.ObjectMeta{Name: "datacenter", Namespace: "default"}}
+	cp := &anywherev1.VSphereMachineConfig{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-cp", Namespace: "default"}}
+	wn := &anywherev1.VSphereMachineConfig{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-wn", Namespace: "default", Generation: 3}}
+	client := fake.NewClientBuilder().WithRuntimeObjects(datacenter, cp, wn).Build()
+
+	generations, err := clusters.ConfigGenerations(context.Background(), client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(generations).To(Equal(map[string]int64{
+		"VSphereDatacenterConfig/datacenter":   0,
+		"VSphereMachineConfig/test-cluster-cp": 0,
+		"VSphereMachineConfig/test-cluster-wn": 3,
+	}))
+}
+
+func TestIndexTemplateRef(t *testing.T) {
+	g := NewWithT(t)
+	machineConfig := &anywherev1.TinkerbellMachineConfig{
+		Spec: anywherev1.TinkerbellMachineConfigSpec{TemplateRef: anywherev1.Ref{Kind: anywherev1.TinkerbellTemplateConfigKind, Name: "template"}},
+	}
+	g.Expect(clusters.IndexTemplateRef(machineConfig)).To(Equal([]string{"template"}))
+	g.Expect(clusters.IndexTemplateRef(&anywherev1.TinkerbellMachineConfig{})).To(BeEmpty())
+	g.Expect(clusters.IndexTemplateRef(&anywherev1.Cluster{})).To(BeEmpty())
+}
+
+func TestConfigGenerationsTemplateConfig(t *testing.T) {
+	g := NewWithT(t)
+	cluster := configsTestCluster()
+	cluster.Spec.DatacenterRef.Kind = anywherev1.TinkerbellDatacenterKind
+	cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Kind = anywherev1.TinkerbellMachineConfigKind
+	cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Kind = anywherev1.TinkerbellMachineConfigKind
+	datacenter := &anywherev1.TinkerbellDatacenterConfig{ObjectMeta: metav1.ObjectMeta{Name: "datacenter", Namespace: "default"}}
+	cp := &anywherev1.TinkerbellMachineConfig{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-cp", Namespace: "default"}}
+	wn := &anywherev1.TinkerbellMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-wn", Namespace: "default"},
+		Spec:       anywherev1.TinkerbellMachineConfigSpec{TemplateRef: anywherev1.Ref{Kind: anywherev1.TinkerbellTemplateConfigKind, Name: "template"}},
+	}
+	template := &anywherev1.TinkerbellTemplateConfig{ObjectMeta: metav1.ObjectMeta{Name: "template", Namespace: "default", Generation: 2}}
+	client := fake.NewClientBuilder().WithRuntimeObjects(datacenter, cp, wn, template).Build()
+
+	generations, err := clusters.ConfigGenerations(context.Background(), client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(generations).To(Equal(map[string]int64{
+		"TinkerbellDatacenterConfig/datacenter":                            0,
+		"TinkerbellMachineConfig/test-cluster-cp":                          0,
+		"TinkerbellMachineConfig/test-cluster-wn":                          0,
+		"TinkerbellMachineConfig/test-cluster-wn/TinkerbellTemplateConfig": 2,
+	}))
+}
+
+func TestConfigGenerationsMissingConfig(t *testing.T) {
+	g := NewWithT(t)
+	client := fake.NewClientBuilder().Build()
+
+	_, err := clusters.ConfigGenerations(context.Background(), client, configsTestCluster())
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestResetSpecsForChangedConfigs(t *testing.T) {
+	applied := map[string]int64{
+		"VSphereDatacenterConfig/datacenter":   1,
+		"VSphereMachineConfig/test-cluster-cp": 1,
+		"VSphereMachineConfig/test-cluster-wn": 1,
+	}
+
+	tests := []struct {
+		name                    string
+		applied                 map[string]int64
+		generations             map[string]int64
+		wantControlPlaneApplied bool
+		wantWorkerNodeApplied   bool
+	}{
+		{
+			name:                    "no changes",
+			applied:                 applied,
+			generations:             applied,
+			wantControlPlaneApplied: true,
+			wantWorkerNodeApplied:   true,
+		},
+		{
+			name:    "control plane machine config changed",
+			applied: applied,
+			generations: map[string]int64{
+				"VSphereDatacenterConfig/datacenter":   1,
+				"VSphereMachineConfig/test-cluster-cp": 2,
+				"VSphereMachineConfig/test-cluster-wn": 1,
+			},
+			wantControlPlaneApplied: false,
+			wantWorkerNodeApplied:   true,
+		},
+		{
+			name:    "worker machine config changed",
+			applied: applied,
+			generations: map[string]int64{
+				"VSphereDatacenterConfig/datacenter":   1,
+				"VSphereMachineConfig/test-cluster-cp": 1,
+				"VSphereMachineConfig/test-cluster-wn": 2,
+			},
+			wantControlPlaneApplied: true,
+			wantWorkerNodeApplied:   false,
+		},
+		{
+			name:    "datacenter changed",
+			applied: applied,
+			generations: map[string]int64{
+				"VSphereDatacenterConfig/datacenter":   2,
+				"VSphereMachineConfig/test-cluster-cp": 1,
+				"VSphereMachineConfig/test-cluster-wn": 1,
+			},
+			wantControlPlaneApplied: false,
+			wantWorkerNodeApplied:   false,
+		},
+		{
+			name: "new worker machine config",
+			applied: map[string]int64{
+				"VSphereDatacenterConfig/datacenter":   1,
+				"VSphereMachineConfig/test-cluster-cp": 1,
+			},
+			generations:             applied,
+			wantControlPlaneApplied: true,
+			wantWorkerNodeApplied:   false,
+		},
+		{
+			name: "worker template config changed",
+			applied: map[string]int64{
+				"VSphereDatacenterConfig/datacenter":                            1,
+				"VSphereMachineConfig/test-cluster-cp":                          1,
+				"VSphereMachineConfig/test-cluster-wn":                          1,
+				"VSphereMachineConfig/test-cluster-wn/TinkerbellTemplateConfig": 1,
+			},
+			generations: map[string]int64{
+				"VSphereDatacenterConfig/datacenter":                            1,
+				"VSphereMachineConfig/test-cluster-cp":                          1,
+				"VSphereMachineConfig/test-cluster-wn":                          1,
+				"VSphereMachineConfig/test-cluster-wn/TinkerbellTemplateConfig": 2,
+			},
+			wantControlPlaneApplied: true,
+			wantWorkerNodeApplied:   false,
+		},
+		{
+			name:    "no applied generations",
+			applied: nil,
+			generations: map[string]int64{
+				"VSphereDatacenterConfig/datacenter":   2,
+				"VSphereMachineConfig/test-cluster-cp": 2,
+				"VSphereMachineConfig/test-cluster-wn": 2,
+			},
+			wantControlPlaneApplied: true,
+			wantWorkerNodeApplied:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := configsTestCluster()
+			conditions.MarkTrue(cluster, "ControlPlaneSpecApplied")
+			conditions.MarkTrue(cluster, "WorkerNodeSpecApplied")
+			cluster.Status.AppliedConfigGenerations = tt.applied
+
+			clusters.ResetSpecsForChangedConfigs(cluster, tt.generations)
+
+			g.Expect(conditions.IsTrue(cluster, "ControlPlaneSpecApplied")).To(Equal(tt.wantControlPlaneApplied))
+			g.Expect(conditions.IsTrue(cluster, "WorkerNodeSpecApplied")).To(Equal(tt.wantWorkerNodeApplied))
+		})
+	}
+}
+
+func TestResetSpecsForNewGeneration(t *testing.T) {
+	specApplied := []clusterv1.ConditionType{"ControlPlaneSpecApplied", "WorkerNodeSpecApplied", "ExtraObjectsSpecApplied", "CNISpecApplied"}
+	tests := []struct {
+		name               string
+		generation         int64
+		observedGeneration int64
+		wantApplied        bool
+	}{
+		{
+			name:               "generation already observed",
+			generation:         2,
+			observedGeneration: 2,
+			wantApplied:        true,
+		},
+		{
+			name:               "new generation",
+			generation:         3,
+			observedGeneration: 2,
+			wantApplied:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := configsTestCluster()
+			cluster.Generation = tt.generation
+			cluster.Status.ObservedGeneration = tt.observedGeneration
+			for _, condition := range specApplied {
+				conditions.MarkTrue(cluster, condition)
+			}
+
+			clusters.ResetSpecsForNewGeneration(cluster)
+
+			for _, condition := range specApplied {
+				g.Expect(conditions.IsTrue(cluster, condition)).To(Equal(tt.wantApplied), "condition %s", condition)
+			}
+		})
+	}
+}
+
+func TestRecordAppliedConfigGenerations(t *testing.T) {
+	g := NewWithT(t)
+	cluster := configsTestCluster()
+	generations := map[string]int64{"VSphereDatacenterConfig/datacenter": 1}
+
+	conditions.MarkTrue(cluster, "ControlPlaneSpecApplied")
+	clusters.RecordAppliedConfigGenerations(cluster, generations)
+	g.Expect(cluster.Status.AppliedConfigGenerations).To(BeNil())
+
+	conditions.MarkTrue(cluster, "WorkerNodeSpecApplied")
+	clusters.RecordAppliedConfigGenerations(cluster, generations)
+	g.Expect(cluster.Status.AppliedConfigGenerations).To(Equal(generations))
+}
+
+func TestSpecsApplied(t *testing.T) {
+	g := NewWithT(t)
+	cluster := configsTestCluster()
+
+	for _, condition := range []clusterv1.ConditionType{"ControlPlaneSpecApplied", "WorkerNodeSpecApplied", "ExtraObjectsSpecApplied"} {
+		conditions.MarkTrue(cluster, condition)
+	}
+	g.Expect(clusters.SpecsApplied(cluster)).To(BeFalse())
+
+	conditions.MarkTrue(cluster, "CNISpecApplied")
+	g.Expect(clusters.SpecsApplied(cluster)).To(BeTrue())
+}
+
+func configsTestCluster() *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{
+				Kind: anywherev1.VSphereDatacenterKind,
+				Name: "datacenter",
+			},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				Count: 1,
+				MachineGroupRef: &anywherev1.Ref{
+					Kind: anywherev1.VSphereMachineConfigKind,
+					Name: "test-cluster-cp",
+				},
+			},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{
+					Name:  "md-0",
+					Count: 1,
+					MachineGroupRef: &anywherev1.Ref{
+						Kind: anywherev1.VSphereMachineConfigKind,
+						Name: "test-cluster-wn",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// DeleteConfigObjects deletes the datacenter, machine, identity provider and GitOps configs referenced by a cluster
+// being deleted, the same objects the CLI deletes with a managed cluster. Objects also referenced by another cluster
+// in the namespace, even one being deleted, are kept and only deleted by the last cluster releasing them. It returns
+// the objects that still exist after being deleted, usually because they have finalizers, and the shared objects the
+// cluster has to delete once the other clusters release them, so the caller can wait for them before releasing the cluster
+func DeleteConfigObjects(ctx context.Context, c client.Client, log logr.Logger, cluster *anywherev1.Cluster) (pending []string, err error) {
+	users, err := refUsers(ctx, c, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range configRefs(cluster) {
+		if others := users[ref]; len(others) > 0 {
+			if releasesFirst(cluster, others) {
+				log.Info("Keeping config object shared with another cluster", "kind", ref.Kind, "name", ref.Name, "cluster", others[0].Name)
+			} else {
+				log.Info("Waiting for the clusters being deleted to release shared config object", "kind", ref.Kind, "name", ref.Name, "cluster", others[0].Name)
+				pending = append(pending, fmt.Sprintf("%s %s", ref.Kind, ref.Name))
+			}
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(anywherev1.GroupVersion.WithKind(ref.Kind))
+		if err := c.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: ref.Name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed getting %s %s: %v", ref.Kind, ref.Name, err)
+		}
+
+		if obj.GetDeletionTimestamp().IsZero() {
+			if err := c.Delete(ctx, obj); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("failed deleting %s %s: %v", ref.Kind, ref.Name, err)
+			}
+			// Objects without finalizers are removed right away
+			if len(obj.GetFinalizers()) == 0 {
+				continue
+			}
+		}
+
+		pending = append(pending, fmt.Sprintf("%s %s", ref.Kind, ref.Name))
+	}
+
+	return pending, nil
+}
+
+// refUsers returns the clusters in the namespace of cluster, other than cluster itself, referencing each config object
+func refUsers(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) (map[anywherev1.Ref][]*anywherev1.Cluster, error) {
+	clusters := &anywherev1.ClusterList{}
+	if err := c.List(ctx, clusters, client.InNamespace(cluster.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing clusters: %v", err)
+	}
+
+	users := map[anywherev1.Ref][]*anywherev1.Cluster{}
+	for i := range clusters.Items {
+		other := &clusters.Items[i]
+		if other.Name == cluster.Name {
+			continue
+		}
+		for _, ref := range configRefs(other) {
+			users[ref] = append(users[ref], other)
+		}
+	}
+	return users, nil
+}
+
+// releasesFirst returns true when cluster can release a config object shared with others without deleting it. That's
+// the case when one of the others isn't being deleted, or when cluster comes first by name among the clusters being
+// deleted together, so they release the object one at a time and the last one deletes it. A cluster that has to wait
+// keeps waiting while a stale cache still lists the clusters already gone, instead of releasing the object with them
+func releasesFirst(cluster *anywherev1.Cluster, others []*anywherev1.Cluster) bool {
+	for _, other := range others {
+		if other.DeletionTimestamp.IsZero() {
+			return true
+		}
+	}
+	for _, other := range others {
+		if other.Name < cluster.Name {
+			return false
+		}
+	}
+	return true
+}
+
+// configRefs returns the refs to the config objects owned by a cluster
+func configRefs(cluster *anywherev1.Cluster) []anywherev1.Ref {
+	refs := make([]anywherev1.Ref, 0, 4)
+	if cluster.Spec.GitOpsRef != nil {
+		refs = append(refs, *cluster.Spec.GitOpsRef)
+	}
+	refs = append(refs, cluster.Spec.IdentityProviderRefs...)
+	refs = append(refs, cluster.MachineConfigRefs()...)
+	refs = append(refs, cluster.Spec.DatacenterRef)
+
+	validRefs := refs[:0]
+	for _, ref := range refs {
+		if ref.Kind != "" && ref.Name != "" {
+			validRefs = append(validRefs, ref)
+		}
+	}
+	return validRefs
+}
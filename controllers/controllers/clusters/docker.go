@@ -2,19 +2,58 @@ package clusters
 
 import (
 	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/controllers/controllers/reconciler"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
 )
 
 type DockerReconciler struct {
 	*providerClusterReconciler
 }
 
-func NewDockerReconciler() *DockerReconciler {
-	return &DockerReconciler{providerClusterReconciler: &providerClusterReconciler{}}
+func NewDockerReconciler(client client.Client, log logr.Logger, helm cilium.Helm, tracker *remote.ClusterCacheTracker) *DockerReconciler {
+	return &DockerReconciler{
+		providerClusterReconciler: newProviderClusterReconciler(client, log, helm, tracker),
+	}
 }
 
-func (v *DockerReconciler) Reconcile(ctx context.Context, cluster *anywherev1.Cluster) (reconciler.Result, error) {
-	return reconciler.Result{}, nil
+func (d *DockerReconciler) Reconcile(ctx context.Context, cluster *anywherev1.Cluster) (reconciler.Result, error) {
+	dataCenterConfig := &anywherev1.DockerDatacenterConfig{}
+	dataCenterName := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Spec.DatacenterRef.Name}
+	if err := d.providerClient.Get(ctx, dataCenterName, dataCenterConfig); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	specWithBundles, err := d.specWithBundles(ctx, cluster)
+	if err != nil {
+		return reconciler.Result{}, err
+	}
+
+	templateBuilder := docker.NewDockerTemplateBuilder(time.Now)
+	clusterName := cluster.ObjectMeta.Name
+
+	kubeadmconfigTemplateNames := make(map[string]string, len(cluster.Spec.WorkerNodeGroupConfigurations))
+	workloadTemplateNames := make(map[string]string, len(cluster.Spec.WorkerNodeGroupConfigurations))
+
+	for _, wnConfig := range cluster.Spec.WorkerNodeGroupConfigurations {
+		kubeadmconfigTemplateNames[wnConfig.Name] = common.KubeadmConfigTemplateName(clusterName, wnConfig.Name, time.Now)
+		workloadTemplateNames[wnConfig.Name] = common.WorkerMachineTemplateName(clusterName, wnConfig.Name, time.Now)
+	}
+
+	cpOpt := func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = common.CPMachineTemplateName(clusterName, time.Now)
+		values["etcdTemplateName"] = common.EtcdMachineTemplateName(clusterName, time.Now)
+	}
+	d.log.Info("cluster", "name", cluster.Name)
+
+	return d.reconcileCAPISpecs(ctx, cluster, templateBuilder, specWithBundles, cpOpt, workloadTemplateNames, kubeadmconfigTemplateNames)
 }
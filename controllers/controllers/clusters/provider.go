@@ -3,32 +3,87 @@ package clusters
 import (
 	"context"
 	"fmt"
+	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/controllers/controllers/reconciler"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/networking/calico"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
+const defaultRequeueTime = time.Minute
+
+// TODO move these constants
+const (
+	managedEtcdReadyCondition             clusterv1.ConditionType = "ManagedEtcdReady"
+	controlSpecPlaneAppliedCondition      clusterv1.ConditionType = "ControlPlaneSpecApplied"
+	workerNodeSpecPlaneAppliedCondition   clusterv1.ConditionType = "WorkerNodeSpecApplied"
+	extraObjectsSpecPlaneAppliedCondition clusterv1.ConditionType = "ExtraObjectsSpecApplied"
+	cniSpecAppliedCondition               clusterv1.ConditionType = "CNISpecApplied"
+	controlPlaneReadyCondition            clusterv1.ConditionType = "ControlPlaneReady"
+)
+
+// providerNamespaces are the namespaces of the CAPI infrastructure providers installed by the CLI, whose traffic
+// is allowed by the cilium network policies
+var providerNamespaces = map[string][]string{
+	anywherev1.VSphereDatacenterKind:    {"capv-system"},
+	anywherev1.DockerDatacenterKind:     {"capd-system"},
+	anywherev1.CloudStackDatacenterKind: {"capc-system"},
+	anywherev1.TinkerbellDatacenterKind: {"capt-system"},
+	anywherev1.SnowDatacenterKind:       {"capas-system"},
+}
+
 type ProviderClusterReconciler interface {
 	Reconcile(ctx context.Context, cluster *anywherev1.Cluster) (reconciler.Result, error)
 }
 
-func BuildProviderReconciler(datacenterKind string, client client.Client, log logr.Logger, validator *vsphere.Validator, defaulter *vsphere.Defaulter, tracker *remote.ClusterCacheTracker) (ProviderClusterReconciler, error) {
+func BuildProviderReconciler(datacenterKind string, client client.Client, log logr.Logger, validator *vsphere.Validator, defaulter *vsphere.Defaulter, cmkBuilder CmkBuilder, helm cilium.Helm, tracker *remote.ClusterCacheTracker) (ProviderClusterReconciler, error) {
 	switch datacenterKind {
 	case anywherev1.VSphereDatacenterKind:
-		return NewVSphereReconciler(client, log, validator, defaulter, tracker), nil
+		return NewVSphereReconciler(client, log, validator, defaulter, helm, tracker), nil
+	case anywherev1.DockerDatacenterKind:
+		return NewDockerReconciler(client, log, helm, tracker), nil
+	case anywherev1.CloudStackDatacenterKind:
+		return NewCloudStackReconciler(client, log, cmkBuilder, helm, tracker), nil
+	case anywherev1.TinkerbellDatacenterKind:
+		return NewTinkerbellReconciler(client, log, helm, tracker), nil
+	case anywherev1.SnowDatacenterKind:
+		return NewSnowReconciler(client, log, helm, tracker), nil
 	}
 	return nil, fmt.Errorf("invalid data center type %s", datacenterKind)
 }
 
+// providerClusterReconciler holds the reconcile steps that don't depend on the provider
 type providerClusterReconciler struct {
 	providerClient client.Client
+	log            logr.Logger
+	helm           cilium.Helm
+	tracker        *remote.ClusterCacheTracker
+}
+
+func newProviderClusterReconciler(client client.Client, log logr.Logger, helm cilium.Helm, tracker *remote.ClusterCacheTracker) *providerClusterReconciler {
+	return &providerClusterReconciler{
+		providerClient: client,
+		log:            log,
+		helm:           helm,
+		tracker:        tracker,
+	}
 }
 
 func (p *providerClusterReconciler) eksdRelease(ctx context.Context, name, namespace string) (*eksdv1alpha1.Release, error) {
@@ -41,3 +96,264 @@ func (p *providerClusterReconciler) eksdRelease(ctx context.Context, name, names
 
 	return eksd, nil
 }
+
+func (p *providerClusterReconciler) bundles(ctx context.Context, name, namespace string) (*releasev1alpha1.Bundles, error) {
+	clusterBundle := &releasev1alpha1.Bundles{}
+	bundleName := types.NamespacedName{Namespace: namespace, Name: name}
+
+	if err := p.providerClient.Get(ctx, bundleName, clusterBundle); err != nil {
+		return nil, err
+	}
+
+	return clusterBundle, nil
+}
+
+// specWithBundles builds the cluster spec with the bundles of its management cluster and their eks-d release
+func (p *providerClusterReconciler) specWithBundles(ctx context.Context, cluster *anywherev1.Cluster) (*c.Spec, error) {
+	p.log.V(4).Info("Fetching bundle", "cluster name", cluster.Spec.ManagementCluster.Name)
+	bundles, err := p.bundles(ctx, cluster.Spec.ManagementCluster.Name, "default")
+	if err != nil {
+		return nil, err
+	}
+	versionsBundle, err := c.GetVersionsBundle(cluster, bundles)
+	if err != nil {
+		return nil, err
+	}
+	p.log.V(4).Info("Fetching eks-d manifest", "release name", versionsBundle.EksD.Name)
+	eksd, err := p.eksdRelease(ctx, versionsBundle.EksD.Name, constants.EksaSystemNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.BuildSpecFromBundles(cluster, bundles, c.WithEksdRelease(eksd))
+}
+
+// reconcileCAPISpecs applies the control plane and worker specs and, once the control plane is ready,
+// the extra objects and the CNI of the cluster
+func (p *providerClusterReconciler) reconcileCAPISpecs(
+	ctx context.Context, cluster *anywherev1.Cluster, templateBuilder providers.TemplateBuilder, specWithBundles *c.Spec,
+	cpOpt func(values map[string]interface{}), workloadTemplateNames, kubeadmconfigTemplateNames map[string]string,
+) (reconciler.Result, error) {
+	if result, err := p.reconcileControlPlaneSpec(ctx, cluster, templateBuilder, specWithBundles, cpOpt); err != nil {
+		return result, err
+	}
+
+	if result, err := p.reconcileWorkerNodeSpec(ctx, cluster, templateBuilder, specWithBundles, workloadTemplateNames, kubeadmconfigTemplateNames); err != nil {
+		return result, err
+	}
+
+	capiCluster, result, errCAPICLuster := p.getCAPICluster(ctx, cluster)
+	if errCAPICLuster != nil {
+		return result, errCAPICLuster
+	}
+
+	// wait for etcd if necessary
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		if !conditions.Has(capiCluster, managedEtcdReadyCondition) || conditions.IsFalse(capiCluster, managedEtcdReadyCondition) {
+			p.log.Info("Waiting for etcd to be ready", "cluster", cluster.Name)
+			return reconciler.Result{Result: &ctrl.Result{
+				RequeueAfter: defaultRequeueTime,
+			}}, nil
+		}
+	}
+
+	if !conditions.IsTrue(capiCluster, controlPlaneReadyCondition) {
+		p.log.Info("waiting for control plane to be ready", "cluster", capiCluster.Name, "kind", capiCluster.Kind)
+		return reconciler.Result{Result: &ctrl.Result{
+			RequeueAfter: defaultRequeueTime,
+		}}, nil
+	}
+
+	if result, err := p.reconcileExtraObjects(ctx, cluster, capiCluster, specWithBundles); err != nil {
+		return result, err
+	}
+
+	if result, err := p.reconcileCNI(ctx, cluster, capiCluster, specWithBundles); err != nil {
+		return result, err
+	}
+
+	return reconciler.Result{}, nil
+}
+
+func (p *providerClusterReconciler) reconcileCNI(ctx context.Context, cluster *anywherev1.Cluster, capiCluster *clusterv1.Cluster, specWithBundles *c.Spec) (reconciler.Result, error) {
+	if !conditions.IsTrue(cluster, cniSpecAppliedCondition) {
+		p.log.Info("Getting remote client", "client for cluster", capiCluster.Name)
+		key := client.ObjectKey{
+			Namespace: capiCluster.Namespace,
+			Name:      capiCluster.Name,
+		}
+		remoteClient, err := p.tracker.GetClient(ctx, key)
+		if err != nil {
+			return reconciler.Result{}, err
+		}
+
+		p.log.Info("About to apply CNI")
+
+		cniSpec, err := p.generateCNIManifest(ctx, cluster, specWithBundles)
+		if err != nil {
+			return reconciler.Result{}, err
+		}
+		if err := reconciler.ReconcileYaml(ctx, remoteClient, cniSpec); err != nil {
+			return reconciler.Result{}, err
+		}
+		conditions.MarkTrue(cluster, cniSpecAppliedCondition)
+	}
+	return reconciler.Result{}, nil
+}
+
+// generateCNIManifest generates the manifest of the CNI configured in the cluster spec, the same one the CLI installs
+func (p *providerClusterReconciler) generateCNIManifest(ctx context.Context, cluster *anywherev1.Cluster, specWithBundles *c.Spec) ([]byte, error) {
+	spec := specWithCNIConfig(specWithBundles)
+	namespaces := providerNamespaces[cluster.Spec.DatacenterRef.Kind]
+	cniConfig := spec.Cluster.Spec.ClusterNetwork.CNIConfig
+	switch {
+	case cniConfig.Kindnetd != nil:
+		return kindnetd.NewKindnetd(nil).GenerateManifest(ctx, spec, namespaces)
+	case cniConfig.Calico != nil:
+		return calico.NewCalico(nil).GenerateManifest(ctx, spec, namespaces)
+	case cniConfig.Cilium != nil:
+		return cilium.NewCilium(nil, p.helm).GenerateManifest(ctx, spec, namespaces)
+	}
+	return nil, fmt.Errorf("no CNI configured for cluster %s", cluster.Name)
+}
+
+// specWithCNIConfig returns spec with the CNIConfig built from the deprecated CNI field for clusters
+// created before CNIConfig was introduced, since the controller doesn't default the cluster spec
+func specWithCNIConfig(spec *c.Spec) *c.Spec {
+	network := spec.Cluster.Spec.ClusterNetwork
+	if network.CNIConfig != nil {
+		return spec
+	}
+
+	cluster := spec.Cluster.DeepCopy()
+	cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{}
+	switch network.CNI {
+	case anywherev1.Kindnetd:
+		cluster.Spec.ClusterNetwork.CNIConfig.Kindnetd = &anywherev1.KindnetdConfig{}
+	case anywherev1.Cilium, anywherev1.CiliumEnterprise:
+		cluster.Spec.ClusterNetwork.CNIConfig.Cilium = &anywherev1.CiliumConfig{}
+	}
+
+	config := *spec.Config
+	config.Cluster = cluster
+	specCopy := *spec
+	specCopy.Config = &config
+	return &specCopy
+}
+
+func (p *providerClusterReconciler) reconcileExtraObjects(ctx context.Context, cluster *anywherev1.Cluster, capiCluster *clusterv1.Cluster, specWithBundles *c.Spec) (reconciler.Result, error) {
+	if !conditions.IsTrue(cluster, extraObjectsSpecPlaneAppliedCondition) {
+		extraObjects := c.BuildExtraObjects(specWithBundles)
+
+		for _, spec := range extraObjects.Values() {
+			if err := reconciler.ReconcileYaml(ctx, p.providerClient, spec); err != nil {
+				return reconciler.Result{}, err
+			}
+		}
+		conditions.MarkTrue(cluster, extraObjectsSpecPlaneAppliedCondition)
+	}
+	return reconciler.Result{}, nil
+}
+
+func (p *providerClusterReconciler) getCAPICluster(ctx context.Context, cluster *anywherev1.Cluster) (*clusterv1.Cluster, reconciler.Result, error) {
+	capiCluster := &clusterv1.Cluster{}
+	capiClusterName := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: cluster.Name}
+	p.log.Info("Searching for CAPI cluster", "name", cluster.Name)
+	if err := p.providerClient.Get(ctx, capiClusterName, capiCluster); err != nil {
+		return nil, reconciler.Result{Result: &ctrl.Result{
+			Requeue:      true,
+			RequeueAfter: defaultRequeueTime,
+		}}, err
+	}
+	return capiCluster, reconciler.Result{}, nil
+}
+
+func (p *providerClusterReconciler) reconcileWorkerNodeSpec(
+	ctx context.Context, cluster *anywherev1.Cluster, templateBuilder providers.TemplateBuilder,
+	specWithBundles *c.Spec, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string,
+) (reconciler.Result, error) {
+	if !conditions.IsTrue(cluster, workerNodeSpecPlaneAppliedCondition) {
+		if err := p.keepUnchangedWorkerTemplates(ctx, cluster, workloadTemplateNames, kubeadmconfigTemplateNames); err != nil {
+			return reconciler.Result{}, err
+		}
+
+		workersSpec, err := templateBuilder.GenerateCAPISpecWorkers(specWithBundles, workloadTemplateNames, kubeadmconfigTemplateNames)
+		if err != nil {
+			return reconciler.Result{}, err
+		}
+
+		if err := reconciler.ReconcileYaml(ctx, p.providerClient, workersSpec); err != nil {
+			return reconciler.Result{}, err
+		}
+
+		conditions.MarkTrue(cluster, workerNodeSpecPlaneAppliedCondition)
+	}
+	return reconciler.Result{}, nil
+}
+
+// keepUnchangedWorkerTemplates replaces the new template names of the worker node groups whose configs didn't change since
+// the cluster AppliedConfigGenerations with the templates of their MachineDeployment, so only the node groups whose configs
+// changed are rolled out. All groups get new templates when the cluster spec or the datacenter config changed, since those
+// can change the templates of any of them
+func (p *providerClusterReconciler) keepUnchangedWorkerTemplates(ctx context.Context, cluster *anywherev1.Cluster, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string) error {
+	applied := cluster.Status.AppliedConfigGenerations
+	if applied == nil || cluster.Generation != cluster.Status.ObservedGeneration {
+		return nil
+	}
+
+	generations, err := ConfigGenerations(ctx, p.providerClient, cluster)
+	if err != nil {
+		return err
+	}
+	if configsChanged(applied, generations, &cluster.Spec.DatacenterRef) {
+		return nil
+	}
+
+	for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+		if configsChanged(applied, generations, wng.MachineGroupRef) {
+			continue
+		}
+
+		md := &clusterv1.MachineDeployment{}
+		mdName := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: machineDeploymentName(cluster, wng)}
+		if err := p.providerClient.Get(ctx, mdName, md); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		workloadTemplateNames[wng.Name] = md.Spec.Template.Spec.InfrastructureRef.Name
+		if md.Spec.Template.Spec.Bootstrap.ConfigRef != nil {
+			kubeadmconfigTemplateNames[wng.Name] = md.Spec.Template.Spec.Bootstrap.ConfigRef.Name
+		}
+	}
+
+	return nil
+}
+
+func (p *providerClusterReconciler) reconcileControlPlaneSpec(ctx context.Context, cluster *anywherev1.Cluster, templateBuilder providers.TemplateBuilder, specWithBundles *c.Spec, cpOpt func(values map[string]interface{})) (reconciler.Result, error) {
+	if !conditions.IsTrue(cluster, controlSpecPlaneAppliedCondition) {
+		p.log.Info("Applying control plane spec", "name", cluster.Name)
+		controlPlaneSpec, err := templateBuilder.GenerateCAPISpecControlPlane(specWithBundles, cpOpt)
+		if err != nil {
+			return reconciler.Result{}, err
+		}
+		if err := reconciler.ReconcileYaml(ctx, p.providerClient, controlPlaneSpec); err != nil {
+			return reconciler.Result{Result: &ctrl.Result{
+				RequeueAfter: defaultRequeueTime,
+			}}, err
+		}
+		conditions.MarkTrue(cluster, controlSpecPlaneAppliedCondition)
+	}
+	return reconciler.Result{}, nil
+}
+
+// validateSshAuthorizedKey checks that the first user of a machine config has an ssh authorized key, which the
+// templates are generated with. The CLI generates it when creating the cluster, but the controller doesn't
+func validateSshAuthorizedKey(kind, name string, users []anywherev1.UserConfiguration) error {
+	if len(users) == 0 || len(users[0].SshAuthorizedKeys) == 0 || users[0].SshAuthorizedKeys[0] == "" {
+		return fmt.Errorf("%s %s: a user with an ssh authorized key is required", kind, name)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package clusters
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/reconciler"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+type SnowReconciler struct {
+	*providerClusterReconciler
+}
+
+func NewSnowReconciler(client client.Client, log logr.Logger, helm cilium.Helm, tracker *remote.ClusterCacheTracker) *SnowReconciler {
+	return &SnowReconciler{
+		providerClusterReconciler: newProviderClusterReconciler(client, log, helm, tracker),
+	}
+}
+
+func (s *SnowReconciler) Reconcile(ctx context.Context, cluster *anywherev1.Cluster) (reconciler.Result, error) {
+	dataCenterConfig := &anywherev1.SnowDatacenterConfig{}
+	dataCenterName := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Spec.DatacenterRef.Name}
+	if err := s.providerClient.Get(ctx, dataCenterName, dataCenterConfig); err != nil {
+		return reconciler.Result{}, err
+	}
+	if err := dataCenterConfig.Validate(); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	machineConfigMap := map[string]*anywherev1.SnowMachineConfig{}
+	for _, ref := range cluster.MachineConfigRefs() {
+		machineConfig := &anywherev1.SnowMachineConfig{}
+		machineConfigName := types.NamespacedName{Namespace: cluster.Namespace, Name: ref.Name}
+		if err := s.providerClient.Get(ctx, machineConfigName, machineConfig); err != nil {
+			return reconciler.Result{}, err
+		}
+		if err := machineConfig.Validate(); err != nil {
+			return reconciler.Result{}, err
+		}
+		machineConfigMap[ref.Name] = machineConfig
+	}
+
+	specWithBundles, err := s.specWithBundles(ctx, cluster)
+	if err != nil {
+		return reconciler.Result{}, err
+	}
+	specWithBundles.SnowDatacenter = dataCenterConfig
+	specWithBundles.SnowMachineConfigs = machineConfigMap
+
+	oldControlPlaneTemplate, oldWorkerTemplates, err := s.machineTemplatesInCluster(ctx, cluster)
+	if err != nil {
+		return reconciler.Result{}, err
+	}
+
+	templateBuilder := &snowTemplateBuilder{
+		oldControlPlaneTemplate: oldControlPlaneTemplate,
+		oldWorkerTemplates:      oldWorkerTemplates,
+		now:                     time.Now,
+	}
+
+	s.log.Info("cluster", "name", cluster.Name)
+
+	// The snow template builder names the machine templates, so neither the control plane nor the workers
+	// need extra template values
+	return s.reconcileCAPISpecs(ctx, cluster, templateBuilder, specWithBundles, nil, nil, nil)
+}
+
+// machineTemplatesInCluster returns the machine templates of the cluster KubeadmControlPlane and of the MachineDeployment
+// of each worker node group, keyed by worker node group name. The control plane template is nil before the cluster is
+// created, and worker node groups without a MachineDeployment yet have no template
+func (s *SnowReconciler) machineTemplatesInCluster(ctx context.Context, cluster *anywherev1.Cluster) (*snowv1.AWSSnowMachineTemplate, map[string]*snowv1.AWSSnowMachineTemplate, error) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := s.providerClient.Get(ctx, types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: cluster.Name}, kcp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	controlPlaneTemplate, err := s.machineTemplate(ctx, kcp.Spec.MachineTemplate.InfrastructureRef.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workerTemplates := map[string]*snowv1.AWSSnowMachineTemplate{}
+	for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+		md := &clusterv1.MachineDeployment{}
+		if err := s.providerClient.Get(ctx, types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: machineDeploymentName(cluster, wng)}, md); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		workerTemplate, err := s.machineTemplate(ctx, md.Spec.Template.Spec.InfrastructureRef.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		workerTemplates[wng.Name] = workerTemplate
+	}
+
+	return controlPlaneTemplate, workerTemplates, nil
+}
+
+func (s *SnowReconciler) machineTemplate(ctx context.Context, name string) (*snowv1.AWSSnowMachineTemplate, error) {
+	template := &snowv1.AWSSnowMachineTemplate{}
+	if err := s.providerClient.Get(ctx, types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: name}, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// snowTemplateBuilder generates the snow CAPI specs from the api objects built by the snow provider. Machine templates are
+// immutable, so the ones in the cluster are reused if they don't change and templates with new names are created otherwise,
+// the same way the CLI does on upgrade. Only the templates in the cluster are known, so a template is renamed when its
+// content changes
+type snowTemplateBuilder struct {
+	oldControlPlaneTemplate *snowv1.AWSSnowMachineTemplate
+	oldWorkerTemplates      map[string]*snowv1.AWSSnowMachineTemplate
+	now                     func() time.Time
+}
+
+func (b *snowTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *c.Spec, _ ...providers.BuildMapOption) (content []byte, err error) {
+	if b.oldControlPlaneTemplate == nil {
+		return templater.ObjectsToYaml(snow.ControlPlaneObjects(clusterSpec, clusterSpec.SnowMachineConfigs)...)
+	}
+	return templater.ObjectsToYaml(snow.ControlPlaneObjectsForUpgrade(clusterSpec, clusterSpec, b.oldControlPlaneTemplate, b.now)...)
+}
+
+func (b *snowTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *c.Spec, _, _ map[string]string) (content []byte, err error) {
+	if b.oldControlPlaneTemplate == nil {
+		return templater.ObjectsToYaml(snow.WorkersObjects(clusterSpec, clusterSpec.SnowMachineConfigs)...)
+	}
+	return templater.ObjectsToYaml(snow.WorkersObjectsForUpgrade(clusterSpec, clusterSpec, b.oldWorkerTemplates, b.now)...)
+}
@@ -0,0 +1,178 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// UpdateClusterStatus fills in the node group replicas and the conditions of the cluster status
+// from its KubeadmControlPlane and MachineDeployments, and summarizes them in the Ready condition
+func UpdateClusterStatus(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
+	if err := updateControlPlaneStatus(ctx, client, cluster); err != nil {
+		return err
+	}
+
+	if err := updateWorkersStatus(ctx, client, cluster); err != nil {
+		return err
+	}
+
+	updateAddonsStatus(cluster)
+
+	conditions.SetSummary(cluster,
+		conditions.WithConditions(
+			anywherev1.ControlPlaneReadyCondition,
+			anywherev1.WorkersReadyCondition,
+			anywherev1.DefaultCNIConfiguredCondition,
+			anywherev1.CNIReadyCondition,
+			anywherev1.ExtraObjectsAppliedCondition,
+		),
+	)
+
+	return nil
+}
+
+func updateControlPlaneStatus(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	kcpName := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: cluster.Name}
+	if err := client.Get(ctx, kcpName, kcp); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		cluster.Status.ControlPlane = &anywherev1.NodeGroupStatus{}
+		conditions.MarkFalse(cluster, anywherev1.ControlPlaneReadyCondition, anywherev1.ControlPlaneInitializingReason, clusterv1.ConditionSeverityInfo, "Control plane not created yet")
+		return nil
+	}
+
+	cluster.Status.ControlPlane = &anywherev1.NodeGroupStatus{
+		Replicas:        kcp.Status.Replicas,
+		UpdatedReplicas: kcp.Status.UpdatedReplicas,
+		ReadyReplicas:   kcp.Status.ReadyReplicas,
+	}
+
+	if !kcp.Status.Initialized {
+		conditions.MarkFalse(cluster, anywherev1.ControlPlaneReadyCondition, anywherev1.ControlPlaneInitializingReason, clusterv1.ConditionSeverityInfo, "Waiting for the first control plane node to be ready")
+		return nil
+	}
+
+	reason, message := nodeGroupProgress(int32(cluster.Spec.ControlPlaneConfiguration.Count), kcp.Generation, kcp.Status.ObservedGeneration, *cluster.Status.ControlPlane)
+	if reason != "" {
+		conditions.MarkFalse(cluster, anywherev1.ControlPlaneReadyCondition, reason, clusterv1.ConditionSeverityInfo, "Control plane: %s", message)
+		return nil
+	}
+
+	conditions.MarkTrue(cluster, anywherev1.ControlPlaneReadyCondition)
+	return nil
+}
+
+func updateWorkersStatus(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
+	workersStatus := make([]anywherev1.NodeGroupStatus, 0, len(cluster.Spec.WorkerNodeGroupConfigurations))
+	var notReadyReason, notReadyMessage string
+	for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+		md := &clusterv1.MachineDeployment{}
+		mdName := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: machineDeploymentName(cluster, wng)}
+		if err := client.Get(ctx, mdName, md); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			workersStatus = append(workersStatus, anywherev1.NodeGroupStatus{Name: wng.Name})
+			if notReadyReason == "" {
+				notReadyReason = anywherev1.WorkersNotCreatedReason
+				notReadyMessage = fmt.Sprintf("Worker node group %s: MachineDeployment %s not created yet", wng.Name, mdName.Name)
+			}
+			continue
+		}
+
+		status := anywherev1.NodeGroupStatus{
+			Name:            wng.Name,
+			Replicas:        md.Status.Replicas,
+			UpdatedReplicas: md.Status.UpdatedReplicas,
+			ReadyReplicas:   md.Status.ReadyReplicas,
+		}
+		workersStatus = append(workersStatus, status)
+
+		if notReadyReason != "" {
+			continue
+		}
+		reason, message := nodeGroupProgress(int32(wng.Count), md.Generation, md.Status.ObservedGeneration, status)
+		if reason != "" {
+			notReadyReason = reason
+			notReadyMessage = fmt.Sprintf("Worker node group %s: %s", wng.Name, message)
+		}
+	}
+	cluster.Status.WorkerNodeGroups = workersStatus
+
+	if notReadyReason != "" {
+		conditions.MarkFalse(cluster, anywherev1.WorkersReadyCondition, notReadyReason, clusterv1.ConditionSeverityInfo, "%s", notReadyMessage)
+		return nil
+	}
+
+	conditions.MarkTrue(cluster, anywherev1.WorkersReadyCondition)
+	return nil
+}
+
+// updateAddonsStatus reports the CNI and extra objects conditions from the steps of the provider reconcilers,
+// which apply them once the control plane is ready. A node only becomes ready with a working pod network,
+// so the CNI is ready once it has been installed and all nodes are ready
+func updateAddonsStatus(cluster *anywherev1.Cluster) {
+	if conditions.IsTrue(cluster, extraObjectsSpecPlaneAppliedCondition) {
+		conditions.MarkTrue(cluster, anywherev1.ExtraObjectsAppliedCondition)
+	} else {
+		conditions.MarkFalse(cluster, anywherev1.ExtraObjectsAppliedCondition, anywherev1.WaitingForControlPlaneReason, clusterv1.ConditionSeverityInfo, "Extra objects are applied once the control plane is ready")
+	}
+
+	if !conditions.IsTrue(cluster, cniSpecAppliedCondition) {
+		conditions.MarkFalse(cluster, anywherev1.DefaultCNIConfiguredCondition, anywherev1.WaitingForControlPlaneReason, clusterv1.ConditionSeverityInfo, "CNI is installed once the control plane is ready")
+		conditions.MarkFalse(cluster, anywherev1.CNIReadyCondition, anywherev1.WaitingForControlPlaneReason, clusterv1.ConditionSeverityInfo, "CNI is installed once the control plane is ready")
+		return
+	}
+	conditions.MarkTrue(cluster, anywherev1.DefaultCNIConfiguredCondition)
+
+	ready := cluster.Status.ControlPlane.ReadyReplicas
+	desired := int32(cluster.Spec.ControlPlaneConfiguration.Count)
+	for _, nodeGroup := range cluster.Status.WorkerNodeGroups {
+		ready += nodeGroup.ReadyReplicas
+	}
+	for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+		desired += int32(wng.Count)
+	}
+	if ready < desired {
+		conditions.MarkFalse(cluster, anywherev1.CNIReadyCondition, anywherev1.WaitingForCNIReason, clusterv1.ConditionSeverityInfo, "%d of %d nodes ready", ready, desired)
+		return
+	}
+	conditions.MarkTrue(cluster, anywherev1.CNIReadyCondition)
+}
+
+// nodeGroupProgress returns the reason and message of a node group whose replicas are not all
+// up to date and ready yet, or an empty reason if it has finished rolling out
+func nodeGroupProgress(desiredReplicas int32, generation, observedGeneration int64, status anywherev1.NodeGroupStatus) (reason, message string) {
+	switch {
+	case observedGeneration < generation:
+		return anywherev1.RollingUpgradeInProgressReason, "waiting for the latest spec to be observed"
+	case status.Replicas > desiredReplicas:
+		return anywherev1.ScalingDownReason, fmt.Sprintf("scaling down to %d replicas, %d remaining", desiredReplicas, status.Replicas)
+	case status.UpdatedReplicas < status.Replicas:
+		return anywherev1.RollingUpgradeInProgressReason, fmt.Sprintf("%d of %d replicas up to date", status.UpdatedReplicas, status.Replicas)
+	case status.ReadyReplicas < desiredReplicas:
+		return anywherev1.ScalingUpReason, fmt.Sprintf("%d of %d replicas ready", status.ReadyReplicas, desiredReplicas)
+	}
+	return "", ""
+}
+
+// machineDeploymentName returns the name of the MachineDeployment of a worker node group. The snow provider
+// names them after the node group, the rest prefix the node group name with the cluster name
+func machineDeploymentName(cluster *anywherev1.Cluster, wng anywherev1.WorkerNodeGroupConfiguration) string {
+	if cluster.Spec.DatacenterRef.Kind == anywherev1.SnowDatacenterKind {
+		return wng.Name
+	}
+	return fmt.Sprintf("%s-%s", cluster.Name, wng.Name)
+}
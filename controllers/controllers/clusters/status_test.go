@@ -0,0 +1,232 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/clusters"
+	_ "github.com/aws/eks-anywhere/internal/test/envtest"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestUpdateClusterStatusReady(t *testing.T) {
+	g := NewWithT(t)
+	cluster := statusTestCluster(anywherev1.DockerDatacenterKind)
+	kcp := statusTestKCP(cluster.Name, 3, 3, 3)
+	md0 := statusTestMachineDeployment(cluster.Name+"-md-0", 2, 2, 2)
+	md1 := statusTestMachineDeployment(cluster.Name+"-md-1", 1, 1, 1)
+	client := fake.NewClientBuilder().WithRuntimeObjects(kcp, md0, md1).Build()
+
+	g.Expect(clusters.UpdateClusterStatus(context.Background(), client, cluster)).To(Succeed())
+
+	g.Expect(cluster.Status.ControlPlane).To(Equal(&anywherev1.NodeGroupStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3}))
+	g.Expect(cluster.Status.WorkerNodeGroups).To(Equal([]anywherev1.NodeGroupStatus{
+		{Name: "md-0", Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2},
+		{Name: "md-1", Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+	}))
+	for _, condition := range []clusterv1.ConditionType{
+		anywherev1.ControlPlaneReadyCondition,
+		anywherev1.WorkersReadyCondition,
+		anywherev1.DefaultCNIConfiguredCondition,
+		anywherev1.CNIReadyCondition,
+		anywherev1.ExtraObjectsAppliedCondition,
+		clusterv1.ReadyCondition,
+	} {
+		g.Expect(conditions.IsTrue(cluster, condition)).To(BeTrue(), "condition %s should be true", condition)
+	}
+}
+
+func TestUpdateClusterStatusNotReady(t *testing.T) {
+	tests := []struct {
+		name                   string
+		kcp                    *controlplanev1.KubeadmControlPlane
+		machineDeployments     []*clusterv1.MachineDeployment
+		wantControlPlane       string
+		wantWorkers            string
+		wantWorkersMessage     string
+		wantCNIReady           string
+		wantControlPlaneStatus *anywherev1.NodeGroupStatus
+	}{
+		{
+			name:                   "control plane not created",
+			machineDeployments:     []*clusterv1.MachineDeployment{statusTestMachineDeployment("test-cluster-md-0", 2, 2, 2), statusTestMachineDeployment("test-cluster-md-1", 1, 1, 1)},
+			wantControlPlane:       anywherev1.ControlPlaneInitializingReason,
+			wantCNIReady:           anywherev1.WaitingForCNIReason,
+			wantControlPlaneStatus: &anywherev1.NodeGroupStatus{},
+		},
+		{
+			name:                   "control plane scaling down",
+			kcp:                    statusTestKCP("test-cluster", 4, 1, 4),
+			machineDeployments:     []*clusterv1.MachineDeployment{statusTestMachineDeployment("test-cluster-md-0", 2, 2, 2), statusTestMachineDeployment("test-cluster-md-1", 1, 1, 1)},
+			wantControlPlane:       anywherev1.ScalingDownReason,
+			wantCNIReady:           "",
+			wantControlPlaneStatus: &anywherev1.NodeGroupStatus{Replicas: 4, UpdatedReplicas: 1, ReadyReplicas: 4},
+		},
+		{
+			name:                   "worker node group missing",
+			kcp:                    statusTestKCP("test-cluster", 3, 3, 3),
+			machineDeployments:     []*clusterv1.MachineDeployment{statusTestMachineDeployment("test-cluster-md-0", 2, 2, 2)},
+			wantWorkers:            anywherev1.WorkersNotCreatedReason,
+			wantWorkersMessage:     "Worker node group md-1: MachineDeployment test-cluster-md-1 not created yet",
+			wantCNIReady:           anywherev1.WaitingForCNIReason,
+			wantControlPlaneStatus: &anywherev1.NodeGroupStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3},
+		},
+		{
+			name:                   "worker node group rolling upgrade",
+			kcp:                    statusTestKCP("test-cluster", 3, 3, 3),
+			machineDeployments:     []*clusterv1.MachineDeployment{statusTestMachineDeployment("test-cluster-md-0", 2, 1, 2), statusTestMachineDeployment("test-cluster-md-1", 1, 1, 0)},
+			wantWorkers:            anywherev1.RollingUpgradeInProgressReason,
+			wantWorkersMessage:     "Worker node group md-0: 1 of 2 replicas up to date",
+			wantCNIReady:           anywherev1.WaitingForCNIReason,
+			wantControlPlaneStatus: &anywherev1.NodeGroupStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := statusTestCluster(anywherev1.DockerDatacenterKind)
+			objs := []runtime.Object{}
+			if tt.kcp != nil {
+				objs = append(objs, tt.kcp)
+			}
+			for _, md := range tt.machineDeployments {
+				objs = append(objs, md)
+			}
+			client := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+
+			g.Expect(clusters.UpdateClusterStatus(context.Background(), client, cluster)).To(Succeed())
+
+			g.Expect(cluster.Status.ControlPlane).To(Equal(tt.wantControlPlaneStatus))
+			g.Expect(conditions.GetReason(cluster, anywherev1.ControlPlaneReadyCondition)).To(Equal(tt.wantControlPlane))
+			g.Expect(conditions.GetReason(cluster, anywherev1.WorkersReadyCondition)).To(Equal(tt.wantWorkers))
+			if tt.wantWorkersMessage != "" {
+				g.Expect(conditions.GetMessage(cluster, anywherev1.WorkersReadyCondition)).To(Equal(tt.wantWorkersMessage))
+			}
+			g.Expect(conditions.GetReason(cluster, anywherev1.CNIReadyCondition)).To(Equal(tt.wantCNIReady))
+			g.Expect(conditions.IsFalse(cluster, clusterv1.ReadyCondition)).To(BeTrue())
+		})
+	}
+}
+
+func TestUpdateClusterStatusWaitingForAddons(t *testing.T) {
+	g := NewWithT(t)
+	cluster := statusTestCluster(anywherev1.DockerDatacenterKind)
+	cluster.Status.Conditions = nil
+	kcp := statusTestKCP(cluster.Name, 3, 3, 3)
+	md0 := statusTestMachineDeployment(cluster.Name+"-md-0", 2, 2, 2)
+	md1 := statusTestMachineDeployment(cluster.Name+"-md-1", 1, 1, 1)
+	client := fake.NewClientBuilder().WithRuntimeObjects(kcp, md0, md1).Build()
+
+	g.Expect(clusters.UpdateClusterStatus(context.Background(), client, cluster)).To(Succeed())
+
+	g.Expect(conditions.IsTrue(cluster, anywherev1.ControlPlaneReadyCondition)).To(BeTrue())
+	g.Expect(conditions.IsTrue(cluster, anywherev1.WorkersReadyCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(cluster, anywherev1.DefaultCNIConfiguredCondition)).To(Equal(anywherev1.WaitingForControlPlaneReason))
+	g.Expect(conditions.GetReason(cluster, anywherev1.CNIReadyCondition)).To(Equal(anywherev1.WaitingForControlPlaneReason))
+	g.Expect(conditions.GetReason(cluster, anywherev1.ExtraObjectsAppliedCondition)).To(Equal(anywherev1.WaitingForControlPlaneReason))
+	g.Expect(conditions.IsFalse(cluster, clusterv1.ReadyCondition)).To(BeTrue())
+}
+
+func TestUpdateClusterStatusControlPlaneNotObserved(t *testing.T) {
+	g := NewWithT(t)
+	cluster := statusTestCluster(anywherev1.DockerDatacenterKind)
+	kcp := statusTestKCP(cluster.Name, 3, 3, 3)
+	kcp.Generation = 3
+	md0 := statusTestMachineDeployment(cluster.Name+"-md-0", 2, 2, 2)
+	md1 := statusTestMachineDeployment(cluster.Name+"-md-1", 1, 1, 1)
+	client := fake.NewClientBuilder().WithRuntimeObjects(kcp, md0, md1).Build()
+
+	g.Expect(clusters.UpdateClusterStatus(context.Background(), client, cluster)).To(Succeed())
+
+	g.Expect(conditions.GetReason(cluster, anywherev1.ControlPlaneReadyCondition)).To(Equal(anywherev1.RollingUpgradeInProgressReason))
+	g.Expect(conditions.IsTrue(cluster, anywherev1.WorkersReadyCondition)).To(BeTrue())
+}
+
+func TestUpdateClusterStatusSnowMachineDeploymentNames(t *testing.T) {
+	g := NewWithT(t)
+	cluster := statusTestCluster(anywherev1.SnowDatacenterKind)
+	kcp := statusTestKCP(cluster.Name, 3, 3, 3)
+	md0 := statusTestMachineDeployment("md-0", 2, 2, 2)
+	md1 := statusTestMachineDeployment("md-1", 1, 1, 1)
+	client := fake.NewClientBuilder().WithRuntimeObjects(kcp, md0, md1).Build()
+
+	g.Expect(clusters.UpdateClusterStatus(context.Background(), client, cluster)).To(Succeed())
+
+	g.Expect(conditions.IsTrue(cluster, anywherev1.WorkersReadyCondition)).To(BeTrue())
+	g.Expect(conditions.IsTrue(cluster, clusterv1.ReadyCondition)).To(BeTrue())
+}
+
+func statusTestCluster(datacenterKind string) *anywherev1.Cluster {
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{
+				Kind: datacenterKind,
+				Name: "datacenter",
+			},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				Count: 3,
+			},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: 2},
+				{Name: "md-1", Count: 1},
+			},
+		},
+	}
+	conditions.MarkTrue(cluster, "CNISpecApplied")
+	conditions.MarkTrue(cluster, "ExtraObjectsSpecApplied")
+	return cluster
+}
+
+func statusTestKCP(name string, replicas, updatedReplicas, readyReplicas int32) *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "eksa-system",
+			Name:       name,
+			Generation: 1,
+		},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			Initialized:        true,
+			ObservedGeneration: 1,
+			Replicas:           replicas,
+			UpdatedReplicas:    updatedReplicas,
+			ReadyReplicas:      readyReplicas,
+		},
+	}
+}
+
+func statusTestMachineDeployment(name string, replicas, updatedReplicas, readyReplicas int32) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MachineDeployment",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "eksa-system",
+			Name:       name,
+			Generation: 1,
+		},
+		Status: clusterv1.MachineDeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           replicas,
+			UpdatedReplicas:    updatedReplicas,
+			ReadyReplicas:      readyReplicas,
+		},
+	}
+}
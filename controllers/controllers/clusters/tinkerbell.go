@@ -0,0 +1,119 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/reconciler"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+type TinkerbellReconciler struct {
+	*providerClusterReconciler
+	validator *tinkerbell.Validator
+}
+
+func NewTinkerbellReconciler(client client.Client, log logr.Logger, helm cilium.Helm, tracker *remote.ClusterCacheTracker) *TinkerbellReconciler {
+	return &TinkerbellReconciler{
+		providerClusterReconciler: newProviderClusterReconciler(client, log, helm, tracker),
+		// The hardware is registered in the Tinkerbell stack by the CLI, the controller only runs
+		// the validations that don't need to reach it
+		validator: tinkerbell.NewValidator(nil, &networkutils.DefaultNetClient{}, hardware.HardwareConfig{}, nil),
+	}
+}
+
+func (t *TinkerbellReconciler) Reconcile(ctx context.Context, cluster *anywherev1.Cluster) (reconciler.Result, error) {
+	dataCenterConfig := &anywherev1.TinkerbellDatacenterConfig{}
+	dataCenterName := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Spec.DatacenterRef.Name}
+	if err := t.providerClient.Get(ctx, dataCenterName, dataCenterConfig); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	machineConfigMap := map[string]*anywherev1.TinkerbellMachineConfig{}
+	templateConfigMap := map[string]*anywherev1.TinkerbellTemplateConfig{}
+	for _, ref := range cluster.MachineConfigRefs() {
+		machineConfig := &anywherev1.TinkerbellMachineConfig{}
+		machineConfigName := types.NamespacedName{Namespace: cluster.Namespace, Name: ref.Name}
+		if err := t.providerClient.Get(ctx, machineConfigName, machineConfig); err != nil {
+			return reconciler.Result{}, err
+		}
+		if err := validateSshAuthorizedKey(anywherev1.TinkerbellMachineConfigKind, ref.Name, machineConfig.Spec.Users); err != nil {
+			return reconciler.Result{}, err
+		}
+		machineConfigMap[ref.Name] = machineConfig
+
+		templateName := machineConfig.Spec.TemplateRef.Name
+		if _, ok := templateConfigMap[templateName]; ok {
+			continue
+		}
+		templateConfig := &anywherev1.TinkerbellTemplateConfig{}
+		templateConfigName := types.NamespacedName{Namespace: cluster.Namespace, Name: templateName}
+		if err := t.providerClient.Get(ctx, templateConfigName, templateConfig); err != nil {
+			return reconciler.Result{}, err
+		}
+		templateConfigMap[templateName] = templateConfig
+	}
+
+	specWithBundles, err := t.specWithBundles(ctx, cluster)
+	if err != nil {
+		return reconciler.Result{}, err
+	}
+	specWithBundles.TinkerbellTemplateConfigs = templateConfigMap
+
+	if err := t.validator.ValidateClusterMachineConfigs(ctx, tinkerbell.NewSpec(specWithBundles, machineConfigMap, dataCenterConfig)); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	cp := machineConfigMap[cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+	if err := t.validator.ValidateTinkerbellTemplate(ctx, dataCenterConfig.Spec.TinkerbellIP, templateConfigMap[cp.Spec.TemplateRef.Name]); err != nil {
+		return reconciler.Result{}, fmt.Errorf("failed validating control plane template config: %v", err)
+	}
+
+	workerNodeGroupMachineSpecs := make(map[string]anywherev1.TinkerbellMachineConfigSpec, len(cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, wnConfig := range cluster.Spec.WorkerNodeGroupConfigurations {
+		worker := machineConfigMap[wnConfig.MachineGroupRef.Name]
+		if err := t.validator.ValidateTinkerbellTemplate(ctx, dataCenterConfig.Spec.TinkerbellIP, templateConfigMap[worker.Spec.TemplateRef.Name]); err != nil {
+			return reconciler.Result{}, fmt.Errorf("failed validating worker node template config: %v", err)
+		}
+		workerNodeGroupMachineSpecs[wnConfig.MachineGroupRef.Name] = worker.Spec
+	}
+
+	var etcdSpec *anywherev1.TinkerbellMachineConfigSpec
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdSpec = &machineConfigMap[cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name].Spec
+	}
+
+	templateBuilder := tinkerbell.NewTinkerbellTemplateBuilder(&dataCenterConfig.Spec, &cp.Spec, etcdSpec, workerNodeGroupMachineSpecs, time.Now)
+	clusterName := cluster.ObjectMeta.Name
+
+	kubeadmconfigTemplateNames := make(map[string]string, len(cluster.Spec.WorkerNodeGroupConfigurations))
+	workloadTemplateNames := make(map[string]string, len(cluster.Spec.WorkerNodeGroupConfigurations))
+
+	for _, wnConfig := range cluster.Spec.WorkerNodeGroupConfigurations {
+		kubeadmconfigTemplateNames[wnConfig.Name] = common.KubeadmConfigTemplateName(clusterName, wnConfig.Name, time.Now)
+		workloadTemplateNames[wnConfig.Name] = common.WorkerMachineTemplateName(clusterName, wnConfig.Name, time.Now)
+	}
+
+	cpOpt := func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = common.CPMachineTemplateName(clusterName, time.Now)
+		values["controlPlaneSshAuthorizedKey"] = cp.Spec.Users[0].SshAuthorizedKeys[0]
+		if etcdSpec != nil {
+			values["etcdSshAuthorizedKey"] = etcdSpec.Users[0].SshAuthorizedKeys[0]
+		}
+		values["etcdTemplateName"] = common.EtcdMachineTemplateName(clusterName, time.Now)
+	}
+	t.log.Info("cluster", "name", cluster.Name)
+
+	return t.reconcileCAPISpecs(ctx, cluster, templateBuilder, specWithBundles, cpOpt, workloadTemplateNames, kubeadmconfigTemplateNames)
+}
@@ -25,6 +25,9 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -49,6 +52,7 @@ func init() {
 	utilruntime.Must(etcdv1.AddToScheme(scheme))
 	utilruntime.Must(kubeadmv1.AddToScheme(scheme))
 	utilruntime.Must(eksdv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(snowv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -105,7 +109,7 @@ func main() {
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	if features.IsActive(features.FullLifecycleAPI()) {
 		factory := dependencies.NewFactory()
-		deps, err := factory.WithGovc().Build(ctx)
+		deps, err := factory.WithGovc().WithCmkBuilder().WithHelm().Build(ctx)
 		if err != nil {
 			setupLog.Error(err, "unable to build dependencies")
 			os.Exit(1)
@@ -123,12 +127,18 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			os.Exit(1)
 		}
 
+		cmkBuilder := func(execConfig decoder.CloudStackExecConfig) cloudstack.ProviderCmkClient {
+			return deps.CmkBuilder(execConfig)
+		}
+
 		setupLog.Info("Setting up cluster controller")
 		if err := (controllers.NewClusterReconciler(
 			mgr.GetClient(),
 			ctrl.Log.WithName("controllers").WithName(anywherev1.ClusterKind),
 			mgr.GetScheme(),
 			deps.Govc,
+			cmkBuilder,
+			deps.Helm,
 			tracker,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", anywherev1.ClusterKind)
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -45,6 +46,7 @@ func init() {
 	utilruntime.Must(admissionv1beta1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(anywherev1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(eksdv1alpha1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(snowv1.AddToScheme(scheme.Scheme))
 }
 
 var packages = mustBuildModulesWithCRDs(capiPackage, capvPackage)
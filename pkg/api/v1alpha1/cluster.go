@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/version"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -162,18 +163,76 @@ func NewCluster(clusterName string) *Cluster {
 	return c
 }
 
-var clusterConfigValidations = []func(*Cluster) error{
-	validateClusterConfigName,
-	validateControlPlaneReplicas,
-	validateWorkerNodeGroups,
-	validateNetworking,
-	validateGitOps,
-	validateEtcdReplicas,
-	validateIdentityProviderRefs,
-	validateProxyConfig,
-	validateMirrorConfig,
-	validatePodIAMConfig,
-	validateControlPlaneLabels,
+// clusterConfigValidation is a Cluster validation together with the field it checks,
+// so its errors can be reported as field errors
+type clusterConfigValidation struct {
+	validate func(*Cluster) error
+	path     *field.Path
+}
+
+// clusterFieldError is the error of a clusterConfigValidation. Its message is the one of the validation, and it wraps
+// a field error with the path of the field it checks, without its value, so validation reports can tell where it is
+type clusterFieldError struct {
+	err *field.Error
+}
+
+func (e *clusterFieldError) Error() string {
+	return e.err.Detail
+}
+
+func (e *clusterFieldError) Unwrap() error {
+	return e.err
+}
+
+var clusterConfigValidations = []clusterConfigValidation{
+	{
+		validate: validateClusterConfigName,
+		path:     field.NewPath("metadata", "name"),
+	},
+	{
+		validate: validateControlPlaneReplicas,
+		path:     field.NewPath("spec", "controlPlaneConfiguration", "count"),
+	},
+	{
+		validate: validateWorkerNodeGroups,
+		path:     field.NewPath("spec", "workerNodeGroupConfigurations"),
+	},
+	{
+		validate: validateNetworking,
+		path:     field.NewPath("spec", "clusterNetwork"),
+	},
+	{
+		validate: validateGitOps,
+		path:     field.NewPath("spec", "gitOpsRef"),
+	},
+	{
+		validate: validateEtcdReplicas,
+		path:     field.NewPath("spec", "externalEtcdConfiguration", "count"),
+	},
+	{
+		validate: validateIdentityProviderRefs,
+		path:     field.NewPath("spec", "identityProviderRefs"),
+	},
+	{
+		validate: validateProxyConfig,
+		path:     field.NewPath("spec", "proxyConfiguration"),
+	},
+	{
+		validate: validateMirrorConfig,
+		path:     field.NewPath("spec", "registryMirrorConfiguration"),
+	},
+	{
+		validate: validatePodIAMConfig,
+		path:     field.NewPath("spec", "podIamConfig"),
+	},
+	{
+		validate: validateClusterAutoscalerConfig,
+		path:     field.NewPath("spec", "clusterAutoscalerConfiguration"),
+	},
+	{
+		validate: validateControlPlaneLabels,
+		path:     field.NewPath("spec", "controlPlaneConfiguration", "labels"),
+	},
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -222,10 +281,11 @@ func GetClusterDefaultKubernetesVersion() KubernetesVersion {
 
 // ValidateClusterConfigContent validates a Cluster object without modifying it
 // Some of the validations are a bit heavy and need a network connection
+// The error wraps a *field.Error with the path of the invalid field
 func ValidateClusterConfigContent(clusterConfig *Cluster) error {
 	for _, v := range clusterConfigValidations {
-		if err := v(clusterConfig); err != nil {
-			return err
+		if err := v.validate(clusterConfig); err != nil {
+			return &clusterFieldError{err: field.Invalid(v.path, "", err.Error())}
 		}
 	}
 	return nil
@@ -371,14 +431,109 @@ func validateWorkerNodeGroups(clusterConfig *Cluster) error {
 		if err := validateNodeLabels(workerNodeGroupConfig.Labels, field.NewPath("spec", workerNodeGroupField, "labels")); err != nil {
 			return fmt.Errorf("labels for worker node group %v not valid: %v", workerNodeGroupConfig.Name, err)
 		}
+		if err := validateAutoScalingConfig(workerNodeGroupConfig); err != nil {
+			return fmt.Errorf("autoscaling configuration for worker node group %v not valid: %v", workerNodeGroupConfig.Name, err)
+		}
+		if err := validateWorkerNodeGroupKubernetesVersion(clusterConfig, workerNodeGroupConfig); err != nil {
+			return fmt.Errorf("kubernetes version for worker node group %v not valid: %v", workerNodeGroupConfig.Name, err)
+		}
 		workerNodeGroupNames[workerNodeGroupConfig.Name] = true
 	}
 	if len(noExecuteNoScheduleTaintedNodeGroups) == len(workerNodeGroupConfigs) {
 		return errors.New("at least one WorkerNodeGroupConfiguration must not have NoExecute and/or NoSchedule taints")
 	}
+	return validateMachineGroupRefsKubernetesVersion(clusterConfig)
+}
+
+func validateWorkerNodeGroupKubernetesVersion(clusterConfig *Cluster, workerNodeGroupConfig WorkerNodeGroupConfiguration) error {
+	if workerNodeGroupConfig.KubernetesVersion == nil || *workerNodeGroupConfig.KubernetesVersion == clusterConfig.Spec.KubernetesVersion {
+		return nil
+	}
+	if _, ok := workerNodeGroupKubernetesVersionProviders[clusterConfig.Spec.DatacenterRef.Kind]; !ok {
+		return fmt.Errorf("setting a kubernetes version different from the cluster one is not supported for %s", clusterConfig.Spec.DatacenterRef.Kind)
+	}
+
+	return ValidateKubernetesVersionSkew(*workerNodeGroupConfig.KubernetesVersion, clusterConfig.Spec.KubernetesVersion)
+}
+
+// ValidateKubernetesVersionSkew checks that nodes with workerVersion can join a control plane with controlPlaneVersion,
+// which requires the workers to be at most MaxWorkerNodeGroupVersionSkew minor versions older and never newer
+func ValidateKubernetesVersionSkew(workerVersion, controlPlaneVersion KubernetesVersion) error {
+	parsedWorkerVersion, err := version.ParseGeneric(string(workerVersion))
+	if err != nil {
+		return fmt.Errorf("invalid kubernetes version %s: %v", workerVersion, err)
+	}
+	parsedControlPlaneVersion, err := version.ParseGeneric(string(controlPlaneVersion))
+	if err != nil {
+		return fmt.Errorf("invalid kubernetes version %s: %v", controlPlaneVersion, err)
+	}
+
+	if parsedWorkerVersion.Major() != parsedControlPlaneVersion.Major() || parsedWorkerVersion.Minor() > parsedControlPlaneVersion.Minor() {
+		return fmt.Errorf("version %s can't be newer than the control plane version %s", workerVersion, controlPlaneVersion)
+	}
+	if parsedControlPlaneVersion.Minor()-parsedWorkerVersion.Minor() > MaxWorkerNodeGroupVersionSkew {
+		return fmt.Errorf("version %s can't be more than %d minor version(s) older than the control plane version %s", workerVersion, MaxWorkerNodeGroupVersionSkew, controlPlaneVersion)
+	}
+	return nil
+}
+
+// validateMachineGroupRefsKubernetesVersion checks that machine configs are not shared by nodes
+// with different kubernetes versions, since the machine image depends on the version
+func validateMachineGroupRefsKubernetesVersion(clusterConfig *Cluster) error {
+	versions := map[string]KubernetesVersion{}
+	if ref := clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef; ref != nil {
+		versions[ref.Kind+ref.Name] = clusterConfig.Spec.KubernetesVersion
+	}
+	if clusterConfig.Spec.ExternalEtcdConfiguration != nil && clusterConfig.Spec.ExternalEtcdConfiguration.MachineGroupRef != nil {
+		ref := clusterConfig.Spec.ExternalEtcdConfiguration.MachineGroupRef
+		versions[ref.Kind+ref.Name] = clusterConfig.Spec.KubernetesVersion
+	}
+	for _, w := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		if w.MachineGroupRef == nil {
+			continue
+		}
+		v := clusterConfig.WorkerNodeGroupKubernetesVersion(w)
+		key := w.MachineGroupRef.Kind + w.MachineGroupRef.Name
+		if existing, ok := versions[key]; ok && existing != v {
+			return fmt.Errorf("machine config %s can't be used by nodes with different kubernetes versions (%s and %s)", w.MachineGroupRef.Name, existing, v)
+		}
+		versions[key] = v
+	}
+	return nil
+}
+
+func validateAutoScalingConfig(workerNodeGroupConfig WorkerNodeGroupConfiguration) error {
+	config := workerNodeGroupConfig.AutoScalingConfiguration
+	if config == nil {
+		return nil
+	}
+	if config.MinCount < 0 {
+		return errors.New("min count must be non negative")
+	}
+	if config.MaxCount < 1 {
+		return errors.New("max count must be greater than 0")
+	}
+	if config.MinCount > config.MaxCount {
+		return errors.New("min count must be no greater than max count")
+	}
+	if workerNodeGroupConfig.Count < config.MinCount || workerNodeGroupConfig.Count > config.MaxCount {
+		return errors.New("count must be between min and max count")
+	}
 	return nil
 }
 
+func validateClusterAutoscalerConfig(clusterConfig *Cluster) error {
+	if !clusterConfig.Spec.ClusterAutoscalerConfiguration.InstallClusterAutoscaler() {
+		return nil
+	}
+	for _, workerNodeGroupConfig := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		if workerNodeGroupConfig.AutoScalingConfiguration != nil {
+			return nil
+		}
+	}
+	return errors.New("installing the cluster-autoscaler requires at least one worker node group with autoscalingConfiguration")
+}
+
 func validateNodeLabels(labels map[string]string, fldPath *field.Path) error {
 	errList := validation.ValidateLabels(labels, fldPath)
 	if len(errList) != 0 {
@@ -466,6 +621,13 @@ func validateCNIConfig(cniConfig *CNIConfig) error {
 		cniPluginSpecified++
 	}
 
+	if cniConfig.Calico != nil {
+		cniPluginSpecified++
+		if err := validateCalicoConfig(cniConfig.Calico); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
 	if cniPluginSpecified == 0 {
 		allErrs = append(allErrs, fmt.Errorf("no cni plugin specified"))
 	} else if cniPluginSpecified > 1 {
@@ -481,15 +643,65 @@ func validateCNIConfig(cniConfig *CNIConfig) error {
 }
 
 func validateCiliumConfig(cilium *CiliumConfig) error {
-	if cilium.PolicyEnforcementMode == "" {
-		return nil
-	}
-	if !validCiliumPolicyEnforcementModes[cilium.PolicyEnforcementMode] {
+	if cilium.PolicyEnforcementMode != "" && !validCiliumPolicyEnforcementModes[cilium.PolicyEnforcementMode] {
 		return fmt.Errorf("cilium policyEnforcementMode \"%s\" not supported", cilium.PolicyEnforcementMode)
 	}
+	if cilium.PolicyAuditMode && cilium.PolicyEnforcementMode == CiliumPolicyModeNever {
+		return errors.New("cilium policyAuditMode can't be enabled with policyEnforcementMode never")
+	}
+	if cilium.RoutingMode != "" && !validCiliumRoutingModes[cilium.RoutingMode] {
+		return fmt.Errorf("cilium routingMode \"%s\" not supported", cilium.RoutingMode)
+	}
+	if cilium.IPAMMode != "" && !validCiliumIPAMModes[cilium.IPAMMode] {
+		return fmt.Errorf("cilium ipamMode \"%s\" not supported", cilium.IPAMMode)
+	}
+	if cilium.KubeProxyReplacement != "" && !validCiliumKubeProxyReplacements[cilium.KubeProxyReplacement] {
+		return fmt.Errorf("cilium kubeProxyReplacement \"%s\" not supported", cilium.KubeProxyReplacement)
+	}
+	if cilium.MTU != 0 && (cilium.MTU < minCiliumMTU || cilium.MTU > maxCiliumMTU) {
+		return fmt.Errorf("cilium mtu %d is invalid, it must be between %d and %d", cilium.MTU, minCiliumMTU, maxCiliumMTU)
+	}
+	if cilium.Hubble != nil && cilium.Hubble.UI && !cilium.Hubble.Relay {
+		return errors.New("cilium hubble ui requires hubble relay")
+	}
+	if cilium.HelmValues != "" {
+		if _, err := cilium.ParseHelmValues(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func validateCalicoConfig(calico *CalicoConfig) error {
+	if calico.Encapsulation != "" && !validCalicoEncapsulations[calico.Encapsulation] {
+		return fmt.Errorf("calico encapsulation \"%s\" not supported", calico.Encapsulation)
+	}
+	if len(calico.BGPPeers) > 0 && (calico.Encapsulation == "" || calico.Encapsulation == CalicoEncapsulationVXLAN) {
+		return errors.New("calico bgpPeers require ipip or none encapsulation")
+	}
+	for _, peer := range calico.BGPPeers {
+		if net.ParseIP(peer.PeerIP) == nil {
+			return fmt.Errorf("calico bgp peer ip \"%s\" is invalid", peer.PeerIP)
+		}
+		if peer.ASNumber == 0 {
+			return fmt.Errorf("calico bgp peer %s requires an asNumber", peer.PeerIP)
+		}
+	}
+	return nil
+}
+
+// ParseHelmValues returns the extra Helm values for the Cilium chart in HelmValues, or nil if there are none.
+func (n *CiliumConfig) ParseHelmValues() (map[string]interface{}, error) {
+	if n.HelmValues == "" {
+		return nil, nil
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(n.HelmValues), &values); err != nil {
+		return nil, fmt.Errorf("cilium helmValues must be a yaml map: %v", err)
+	}
+	return values, nil
+}
+
 func validateProxyConfig(clusterConfig *Cluster) error {
 	if clusterConfig.Spec.ProxyConfiguration == nil {
 		return nil
@@ -7,8 +7,10 @@ import (
 	"strings"
 	"testing"
 
+	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func TestValidateClusterName(t *testing.T) {
@@ -1914,6 +1916,144 @@ func TestValidateCNIConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "valid cilium network options",
+			wantErr: nil,
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						RoutingMode:          "native",
+						IPAMMode:             "cluster-pool",
+						Hubble:               &CiliumHubbleConfig{Relay: true, UI: true},
+						KubeProxyReplacement: "strict",
+						MTU:                  9000,
+						HelmValues:           "hubble:\n  metrics:\n    enabled: [dns]\n",
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium policy audit mode",
+			wantErr: nil,
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{PolicyEnforcementMode: "always", PolicyAuditMode: true},
+				},
+			},
+		},
+		{
+			name:    "cilium policy audit mode with enforcement never",
+			wantErr: fmt.Errorf("error validating cniConfig: cilium policyAuditMode can't be enabled with policyEnforcementMode never"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{PolicyEnforcementMode: "never", PolicyAuditMode: true},
+				},
+			},
+		},
+		{
+			name:    "invalid cilium routing mode",
+			wantErr: fmt.Errorf("error validating cniConfig: cilium routingMode \"bgp\" not supported"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{RoutingMode: "bgp"},
+				},
+			},
+		},
+		{
+			name:    "invalid cilium ipam mode",
+			wantErr: fmt.Errorf("error validating cniConfig: cilium ipamMode \"eni\" not supported"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{IPAMMode: "eni"},
+				},
+			},
+		},
+		{
+			name:    "invalid cilium kube proxy replacement",
+			wantErr: fmt.Errorf("error validating cniConfig: cilium kubeProxyReplacement \"full\" not supported"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{KubeProxyReplacement: "full"},
+				},
+			},
+		},
+		{
+			name:    "invalid cilium mtu",
+			wantErr: fmt.Errorf("error validating cniConfig: cilium mtu 100 is invalid, it must be between 1280 and 9000"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{MTU: 100},
+				},
+			},
+		},
+		{
+			name:    "cilium hubble ui without relay",
+			wantErr: fmt.Errorf("error validating cniConfig: cilium hubble ui requires hubble relay"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{Hubble: &CiliumHubbleConfig{UI: true}},
+				},
+			},
+		},
+		{
+			name:    "valid calico with bgp peers",
+			wantErr: nil,
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Calico: &CalicoConfig{
+						Encapsulation: "none",
+						ASNumber:      65000,
+						BGPPeers:      []CalicoBGPPeer{{PeerIP: "10.0.0.1", ASNumber: 65001}},
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium and calico",
+			wantErr: fmt.Errorf("error validating cniConfig: cannot specify more than one cni plugins"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{},
+					Calico: &CalicoConfig{},
+				},
+			},
+		},
+		{
+			name:    "invalid calico encapsulation",
+			wantErr: fmt.Errorf("error validating cniConfig: calico encapsulation \"geneve\" not supported"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Calico: &CalicoConfig{Encapsulation: "geneve"},
+				},
+			},
+		},
+		{
+			name:    "calico bgp peers with vxlan",
+			wantErr: fmt.Errorf("error validating cniConfig: calico bgpPeers require ipip or none encapsulation"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Calico: &CalicoConfig{BGPPeers: []CalicoBGPPeer{{PeerIP: "10.0.0.1", ASNumber: 65001}}},
+				},
+			},
+		},
+		{
+			name:    "calico bgp peer invalid ip",
+			wantErr: fmt.Errorf("error validating cniConfig: calico bgp peer ip \"10.0.0\" is invalid"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Calico: &CalicoConfig{Encapsulation: "ipip", BGPPeers: []CalicoBGPPeer{{PeerIP: "10.0.0", ASNumber: 65001}}},
+				},
+			},
+		},
+		{
+			name:    "calico bgp peer without as number",
+			wantErr: fmt.Errorf("error validating cniConfig: calico bgp peer 10.0.0.1 requires an asNumber"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Calico: &CalicoConfig{Encapsulation: "ipip", BGPPeers: []CalicoBGPPeer{{PeerIP: "10.0.0.1"}}},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1924,3 +2064,268 @@ func TestValidateCNIConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCNIConfigInvalidCiliumHelmValues(t *testing.T) {
+	g := NewWithT(t)
+	cniConfig := &CNIConfig{Cilium: &CiliumConfig{HelmValues: "- not\n- a map"}}
+
+	g.Expect(validateCNIConfig(cniConfig)).To(MatchError(ContainSubstring("cilium helmValues must be a yaml map")))
+}
+
+func TestValidateAutoScalingConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		config  WorkerNodeGroupConfiguration
+	}{
+		{
+			name:   "no autoscaling",
+			config: WorkerNodeGroupConfiguration{Count: 3},
+		},
+		{
+			name: "valid autoscaling",
+			config: WorkerNodeGroupConfiguration{
+				Count:                    3,
+				AutoScalingConfiguration: &AutoScalingConfiguration{MinCount: 1, MaxCount: 5},
+			},
+		},
+		{
+			name:    "negative min count",
+			wantErr: "min count must be non negative",
+			config: WorkerNodeGroupConfiguration{
+				Count:                    1,
+				AutoScalingConfiguration: &AutoScalingConfiguration{MinCount: -1, MaxCount: 5},
+			},
+		},
+		{
+			name:    "zero max count",
+			wantErr: "max count must be greater than 0",
+			config: WorkerNodeGroupConfiguration{
+				AutoScalingConfiguration: &AutoScalingConfiguration{MinCount: 0, MaxCount: 0},
+			},
+		},
+		{
+			name:    "min greater than max",
+			wantErr: "min count must be no greater than max count",
+			config: WorkerNodeGroupConfiguration{
+				Count:                    3,
+				AutoScalingConfiguration: &AutoScalingConfiguration{MinCount: 4, MaxCount: 2},
+			},
+		},
+		{
+			name:    "count out of bounds",
+			wantErr: "count must be between min and max count",
+			config: WorkerNodeGroupConfiguration{
+				Count:                    6,
+				AutoScalingConfiguration: &AutoScalingConfiguration{MinCount: 1, MaxCount: 5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAutoScalingConfig(tt.config)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("%v got = %v, want nil", tt.name, err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("%v got = %v, want %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateClusterConfigContentFieldError(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "1cluster",
+		},
+		Spec: ClusterSpec{
+			ControlPlaneConfiguration: ControlPlaneConfiguration{
+				Count: 2,
+			},
+			WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+				{
+					Name:  "md-0",
+					Count: 1,
+				},
+			},
+			ClusterNetwork: ClusterNetwork{
+				Pods: Pods{
+					CidrBlocks: []string{"192.168.0.0/16"},
+				},
+				Services: Services{
+					CidrBlocks: []string{"10.96.0.0/12"},
+				},
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+			},
+		},
+	}
+
+	err := ValidateClusterConfigContent(c)
+	g.Expect(err).To(MatchError(HavePrefix("failed to validate cluster config name")))
+	fieldErr := &field.Error{}
+	g.Expect(errors.As(err, &fieldErr)).To(BeTrue())
+	g.Expect(fieldErr.Field).To(Equal("metadata.name"))
+	g.Expect(fieldErr.BadValue).To(BeEmpty())
+	g.Expect(fieldErr.Detail).To(Equal(err.Error()))
+}
+
+func TestValidateKubernetesVersionSkew(t *testing.T) {
+	tests := []struct {
+		name                string
+		workerVersion       KubernetesVersion
+		controlPlaneVersion KubernetesVersion
+		wantErr             string
+	}{
+		{
+			name:                "same version",
+			workerVersion:       Kube121,
+			controlPlaneVersion: Kube121,
+		},
+		{
+			name:                "one minor version older",
+			workerVersion:       Kube120,
+			controlPlaneVersion: Kube121,
+		},
+		{
+			name:                "two minor versions older",
+			workerVersion:       Kube119,
+			controlPlaneVersion: Kube121,
+			wantErr:             "version 1.19 can't be more than 1 minor version(s) older than the control plane version 1.21",
+		},
+		{
+			name:                "newer than control plane",
+			workerVersion:       Kube122,
+			controlPlaneVersion: Kube121,
+			wantErr:             "version 1.22 can't be newer than the control plane version 1.21",
+		},
+		{
+			name:                "invalid version",
+			workerVersion:       "abc",
+			controlPlaneVersion: Kube121,
+			wantErr:             "invalid kubernetes version abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateKubernetesVersionSkew(tt.workerVersion, tt.controlPlaneVersion)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateWorkerNodeGroupsKubernetesVersion(t *testing.T) {
+	kube120 := Kube120
+	kube122 := Kube122
+	tests := []struct {
+		name           string
+		datacenterKind string
+		workers        []WorkerNodeGroupConfiguration
+		wantErr        string
+	}{
+		{
+			name:           "version older than control plane",
+			datacenterKind: VSphereDatacenterKind,
+			workers: []WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: 1, KubernetesVersion: &kube120, MachineGroupRef: &Ref{Kind: VSphereMachineConfigKind, Name: "worker-0"}},
+				{Name: "md-1", Count: 1, MachineGroupRef: &Ref{Kind: VSphereMachineConfigKind, Name: "worker-1"}},
+			},
+		},
+		{
+			name:           "version newer than control plane",
+			datacenterKind: VSphereDatacenterKind,
+			workers: []WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: 1, KubernetesVersion: &kube122, MachineGroupRef: &Ref{Kind: VSphereMachineConfigKind, Name: "worker-0"}},
+			},
+			wantErr: "kubernetes version for worker node group md-0 not valid: version 1.22 can't be newer than the control plane version 1.21",
+		},
+		{
+			name:           "provider not supported",
+			datacenterKind: CloudStackDatacenterKind,
+			workers: []WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: 1, KubernetesVersion: &kube120, MachineGroupRef: &Ref{Kind: CloudStackMachineConfigKind, Name: "worker-0"}},
+			},
+			wantErr: "setting a kubernetes version different from the cluster one is not supported for CloudStackDatacenterConfig",
+		},
+		{
+			name:           "machine config shared across versions",
+			datacenterKind: VSphereDatacenterKind,
+			workers: []WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: 1, KubernetesVersion: &kube120, MachineGroupRef: &Ref{Kind: VSphereMachineConfigKind, Name: "worker"}},
+				{Name: "md-1", Count: 1, MachineGroupRef: &Ref{Kind: VSphereMachineConfigKind, Name: "worker"}},
+			},
+			wantErr: "machine config worker can't be used by nodes with different kubernetes versions (1.20 and 1.21)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := &Cluster{
+				Spec: ClusterSpec{
+					KubernetesVersion: Kube121,
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Count:           1,
+						MachineGroupRef: &Ref{Kind: VSphereMachineConfigKind, Name: "cp"},
+					},
+					WorkerNodeGroupConfigurations: tt.workers,
+					DatacenterRef:                 Ref{Kind: tt.datacenterKind},
+				},
+			}
+			err := validateWorkerNodeGroups(c)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateClusterAutoscalerConfig(t *testing.T) {
+	autoscaledGroup := WorkerNodeGroupConfiguration{
+		Count:                    3,
+		AutoScalingConfiguration: &AutoScalingConfiguration{MinCount: 1, MaxCount: 5},
+	}
+	tests := []struct {
+		name    string
+		wantErr string
+		spec    ClusterSpec
+	}{
+		{
+			name: "not installed",
+			spec: ClusterSpec{WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{Count: 3}}},
+		},
+		{
+			name: "installed with autoscaling",
+			spec: ClusterSpec{
+				WorkerNodeGroupConfigurations:  []WorkerNodeGroupConfiguration{{Count: 3}, autoscaledGroup},
+				ClusterAutoscalerConfiguration: &ClusterAutoscalerConfiguration{Install: true},
+			},
+		},
+		{
+			name:    "installed without autoscaling",
+			wantErr: "requires at least one worker node group with autoscalingConfiguration",
+			spec: ClusterSpec{
+				WorkerNodeGroupConfigurations:  []WorkerNodeGroupConfiguration{{Count: 3}},
+				ClusterAutoscalerConfiguration: &ClusterAutoscalerConfiguration{Install: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateClusterAutoscalerConfig(&Cluster{Spec: tt.spec})
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("%v got = %v, want nil", tt.name, err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("%v got = %v, want %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
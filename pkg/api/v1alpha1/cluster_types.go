@@ -51,6 +51,8 @@ type ClusterSpec struct {
 	RegistryMirrorConfiguration *RegistryMirrorConfiguration `json:"registryMirrorConfiguration,omitempty"`
 	ManagementCluster           ManagementCluster            `json:"managementCluster,omitempty"`
 	PodIAMConfig                *PodIAMConfig                `json:"podIamConfig,omitempty"`
+	// ClusterAutoscalerConfiguration configures the cluster-autoscaler for the worker node groups with an AutoScalingConfiguration
+	ClusterAutoscalerConfiguration *ClusterAutoscalerConfiguration `json:"clusterAutoscalerConfiguration,omitempty"`
 }
 
 func (n *Cluster) Equal(o *Cluster) bool {
@@ -93,6 +95,9 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.ManagementClusterEqual(o) {
 		return false
 	}
+	if !n.Spec.ClusterAutoscalerConfiguration.Equal(o.Spec.ClusterAutoscalerConfiguration) {
+		return false
+	}
 	return true
 }
 
@@ -229,6 +234,19 @@ type WorkerNodeGroupConfiguration struct {
 	Taints []corev1.Taint `json:"taints,omitempty"`
 	// Labels define the labels to assign to the node
 	Labels map[string]string `json:"labels,omitempty"`
+	// AutoScalingConfiguration defines the auto scaling configuration
+	AutoScalingConfiguration *AutoScalingConfiguration `json:"autoscalingConfiguration,omitempty"`
+	// KubernetesVersion defines the version for the worker nodes. Defaults to the Cluster KubernetesVersion.
+	// It can't be newer than the Cluster KubernetesVersion nor older than the kubeadm supported skew
+	KubernetesVersion *KubernetesVersion `json:"kubernetesVersion,omitempty"`
+}
+
+// AutoScalingConfiguration defines the configuration for the node autoscaling feature.
+type AutoScalingConfiguration struct {
+	// MinCount defines the minimum number of nodes for the associated resource group.
+	MinCount int `json:"minCount,omitempty"`
+	// MaxCount defines the maximum number of nodes for the associated resource group.
+	MaxCount int `json:"maxCount,omitempty"`
 }
 
 func generateWorkerNodeGroupKey(c WorkerNodeGroupConfiguration) (key string) {
@@ -261,7 +279,10 @@ func WorkerNodeGroupConfigurationsSliceEqual(a, b []WorkerNodeGroupConfiguration
 		return false
 	}
 
-	return WorkerNodeGroupConfigurationSliceTaintsEqual(a, b) && WorkerNodeGroupConfigurationsLabelsMapEqual(a, b)
+	return WorkerNodeGroupConfigurationSliceTaintsEqual(a, b) &&
+		WorkerNodeGroupConfigurationsLabelsMapEqual(a, b) &&
+		WorkerNodeGroupConfigurationsAutoScalingEqual(a, b) &&
+		WorkerNodeGroupConfigurationsKubernetesVersionEqual(a, b)
 }
 
 func WorkerNodeGroupConfigurationSliceTaintsEqual(a, b []WorkerNodeGroupConfiguration) bool {
@@ -306,6 +327,66 @@ func WorkerNodeGroupConfigurationsLabelsMapEqual(a, b []WorkerNodeGroupConfigura
 	return true
 }
 
+func WorkerNodeGroupConfigurationsAutoScalingEqual(a, b []WorkerNodeGroupConfiguration) bool {
+	m := make(map[string]*AutoScalingConfiguration, len(a))
+	for _, nodeGroup := range a {
+		m[nodeGroup.Name] = nodeGroup.AutoScalingConfiguration
+	}
+
+	for _, nodeGroup := range b {
+		if _, ok := m[nodeGroup.Name]; !ok {
+			// this method is not concerned with added/removed node groups,
+			// only with the comparison of autoscaling configs on existing node groups
+			continue
+		} else {
+			if !m[nodeGroup.Name].Equal(nodeGroup.AutoScalingConfiguration) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func WorkerNodeGroupConfigurationsKubernetesVersionEqual(a, b []WorkerNodeGroupConfiguration) bool {
+	m := make(map[string]*KubernetesVersion, len(a))
+	for _, nodeGroup := range a {
+		m[nodeGroup.Name] = nodeGroup.KubernetesVersion
+	}
+
+	for _, nodeGroup := range b {
+		if _, ok := m[nodeGroup.Name]; !ok {
+			// this method is not concerned with added/removed node groups,
+			// only with the comparison of kubernetes versions on existing node groups
+			continue
+		} else {
+			if !kubernetesVersionPtrEqual(m[nodeGroup.Name], nodeGroup.KubernetesVersion) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func kubernetesVersionPtrEqual(n, o *KubernetesVersion) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return *n == *o
+}
+
+func (n *AutoScalingConfiguration) Equal(o *AutoScalingConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.MinCount == o.MinCount && n.MaxCount == o.MaxCount
+}
+
 type ClusterNetwork struct {
 	// Comma-separated list of CIDR blocks to use for pod and service subnets.
 	// Defaults to 192.168.0.0/16 for pod subnet.
@@ -382,6 +463,9 @@ func (n *CNIConfig) Equal(o *CNIConfig) bool {
 	if !n.Kindnetd.Equal(o.Kindnetd) {
 		return false
 	}
+	if !n.Calico.Equal(o.Calico) {
+		return false
+	}
 	return true
 }
 
@@ -392,7 +476,24 @@ func (n *CiliumConfig) Equal(o *CiliumConfig) bool {
 	if n == nil || o == nil {
 		return false
 	}
-	return n.PolicyEnforcementMode == o.PolicyEnforcementMode
+	return n.PolicyEnforcementMode == o.PolicyEnforcementMode &&
+		n.PolicyAuditMode == o.PolicyAuditMode &&
+		n.RoutingMode == o.RoutingMode &&
+		n.IPAMMode == o.IPAMMode &&
+		n.Hubble.Equal(o.Hubble) &&
+		n.KubeProxyReplacement == o.KubeProxyReplacement &&
+		n.MTU == o.MTU &&
+		n.HelmValues == o.HelmValues
+}
+
+func (n *CiliumHubbleConfig) Equal(o *CiliumHubbleConfig) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return *n == *o
 }
 
 func (n *KindnetdConfig) Equal(o *KindnetdConfig) bool {
@@ -405,6 +506,56 @@ func (n *KindnetdConfig) Equal(o *KindnetdConfig) bool {
 	return true
 }
 
+func (n *CalicoConfig) Equal(o *CalicoConfig) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	if n.Encapsulation != o.Encapsulation || n.ASNumber != o.ASNumber || len(n.BGPPeers) != len(o.BGPPeers) {
+		return false
+	}
+	for i := range n.BGPPeers {
+		if n.BGPPeers[i] != o.BGPPeers[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// EffectiveRoutingMode returns the configured RoutingMode or the default, tunnel.
+func (n *CiliumConfig) EffectiveRoutingMode() CiliumRoutingMode {
+	if n.RoutingMode == "" {
+		return CiliumRoutingModeTunnel
+	}
+	return n.RoutingMode
+}
+
+// EffectiveIPAMMode returns the configured IPAMMode or the default, kubernetes.
+func (n *CiliumConfig) EffectiveIPAMMode() CiliumIPAMMode {
+	if n.IPAMMode == "" {
+		return CiliumIPAMModeKubernetes
+	}
+	return n.IPAMMode
+}
+
+// EncapsulationMode returns the configured Encapsulation or the default, vxlan.
+func (n *CalicoConfig) EncapsulationMode() CalicoEncapsulation {
+	if n.Encapsulation == "" {
+		return CalicoEncapsulationVXLAN
+	}
+	return n.Encapsulation
+}
+
+// NodeASNumber returns the configured ASNumber or DefaultCalicoASNumber.
+func (n *CalicoConfig) NodeASNumber() uint32 {
+	if n.ASNumber == 0 {
+		return DefaultCalicoASNumber
+	}
+	return n.ASNumber
+}
+
 func CNIPluginSame(n ClusterNetwork, o ClusterNetwork) bool {
 	if n.CNI != "" {
 		/*This shouldn't be required since we set CNIConfig and unset CNI as part of cluster_defaults. However, while upgrading an existing cluster, the eks-a controller
@@ -436,12 +587,35 @@ func CNIPluginSame(n ClusterNetwork, o ClusterNetwork) bool {
 			if (n.CNIConfig.Kindnetd != nil && o.CNIConfig.Kindnetd == nil) || (n.CNIConfig.Kindnetd == nil && o.CNIConfig.Kindnetd != nil) {
 				return false
 			}
+			if (n.CNIConfig.Calico != nil && o.CNIConfig.Calico == nil) || (n.CNIConfig.Calico == nil && o.CNIConfig.Calico != nil) {
+				return false
+			}
 		}
 	}
 
 	return true
 }
 
+// KindnetdToCiliumMigration returns true if o runs kindnetd and n runs cilium, the only CNI change
+// supported on an existing cluster.
+func KindnetdToCiliumMigration(n ClusterNetwork, o ClusterNetwork) bool {
+	return usesKindnetd(o) && usesCilium(n)
+}
+
+func usesKindnetd(n ClusterNetwork) bool {
+	if n.CNIConfig != nil {
+		return n.CNIConfig.Kindnetd != nil
+	}
+	return n.CNI == Kindnetd
+}
+
+func usesCilium(n ClusterNetwork) bool {
+	if n.CNIConfig != nil {
+		return n.CNIConfig.Cilium != nil
+	}
+	return n.CNI == Cilium || n.CNI == CiliumEnterprise
+}
+
 func SliceEqual(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -522,22 +696,85 @@ const (
 	Kube122 KubernetesVersion = "1.22"
 )
 
+// MaxWorkerNodeGroupVersionSkew is the number of minor versions worker nodes can be behind the control plane,
+// as supported by kubeadm when joining nodes
+const MaxWorkerNodeGroupVersionSkew = 1
+
+// workerNodeGroupKubernetesVersionProviders are the datacenter kinds that support worker node groups with
+// a kubernetes version different from the control plane one
+var workerNodeGroupKubernetesVersionProviders = map[string]struct{}{
+	DockerDatacenterKind:  {},
+	VSphereDatacenterKind: {},
+}
+
 type CNI string
 
 type CiliumPolicyEnforcementMode string
 
+type CiliumRoutingMode string
+
+type CiliumIPAMMode string
+
+type CiliumKubeProxyReplacement string
+
+type CalicoEncapsulation string
+
 type CNIConfig struct {
 	Cilium   *CiliumConfig   `json:"cilium,omitempty"`
 	Kindnetd *KindnetdConfig `json:"kindnetd,omitempty"`
+	Calico   *CalicoConfig   `json:"calico,omitempty"`
 }
 
 type CiliumConfig struct {
 	// PolicyEnforcementMode determines communication allowed between pods. Accepted values are default, always, never.
 	PolicyEnforcementMode CiliumPolicyEnforcementMode `json:"policyEnforcementMode,omitempty"`
+	// PolicyAuditMode makes Cilium report the flows denied by network policies instead of dropping them, and installs
+	// the EKS Anywhere network policies so their denied flows show up in Hubble and the support bundle.
+	PolicyAuditMode bool `json:"policyAuditMode,omitempty"`
+	// RoutingMode determines how traffic between pods on different nodes is routed. Accepted values are tunnel,
+	// which encapsulates it with geneve, and native, which relies on the network routing the pod CIDR. Defaults to tunnel.
+	RoutingMode CiliumRoutingMode `json:"routingMode,omitempty"`
+	// IPAMMode determines how pod IPs are allocated. Accepted values are kubernetes, which uses the pod CIDR allocated
+	// to each node, and cluster-pool. Defaults to kubernetes.
+	IPAMMode CiliumIPAMMode `json:"ipamMode,omitempty"`
+	// Hubble enables Hubble network observability.
+	Hubble *CiliumHubbleConfig `json:"hubble,omitempty"`
+	// KubeProxyReplacement determines if Cilium replaces kube-proxy. Accepted values are disabled, partial, probe, strict.
+	KubeProxyReplacement CiliumKubeProxyReplacement `json:"kubeProxyReplacement,omitempty"`
+	// MTU of the pod network devices. Defaults to the MTU of the node network device.
+	MTU int `json:"mtu,omitempty"`
+	// HelmValues is a yaml map of extra Helm values for the Cilium chart. They take precedence over the values
+	// set from the other fields.
+	HelmValues string `json:"helmValues,omitempty"`
+}
+
+type CiliumHubbleConfig struct {
+	// Relay enables Hubble Relay, which exposes the network flows of the whole cluster.
+	Relay bool `json:"relay,omitempty"`
+	// UI enables the Hubble UI. It requires Relay.
+	UI bool `json:"ui,omitempty"`
 }
 
 type KindnetdConfig struct{}
 
+type CalicoConfig struct {
+	// Encapsulation determines how traffic between pods on different nodes is encapsulated. Accepted values are
+	// vxlan, ipip and none, which relies on BGP to route the pod CIDR. Defaults to vxlan.
+	Encapsulation CalicoEncapsulation `json:"encapsulation,omitempty"`
+	// ASNumber is the BGP autonomous system number of the nodes. Defaults to 64512.
+	ASNumber uint32 `json:"asNumber,omitempty"`
+	// BGPPeers are the routers, like top-of-rack switches, every node peers with to advertise the pod routes.
+	// They require ipip or none encapsulation.
+	BGPPeers []CalicoBGPPeer `json:"bgpPeers,omitempty"`
+}
+
+type CalicoBGPPeer struct {
+	// PeerIP is the IP address of the router.
+	PeerIP string `json:"peerIP"`
+	// ASNumber is the BGP autonomous system number of the router.
+	ASNumber uint32 `json:"asNumber"`
+}
+
 const (
 	Cilium           CNI = "cilium"
 	CiliumEnterprise CNI = "cilium-enterprise"
@@ -561,6 +798,60 @@ var validCiliumPolicyEnforcementModes = map[CiliumPolicyEnforcementMode]bool{
 	CiliumPolicyModeNever:   true,
 }
 
+const (
+	CiliumRoutingModeTunnel CiliumRoutingMode = "tunnel"
+	CiliumRoutingModeNative CiliumRoutingMode = "native"
+)
+
+var validCiliumRoutingModes = map[CiliumRoutingMode]bool{
+	CiliumRoutingModeTunnel: true,
+	CiliumRoutingModeNative: true,
+}
+
+const (
+	CiliumIPAMModeKubernetes  CiliumIPAMMode = "kubernetes"
+	CiliumIPAMModeClusterPool CiliumIPAMMode = "cluster-pool"
+)
+
+var validCiliumIPAMModes = map[CiliumIPAMMode]bool{
+	CiliumIPAMModeKubernetes:  true,
+	CiliumIPAMModeClusterPool: true,
+}
+
+const (
+	CiliumKubeProxyReplacementDisabled CiliumKubeProxyReplacement = "disabled"
+	CiliumKubeProxyReplacementPartial  CiliumKubeProxyReplacement = "partial"
+	CiliumKubeProxyReplacementProbe    CiliumKubeProxyReplacement = "probe"
+	CiliumKubeProxyReplacementStrict   CiliumKubeProxyReplacement = "strict"
+)
+
+var validCiliumKubeProxyReplacements = map[CiliumKubeProxyReplacement]bool{
+	CiliumKubeProxyReplacementDisabled: true,
+	CiliumKubeProxyReplacementPartial:  true,
+	CiliumKubeProxyReplacementProbe:    true,
+	CiliumKubeProxyReplacementStrict:   true,
+}
+
+const (
+	minCiliumMTU = 1280
+	maxCiliumMTU = 9000
+)
+
+const (
+	CalicoEncapsulationVXLAN CalicoEncapsulation = "vxlan"
+	CalicoEncapsulationIPIP  CalicoEncapsulation = "ipip"
+	CalicoEncapsulationNone  CalicoEncapsulation = "none"
+)
+
+var validCalicoEncapsulations = map[CalicoEncapsulation]bool{
+	CalicoEncapsulationVXLAN: true,
+	CalicoEncapsulationIPIP:  true,
+	CalicoEncapsulationNone:  true,
+}
+
+// DefaultCalicoASNumber is the BGP autonomous system number of the nodes if none is configured.
+const DefaultCalicoASNumber uint32 = 64512
+
 // ClusterStatus defines the observed state of Cluster
 type ClusterStatus struct {
 	// Descriptive message about a fatal problem while reconciling a cluster
@@ -570,6 +861,32 @@ type ClusterStatus struct {
 	EksdReleaseRef *EksdReleaseRef `json:"eksdReleaseRef,omitempty"`
 	// +optional
 	Conditions []clusterv1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the latest generation of the cluster spec processed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ControlPlane reports the replicas of the control plane nodes
+	// +optional
+	ControlPlane *NodeGroupStatus `json:"controlPlane,omitempty"`
+	// WorkerNodeGroups reports the replicas of each worker node group
+	// +optional
+	WorkerNodeGroups []NodeGroupStatus `json:"workerNodeGroups,omitempty"`
+	// AppliedConfigGenerations are the generations of the datacenter and machine configs, by kind and name,
+	// the CAPI specs of the cluster were last generated from
+	// +optional
+	AppliedConfigGenerations map[string]int64 `json:"appliedConfigGenerations,omitempty"`
+}
+
+// NodeGroupStatus defines the observed replicas of a group of nodes
+type NodeGroupStatus struct {
+	// Name of the node group, empty for the control plane
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Replicas is the number of machines in the node group
+	Replicas int32 `json:"replicas"`
+	// UpdatedReplicas is the number of machines up to date with the node group spec
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+	// ReadyReplicas is the number of machines with a ready node
+	ReadyReplicas int32 `json:"readyReplicas"`
 }
 
 type EksdReleaseRef struct {
@@ -651,8 +968,34 @@ func (n *PodIAMConfig) Equal(o *PodIAMConfig) bool {
 	return n.ServiceAccountIssuer == o.ServiceAccountIssuer
 }
 
+// ClusterAutoscalerConfiguration defines the cluster-autoscaler of a cluster.
+type ClusterAutoscalerConfiguration struct {
+	// Install deploys the cluster-autoscaler in the management cluster, where it scales the worker node groups
+	// with an AutoScalingConfiguration within their bounds.
+	Install bool `json:"install,omitempty"`
+}
+
+func (n *ClusterAutoscalerConfiguration) Equal(o *ClusterAutoscalerConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Install == o.Install
+}
+
+// InstallClusterAutoscaler returns true if the cluster-autoscaler is enabled for the cluster
+func (n *ClusterAutoscalerConfiguration) InstallClusterAutoscaler() bool {
+	return n != nil && n.Install
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="ControlPlaneReady",type="string",JSONPath=".status.conditions[?(@.type==\"ControlPlaneReady\")].status"
+// +kubebuilder:printcolumn:name="WorkersReady",type="string",JSONPath=".status.conditions[?(@.type==\"WorkersReady\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // Cluster is the Schema for the clusters API
 type Cluster struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -744,6 +1087,29 @@ func (c *Cluster) MachineConfigRefs() []Ref {
 	return machineConfigRefMap.toSlice()
 }
 
+// WorkerNodeGroupKubernetesVersion returns the kubernetes version of a worker node group,
+// which is the Cluster version unless the worker node group sets its own
+func (c *Cluster) WorkerNodeGroupKubernetesVersion(w WorkerNodeGroupConfiguration) KubernetesVersion {
+	if w.KubernetesVersion != nil {
+		return *w.KubernetesVersion
+	}
+	return c.Spec.KubernetesVersion
+}
+
+// KubernetesVersions returns the distinct kubernetes versions of the Cluster nodes, starting with the Cluster version
+func (c *Cluster) KubernetesVersions() []KubernetesVersion {
+	versions := []KubernetesVersion{c.Spec.KubernetesVersion}
+	seen := map[KubernetesVersion]bool{c.Spec.KubernetesVersion: true}
+	for _, w := range c.Spec.WorkerNodeGroupConfigurations {
+		v := c.WorkerNodeGroupKubernetesVersion(w)
+		if !seen[v] {
+			seen[v] = true
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
 type refSet map[Ref]struct{}
 
 func (r refSet) addIfNotNil(ref *Ref) bool {
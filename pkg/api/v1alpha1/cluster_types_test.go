@@ -498,6 +498,46 @@ func TestClusterEqualWorkerNodeGroupConfigurations(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			testName: "both exist, same autoscaling config",
+			cluster1Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+				},
+			},
+			cluster2Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+				},
+			},
+			want: true,
+		},
+		{
+			testName: "both exist, autoscaling bounds changed",
+			cluster1Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+				},
+			},
+			cluster2Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 5},
+				},
+			},
+			want: false,
+		},
+		{
+			testName: "both exist, one with autoscaling config",
+			cluster1Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+				},
+			},
+			cluster2Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -832,6 +872,90 @@ func TestClusterEqualClusterNetwork(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			testName: "same cni plugin (cilium), diff cilium hubble configuration",
+			cluster1ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{Hubble: &v1alpha1.CiliumHubbleConfig{Relay: true}}},
+			},
+			cluster2ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{Hubble: &v1alpha1.CiliumHubbleConfig{Relay: true, UI: true}}},
+			},
+			want: false,
+		},
+		{
+			testName: "same cni plugin (cilium), diff cilium policy audit mode",
+			cluster1ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{PolicyAuditMode: true}},
+			},
+			cluster2ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}},
+			},
+			want: false,
+		},
+		{
+			testName: "different cni plugin (cilium to calico)",
+			cluster1ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}},
+			},
+			cluster2ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Calico: &v1alpha1.CalicoConfig{}},
+			},
+			want: false,
+		},
+		{
+			testName: "different cni plugin (calico), old format",
+			cluster1ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNI: v1alpha1.Cilium,
+			},
+			cluster2ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Calico: &v1alpha1.CalicoConfig{}},
+			},
+			want: false,
+		},
+		{
+			testName: "same cni plugin (calico), diff bgp peers",
+			cluster1ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Calico: &v1alpha1.CalicoConfig{
+					Encapsulation: "none",
+					BGPPeers:      []v1alpha1.CalicoBGPPeer{{PeerIP: "10.0.0.1", ASNumber: 65001}},
+				}},
+			},
+			cluster2ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Calico: &v1alpha1.CalicoConfig{
+					Encapsulation: "none",
+					BGPPeers:      []v1alpha1.CalicoBGPPeer{{PeerIP: "10.0.0.2", ASNumber: 65001}},
+				}},
+			},
+			want: false,
+		},
+		{
+			testName: "same cni plugin (calico), same configuration",
+			cluster1ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Calico: &v1alpha1.CalicoConfig{
+					Encapsulation: "ipip",
+					ASNumber:      65000,
+					BGPPeers:      []v1alpha1.CalicoBGPPeer{{PeerIP: "10.0.0.1", ASNumber: 65001}},
+				}},
+			},
+			cluster2ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Calico: &v1alpha1.CalicoConfig{
+					Encapsulation: "ipip",
+					ASNumber:      65000,
+					BGPPeers:      []v1alpha1.CalicoBGPPeer{{PeerIP: "10.0.0.1", ASNumber: 65001}},
+				}},
+			},
+			want: true,
+		},
+		{
+			testName: "same cni plugin (cilium), same cilium network options",
+			cluster1ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{RoutingMode: "native", MTU: 9000, Hubble: &v1alpha1.CiliumHubbleConfig{Relay: true}}},
+			},
+			cluster2ClusterNetwork: v1alpha1.ClusterNetwork{
+				CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{RoutingMode: "native", MTU: 9000, Hubble: &v1alpha1.CiliumHubbleConfig{Relay: true}}},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -1456,3 +1580,69 @@ func setSelfManaged(c *v1alpha1.Cluster, s bool) {
 		c.SetManagedBy("management-cluster")
 	}
 }
+
+func TestClusterKubernetesVersions(t *testing.T) {
+	g := NewWithT(t)
+	kube120 := v1alpha1.Kube120
+	kube121 := v1alpha1.Kube121
+	c := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			KubernetesVersion: v1alpha1.Kube121,
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", KubernetesVersion: &kube120},
+				{Name: "md-1", KubernetesVersion: &kube121},
+				{Name: "md-2"},
+				{Name: "md-3", KubernetesVersion: &kube120},
+			},
+		},
+	}
+
+	g.Expect(c.KubernetesVersions()).To(Equal([]v1alpha1.KubernetesVersion{v1alpha1.Kube121, v1alpha1.Kube120}))
+	g.Expect(c.WorkerNodeGroupKubernetesVersion(c.Spec.WorkerNodeGroupConfigurations[0])).To(Equal(v1alpha1.Kube120))
+	g.Expect(c.WorkerNodeGroupKubernetesVersion(c.Spec.WorkerNodeGroupConfigurations[2])).To(Equal(v1alpha1.Kube121))
+}
+
+func TestKindnetdToCiliumMigration(t *testing.T) {
+	testCases := []struct {
+		testName string
+		new, old v1alpha1.ClusterNetwork
+		want     bool
+	}{
+		{
+			testName: "kindnetd to cilium",
+			old:      v1alpha1.ClusterNetwork{CNIConfig: &v1alpha1.CNIConfig{Kindnetd: &v1alpha1.KindnetdConfig{}}},
+			new:      v1alpha1.ClusterNetwork{CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}},
+			want:     true,
+		},
+		{
+			testName: "deprecated kindnetd field to cilium",
+			old:      v1alpha1.ClusterNetwork{CNI: v1alpha1.Kindnetd},
+			new:      v1alpha1.ClusterNetwork{CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}},
+			want:     true,
+		},
+		{
+			testName: "cilium to kindnetd",
+			old:      v1alpha1.ClusterNetwork{CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}},
+			new:      v1alpha1.ClusterNetwork{CNIConfig: &v1alpha1.CNIConfig{Kindnetd: &v1alpha1.KindnetdConfig{}}},
+			want:     false,
+		},
+		{
+			testName: "kindnetd to calico",
+			old:      v1alpha1.ClusterNetwork{CNIConfig: &v1alpha1.CNIConfig{Kindnetd: &v1alpha1.KindnetdConfig{}}},
+			new:      v1alpha1.ClusterNetwork{CNIConfig: &v1alpha1.CNIConfig{Calico: &v1alpha1.CalicoConfig{}}},
+			want:     false,
+		},
+		{
+			testName: "cilium to cilium",
+			old:      v1alpha1.ClusterNetwork{CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}},
+			new:      v1alpha1.ClusterNetwork{CNIConfig: &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}},
+			want:     false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(v1alpha1.KindnetdToCiliumMigration(tt.new, tt.old)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package v1alpha1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+// Conditions reported in the status of a Cluster. The Ready condition summarizes all of them.
+const (
+	// ControlPlaneReadyCondition reports whether all the control plane nodes are up to date with the cluster spec and ready.
+	ControlPlaneReadyCondition clusterv1.ConditionType = "ControlPlaneReady"
+
+	// WorkersReadyCondition reports whether all the worker nodes of every node group are up to date with the cluster spec and ready.
+	WorkersReadyCondition clusterv1.ConditionType = "WorkersReady"
+
+	// DefaultCNIConfiguredCondition reports whether the CNI shipped with EKS Anywhere has been installed in the cluster.
+	DefaultCNIConfiguredCondition clusterv1.ConditionType = "DefaultCNIConfigured"
+
+	// CNIReadyCondition reports whether the CNI has been installed and all the nodes are ready, which requires a working pod network.
+	CNIReadyCondition clusterv1.ConditionType = "CNIReady"
+
+	// ExtraObjectsAppliedCondition reports whether the extra objects the cluster needs, like the CoreDNS config, have been applied.
+	ExtraObjectsAppliedCondition clusterv1.ConditionType = "ExtraObjectsApplied"
+)
+
+// Reasons set in the Cluster conditions when they are not true.
+const (
+	// ControlPlaneInitializingReason means the control plane objects are not created yet or its first node is not ready.
+	ControlPlaneInitializingReason = "ControlPlaneInitializing"
+
+	// ScalingUpReason means a node group has fewer ready replicas than desired.
+	ScalingUpReason = "ScalingUp"
+
+	// ScalingDownReason means a node group has more replicas than desired.
+	ScalingDownReason = "ScalingDown"
+
+	// RollingUpgradeInProgressReason means some nodes still run a previous version of their spec.
+	RollingUpgradeInProgressReason = "RollingUpgradeInProgress"
+
+	// WorkersNotCreatedReason means some of the MachineDeployments of the worker node groups don't exist yet.
+	WorkersNotCreatedReason = "WorkersNotCreated"
+
+	// WaitingForControlPlaneReason means the step waits for the control plane to be ready.
+	WaitingForControlPlaneReason = "WaitingForControlPlane"
+
+	// WaitingForCNIReason means the CNI is installed but some nodes are not ready yet.
+	WaitingForCNIReason = "WaitingForCNI"
+)
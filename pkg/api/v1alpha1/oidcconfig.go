@@ -3,6 +3,8 @@ package v1alpha1
 import (
 	"fmt"
 	"net/url"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const OIDCConfigKind = "OIDCConfig"
@@ -44,23 +46,24 @@ func validateOIDCConfig(config *OIDCConfig) error {
 	}
 
 	if config.Spec.ClientId == "" {
-		return fmt.Errorf("OIDCConfig clientId is required")
+		return field.Required(field.NewPath("spec", "clientId"), "OIDCConfig clientId is required")
 	}
+	issuerUrlPath := field.NewPath("spec", "issuerUrl")
 	if config.Spec.IssuerUrl == "" {
-		return fmt.Errorf("OIDCConfig issuerUrl is required")
+		return field.Required(issuerUrlPath, "OIDCConfig issuerUrl is required")
 	}
 
 	u, err := url.ParseRequestURI(config.Spec.IssuerUrl)
 	if err != nil {
-		return fmt.Errorf("OIDCConfig issuerUrl is invalid: %v", err)
+		return field.Invalid(issuerUrlPath, config.Spec.IssuerUrl, fmt.Sprintf("OIDCConfig issuerUrl is invalid: %v", err))
 	}
 
 	if u.Scheme != "https" {
-		return fmt.Errorf("OIDCConfig issuerUrl should have HTTPS scheme")
+		return field.Invalid(issuerUrlPath, config.Spec.IssuerUrl, "OIDCConfig issuerUrl should have HTTPS scheme")
 	}
 
 	if len(config.Spec.RequiredClaims) > 1 {
-		return fmt.Errorf("only one OIDConfig RequiredClaim is supported at this time")
+		return field.Forbidden(field.NewPath("spec", "requiredClaims"), "only one OIDConfig RequiredClaim is supported at this time")
 	}
 	return nil
 }
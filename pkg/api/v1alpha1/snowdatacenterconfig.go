@@ -1,6 +1,9 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"path/filepath"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -40,3 +43,19 @@ func GetSnowDatacenterConfig(fileName string) (*SnowDatacenterConfig, error) {
 	}
 	return &clusterConfig, nil
 }
+
+func validateSnowDatacenterConfig(config *SnowDatacenterConfig) error {
+	if mhc := config.Spec.MachineHealthCheck; mhc != nil {
+		if mhc.NodeStartupTimeout != nil && mhc.NodeStartupTimeout.Duration <= 0 {
+			return fmt.Errorf("SnowDatacenterConfig machineHealthCheck nodeStartupTimeout must be positive")
+		}
+		if mhc.UnhealthyMachineTimeout != nil && mhc.UnhealthyMachineTimeout.Duration <= 0 {
+			return fmt.Errorf("SnowDatacenterConfig machineHealthCheck unhealthyMachineTimeout must be positive")
+		}
+	}
+
+	if storage := config.Spec.Storage; storage != nil && storage.LocalVolumePath != "" && !filepath.IsAbs(storage.LocalVolumePath) {
+		return fmt.Errorf("SnowDatacenterConfig storage localVolumePath %s must be an absolute path", storage.LocalVolumePath)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -56,3 +57,72 @@ func TestGetSnowDatacenterConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSnowDatacenterConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     *SnowDatacenterConfig
+		wantErr string
+	}{
+		{
+			name:    "empty spec",
+			obj:     &SnowDatacenterConfig{},
+			wantErr: "",
+		},
+		{
+			name: "valid machine health check and storage",
+			obj: &SnowDatacenterConfig{
+				Spec: SnowDatacenterConfigSpec{
+					MachineHealthCheck: &SnowMachineHealthCheck{
+						NodeStartupTimeout:      &metav1.Duration{Duration: 20 * time.Minute},
+						UnhealthyMachineTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+					Storage: &SnowStorage{LocalVolumePath: "/data/volumes"},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid node startup timeout",
+			obj: &SnowDatacenterConfig{
+				Spec: SnowDatacenterConfigSpec{
+					MachineHealthCheck: &SnowMachineHealthCheck{
+						NodeStartupTimeout: &metav1.Duration{},
+					},
+				},
+			},
+			wantErr: "nodeStartupTimeout must be positive",
+		},
+		{
+			name: "invalid unhealthy machine timeout",
+			obj: &SnowDatacenterConfig{
+				Spec: SnowDatacenterConfigSpec{
+					MachineHealthCheck: &SnowMachineHealthCheck{
+						UnhealthyMachineTimeout: &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+			},
+			wantErr: "unhealthyMachineTimeout must be positive",
+		},
+		{
+			name: "relative local volume path",
+			obj: &SnowDatacenterConfig{
+				Spec: SnowDatacenterConfigSpec{
+					Storage: &SnowStorage{LocalVolumePath: "data/volumes"},
+				},
+			},
+			wantErr: "localVolumePath data/volumes must be an absolute path",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tt.obj.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
@@ -6,6 +6,31 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 // SnowDatacenterConfigSpec defines the desired state of SnowDatacenterConfig
 type SnowDatacenterConfigSpec struct { // Important: Run "make generate" to regenerate code after modifying this file
+	// MachineHealthCheck configures the timeouts of the machine health checks that remediate unhealthy nodes.
+	// +optional
+	MachineHealthCheck *SnowMachineHealthCheck `json:"machineHealthCheck,omitempty"`
+
+	// Storage configures the default storage of the cluster. No StorageClass is installed if omitted.
+	// +optional
+	Storage *SnowStorage `json:"storage,omitempty"`
+}
+
+// SnowMachineHealthCheck defines when a Snow machine is considered unhealthy and replaced
+type SnowMachineHealthCheck struct {
+	// NodeStartupTimeout is the time a machine has to get its node registered in the cluster. Defaults to 10m.
+	// +optional
+	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
+
+	// UnhealthyMachineTimeout is the time a node can be not ready or unknown before its machine is replaced. Defaults to 5m.
+	// +optional
+	UnhealthyMachineTimeout *metav1.Duration `json:"unhealthyMachineTimeout,omitempty"`
+}
+
+// SnowStorage installs a local volume provisioner backed by the node disks and makes it the default StorageClass
+type SnowStorage struct {
+	// LocalVolumePath is the directory on the nodes where the volumes are created. Defaults to /opt/local-path-provisioner.
+	// +optional
+	LocalVolumePath string `json:"localVolumePath,omitempty"`
 }
 
 // SnowDatacenterConfigStatus defines the observed state of SnowDatacenterConfig
@@ -31,6 +56,10 @@ func (s *SnowDatacenterConfig) ExpectedKind() string {
 	return SnowDatacenterKind
 }
 
+func (s *SnowDatacenterConfig) Validate() error {
+	return validateSnowDatacenterConfig(s)
+}
+
 func (s *SnowDatacenterConfig) PauseReconcile() {
 	if s.Annotations == nil {
 		s.Annotations = map[string]string{}
@@ -75,3 +104,16 @@ type SnowDatacenterConfigGenerate struct {
 
 	Spec SnowDatacenterConfigSpec `json:"spec,omitempty"`
 }
+
+//+kubebuilder:object:root=true
+
+// SnowDatacenterConfigList contains a list of SnowDatacenterConfig
+type SnowDatacenterConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SnowDatacenterConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SnowDatacenterConfig{}, &SnowDatacenterConfigList{})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
@@ -47,11 +48,12 @@ func (s *SnowMachineConfigGenerate) Name() string {
 
 func validateSnowMachineConfig(config *SnowMachineConfig) error {
 	if config.Spec.AMIID == "" {
-		return fmt.Errorf("SnowMachineConfig AMIID is a required field")
+		return field.Required(field.NewPath("spec", "amiID"), "SnowMachineConfig AMIID is a required field")
 	}
 
 	if config.Spec.InstanceType != SbeCLarge && config.Spec.InstanceType != SbeCXLarge && config.Spec.InstanceType != SbeC2XLarge && config.Spec.InstanceType != SbeC4XLarge {
-		return fmt.Errorf("SnowMachineConfig InstanceType %s is not supported, please use one of the following: %s, %s, %s, %s ", config.Spec.InstanceType, SbeCLarge, SbeCXLarge, SbeC2XLarge, SbeC4XLarge)
+		return field.Invalid(field.NewPath("spec", "instanceType"), config.Spec.InstanceType,
+			fmt.Sprintf("SnowMachineConfig InstanceType %s is not supported, please use one of the following: %s, %s, %s, %s ", config.Spec.InstanceType, SbeCLarge, SbeCXLarge, SbeC2XLarge, SbeC4XLarge))
 	}
 	return nil
 }
@@ -20,6 +20,7 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -221,19 +222,39 @@ func (in *AWSIamConfigStatus) DeepCopy() *AWSIamConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoScalingConfiguration) DeepCopyInto(out *AutoScalingConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalingConfiguration.
+func (in *AutoScalingConfiguration) DeepCopy() *AutoScalingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoScalingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CNIConfig) DeepCopyInto(out *CNIConfig) {
 	*out = *in
 	if in.Cilium != nil {
 		in, out := &in.Cilium, &out.Cilium
 		*out = new(CiliumConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Kindnetd != nil {
 		in, out := &in.Kindnetd, &out.Kindnetd
 		*out = new(KindnetdConfig)
 		**out = **in
 	}
+	if in.Calico != nil {
+		in, out := &in.Calico, &out.Calico
+		*out = new(CalicoConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIConfig.
@@ -246,9 +267,49 @@ func (in *CNIConfig) DeepCopy() *CNIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalicoBGPPeer) DeepCopyInto(out *CalicoBGPPeer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoBGPPeer.
+func (in *CalicoBGPPeer) DeepCopy() *CalicoBGPPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(CalicoBGPPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalicoConfig) DeepCopyInto(out *CalicoConfig) {
+	*out = *in
+	if in.BGPPeers != nil {
+		in, out := &in.BGPPeers, &out.BGPPeers
+		*out = make([]CalicoBGPPeer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoConfig.
+func (in *CalicoConfig) DeepCopy() *CalicoConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CalicoConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CiliumConfig) DeepCopyInto(out *CiliumConfig) {
 	*out = *in
+	if in.Hubble != nil {
+		in, out := &in.Hubble, &out.Hubble
+		*out = new(CiliumHubbleConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumConfig.
@@ -261,6 +322,21 @@ func (in *CiliumConfig) DeepCopy() *CiliumConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumHubbleConfig) DeepCopyInto(out *CiliumHubbleConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumHubbleConfig.
+func (in *CiliumHubbleConfig) DeepCopy() *CiliumHubbleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumHubbleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudStackDatacenterConfig) DeepCopyInto(out *CloudStackDatacenterConfig) {
 	*out = *in
@@ -523,6 +599,21 @@ func (in *Cluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalerConfiguration) DeepCopyInto(out *ClusterAutoscalerConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerConfiguration.
+func (in *ClusterAutoscalerConfiguration) DeepCopy() *ClusterAutoscalerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscalerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	*out = *in
@@ -622,6 +713,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(PodIAMConfig)
 		**out = **in
 	}
+	if in.ClusterAutoscalerConfiguration != nil {
+		in, out := &in.ClusterAutoscalerConfiguration, &out.ClusterAutoscalerConfiguration
+		*out = new(ClusterAutoscalerConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -654,6 +750,23 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ControlPlane != nil {
+		in, out := &in.ControlPlane, &out.ControlPlane
+		*out = new(NodeGroupStatus)
+		**out = **in
+	}
+	if in.WorkerNodeGroups != nil {
+		in, out := &in.WorkerNodeGroups, &out.WorkerNodeGroups
+		*out = make([]NodeGroupStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedConfigGenerations != nil {
+		in, out := &in.AppliedConfigGenerations, &out.AppliedConfigGenerations
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -1184,6 +1297,21 @@ func (in *MapUsers) DeepCopy() *MapUsers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupStatus) DeepCopyInto(out *NodeGroupStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupStatus.
+func (in *NodeGroupStatus) DeepCopy() *NodeGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
 	*out = *in
@@ -1440,7 +1568,7 @@ func (in *SnowDatacenterConfig) DeepCopyInto(out *SnowDatacenterConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -1462,9 +1590,51 @@ func (in *SnowDatacenterConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowDatacenterConfigList) DeepCopyInto(out *SnowDatacenterConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SnowDatacenterConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowDatacenterConfigList.
+func (in *SnowDatacenterConfigList) DeepCopy() *SnowDatacenterConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowDatacenterConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnowDatacenterConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnowDatacenterConfigSpec) DeepCopyInto(out *SnowDatacenterConfigSpec) {
 	*out = *in
+	if in.MachineHealthCheck != nil {
+		in, out := &in.MachineHealthCheck, &out.MachineHealthCheck
+		*out = new(SnowMachineHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(SnowStorage)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowDatacenterConfigSpec.
@@ -1519,6 +1689,38 @@ func (in *SnowMachineConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowMachineConfigList) DeepCopyInto(out *SnowMachineConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SnowMachineConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowMachineConfigList.
+func (in *SnowMachineConfigList) DeepCopy() *SnowMachineConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowMachineConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnowMachineConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnowMachineConfigSpec) DeepCopyInto(out *SnowMachineConfigSpec) {
 	*out = *in
@@ -1549,6 +1751,46 @@ func (in *SnowMachineConfigStatus) DeepCopy() *SnowMachineConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowMachineHealthCheck) DeepCopyInto(out *SnowMachineHealthCheck) {
+	*out = *in
+	if in.NodeStartupTimeout != nil {
+		in, out := &in.NodeStartupTimeout, &out.NodeStartupTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.UnhealthyMachineTimeout != nil {
+		in, out := &in.UnhealthyMachineTimeout, &out.UnhealthyMachineTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowMachineHealthCheck.
+func (in *SnowMachineHealthCheck) DeepCopy() *SnowMachineHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowMachineHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowStorage) DeepCopyInto(out *SnowStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowStorage.
+func (in *SnowStorage) DeepCopy() *SnowStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TinkerbellDatacenterConfig) DeepCopyInto(out *TinkerbellDatacenterConfig) {
 	*out = *in
@@ -2057,6 +2299,16 @@ func (in *WorkerNodeGroupConfiguration) DeepCopyInto(out *WorkerNodeGroupConfigu
 			(*out)[key] = val
 		}
 	}
+	if in.AutoScalingConfiguration != nil {
+		in, out := &in.AutoScalingConfiguration, &out.AutoScalingConfiguration
+		*out = new(AutoScalingConfiguration)
+		**out = **in
+	}
+	if in.KubernetesVersion != nil {
+		in, out := &in.KubernetesVersion, &out.KubernetesVersion
+		*out = new(KubernetesVersion)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodeGroupConfiguration.
@@ -0,0 +1,206 @@
+package certificates
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	kubernetesPKIDir   = "/etc/kubernetes/pki"
+	externalEtcdPKIDir = "/etc/etcd/pki"
+	// awsIamAuthCertPath is where the kubeadm files of the control plane write the aws-iam-authenticator-ca secret
+	awsIamAuthCertPath = "/var/lib/kubeadm/aws-iam-authenticator/pki/cert.pem"
+	awsIamAuthKeyPath  = "/var/lib/kubeadm/aws-iam-authenticator/pki/key.pem"
+	// certificateMarker precedes the content of each file in the output of readCertificatesCommand
+	certificateMarker = "eksa-certificate: "
+
+	defaultControlPlaneRolloutTimeout = 60 * time.Minute
+	controlPlaneRolloutBackoff        = 10 * time.Second
+)
+
+var (
+	controlPlaneCertificates = []string{
+		kubernetesPKIDir + "/apiserver.crt",
+		kubernetesPKIDir + "/apiserver-kubelet-client.crt",
+		kubernetesPKIDir + "/apiserver-etcd-client.crt",
+		kubernetesPKIDir + "/front-proxy-client.crt",
+	}
+	stackedEtcdCertificates = []string{
+		kubernetesPKIDir + "/etcd/server.crt",
+		kubernetesPKIDir + "/etcd/peer.crt",
+		kubernetesPKIDir + "/etcd/healthcheck-client.crt",
+	}
+	externalEtcdCertificates = []string{
+		externalEtcdPKIDir + "/server.crt",
+		externalEtcdPKIDir + "/peer.crt",
+		externalEtcdPKIDir + "/etcdctl-etcd-client.crt",
+	}
+)
+
+type KubectlClient interface {
+	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
+	GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*etcdv1.EtcdadmCluster, error)
+	GetKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*controlplanev1.KubeadmControlPlane, error)
+	RolloutKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, rolloutAfter time.Time) error
+	GetSecret(ctx context.Context, secretObjectName string, opts ...executables.KubectlOpt) (*corev1.Secret, error)
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	RolloutRestartDaemonSet(ctx context.Context, name, namespace, kubeconfig string) error
+}
+
+type ClusterManager interface {
+	CreateAwsIamAuthCaSecret(ctx context.Context, cluster *types.Cluster) error
+	PauseEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
+	ResumeEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
+	RefreshWorkloadKubeconfig(ctx context.Context, managementCluster, workloadCluster *types.Cluster, provider providers.Provider) error
+}
+
+// Certificate is a certificate file in one of the cluster machines
+type Certificate struct {
+	Node     string
+	Path     string
+	NotAfter time.Time
+}
+
+type Certificates struct {
+	kubectl        KubectlClient
+	runner         etcdbackup.NodeRunner
+	clusterManager ClusterManager
+	retrier        *retrier.Retrier
+}
+
+type CertificatesOpt func(*Certificates)
+
+// WithRetrier sets the retrier used to wait for the control plane machines to be replaced
+func WithRetrier(retrier *retrier.Retrier) CertificatesOpt {
+	return func(c *Certificates) {
+		c.retrier = retrier
+	}
+}
+
+func New(kubectl KubectlClient, runner etcdbackup.NodeRunner, clusterManager ClusterManager, opts ...CertificatesOpt) *Certificates {
+	c := &Certificates{
+		kubectl:        kubectl,
+		runner:         runner,
+		clusterManager: clusterManager,
+		retrier: retrier.New(defaultControlPlaneRolloutTimeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+			return true, controlPlaneRolloutBackoff
+		})),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Get reads the certificates of the control plane and etcd machines of the cluster and returns when they expire
+func (c *Certificates) Get(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) ([]Certificate, error) {
+	nodes, err := etcdbackup.GetClusterNodes(ctx, c.kubectl, managementCluster, clusterSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []Certificate
+	for _, node := range nodes.ControlPlane {
+		nodeCerts, err := c.readCertificates(ctx, node, controlPlaneCertificatePaths(clusterSpec))
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, nodeCerts...)
+	}
+
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		for _, node := range nodes.Etcd {
+			nodeCerts, err := c.readCertificates(ctx, node, externalEtcdCertificates)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, nodeCerts...)
+		}
+	}
+
+	return certs, nil
+}
+
+func controlPlaneCertificatePaths(clusterSpec *cluster.Spec) []string {
+	paths := append([]string{}, controlPlaneCertificates...)
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration == nil {
+		paths = append(paths, stackedEtcdCertificates...)
+	}
+	if clusterSpec.AWSIamConfig != nil {
+		paths = append(paths, awsIamAuthCertPath)
+	}
+	return paths
+}
+
+func (c *Certificates) readCertificates(ctx context.Context, node etcdbackup.Node, paths []string) ([]Certificate, error) {
+	logger.V(3).Info("Reading certificates", "node", node.Name)
+	out, err := c.runner.Run(ctx, node, readCertificatesCommand(paths), nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading certificates from %s: %v", node.Name, err)
+	}
+
+	files := splitCertificateFiles(out)
+	certs := make([]Certificate, 0, len(paths))
+	for _, path := range paths {
+		content, ok := files[path]
+		if !ok {
+			return nil, fmt.Errorf("certificate %s not found in %s", path, node.Name)
+		}
+		notAfter, err := expiration(content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate %s from %s: %v", path, node.Name, err)
+		}
+		certs = append(certs, Certificate{Node: node.Name, Path: path, NotAfter: notAfter})
+	}
+
+	return certs, nil
+}
+
+// readCertificatesCommand prints all the files in a single call, each one preceded by a marker line with its path
+func readCertificatesCommand(paths []string) string {
+	return fmt.Sprintf("for f in %s; do echo \"%s$f\" && cat \"$f\" || exit 1; done", strings.Join(paths, " "), certificateMarker)
+}
+
+func splitCertificateFiles(out []byte) map[string][]byte {
+	files := map[string][]byte{}
+	var path string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, certificateMarker) {
+			path = strings.TrimPrefix(line, certificateMarker)
+			files[path] = nil
+			continue
+		}
+		if path != "" {
+			files[path] = append(files[path], line+"\n"...)
+		}
+	}
+	return files
+}
+
+func expiration(content []byte) (time.Time, error) {
+	cert, err := parseCertificate(content)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
@@ -0,0 +1,212 @@
+package certificates_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/certificates/mocks"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	etcdbackupmocks "github.com/aws/eks-anywhere/pkg/etcdbackup/mocks"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	stackedReadCommand                    = `for f in /etc/kubernetes/pki/apiserver.crt /etc/kubernetes/pki/apiserver-kubelet-client.crt /etc/kubernetes/pki/apiserver-etcd-client.crt /etc/kubernetes/pki/front-proxy-client.crt /etc/kubernetes/pki/etcd/server.crt /etc/kubernetes/pki/etcd/peer.crt /etc/kubernetes/pki/etcd/healthcheck-client.crt; do echo "eksa-certificate: $f" && cat "$f" || exit 1; done`
+	controlPlaneWithAwsIamAuthReadCommand = `for f in /etc/kubernetes/pki/apiserver.crt /etc/kubernetes/pki/apiserver-kubelet-client.crt /etc/kubernetes/pki/apiserver-etcd-client.crt /etc/kubernetes/pki/front-proxy-client.crt /var/lib/kubeadm/aws-iam-authenticator/pki/cert.pem; do echo "eksa-certificate: $f" && cat "$f" || exit 1; done`
+	externalEtcdReadCommand               = `for f in /etc/etcd/pki/server.crt /etc/etcd/pki/peer.crt /etc/etcd/pki/etcdctl-etcd-client.crt; do echo "eksa-certificate: $f" && cat "$f" || exit 1; done`
+)
+
+type certificatesTest struct {
+	*WithT
+	ctx               context.Context
+	kubectl           *mocks.MockKubectlClient
+	clusterManager    *mocks.MockClusterManager
+	runner            *etcdbackupmocks.MockNodeRunner
+	provider          *providermocks.MockProvider
+	managementCluster *types.Cluster
+	workloadCluster   *types.Cluster
+	clusterSpec       *cluster.Spec
+	certificates      *certificates.Certificates
+}
+
+func newCertificatesTest(t *testing.T) *certificatesTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	clusterManager := mocks.NewMockClusterManager(ctrl)
+	runner := etcdbackupmocks.NewMockNodeRunner(ctrl)
+	return &certificatesTest{
+		WithT:          NewWithT(t),
+		ctx:            context.Background(),
+		kubectl:        kubectl,
+		clusterManager: clusterManager,
+		runner:         runner,
+		provider:       providermocks.NewMockProvider(ctrl),
+		managementCluster: &types.Cluster{
+			Name:           "management-cluster",
+			KubeconfigFile: "management.kubeconfig",
+		},
+		workloadCluster: &types.Cluster{
+			Name:           "test-cluster",
+			KubeconfigFile: "test-cluster.kubeconfig",
+		},
+		clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "test-cluster"
+		}),
+		certificates: certificates.New(kubectl, runner, clusterManager, certificates.WithRetrier(retrier.NewWithMaxRetries(2, 0))),
+	}
+}
+
+func (tt *certificatesTest) expectMachines(machines ...types.Machine) {
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test-cluster").Return(machines, nil)
+}
+
+func (tt *certificatesTest) withExternalEtcd() {
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 1}
+	tt.kubectl.EXPECT().GetEtcdadmCluster(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any(), gomock.Any()).Return(
+		&etcdv1.EtcdadmCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-etcd"},
+			Status:     etcdv1.EtcdadmClusterStatus{Ready: true},
+		}, nil,
+	)
+}
+
+func controlPlaneMachine(name, address string) types.Machine {
+	return machine(name, address, map[string]string{clusterv1.MachineControlPlaneLabelName: ""})
+}
+
+func etcdMachine(name, address string) types.Machine {
+	return machine(name, address, map[string]string{clusterv1.MachineEtcdClusterLabelName: "test-cluster-etcd"})
+}
+
+func machine(name, address string, labels map[string]string) types.Machine {
+	return types.Machine{
+		Metadata: types.MachineMetadata{Name: name, Labels: labels},
+		Status: types.MachineStatus{
+			Addresses: []types.MachineAddress{{Type: string(clusterv1.MachineInternalIP), Address: address}},
+		},
+	}
+}
+
+func certificatePEM(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func readOutput(t *testing.T, notAfter time.Time, paths ...string) []byte {
+	var out []byte
+	for _, path := range paths {
+		out = append(out, fmt.Sprintf("eksa-certificate: %s\n", path)...)
+		out = append(out, certificatePEM(t, notAfter)...)
+	}
+	return out
+}
+
+var stackedPaths = []string{
+	"/etc/kubernetes/pki/apiserver.crt",
+	"/etc/kubernetes/pki/apiserver-kubelet-client.crt",
+	"/etc/kubernetes/pki/apiserver-etcd-client.crt",
+	"/etc/kubernetes/pki/front-proxy-client.crt",
+	"/etc/kubernetes/pki/etcd/server.crt",
+	"/etc/kubernetes/pki/etcd/peer.crt",
+	"/etc/kubernetes/pki/etcd/healthcheck-client.crt",
+}
+
+func TestCertificatesGetStackedEtcd(t *testing.T) {
+	tt := newCertificatesTest(t)
+	notAfter := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	tt.expectMachines(controlPlaneMachine("cp-1", "10.0.0.1"), machine("worker-1", "10.0.0.5", nil))
+	tt.runner.EXPECT().Run(tt.ctx, etcdbackup.Node{Name: "cp-1", Address: "10.0.0.1"}, stackedReadCommand, nil).Return(readOutput(t, notAfter, stackedPaths...), nil)
+
+	certs, err := tt.certificates.Get(tt.ctx, tt.managementCluster, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(certs).To(HaveLen(len(stackedPaths)))
+	for i, path := range stackedPaths {
+		tt.Expect(certs[i]).To(Equal(certificates.Certificate{Node: "cp-1", Path: path, NotAfter: notAfter}))
+	}
+}
+
+func TestCertificatesGetExternalEtcdWithAwsIamAuth(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.withExternalEtcd()
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{}
+	notAfter := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	controlPlanePaths := []string{
+		"/etc/kubernetes/pki/apiserver.crt",
+		"/etc/kubernetes/pki/apiserver-kubelet-client.crt",
+		"/etc/kubernetes/pki/apiserver-etcd-client.crt",
+		"/etc/kubernetes/pki/front-proxy-client.crt",
+		"/var/lib/kubeadm/aws-iam-authenticator/pki/cert.pem",
+	}
+	etcdPaths := []string{"/etc/etcd/pki/server.crt", "/etc/etcd/pki/peer.crt", "/etc/etcd/pki/etcdctl-etcd-client.crt"}
+	tt.expectMachines(controlPlaneMachine("cp-1", "10.0.0.1"), etcdMachine("etcd-1", "10.0.0.2"))
+	tt.runner.EXPECT().Run(tt.ctx, etcdbackup.Node{Name: "cp-1", Address: "10.0.0.1"}, controlPlaneWithAwsIamAuthReadCommand, nil).Return(readOutput(t, notAfter, controlPlanePaths...), nil)
+	tt.runner.EXPECT().Run(tt.ctx, etcdbackup.Node{Name: "etcd-1", Address: "10.0.0.2"}, externalEtcdReadCommand, nil).Return(readOutput(t, notAfter, etcdPaths...), nil)
+
+	certs, err := tt.certificates.Get(tt.ctx, tt.managementCluster, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(certs).To(HaveLen(len(controlPlanePaths) + len(etcdPaths)))
+	tt.Expect(certs[4]).To(Equal(certificates.Certificate{Node: "cp-1", Path: "/var/lib/kubeadm/aws-iam-authenticator/pki/cert.pem", NotAfter: notAfter}))
+	tt.Expect(certs[5]).To(Equal(certificates.Certificate{Node: "etcd-1", Path: "/etc/etcd/pki/server.crt", NotAfter: notAfter}))
+}
+
+func TestCertificatesGetErrorReading(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.expectMachines(controlPlaneMachine("cp-1", "10.0.0.1"))
+	tt.runner.EXPECT().Run(tt.ctx, gomock.Any(), stackedReadCommand, nil).Return(nil, errors.New("cat: no such file"))
+
+	_, err := tt.certificates.Get(tt.ctx, tt.managementCluster, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading certificates from cp-1: cat: no such file")))
+}
+
+func TestCertificatesGetErrorMissingCertificate(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.expectMachines(controlPlaneMachine("cp-1", "10.0.0.1"))
+	tt.runner.EXPECT().Run(tt.ctx, gomock.Any(), stackedReadCommand, nil).Return(readOutput(t, time.Now(), stackedPaths[:2]...), nil)
+
+	_, err := tt.certificates.Get(tt.ctx, tt.managementCluster, tt.clusterSpec)
+	tt.Expect(err).To(MatchError("certificate /etc/kubernetes/pki/apiserver-etcd-client.crt not found in cp-1"))
+}
+
+func TestCertificatesGetErrorInvalidCertificate(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.expectMachines(controlPlaneMachine("cp-1", "10.0.0.1"))
+	tt.runner.EXPECT().Run(tt.ctx, gomock.Any(), stackedReadCommand, nil).Return([]byte("eksa-certificate: /etc/kubernetes/pki/apiserver.crt\nnot a certificate\n"), nil)
+
+	_, err := tt.certificates.Get(tt.ctx, tt.managementCluster, tt.clusterSpec)
+	tt.Expect(err).To(MatchError("parsing certificate /etc/kubernetes/pki/apiserver.crt from cp-1: no PEM data found"))
+}
@@ -0,0 +1,190 @@
+package certificates
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/keyutil"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// apiServerEtcdClientCertPath is where kubeadm reads the client certificate of the api server for external etcd
+const apiServerEtcdClientCertPath = kubernetesPKIDir + "/apiserver-etcd-client.crt"
+
+// restartEtcdCommand restarts the etcd systemd unit installed by etcdadm so it loads the renewed certificates, and
+// waits for the member to be healthy before returning
+var restartEtcdCommand = "systemctl restart etcd && until ETCDCTL_API=3 /opt/bin/etcdctl" +
+	" --endpoints=https://127.0.0.1:2379" +
+	" --cacert=" + externalEtcdPKIDir + "/ca.crt" +
+	" --cert=" + externalEtcdPKIDir + "/etcdctl-etcd-client.crt" +
+	" --key=" + externalEtcdPKIDir + "/etcdctl-etcd-client.key" +
+	" endpoint health; do sleep 1; done"
+
+// etcdCA signs the certificates of the external etcd machines and the api server etcd client certificate
+type etcdCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// renewExternalEtcd issues again the certificates of the etcd machines one at a time, so etcd keeps its quorum while
+// the members restart, and the api server etcd client certificate kept in the CAPI secret of the cluster, which is
+// used by the control plane machines. The certificates are signed with the etcd CA created by the etcdadm controller
+// for the existing public keys, so no private key leaves the machines or changes
+func (c *Certificates) renewExternalEtcd(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	nodes, err := etcdbackup.GetClusterNodes(ctx, c.kubectl, managementCluster, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	ca, err := c.getEtcdCA(ctx, managementCluster, clusterSpec.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes.Etcd {
+		logger.Info("Renewing etcd certificates", "node", node.Name)
+		out, err := c.runner.Run(ctx, node, readCertificatesCommand(externalEtcdCertificates), nil)
+		if err != nil {
+			return fmt.Errorf("reading certificates from %s: %v", node.Name, err)
+		}
+
+		files := splitCertificateFiles(out)
+		for _, path := range externalEtcdCertificates {
+			content, ok := files[path]
+			if !ok {
+				return fmt.Errorf("certificate %s not found in %s", path, node.Name)
+			}
+			renewed, err := ca.reissue(content)
+			if err != nil {
+				return fmt.Errorf("renewing certificate %s from %s: %v", path, node.Name, err)
+			}
+			if _, err = c.runner.Run(ctx, node, writeFileCommand(path), renewed); err != nil {
+				return fmt.Errorf("writing certificate %s in %s: %v", path, node.Name, err)
+			}
+		}
+
+		logger.V(3).Info("Restarting etcd", "node", node.Name)
+		if _, err = c.runner.Run(ctx, node, restartEtcdCommand, nil); err != nil {
+			return fmt.Errorf("restarting etcd in %s: %v", node.Name, err)
+		}
+	}
+
+	return c.renewAPIServerEtcdClient(ctx, managementCluster, clusterSpec.Cluster.Name, ca)
+}
+
+func (c *Certificates) getEtcdCA(ctx context.Context, managementCluster *types.Cluster, clusterName string) (*etcdCA, error) {
+	caSecret, err := c.kubectl.GetSecret(ctx, secret.Name(clusterName, secret.ManagedExternalEtcdCA),
+		executables.WithCluster(managementCluster),
+		executables.WithNamespace(constants.EksaSystemNamespace),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getting etcd CA: %v", err)
+	}
+
+	cert, err := parseCertificate(caSecret.Data[secret.TLSCrtDataName])
+	if err != nil {
+		return nil, fmt.Errorf("parsing etcd CA certificate: %v", err)
+	}
+	key, err := keyutil.ParsePrivateKeyPEM(caSecret.Data[secret.TLSKeyDataName])
+	if err != nil {
+		return nil, fmt.Errorf("parsing etcd CA key: %v", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("parsing etcd CA key: unsupported key type %T", key)
+	}
+
+	return &etcdCA{cert: cert, key: signer}, nil
+}
+
+// renewAPIServerEtcdClient only updates the certificate in the secret, its key is kept
+func (c *Certificates) renewAPIServerEtcdClient(ctx context.Context, managementCluster *types.Cluster, clusterName string, ca *etcdCA) error {
+	name := secret.Name(clusterName, secret.APIServerEtcdClient)
+	clientSecret, err := c.kubectl.GetSecret(ctx, name,
+		executables.WithCluster(managementCluster),
+		executables.WithNamespace(constants.EksaSystemNamespace),
+	)
+	if err != nil {
+		return fmt.Errorf("getting api server etcd client certificate: %v", err)
+	}
+
+	renewed, err := ca.reissue(clientSecret.Data[secret.TLSCrtDataName])
+	if err != nil {
+		return fmt.Errorf("renewing api server etcd client certificate: %v", err)
+	}
+
+	logger.V(3).Info("Updating api server etcd client certificate", "secret", name)
+	updated, err := yaml.Marshal(&corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{secret.TLSCrtDataName: renewed},
+	})
+	if err != nil {
+		return fmt.Errorf("marshalling api server etcd client certificate secret: %v", err)
+	}
+	if err = c.kubectl.ApplyKubeSpecFromBytes(ctx, managementCluster, updated); err != nil {
+		return fmt.Errorf("updating api server etcd client certificate: %v", err)
+	}
+
+	return nil
+}
+
+// reissue signs a new certificate with the same subject, names, usages, public key and validity period as the given
+// one, starting now
+func (ca *etcdCA) reissue(certPEM []byte) ([]byte, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      cert.Subject,
+		DNSNames:     cert.DNSNames,
+		IPAddresses:  cert.IPAddresses,
+		KeyUsage:     cert.KeyUsage,
+		ExtKeyUsage:  cert.ExtKeyUsage,
+		NotBefore:    now,
+		NotAfter:     now.Add(cert.NotAfter.Sub(cert.NotBefore)),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, cert.PublicKey, ca.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
+
+func parseCertificate(content []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
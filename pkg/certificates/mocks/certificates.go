@@ -0,0 +1,239 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/certificates/certificates.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	executables "github.com/aws/eks-anywhere/pkg/executables"
+	providers "github.com/aws/eks-anywhere/pkg/providers"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+	v1beta1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	v1beta10 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+)
+
+// MockKubectlClient is a mock of KubectlClient interface.
+type MockKubectlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubectlClientMockRecorder
+}
+
+// MockKubectlClientMockRecorder is the mock recorder for MockKubectlClient.
+type MockKubectlClientMockRecorder struct {
+	mock *MockKubectlClient
+}
+
+// NewMockKubectlClient creates a new mock instance.
+func NewMockKubectlClient(ctrl *gomock.Controller) *MockKubectlClient {
+	mock := &MockKubectlClient{ctrl: ctrl}
+	mock.recorder = &MockKubectlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
+	return m.recorder
+}
+
+// ApplyKubeSpecFromBytes mocks base method.
+func (m *MockKubectlClient) ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytes", ctx, cluster, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytes indicates an expected call of ApplyKubeSpecFromBytes.
+func (mr *MockKubectlClientMockRecorder) ApplyKubeSpecFromBytes(ctx, cluster, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytes", reflect.TypeOf((*MockKubectlClient)(nil).ApplyKubeSpecFromBytes), ctx, cluster, data)
+}
+
+// GetEtcdadmCluster mocks base method.
+func (m *MockKubectlClient) GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*v1beta1.EtcdadmCluster, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, cluster, clusterName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetEtcdadmCluster", varargs...)
+	ret0, _ := ret[0].(*v1beta1.EtcdadmCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEtcdadmCluster indicates an expected call of GetEtcdadmCluster.
+func (mr *MockKubectlClientMockRecorder) GetEtcdadmCluster(ctx, cluster, clusterName interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, cluster, clusterName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEtcdadmCluster", reflect.TypeOf((*MockKubectlClient)(nil).GetEtcdadmCluster), varargs...)
+}
+
+// GetKubeadmControlPlane mocks base method.
+func (m *MockKubectlClient) GetKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*v1beta10.KubeadmControlPlane, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, cluster, clusterName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetKubeadmControlPlane", varargs...)
+	ret0, _ := ret[0].(*v1beta10.KubeadmControlPlane)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKubeadmControlPlane indicates an expected call of GetKubeadmControlPlane.
+func (mr *MockKubectlClientMockRecorder) GetKubeadmControlPlane(ctx, cluster, clusterName interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, cluster, clusterName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubeadmControlPlane", reflect.TypeOf((*MockKubectlClient)(nil).GetKubeadmControlPlane), varargs...)
+}
+
+// GetMachines mocks base method.
+func (m *MockKubectlClient) GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachines", ctx, cluster, clusterName)
+	ret0, _ := ret[0].([]types.Machine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachines indicates an expected call of GetMachines.
+func (mr *MockKubectlClientMockRecorder) GetMachines(ctx, cluster, clusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachines", reflect.TypeOf((*MockKubectlClient)(nil).GetMachines), ctx, cluster, clusterName)
+}
+
+// GetSecret mocks base method.
+func (m *MockKubectlClient) GetSecret(ctx context.Context, secretObjectName string, opts ...executables.KubectlOpt) (*v1.Secret, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, secretObjectName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSecret", varargs...)
+	ret0, _ := ret[0].(*v1.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockKubectlClientMockRecorder) GetSecret(ctx, secretObjectName interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, secretObjectName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockKubectlClient)(nil).GetSecret), varargs...)
+}
+
+// RolloutKubeadmControlPlane mocks base method.
+func (m *MockKubectlClient) RolloutKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, rolloutAfter time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RolloutKubeadmControlPlane", ctx, cluster, clusterName, rolloutAfter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RolloutKubeadmControlPlane indicates an expected call of RolloutKubeadmControlPlane.
+func (mr *MockKubectlClientMockRecorder) RolloutKubeadmControlPlane(ctx, cluster, clusterName, rolloutAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RolloutKubeadmControlPlane", reflect.TypeOf((*MockKubectlClient)(nil).RolloutKubeadmControlPlane), ctx, cluster, clusterName, rolloutAfter)
+}
+
+// RolloutRestartDaemonSet mocks base method.
+func (m *MockKubectlClient) RolloutRestartDaemonSet(ctx context.Context, name, namespace, kubeconfig string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RolloutRestartDaemonSet", ctx, name, namespace, kubeconfig)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RolloutRestartDaemonSet indicates an expected call of RolloutRestartDaemonSet.
+func (mr *MockKubectlClientMockRecorder) RolloutRestartDaemonSet(ctx, name, namespace, kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RolloutRestartDaemonSet", reflect.TypeOf((*MockKubectlClient)(nil).RolloutRestartDaemonSet), ctx, name, namespace, kubeconfig)
+}
+
+// MockClusterManager is a mock of ClusterManager interface.
+type MockClusterManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockClusterManagerMockRecorder
+}
+
+// MockClusterManagerMockRecorder is the mock recorder for MockClusterManager.
+type MockClusterManagerMockRecorder struct {
+	mock *MockClusterManager
+}
+
+// NewMockClusterManager creates a new mock instance.
+func NewMockClusterManager(ctrl *gomock.Controller) *MockClusterManager {
+	mock := &MockClusterManager{ctrl: ctrl}
+	mock.recorder = &MockClusterManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClusterManager) EXPECT() *MockClusterManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateAwsIamAuthCaSecret mocks base method.
+func (m *MockClusterManager) CreateAwsIamAuthCaSecret(ctx context.Context, cluster *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAwsIamAuthCaSecret", ctx, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAwsIamAuthCaSecret indicates an expected call of CreateAwsIamAuthCaSecret.
+func (mr *MockClusterManagerMockRecorder) CreateAwsIamAuthCaSecret(ctx, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAwsIamAuthCaSecret", reflect.TypeOf((*MockClusterManager)(nil).CreateAwsIamAuthCaSecret), ctx, cluster)
+}
+
+// PauseEKSAControllerReconcile mocks base method.
+func (m *MockClusterManager) PauseEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseEKSAControllerReconcile", ctx, cluster, clusterSpec, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseEKSAControllerReconcile indicates an expected call of PauseEKSAControllerReconcile.
+func (mr *MockClusterManagerMockRecorder) PauseEKSAControllerReconcile(ctx, cluster, clusterSpec, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseEKSAControllerReconcile", reflect.TypeOf((*MockClusterManager)(nil).PauseEKSAControllerReconcile), ctx, cluster, clusterSpec, provider)
+}
+
+// RefreshWorkloadKubeconfig mocks base method.
+func (m *MockClusterManager) RefreshWorkloadKubeconfig(ctx context.Context, managementCluster, workloadCluster *types.Cluster, provider providers.Provider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshWorkloadKubeconfig", ctx, managementCluster, workloadCluster, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshWorkloadKubeconfig indicates an expected call of RefreshWorkloadKubeconfig.
+func (mr *MockClusterManagerMockRecorder) RefreshWorkloadKubeconfig(ctx, managementCluster, workloadCluster, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshWorkloadKubeconfig", reflect.TypeOf((*MockClusterManager)(nil).RefreshWorkloadKubeconfig), ctx, managementCluster, workloadCluster, provider)
+}
+
+// ResumeEKSAControllerReconcile mocks base method.
+func (m *MockClusterManager) ResumeEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeEKSAControllerReconcile", ctx, cluster, clusterSpec, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeEKSAControllerReconcile indicates an expected call of ResumeEKSAControllerReconcile.
+func (mr *MockClusterManagerMockRecorder) ResumeEKSAControllerReconcile(ctx, cluster, clusterSpec, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeEKSAControllerReconcile", reflect.TypeOf((*MockClusterManager)(nil).ResumeEKSAControllerReconcile), ctx, cluster, clusterSpec, provider)
+}
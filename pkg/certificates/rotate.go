@@ -0,0 +1,260 @@
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type RotationMethod string
+
+const (
+	// RolloutRotation replaces all the control plane machines, which get new certificates when they are created
+	RolloutRotation RotationMethod = "rollout"
+	// InPlaceRotation renews the certificates of the existing control plane machines with kubeadm
+	InPlaceRotation RotationMethod = "in-place"
+
+	awsIamAuthName         = "aws-iam-authenticator"
+	awsIamAuthCaSecretName = "aws-iam-authenticator-ca"
+	awsIamAuthCertKey      = "cert.pem"
+	awsIamAuthKeyKey       = "key.pem"
+
+	renewAllCertificatesCommand = "kubeadm certs renew all"
+
+	staticPodManifestDir = "/etc/kubernetes/manifests"
+	stoppedManifestDir   = "/etc/kubernetes/eksa-certificates-manifests"
+)
+
+// restartControlPlaneCommand recreates the control plane static pods so they load the renewed certificates, and waits
+// for the api server to be back before returning
+var restartControlPlaneCommand = fmt.Sprintf("mkdir -p %[1]s && mv %[2]s/*.yaml %[1]s/"+
+	" && while crictl ps -q --name '^(kube-apiserver|kube-controller-manager|kube-scheduler|etcd)$' | grep -q .; do sleep 1; done"+
+	" && mv %[1]s/*.yaml %[2]s/ && rmdir %[1]s"+
+	" && until crictl ps -q --name '^kube-apiserver$' | grep -q .; do sleep 1; done",
+	stoppedManifestDir, staticPodManifestDir)
+
+// externalEtcdRenewedCertificates are the ones kubeadm can renew with external etcd. The etcd client certificate is
+// signed by the etcdadm CA, which kubeadm doesn't have
+var externalEtcdRenewedCertificates = []string{
+	"apiserver",
+	"apiserver-kubelet-client",
+	"front-proxy-client",
+	"admin.conf",
+	"controller-manager.conf",
+	"scheduler.conf",
+}
+
+// Rotate issues new certificates for the control plane of the cluster, either replacing its machines or renewing them
+// in place, and writes again the cluster kubeconfig file. With external etcd, the certificates of the etcd machines
+// and the api server etcd client certificate are renewed first, for both methods.
+// When aws-iam-authenticator is configured its certificate is generated again. The aws-iam-authenticator-ca secret
+// is shared by all the clusters in the management cluster, the existing machines of the other clusters keep
+// the certificate they were created with. The aws-iam-authenticator kubeconfig doesn't need to be refreshed since
+// it only holds the cluster CA, which is kept
+func (c *Certificates) Rotate(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider, method RotationMethod) (err error) {
+	if err = validateRotation(clusterSpec, provider, method); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Pausing EKS-A cluster controller reconcile")
+	if err = c.clusterManager.PauseEKSAControllerReconcile(ctx, managementCluster, clusterSpec, provider); err != nil {
+		return err
+	}
+	defer func() {
+		logger.V(3).Info("Resuming EKS-A cluster controller reconcile")
+		if resumeErr := c.clusterManager.ResumeEKSAControllerReconcile(ctx, managementCluster, clusterSpec, provider); resumeErr != nil && err == nil {
+			err = resumeErr
+		}
+	}()
+
+	if clusterSpec.AWSIamConfig != nil {
+		logger.Info("Generating new aws-iam-authenticator certificate")
+		if err = c.clusterManager.CreateAwsIamAuthCaSecret(ctx, managementCluster); err != nil {
+			return err
+		}
+	}
+
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		if err = c.renewExternalEtcd(ctx, managementCluster, clusterSpec); err != nil {
+			return err
+		}
+	}
+
+	switch method {
+	case RolloutRotation:
+		err = c.rolloutControlPlane(ctx, managementCluster, clusterSpec)
+	case InPlaceRotation:
+		err = c.renewInPlace(ctx, managementCluster, workloadCluster, clusterSpec)
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Refreshing cluster kubeconfig")
+	return c.clusterManager.RefreshWorkloadKubeconfig(ctx, managementCluster, workloadCluster, provider)
+}
+
+func validateRotation(clusterSpec *cluster.Spec, provider providers.Provider, method RotationMethod) error {
+	switch method {
+	case RolloutRotation:
+		return nil
+	case InPlaceRotation:
+		machineGroupRef := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef
+		if machineGroupRef == nil {
+			return nil
+		}
+		for _, m := range provider.MachineConfigs(clusterSpec) {
+			if m.GetName() == machineGroupRef.Name && m.OSFamily() == v1alpha1.Bottlerocket {
+				return fmt.Errorf("%s certificate rotation is not supported for %s control plane machines, use the %s method", InPlaceRotation, v1alpha1.Bottlerocket, RolloutRotation)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid certificate rotation method %s, supported methods are %s and %s", method, RolloutRotation, InPlaceRotation)
+	}
+}
+
+func (c *Certificates) rolloutControlPlane(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	clusterName := clusterSpec.Cluster.Name
+	logger.Info("Rolling out control plane machines")
+	if err := c.kubectl.RolloutKubeadmControlPlane(ctx, managementCluster, clusterName, time.Now()); err != nil {
+		return err
+	}
+
+	logger.Info("Waiting for control plane machines to be replaced")
+	err := c.retrier.Retry(
+		func() error {
+			return c.controlPlaneRolledOut(ctx, managementCluster, clusterName)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("waiting for control plane rollout: %v", err)
+	}
+
+	return nil
+}
+
+func (c *Certificates) controlPlaneRolledOut(ctx context.Context, managementCluster *types.Cluster, clusterName string) error {
+	kcp, err := c.kubectl.GetKubeadmControlPlane(ctx, managementCluster, clusterName,
+		executables.WithCluster(managementCluster),
+		executables.WithNamespace(constants.EksaSystemNamespace),
+	)
+	if err != nil {
+		return err
+	}
+
+	if kcp.Status.ObservedGeneration != kcp.Generation {
+		return fmt.Errorf("kubeadm control plane %s status needs to be refreshed: observed generation is %d, want %d", kcp.Name, kcp.Status.ObservedGeneration, kcp.Generation)
+	}
+
+	replicas := kcp.Status.Replicas
+	if kcp.Spec.Replicas != nil {
+		replicas = *kcp.Spec.Replicas
+	}
+	if kcp.Status.Replicas != replicas || kcp.Status.UpdatedReplicas != replicas {
+		return fmt.Errorf("%d of %d control plane machines replaced", kcp.Status.UpdatedReplicas, replicas)
+	}
+	if kcp.Status.ReadyReplicas != replicas {
+		return fmt.Errorf("%d control plane replicas are not ready", replicas-kcp.Status.ReadyReplicas)
+	}
+
+	return nil
+}
+
+// renewInPlace renews the certificates one machine at a time, so the control plane and stacked etcd keep
+// their quorum while the components restart
+func (c *Certificates) renewInPlace(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	nodes, err := etcdbackup.GetClusterNodes(ctx, c.kubectl, managementCluster, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	var awsIamAuthCert, awsIamAuthKey []byte
+	if clusterSpec.AWSIamConfig != nil {
+		awsIamAuthSecret, err := c.kubectl.GetSecret(ctx, awsIamAuthCaSecretName,
+			executables.WithCluster(managementCluster),
+			executables.WithNamespace(constants.EksaSystemNamespace),
+		)
+		if err != nil {
+			return fmt.Errorf("getting aws-iam-authenticator certificate: %v", err)
+		}
+		awsIamAuthCert, awsIamAuthKey = awsIamAuthSecret.Data[awsIamAuthCertKey], awsIamAuthSecret.Data[awsIamAuthKeyKey]
+	}
+
+	var apiServerEtcdClientCert []byte
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		clientSecret, err := c.kubectl.GetSecret(ctx, secret.Name(clusterSpec.Cluster.Name, secret.APIServerEtcdClient),
+			executables.WithCluster(managementCluster),
+			executables.WithNamespace(constants.EksaSystemNamespace),
+		)
+		if err != nil {
+			return fmt.Errorf("getting api server etcd client certificate: %v", err)
+		}
+		apiServerEtcdClientCert = clientSecret.Data[secret.TLSCrtDataName]
+	}
+
+	renew := renewCommand(clusterSpec)
+	for _, node := range nodes.ControlPlane {
+		logger.Info("Renewing certificates", "node", node.Name)
+		if _, err = c.runner.Run(ctx, node, renew, nil); err != nil {
+			return fmt.Errorf("renewing certificates in %s: %v", node.Name, err)
+		}
+
+		if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+			if _, err = c.runner.Run(ctx, node, writeFileCommand(apiServerEtcdClientCertPath), apiServerEtcdClientCert); err != nil {
+				return fmt.Errorf("writing api server etcd client certificate in %s: %v", node.Name, err)
+			}
+		}
+
+		if clusterSpec.AWSIamConfig != nil {
+			if _, err = c.runner.Run(ctx, node, writeFileCommand(awsIamAuthCertPath), awsIamAuthCert); err != nil {
+				return fmt.Errorf("writing aws-iam-authenticator certificate in %s: %v", node.Name, err)
+			}
+			if _, err = c.runner.Run(ctx, node, writeFileCommand(awsIamAuthKeyPath), awsIamAuthKey); err != nil {
+				return fmt.Errorf("writing aws-iam-authenticator key in %s: %v", node.Name, err)
+			}
+		}
+
+		logger.V(3).Info("Restarting control plane components", "node", node.Name)
+		if _, err = c.runner.Run(ctx, node, restartControlPlaneCommand, nil); err != nil {
+			return fmt.Errorf("restarting control plane in %s: %v", node.Name, err)
+		}
+	}
+
+	if clusterSpec.AWSIamConfig != nil {
+		logger.V(3).Info("Restarting aws-iam-authenticator")
+		if err = c.kubectl.RolloutRestartDaemonSet(ctx, awsIamAuthName, constants.KubeSystemNamespace, workloadCluster.KubeconfigFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renewCommand(clusterSpec *cluster.Spec) string {
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration == nil {
+		return renewAllCertificatesCommand
+	}
+
+	commands := make([]string, 0, len(externalEtcdRenewedCertificates))
+	for _, cert := range externalEtcdRenewedCertificates {
+		commands = append(commands, "kubeadm certs renew "+cert)
+	}
+	return strings.Join(commands, " && ")
+}
+
+func writeFileCommand(path string) string {
+	return "cat > " + path
+}
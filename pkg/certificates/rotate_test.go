@@ -0,0 +1,390 @@
+package certificates_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const restartControlPlaneCommand = "mkdir -p /etc/kubernetes/eksa-certificates-manifests && mv /etc/kubernetes/manifests/*.yaml /etc/kubernetes/eksa-certificates-manifests/" +
+	" && while crictl ps -q --name '^(kube-apiserver|kube-controller-manager|kube-scheduler|etcd)$' | grep -q .; do sleep 1; done" +
+	" && mv /etc/kubernetes/eksa-certificates-manifests/*.yaml /etc/kubernetes/manifests/ && rmdir /etc/kubernetes/eksa-certificates-manifests" +
+	" && until crictl ps -q --name '^kube-apiserver$' | grep -q .; do sleep 1; done"
+
+const restartEtcdCommand = "systemctl restart etcd && until ETCDCTL_API=3 /opt/bin/etcdctl --endpoints=https://127.0.0.1:2379" +
+	" --cacert=/etc/etcd/pki/ca.crt --cert=/etc/etcd/pki/etcdctl-etcd-client.crt --key=/etc/etcd/pki/etcdctl-etcd-client.key" +
+	" endpoint health; do sleep 1; done"
+
+var externalEtcdPaths = []string{"/etc/etcd/pki/server.crt", "/etc/etcd/pki/peer.crt", "/etc/etcd/pki/etcdctl-etcd-client.crt"}
+
+// testCA is an etcd CA issuing certificates valid for a year, most of which has already passed
+type testCA struct {
+	t    *testing.T
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "etcd-ca"},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{t: t, cert: cert, key: key}
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func (ca *testCA) secret() *corev1.Secret {
+	keyDer, err := x509.MarshalECPrivateKey(ca.key)
+	if err != nil {
+		ca.t.Fatal(err)
+	}
+	return &corev1.Secret{
+		Data: map[string][]byte{
+			"tls.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}),
+			"tls.key": pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		},
+	}
+}
+
+func (ca *testCA) issue(commonName string) []byte {
+	key := newKey(ca.t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		NotBefore:    time.Now().Add(-300 * 24 * time.Hour),
+		NotAfter:     time.Now().Add(65 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		ca.t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// readOutput returns the output of reading the certificates in paths from a machine, and the certificates by path
+func (ca *testCA) readOutput(paths ...string) ([]byte, map[string][]byte) {
+	var out []byte
+	certs := map[string][]byte{}
+	for _, path := range paths {
+		certs[path] = ca.issue(path)
+		out = append(out, fmt.Sprintf("eksa-certificate: %s\n", path)...)
+		out = append(out, certs[path]...)
+	}
+	return out, certs
+}
+
+// expectReissued checks renewed is signed by the CA for the same subject, names, public key and validity period as
+// original, starting now
+func (ca *testCA) expectReissued(renewed, original []byte) {
+	g := NewWithT(ca.t)
+	parse := func(content []byte) *x509.Certificate {
+		block, _ := pem.Decode(content)
+		g.Expect(block).NotTo(BeNil())
+		cert, err := x509.ParseCertificate(block.Bytes)
+		g.Expect(err).NotTo(HaveOccurred())
+		return cert
+	}
+	renewedCert, originalCert := parse(renewed), parse(original)
+
+	g.Expect(renewedCert.CheckSignatureFrom(ca.cert)).To(Succeed())
+	g.Expect(renewedCert.Subject).To(Equal(originalCert.Subject))
+	g.Expect(renewedCert.DNSNames).To(Equal(originalCert.DNSNames))
+	g.Expect(renewedCert.ExtKeyUsage).To(Equal(originalCert.ExtKeyUsage))
+	g.Expect(renewedCert.PublicKey).To(Equal(originalCert.PublicKey))
+	g.Expect(renewedCert.NotBefore).To(BeTemporally("~", time.Now(), time.Minute))
+	g.Expect(renewedCert.NotAfter.Sub(renewedCert.NotBefore)).To(Equal(originalCert.NotAfter.Sub(originalCert.NotBefore)))
+}
+
+// expectRenewExternalEtcd expects the certificates of the etcd nodes to be renewed one node at a time, and the api
+// server etcd client certificate secret to be updated
+func (tt *certificatesTest) expectRenewExternalEtcd(ca *testCA, nodes ...etcdbackup.Node) {
+	tt.kubectl.EXPECT().GetSecret(tt.ctx, "test-cluster-managed-etcd", gomock.Any(), gomock.Any()).Return(ca.secret(), nil)
+
+	var calls []*gomock.Call
+	for _, node := range nodes {
+		out, certs := ca.readOutput(externalEtcdPaths...)
+		calls = append(calls, tt.runner.EXPECT().Run(tt.ctx, node, externalEtcdReadCommand, nil).Return(out, nil))
+		for _, path := range externalEtcdPaths {
+			original := certs[path]
+			calls = append(calls, tt.runner.EXPECT().Run(tt.ctx, node, "cat > "+path, gomock.Any()).Do(
+				func(_ context.Context, _ etcdbackup.Node, _ string, stdin []byte) {
+					ca.expectReissued(stdin, original)
+				},
+			))
+		}
+		calls = append(calls, tt.runner.EXPECT().Run(tt.ctx, node, restartEtcdCommand, nil))
+	}
+
+	clientCert, clientKey := ca.issue("test-cluster-kube-apiserver-etcd-client"), []byte("key")
+	calls = append(calls,
+		tt.kubectl.EXPECT().GetSecret(tt.ctx, "test-cluster-apiserver-etcd-client", gomock.Any(), gomock.Any()).Return(
+			&corev1.Secret{Data: map[string][]byte{"tls.crt": clientCert, "tls.key": clientKey}}, nil,
+		),
+		tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.managementCluster, gomock.Any()).Do(
+			func(_ context.Context, _ *types.Cluster, data []byte) {
+				secret := &corev1.Secret{}
+				tt.Expect(yaml.Unmarshal(data, secret)).To(Succeed())
+				tt.Expect(secret.Name).To(Equal("test-cluster-apiserver-etcd-client"))
+				tt.Expect(secret.Namespace).To(Equal("eksa-system"))
+				tt.Expect(secret.Data).To(HaveLen(1))
+				ca.expectReissued(secret.Data["tls.crt"], clientCert)
+			},
+		),
+	)
+	gomock.InOrder(calls...)
+}
+
+func (tt *certificatesTest) expectPauseAndResume() {
+	gomock.InOrder(
+		tt.clusterManager.EXPECT().PauseEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider),
+		tt.clusterManager.EXPECT().ResumeEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider),
+	)
+}
+
+func (tt *certificatesTest) expectRefreshKubeconfig() {
+	tt.clusterManager.EXPECT().RefreshWorkloadKubeconfig(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.provider)
+}
+
+func kubeadmControlPlane(replicas, updatedReplicas, readyReplicas int32) *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Generation: 2},
+		Spec:       controlplanev1.KubeadmControlPlaneSpec{Replicas: &replicas},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			ObservedGeneration: 2,
+			Replicas:           replicas,
+			UpdatedReplicas:    updatedReplicas,
+			ReadyReplicas:      readyReplicas,
+		},
+	}
+}
+
+func TestCertificatesRotateRollout(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.expectPauseAndResume()
+	tt.kubectl.EXPECT().RolloutKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any())
+	gomock.InOrder(
+		tt.kubectl.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any(), gomock.Any()).Return(kubeadmControlPlane(3, 1, 3), nil),
+		tt.kubectl.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any(), gomock.Any()).Return(kubeadmControlPlane(3, 3, 3), nil),
+	)
+	tt.expectRefreshKubeconfig()
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.RolloutRotation)).To(Succeed())
+}
+
+func TestCertificatesRotateRolloutWithAwsIamAuth(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{}
+	tt.expectPauseAndResume()
+	tt.clusterManager.EXPECT().CreateAwsIamAuthCaSecret(tt.ctx, tt.managementCluster)
+	tt.kubectl.EXPECT().RolloutKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any())
+	tt.kubectl.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any(), gomock.Any()).Return(kubeadmControlPlane(1, 1, 1), nil)
+	tt.expectRefreshKubeconfig()
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.RolloutRotation)).To(Succeed())
+}
+
+func TestCertificatesRotateRolloutErrorWaiting(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.expectPauseAndResume()
+	tt.kubectl.EXPECT().RolloutKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any())
+	tt.kubectl.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any(), gomock.Any()).Return(kubeadmControlPlane(3, 3, 2), nil).Times(2)
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.RolloutRotation)).To(
+		MatchError("waiting for control plane rollout: 1 control plane replicas are not ready"),
+	)
+}
+
+func TestCertificatesRotateErrorPausing(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.clusterManager.EXPECT().PauseEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider).Return(errors.New("error pausing"))
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.RolloutRotation)).To(MatchError("error pausing"))
+}
+
+func TestCertificatesRotateErrorResuming(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.clusterManager.EXPECT().PauseEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider)
+	tt.kubectl.EXPECT().RolloutKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any())
+	tt.kubectl.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any(), gomock.Any()).Return(kubeadmControlPlane(1, 1, 1), nil)
+	tt.expectRefreshKubeconfig()
+	tt.clusterManager.EXPECT().ResumeEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider).Return(errors.New("error resuming"))
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.RolloutRotation)).To(MatchError("error resuming"))
+}
+
+func TestCertificatesRotateInPlaceWithAwsIamAuth(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{}
+	node1 := etcdbackup.Node{Name: "cp-1", Address: "10.0.0.1"}
+	node2 := etcdbackup.Node{Name: "cp-2", Address: "10.0.0.2"}
+	cert, key := []byte("cert"), []byte("key")
+	tt.expectPauseAndResume()
+	tt.clusterManager.EXPECT().CreateAwsIamAuthCaSecret(tt.ctx, tt.managementCluster)
+	tt.expectMachines(controlPlaneMachine("cp-1", "10.0.0.1"), controlPlaneMachine("cp-2", "10.0.0.2"))
+	tt.kubectl.EXPECT().GetSecret(tt.ctx, "aws-iam-authenticator-ca", gomock.Any(), gomock.Any()).Return(
+		&corev1.Secret{Data: map[string][]byte{"cert.pem": cert, "key.pem": key}}, nil,
+	)
+	for _, node := range []etcdbackup.Node{node1, node2} {
+		gomock.InOrder(
+			tt.runner.EXPECT().Run(tt.ctx, node, "kubeadm certs renew all", nil),
+			tt.runner.EXPECT().Run(tt.ctx, node, "cat > /var/lib/kubeadm/aws-iam-authenticator/pki/cert.pem", cert),
+			tt.runner.EXPECT().Run(tt.ctx, node, "cat > /var/lib/kubeadm/aws-iam-authenticator/pki/key.pem", key),
+			tt.runner.EXPECT().Run(tt.ctx, node, restartControlPlaneCommand, nil),
+		)
+	}
+	tt.kubectl.EXPECT().RolloutRestartDaemonSet(tt.ctx, "aws-iam-authenticator", "kube-system", tt.workloadCluster.KubeconfigFile)
+	tt.expectRefreshKubeconfig()
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.InPlaceRotation)).To(Succeed())
+}
+
+func TestCertificatesRotateRolloutExternalEtcd(t *testing.T) {
+	tt := newCertificatesTest(t)
+	ca := newTestCA(t)
+	tt.expectPauseAndResume()
+	tt.withExternalEtcd()
+	tt.expectMachines(controlPlaneMachine("cp-1", "10.0.0.1"), etcdMachine("etcd-1", "10.0.0.3"))
+	tt.expectRenewExternalEtcd(ca, etcdbackup.Node{Name: "etcd-1", Address: "10.0.0.3"})
+	tt.kubectl.EXPECT().RolloutKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any())
+	tt.kubectl.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any(), gomock.Any()).Return(kubeadmControlPlane(1, 1, 1), nil)
+	tt.expectRefreshKubeconfig()
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.RolloutRotation)).To(Succeed())
+}
+
+func TestCertificatesRotateInPlaceExternalEtcd(t *testing.T) {
+	tt := newCertificatesTest(t)
+	ca := newTestCA(t)
+	node := etcdbackup.Node{Name: "cp-1", Address: "10.0.0.1"}
+	clientCert := ca.issue("test-cluster-kube-apiserver-etcd-client")
+	tt.expectPauseAndResume()
+	tt.withExternalEtcd()
+	tt.kubectl.EXPECT().GetEtcdadmCluster(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any(), gomock.Any()).Return(
+		&etcdv1.EtcdadmCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-etcd"}}, nil,
+	)
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test-cluster").Return(
+		[]types.Machine{controlPlaneMachine("cp-1", "10.0.0.1"), etcdMachine("etcd-1", "10.0.0.3")}, nil,
+	).Times(2)
+	tt.expectRenewExternalEtcd(ca, etcdbackup.Node{Name: "etcd-1", Address: "10.0.0.3"})
+	tt.kubectl.EXPECT().GetSecret(tt.ctx, "test-cluster-apiserver-etcd-client", gomock.Any(), gomock.Any()).Return(
+		&corev1.Secret{Data: map[string][]byte{"tls.crt": clientCert}}, nil,
+	)
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, node, "kubeadm certs renew apiserver && kubeadm certs renew apiserver-kubelet-client && kubeadm certs renew front-proxy-client"+
+			" && kubeadm certs renew admin.conf && kubeadm certs renew controller-manager.conf && kubeadm certs renew scheduler.conf", nil),
+		tt.runner.EXPECT().Run(tt.ctx, node, "cat > /etc/kubernetes/pki/apiserver-etcd-client.crt", clientCert),
+		tt.runner.EXPECT().Run(tt.ctx, node, restartControlPlaneCommand, nil),
+	)
+	tt.expectRefreshKubeconfig()
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.InPlaceRotation)).To(Succeed())
+}
+
+func TestCertificatesRotateExternalEtcdErrorGettingCA(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.expectPauseAndResume()
+	tt.withExternalEtcd()
+	tt.expectMachines(controlPlaneMachine("cp-1", "10.0.0.1"), etcdMachine("etcd-1", "10.0.0.3"))
+	tt.kubectl.EXPECT().GetSecret(tt.ctx, "test-cluster-managed-etcd", gomock.Any(), gomock.Any()).Return(nil, errors.New("secret not found"))
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.RolloutRotation)).To(
+		MatchError("getting etcd CA: secret not found"),
+	)
+}
+
+func TestCertificatesRotateExternalEtcdErrorRestarting(t *testing.T) {
+	tt := newCertificatesTest(t)
+	ca := newTestCA(t)
+	node := etcdbackup.Node{Name: "etcd-1", Address: "10.0.0.3"}
+	tt.expectPauseAndResume()
+	tt.withExternalEtcd()
+	tt.expectMachines(controlPlaneMachine("cp-1", "10.0.0.1"), etcdMachine("etcd-1", "10.0.0.3"))
+	tt.kubectl.EXPECT().GetSecret(tt.ctx, "test-cluster-managed-etcd", gomock.Any(), gomock.Any()).Return(ca.secret(), nil)
+	out, _ := ca.readOutput(externalEtcdPaths...)
+	tt.runner.EXPECT().Run(tt.ctx, node, externalEtcdReadCommand, nil).Return(out, nil)
+	tt.runner.EXPECT().Run(tt.ctx, node, gomock.Any(), gomock.Any()).Times(len(externalEtcdPaths))
+	tt.runner.EXPECT().Run(tt.ctx, node, restartEtcdCommand, nil).Return(nil, errors.New("etcd failed"))
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.RolloutRotation)).To(
+		MatchError("restarting etcd in etcd-1: etcd failed"),
+	)
+}
+
+func TestCertificatesRotateInPlaceErrorRenewing(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.expectPauseAndResume()
+	tt.expectMachines(controlPlaneMachine("cp-1", "10.0.0.1"))
+	tt.runner.EXPECT().Run(tt.ctx, gomock.Any(), "kubeadm certs renew all", nil).Return(nil, errors.New("kubeadm failed"))
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.InPlaceRotation)).To(
+		MatchError("renewing certificates in cp-1: kubeadm failed"),
+	)
+}
+
+func TestCertificatesRotateInPlaceBottlerocket(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &v1alpha1.Ref{Name: "cp-machines"}
+	machineConfig := providermocks.NewMockMachineConfig(gomock.NewController(t))
+	machineConfig.EXPECT().GetName().Return("cp-machines")
+	machineConfig.EXPECT().OSFamily().Return(v1alpha1.Bottlerocket)
+	tt.provider.EXPECT().MachineConfigs(tt.clusterSpec).Return([]providers.MachineConfig{machineConfig})
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, certificates.InPlaceRotation)).To(
+		MatchError("in-place certificate rotation is not supported for bottlerocket control plane machines, use the rollout method"),
+	)
+}
+
+func TestCertificatesRotateInvalidMethod(t *testing.T) {
+	tt := newCertificatesTest(t)
+
+	tt.Expect(tt.certificates.Rotate(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.clusterSpec, tt.provider, "recreate")).To(
+		MatchError("invalid certificate rotation method recreate, supported methods are rollout and in-place"),
+	)
+}
@@ -22,7 +22,7 @@ func awsIamEntry() *ConfigManagerEntry {
 			func(c *Config) error {
 				for _, a := range c.AWSIAMConfigs {
 					if err := a.Validate(); err != nil {
-						return err
+						return errorFor(a, err)
 					}
 				}
 				return nil
@@ -10,7 +10,7 @@ func clusterEntry() *ConfigManagerEntry {
 		},
 		Validations: []Validation{
 			func(c *Config) error {
-				return c.Cluster.Validate()
+				return errorFor(c.Cluster, c.Cluster.Validate())
 			},
 		},
 	}
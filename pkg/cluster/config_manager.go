@@ -83,6 +83,18 @@ func (c *ConfigManager) SetDefaults(config *Config) error {
 
 // Validate performs the registered validations in a Config struct
 func (c *ConfigManager) Validate(config *Config) error {
+	allErrs := c.validationErrors(config)
+
+	if len(allErrs) > 0 {
+		aggregate := utilerrors.NewAggregate(allErrs)
+		return fmt.Errorf("invalid cluster config: %v", aggregate)
+	}
+
+	return nil
+}
+
+// validationErrors performs the registered validations in a Config struct and returns the errors of each of them
+func (c *ConfigManager) validationErrors(config *Config) []error {
 	var allErrs []error
 
 	for _, v := range c.entry.Validations {
@@ -91,12 +103,7 @@ func (c *ConfigManager) Validate(config *Config) error {
 		}
 	}
 
-	if len(allErrs) > 0 {
-		aggregate := utilerrors.NewAggregate(allErrs)
-		return fmt.Errorf("invalid cluster config: %v", aggregate)
-	}
-
-	return nil
+	return allErrs
 }
 
 type basicAPIObject struct {
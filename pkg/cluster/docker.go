@@ -15,7 +15,7 @@ func dockerEntry() *ConfigManagerEntry {
 		Validations: []Validation{
 			func(c *Config) error {
 				if c.DockerDatacenter != nil {
-					return c.DockerDatacenter.Validate()
+					return errorFor(c.DockerDatacenter, c.DockerDatacenter.Validate())
 				}
 				return nil
 			},
@@ -34,6 +34,10 @@ func dockerEntry() *ConfigManagerEntry {
 func processDockerDatacenter(c *Config, objects ObjectLookup) {
 	if c.Cluster.Spec.DatacenterRef.Kind == anywherev1.DockerDatacenterKind {
 		datacenter := objects.GetFromRef(c.Cluster.APIVersion, c.Cluster.Spec.DatacenterRef)
+		if datacenter == nil {
+			return
+		}
+
 		c.DockerDatacenter = datacenter.(*anywherev1.DockerDatacenterConfig)
 	}
 }
@@ -10,6 +10,22 @@ func fluxEntry() *ConfigManagerEntry {
 			},
 		},
 		Processors: []ParsedProcessor{processFlux},
+		Validations: []Validation{
+			func(c *Config) error {
+				if c.FluxConfig != nil {
+					return errorFor(c.FluxConfig, c.FluxConfig.Validate())
+				}
+				return nil
+			},
+			func(c *Config) error {
+				if c.FluxConfig != nil {
+					if err := validateSameNamespace(c, c.FluxConfig); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
 	}
 }
 
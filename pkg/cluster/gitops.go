@@ -26,7 +26,7 @@ func gitOpsEntry() *ConfigManagerEntry {
 		Validations: []Validation{
 			func(c *Config) error {
 				if c.GitOpsConfig != nil {
-					return c.GitOpsConfig.Validate()
+					return errorFor(c.GitOpsConfig, c.GitOpsConfig.Validate())
 				}
 				return nil
 			},
@@ -24,3 +24,13 @@ func NodeGroupsToDelete(currentSpec, newSpec *Spec) []eksav1alpha1.WorkerNodeGro
 	}
 	return nodeGroupsToDelete
 }
+
+// WorkerNodeGroupKubernetesVersionChanged returns true if the kubernetes version of the worker node group
+// in newSpec is different from the one it has in currentSpec, or if the group doesn't exist in currentSpec
+func WorkerNodeGroupKubernetesVersionChanged(currentSpec, newSpec *Spec, workerNodeGroup eksav1alpha1.WorkerNodeGroupConfiguration) bool {
+	currentWorkerNodeGroup, ok := BuildMapForWorkerNodeGroupsByName(currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations)[workerNodeGroup.Name]
+	if !ok {
+		return true
+	}
+	return currentSpec.Cluster.WorkerNodeGroupKubernetesVersion(currentWorkerNodeGroup) != newSpec.Cluster.WorkerNodeGroupKubernetesVersion(workerNodeGroup)
+}
@@ -130,3 +130,64 @@ func TestNodeGroupsToDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkerNodeGroupKubernetesVersionChanged(t *testing.T) {
+	kube119 := anywherev1.Kube119
+	kube120 := anywherev1.Kube120
+	tests := []struct {
+		name           string
+		currentVersion *anywherev1.KubernetesVersion
+		newVersion     *anywherev1.KubernetesVersion
+		newName        string
+		want           bool
+	}{
+		{
+			name:    "same cluster version",
+			newName: "md-0",
+			want:    false,
+		},
+		{
+			name:           "pinned to the cluster version",
+			currentVersion: &kube120,
+			newName:        "md-0",
+			want:           false,
+		},
+		{
+			name:           "upgraded to the cluster version",
+			currentVersion: &kube119,
+			newName:        "md-0",
+			want:           true,
+		},
+		{
+			name:       "pinned to an older version",
+			newVersion: &kube119,
+			newName:    "md-0",
+			want:       true,
+		},
+		{
+			name:    "new worker node group",
+			newName: "md-1",
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.KubernetesVersion = anywherev1.Kube120
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+					{Name: "md-0", KubernetesVersion: tt.currentVersion},
+				}
+			})
+			new := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.KubernetesVersion = anywherev1.Kube120
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+					{Name: tt.newName, KubernetesVersion: tt.newVersion},
+				}
+			})
+
+			if got := cluster.WorkerNodeGroupKubernetesVersionChanged(current, new, new.Cluster.Spec.WorkerNodeGroupConfigurations[0]); got != tt.want {
+				t.Errorf("WorkerNodeGroupKubernetesVersionChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ func oidcEntry() *ConfigManagerEntry {
 			func(c *Config) error {
 				for _, o := range c.OIDCConfigs {
 					if err := o.Validate(); err != nil {
-						return err
+						return errorFor(o, err)
 					}
 				}
 				return nil
@@ -28,7 +28,15 @@ func snowEntry() *ConfigManagerEntry {
 			func(c *Config) error {
 				for _, m := range c.SnowMachineConfigs {
 					if err := m.Validate(); err != nil {
-						return err
+						return errorFor(m, err)
+					}
+				}
+				return nil
+			},
+			func(c *Config) error {
+				if c.SnowDatacenter != nil {
+					if err := c.SnowDatacenter.Validate(); err != nil {
+						return errorFor(c.SnowDatacenter, err)
 					}
 				}
 				return nil
@@ -56,6 +64,10 @@ func snowEntry() *ConfigManagerEntry {
 func processSnowDatacenter(c *Config, objects ObjectLookup) {
 	if c.Cluster.Spec.DatacenterRef.Kind == anywherev1.SnowDatacenterKind {
 		datacenter := objects.GetFromRef(c.Cluster.APIVersion, c.Cluster.Spec.DatacenterRef)
+		if datacenter == nil {
+			return
+		}
+
 		c.SnowDatacenter = datacenter.(*anywherev1.SnowDatacenterConfig)
 	}
 }
@@ -27,14 +27,17 @@ var releasesManifestURL string
 
 type Spec struct {
 	*Config
-	OIDCConfig                *eksav1alpha1.OIDCConfig
-	AWSIamConfig              *eksav1alpha1.AWSIamConfig
-	releasesManifestURL       string
-	bundlesManifestURL        string
-	configFS                  embed.FS
-	userAgent                 string
-	reader                    *ManifestReader
-	VersionsBundle            *VersionsBundle
+	OIDCConfig          *eksav1alpha1.OIDCConfig
+	AWSIamConfig        *eksav1alpha1.AWSIamConfig
+	releasesManifestURL string
+	bundlesManifestURL  string
+	configFS            embed.FS
+	userAgent           string
+	reader              *ManifestReader
+	VersionsBundle      *VersionsBundle
+	// WorkerVersionsBundles holds the VersionsBundle for each worker node group kubernetes version
+	// different from the Cluster one
+	WorkerVersionsBundles     map[eksav1alpha1.KubernetesVersion]*VersionsBundle
 	eksdRelease               *eksdv1alpha1.Release
 	Bundles                   *v1alpha1.Bundles
 	ManagementCluster         *types.Cluster
@@ -42,19 +45,25 @@ type Spec struct {
 }
 
 func (s *Spec) DeepCopy() *Spec {
+	var workerVersionsBundles map[eksav1alpha1.KubernetesVersion]*VersionsBundle
+	if s.WorkerVersionsBundles != nil {
+		workerVersionsBundles = make(map[eksav1alpha1.KubernetesVersion]*VersionsBundle, len(s.WorkerVersionsBundles))
+	}
+	for v, b := range s.WorkerVersionsBundles {
+		workerVersionsBundles[v] = b.deepCopy()
+	}
+
 	return &Spec{
-		Config:              s.Config.DeepCopy(),
-		OIDCConfig:          s.OIDCConfig.DeepCopy(),
-		AWSIamConfig:        s.AWSIamConfig.DeepCopy(),
-		releasesManifestURL: s.releasesManifestURL,
-		bundlesManifestURL:  s.bundlesManifestURL,
-		configFS:            s.configFS,
-		reader:              s.reader,
-		userAgent:           s.userAgent,
-		VersionsBundle: &VersionsBundle{
-			VersionsBundle: s.VersionsBundle.VersionsBundle.DeepCopy(),
-			KubeDistro:     s.VersionsBundle.KubeDistro.deepCopy(),
-		},
+		Config:                    s.Config.DeepCopy(),
+		OIDCConfig:                s.OIDCConfig.DeepCopy(),
+		AWSIamConfig:              s.AWSIamConfig.DeepCopy(),
+		releasesManifestURL:       s.releasesManifestURL,
+		bundlesManifestURL:        s.bundlesManifestURL,
+		configFS:                  s.configFS,
+		reader:                    s.reader,
+		userAgent:                 s.userAgent,
+		VersionsBundle:            s.VersionsBundle.deepCopy(),
+		WorkerVersionsBundles:     workerVersionsBundles,
 		eksdRelease:               s.eksdRelease.DeepCopy(),
 		Bundles:                   s.Bundles.DeepCopy(),
 		TinkerbellTemplateConfigs: s.TinkerbellTemplateConfigs,
@@ -66,6 +75,21 @@ type VersionsBundle struct {
 	KubeDistro *KubeDistro
 }
 
+func (v *VersionsBundle) deepCopy() *VersionsBundle {
+	return &VersionsBundle{
+		VersionsBundle: v.VersionsBundle.DeepCopy(),
+		KubeDistro:     v.KubeDistro.deepCopy(),
+	}
+}
+
+// WorkerNodeGroupVersionsBundle returns the VersionsBundle for the kubernetes version of a worker node group
+func (s *Spec) WorkerNodeGroupVersionsBundle(w eksav1alpha1.WorkerNodeGroupConfiguration) *VersionsBundle {
+	if b, ok := s.WorkerVersionsBundles[s.Cluster.WorkerNodeGroupKubernetesVersion(w)]; ok {
+		return b
+	}
+	return s.VersionsBundle
+}
+
 type KubeDistro struct {
 	Kubernetes          VersionedRepository
 	CoreDNS             VersionedRepository
@@ -200,6 +224,10 @@ func NewSpecFromClusterConfig(clusterConfigPath string, cliVersion version.Info,
 	}
 	s.eksdRelease = eksd
 
+	if err = s.buildWorkerVersionsBundles(bundles); err != nil {
+		return nil, err
+	}
+
 	// Get first aws iam config if it exists
 	// Config supports multiple configs because Cluster references a slice
 	// But we validate that only one of each type is referenced
@@ -263,9 +291,45 @@ func BuildSpecFromBundles(cluster *eksav1alpha1.Cluster, bundles *v1alpha1.Bundl
 		KubeDistro:     kubeDistro,
 	}
 
+	if err = s.buildWorkerVersionsBundles(bundles); err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
 
+// buildWorkerVersionsBundles sets the VersionsBundles for the worker node groups kubernetes versions that
+// differ from the Cluster one
+func (s *Spec) buildWorkerVersionsBundles(bundles *v1alpha1.Bundles) error {
+	s.WorkerVersionsBundles = nil
+	for _, kubeVersion := range s.Cluster.KubernetesVersions()[1:] {
+		versionsBundle, err := s.getVersionsBundle(kubeVersion, bundles)
+		if err != nil {
+			return err
+		}
+
+		eksd, err := s.reader.GetEksdRelease(versionsBundle)
+		if err != nil {
+			return err
+		}
+
+		kubeDistro, err := buildKubeDistro(eksd)
+		if err != nil {
+			return err
+		}
+
+		if s.WorkerVersionsBundles == nil {
+			s.WorkerVersionsBundles = map[eksav1alpha1.KubernetesVersion]*VersionsBundle{}
+		}
+		s.WorkerVersionsBundles[kubeVersion] = &VersionsBundle{
+			VersionsBundle: versionsBundle,
+			KubeDistro:     kubeDistro,
+		}
+	}
+
+	return nil
+}
+
 func (s *Spec) newManifestReader() *ManifestReader {
 	return NewManifestReader(files.WithEmbedFS(s.configFS), files.WithUserAgent(s.userAgent))
 }
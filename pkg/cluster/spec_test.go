@@ -140,6 +140,31 @@ func TestNewSpecWithBundlesOverrideValid(t *testing.T) {
 	validateSpecFromSimpleBundle(t, gotSpec)
 }
 
+func TestNewSpecWithWorkerNodeGroupKubernetesVersion(t *testing.T) {
+	v := version.Info{GitVersion: "v0.0.1"}
+	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_20_workers_1_19.yaml", v,
+		cluster.WithReleasesManifest("testdata/invalid_release_version.yaml"),
+		cluster.WithOverrideBundlesManifest("testdata/bundle_1_19_1_20.yaml"),
+	)
+	if err != nil {
+		t.Fatalf("NewSpec() error = %v, want err nil", err)
+	}
+
+	if gotSpec.VersionsBundle.EksD.ReleaseChannel != "1-20" {
+		t.Errorf("NewSpec() VersionsBundle channel = %s, want 1-20", gotSpec.VersionsBundle.EksD.ReleaseChannel)
+	}
+	workerVersionsBundle := gotSpec.WorkerNodeGroupVersionsBundle(gotSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0])
+	if workerVersionsBundle.EksD.ReleaseChannel != "1-19" {
+		t.Errorf("WorkerNodeGroupVersionsBundle() channel = %s, want 1-19", workerVersionsBundle.EksD.ReleaseChannel)
+	}
+	if workerVersionsBundle.KubeDistro == nil {
+		t.Error("WorkerNodeGroupVersionsBundle() KubeDistro is nil")
+	}
+	if gotSpec.DeepCopy().WorkerNodeGroupVersionsBundle(gotSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0]).EksD.ReleaseChannel != "1-19" {
+		t.Error("DeepCopy() didn't copy the worker node group versions bundles")
+	}
+}
+
 func validateSpecFromSimpleBundle(t *testing.T, gotSpec *cluster.Spec) {
 	validateVersionedRepo(t, gotSpec.VersionsBundle.KubeDistro.Kubernetes, "public.ecr.aws/eks-distro/kubernetes", "v1.19.8-eks-1-19-4")
 	validateVersionedRepo(t, gotSpec.VersionsBundle.KubeDistro.CoreDNS, "public.ecr.aws/eks-distro/coredns", "v1.8.0-eks-1-19-4")
@@ -1,9 +1,11 @@
 package cluster
 
 import (
+	"errors"
 	"fmt"
 
-	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func ValidateConfig(c *Config) error {
@@ -11,14 +13,127 @@ func ValidateConfig(c *Config) error {
 }
 
 type namespaceObject interface {
-	runtime.Object
+	APIObject
 	GetNamespace() string
 }
 
 func validateSameNamespace(c *Config, o namespaceObject) error {
 	if c.Cluster.Namespace != o.GetNamespace() {
-		return fmt.Errorf("%s and Cluster objects must have the same namespace specified", o.GetObjectKind().GroupVersionKind().Kind)
+		err := fmt.Errorf("%s and Cluster objects must have the same namespace specified", o.GetObjectKind().GroupVersionKind().Kind)
+		return errorFor(o, field.Invalid(field.NewPath("metadata", "namespace"), o.GetNamespace(), err.Error()))
 	}
 
 	return nil
 }
+
+// objectError is an error returned by a Validation for one of the objects of the Config, so the
+// validation report can tell which object it refers to
+type objectError struct {
+	obj APIObject
+	err error
+}
+
+func (e *objectError) Error() string {
+	return e.err.Error()
+}
+
+func (e *objectError) Unwrap() error {
+	return e.err
+}
+
+// errorFor attributes err to obj. It returns nil if err is nil
+func errorFor(obj APIObject, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &objectError{obj: obj, err: err}
+}
+
+// ValidationError is a problem found in a cluster config, identified by the object and field it refers to
+// Kind, Name and Field are empty when the error doesn't belong to a particular object or field
+type ValidationError struct {
+	Kind    string `json:"kind,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) String() string {
+	var object string
+	if e.Kind != "" {
+		object = fmt.Sprintf("%s/%s ", e.Kind, e.Name)
+	}
+	if e.Field != "" {
+		return fmt.Sprintf("%s%s: %s", object, e.Field, e.Message)
+	}
+	return object + e.Message
+}
+
+// ValidationReport contains all the errors found validating a cluster config
+type ValidationReport struct {
+	Valid  bool              `json:"valid"`
+	Errors []ValidationError `json:"errors"`
+}
+
+// addError adds err to the report, with the object and field it refers to when available.
+// Aggregated errors are added one by one
+func (r *ValidationReport) addError(err error) {
+	var obj APIObject
+	objErr := &objectError{}
+	if errors.As(err, &objErr) {
+		obj = objErr.obj
+		err = objErr.err
+	}
+
+	var aggregate utilerrors.Aggregate
+	if errors.As(err, &aggregate) {
+		for _, e := range aggregate.Errors() {
+			r.addObjectError(obj, e)
+		}
+		return
+	}
+
+	r.addObjectError(obj, err)
+}
+
+func (r *ValidationReport) addObjectError(obj APIObject, err error) {
+	validationErr := ValidationError{Message: err.Error()}
+	if obj != nil {
+		validationErr.Kind = obj.GetObjectKind().GroupVersionKind().Kind
+		validationErr.Name = obj.GetName()
+	}
+
+	fieldErr := &field.Error{}
+	if errors.As(err, &fieldErr) {
+		validationErr.Field = fieldErr.Field
+		validationErr.Type = string(fieldErr.Type)
+		validationErr.Message = fieldErr.Detail
+	}
+
+	r.Errors = append(r.Errors, validationErr)
+	r.Valid = false
+}
+
+// ValidateConfigManifest parses a cluster config yaml manifest, sets its defaults and runs the validations registered
+// in the config manager, without accessing any infrastructure. Instead of stopping at the first problem, it reports
+// the errors of every validation, with the object and the path of the field they refer to when available
+func ValidateConfigManifest(yamlManifest []byte) *ValidationReport {
+	report := &ValidationReport{Valid: true, Errors: []ValidationError{}}
+
+	config, err := manager().Parse(yamlManifest)
+	if err != nil {
+		report.addError(fmt.Errorf("parsing cluster config: %v", err))
+		return report
+	}
+
+	if err = manager().SetDefaults(config); err != nil {
+		report.addError(errorFor(config.Cluster, err))
+	}
+
+	for _, err := range manager().validationErrors(config) {
+		report.addError(err)
+	}
+
+	return report
+}
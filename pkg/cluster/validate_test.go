@@ -1,6 +1,8 @@
 package cluster_test
 
 import (
+	"io/ioutil"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -75,3 +77,102 @@ func TestValidateConfigDifferentNamespace(t *testing.T) {
 		MatchError(ContainSubstring("VSphereDatacenterConfig and Cluster objects must have the same namespace specified")),
 	)
 }
+
+func TestValidateConfigManifestValid(t *testing.T) {
+	g := NewWithT(t)
+	content, err := ioutil.ReadFile("testdata/docker_cluster_oidc_awsiam_gitops.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	report := cluster.ValidateConfigManifest(content)
+	g.Expect(report.Valid).To(BeTrue())
+	g.Expect(report.Errors).To(BeEmpty())
+}
+
+func TestValidateConfigManifestAllErrors(t *testing.T) {
+	g := NewWithT(t)
+	content, err := ioutil.ReadFile("testdata/docker_cluster_invalid.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	report := cluster.ValidateConfigManifest(content)
+	g.Expect(report.Valid).To(BeFalse())
+	g.Expect(report.Errors).To(Equal([]cluster.ValidationError{
+		{
+			Kind:    anywherev1.ClusterKind,
+			Name:    "m-docker",
+			Field:   "spec.controlPlaneConfiguration.count",
+			Type:    "FieldValueInvalid",
+			Message: "control plane node count cannot be an even number",
+		},
+		{
+			Kind:    anywherev1.OIDCConfigKind,
+			Name:    "eksa-unit-test",
+			Field:   "spec.issuerUrl",
+			Type:    "FieldValueRequired",
+			Message: "OIDCConfig issuerUrl is required",
+		},
+		{
+			Kind:    anywherev1.OIDCConfigKind,
+			Name:    "eksa-unit-test",
+			Field:   "metadata.namespace",
+			Type:    "FieldValueInvalid",
+			Message: "OIDCConfig and Cluster objects must have the same namespace specified",
+		},
+	}))
+}
+
+func TestValidateConfigManifestProviderValidations(t *testing.T) {
+	g := NewWithT(t)
+	content, err := ioutil.ReadFile("testdata/cluster_snow_1_21.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	content = []byte(strings.Replace(string(content), "amiID: eks-d-v1-21-ami", "amiID: \"\"", 1))
+
+	report := cluster.ValidateConfigManifest(content)
+	g.Expect(report.Valid).To(BeFalse())
+	g.Expect(report.Errors).To(ContainElement(cluster.ValidationError{
+		Kind:    anywherev1.SnowMachineConfigKind,
+		Name:    "eksa-unit-test-cp",
+		Field:   "spec.amiID",
+		Type:    "FieldValueRequired",
+		Message: "SnowMachineConfig AMIID is a required field",
+	}))
+}
+
+func TestValidateConfigManifestParseError(t *testing.T) {
+	g := NewWithT(t)
+	report := cluster.ValidateConfigManifest([]byte("apiVersion: v1\nkind: ConfigMap\n"))
+	g.Expect(report.Valid).To(BeFalse())
+	g.Expect(report.Errors).To(Equal([]cluster.ValidationError{
+		{Message: "parsing cluster config: no Cluster found in manifest"},
+	}))
+}
+
+func TestValidationErrorString(t *testing.T) {
+	tests := []struct {
+		name string
+		err  cluster.ValidationError
+		want string
+	}{
+		{
+			name: "with field",
+			err:  cluster.ValidationError{Kind: "Cluster", Name: "c", Field: "metadata.name", Message: "invalid"},
+			want: "Cluster/c metadata.name: invalid",
+		},
+		{
+			name: "without field",
+			err:  cluster.ValidationError{Kind: "OIDCConfig", Name: "o", Message: "invalid"},
+			want: "OIDCConfig/o invalid",
+		},
+		{
+			name: "without object",
+			err:  cluster.ValidationError{Message: "invalid"},
+			want: "invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.err.String()).To(Equal(tt.want))
+		})
+	}
+}
@@ -27,14 +27,14 @@ func vsphereEntry() *ConfigManagerEntry {
 		Validations: []Validation{
 			func(c *Config) error {
 				if c.VSphereDatacenter != nil {
-					return c.VSphereDatacenter.Validate()
+					return errorFor(c.VSphereDatacenter, c.VSphereDatacenter.Validate())
 				}
 				return nil
 			},
 			func(c *Config) error {
 				for _, m := range c.VSphereMachineConfigs {
 					if err := m.Validate(); err != nil {
-						return err
+						return errorFor(m, err)
 					}
 				}
 				return nil
@@ -62,6 +62,10 @@ func vsphereEntry() *ConfigManagerEntry {
 func processVSphereDatacenter(c *Config, objects ObjectLookup) {
 	if c.Cluster.Spec.DatacenterRef.Kind == anywherev1.VSphereDatacenterKind {
 		datacenter := objects.GetFromRef(c.Cluster.APIVersion, c.Cluster.Spec.DatacenterRef)
+		if datacenter == nil {
+			return
+		}
+
 		c.VSphereDatacenter = datacenter.(*anywherev1.VSphereDatacenterConfig)
 	}
 }
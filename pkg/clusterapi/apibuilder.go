@@ -202,7 +202,7 @@ func MachineDeployment(clusterSpec *cluster.Spec, workerNodeGroupConfig v1alpha1
 	replicas := int32(workerNodeGroupConfig.Count)
 	version := clusterSpec.VersionsBundle.KubeDistro.Kubernetes.Tag
 
-	return clusterv1.MachineDeployment{
+	md := clusterv1.MachineDeployment{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: clusterAPIVersion,
 			Kind:       machineDeploymentKind,
@@ -241,4 +241,8 @@ func MachineDeployment(clusterSpec *cluster.Spec, workerNodeGroupConfig v1alpha1
 			Replicas: &replicas,
 		},
 	}
+
+	ConfigureAutoscalingInMachineDeployment(&md, workerNodeGroupConfig)
+
+	return md
 }
@@ -0,0 +1,52 @@
+package clusterapi
+
+import (
+	"strconv"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	// NodeGroupMinSizeAnnotation is read by the cluster-autoscaler clusterapi provider as the min size of a node group
+	NodeGroupMinSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-min-size"
+	// NodeGroupMaxSizeAnnotation is read by the cluster-autoscaler clusterapi provider as the max size of a node group
+	NodeGroupMaxSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size"
+)
+
+// AutoscalerAnnotations returns the cluster-autoscaler annotations for a worker node group's MachineDeployment
+// or nil if the group has no autoscaling configured
+func AutoscalerAnnotations(wnc v1alpha1.WorkerNodeGroupConfiguration) map[string]string {
+	if wnc.AutoScalingConfiguration == nil {
+		return nil
+	}
+	return map[string]string{
+		NodeGroupMinSizeAnnotation: strconv.Itoa(wnc.AutoScalingConfiguration.MinCount),
+		NodeGroupMaxSizeAnnotation: strconv.Itoa(wnc.AutoScalingConfiguration.MaxCount),
+	}
+}
+
+// ConfigureAutoscalingInMachineDeployment adds the cluster-autoscaler annotations to a MachineDeployment
+func ConfigureAutoscalingInMachineDeployment(md *clusterv1.MachineDeployment, wnc v1alpha1.WorkerNodeGroupConfiguration) {
+	annotations := AutoscalerAnnotations(wnc)
+	if annotations == nil {
+		return
+	}
+	if md.Annotations == nil {
+		md.Annotations = make(map[string]string, len(annotations))
+	}
+	for k, v := range annotations {
+		md.Annotations[k] = v
+	}
+}
+
+// AutoscalingEnabled returns true if any worker node group in the cluster has autoscaling configured
+func AutoscalingEnabled(cluster *v1alpha1.Cluster) bool {
+	for _, wnc := range cluster.Spec.WorkerNodeGroupConfigurations {
+		if wnc.AutoScalingConfiguration != nil {
+			return true
+		}
+	}
+	return false
+}
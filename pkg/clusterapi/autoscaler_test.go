@@ -0,0 +1,50 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestConfigureAutoscalingInMachineDeployment(t *testing.T) {
+	g := NewWithT(t)
+	md := &clusterv1.MachineDeployment{}
+	wnc := v1alpha1.WorkerNodeGroupConfiguration{
+		Count:                    1,
+		AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+	}
+
+	clusterapi.ConfigureAutoscalingInMachineDeployment(md, wnc)
+	g.Expect(md.Annotations).To(Equal(map[string]string{
+		clusterapi.NodeGroupMinSizeAnnotation: "1",
+		clusterapi.NodeGroupMaxSizeAnnotation: "3",
+	}))
+}
+
+func TestConfigureAutoscalingInMachineDeploymentNoAutoscaling(t *testing.T) {
+	g := NewWithT(t)
+	md := &clusterv1.MachineDeployment{}
+
+	clusterapi.ConfigureAutoscalingInMachineDeployment(md, v1alpha1.WorkerNodeGroupConfiguration{Count: 1})
+	g.Expect(md.Annotations).To(BeNil())
+}
+
+func TestAutoscalingEnabled(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+	}
+	g.Expect(clusterapi.AutoscalingEnabled(cluster)).To(BeFalse())
+
+	cluster.Spec.WorkerNodeGroupConfigurations = append(cluster.Spec.WorkerNodeGroupConfigurations, v1alpha1.WorkerNodeGroupConfiguration{
+		Name:                     "md-1",
+		AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+	})
+	g.Expect(clusterapi.AutoscalingEnabled(cluster)).To(BeTrue())
+}
@@ -0,0 +1,98 @@
+package clusterapi
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	machineHealthCheckKind = "MachineHealthCheck"
+
+	// DefaultNodeStartupTimeout is the time a machine has to get its node registered before it's remediated
+	DefaultNodeStartupTimeout = 10 * time.Minute
+	// DefaultUnhealthyMachineTimeout is the time a node can be not ready or unknown before its machine is remediated
+	DefaultUnhealthyMachineTimeout = 5 * time.Minute
+
+	controlPlaneMaxUnhealthy = "100%"
+	workerMaxUnhealthy       = "40%"
+)
+
+// MachineHealthCheckTimeouts configures when a machine is considered unhealthy
+type MachineHealthCheckTimeouts struct {
+	NodeStartup      time.Duration
+	UnhealthyMachine time.Duration
+}
+
+// DefaultMachineHealthCheckTimeouts returns the timeouts used by the machine health checks of all providers
+func DefaultMachineHealthCheckTimeouts() MachineHealthCheckTimeouts {
+	return MachineHealthCheckTimeouts{
+		NodeStartup:      DefaultNodeStartupTimeout,
+		UnhealthyMachine: DefaultUnhealthyMachineTimeout,
+	}
+}
+
+// ControlPlaneMachineHealthCheck builds the MachineHealthCheck that remediates the control plane machines of a cluster
+func ControlPlaneMachineHealthCheck(clusterName string, timeouts MachineHealthCheckTimeouts) *clusterv1.MachineHealthCheck {
+	return machineHealthCheck(
+		fmt.Sprintf("%s-kcp-unhealthy", clusterName),
+		clusterName,
+		map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+		controlPlaneMaxUnhealthy,
+		timeouts,
+	)
+}
+
+// WorkerMachineHealthCheck builds the MachineHealthCheck that remediates the machines of a worker node group's MachineDeployment
+func WorkerMachineHealthCheck(clusterName string, workerNodeGroupConfig v1alpha1.WorkerNodeGroupConfiguration, timeouts MachineHealthCheckTimeouts) *clusterv1.MachineHealthCheck {
+	return machineHealthCheck(
+		fmt.Sprintf("%s-%s-worker-unhealthy", clusterName, workerNodeGroupConfig.Name),
+		clusterName,
+		map[string]string{clusterv1.MachineDeploymentLabelName: workerNodeGroupConfig.Name},
+		workerMaxUnhealthy,
+		timeouts,
+	)
+}
+
+func machineHealthCheck(name, clusterName string, matchLabels map[string]string, maxUnhealthy string, timeouts MachineHealthCheckTimeouts) *clusterv1.MachineHealthCheck {
+	maxUnhealthyValue := intstr.FromString(maxUnhealthy)
+	unhealthyTimeout := metav1.Duration{Duration: timeouts.UnhealthyMachine}
+
+	return &clusterv1.MachineHealthCheck{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterAPIVersion,
+			Kind:       machineHealthCheckKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: clusterv1.MachineHealthCheckSpec{
+			ClusterName: clusterName,
+			Selector: metav1.LabelSelector{
+				MatchLabels: matchLabels,
+			},
+			MaxUnhealthy:       &maxUnhealthyValue,
+			NodeStartupTimeout: &metav1.Duration{Duration: timeouts.NodeStartup},
+			UnhealthyConditions: []clusterv1.UnhealthyCondition{
+				{
+					Type:    corev1.NodeReady,
+					Status:  corev1.ConditionUnknown,
+					Timeout: unhealthyTimeout,
+				},
+				{
+					Type:    corev1.NodeReady,
+					Status:  corev1.ConditionFalse,
+					Timeout: unhealthyTimeout,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package clusterapi_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func wantMachineHealthCheck(name string, matchLabels map[string]string, maxUnhealthy string, nodeStartup, unhealthy time.Duration) *clusterv1.MachineHealthCheck {
+	maxUnhealthyValue := intstr.FromString(maxUnhealthy)
+	return &clusterv1.MachineHealthCheck{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "cluster.x-k8s.io/v1beta1",
+			Kind:       "MachineHealthCheck",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: clusterv1.MachineHealthCheckSpec{
+			ClusterName:        "test-cluster",
+			Selector:           metav1.LabelSelector{MatchLabels: matchLabels},
+			MaxUnhealthy:       &maxUnhealthyValue,
+			NodeStartupTimeout: &metav1.Duration{Duration: nodeStartup},
+			UnhealthyConditions: []clusterv1.UnhealthyCondition{
+				{
+					Type:    corev1.NodeReady,
+					Status:  corev1.ConditionUnknown,
+					Timeout: metav1.Duration{Duration: unhealthy},
+				},
+				{
+					Type:    corev1.NodeReady,
+					Status:  corev1.ConditionFalse,
+					Timeout: metav1.Duration{Duration: unhealthy},
+				},
+			},
+		},
+	}
+}
+
+func TestControlPlaneMachineHealthCheck(t *testing.T) {
+	g := NewWithT(t)
+	got := clusterapi.ControlPlaneMachineHealthCheck("test-cluster", clusterapi.DefaultMachineHealthCheckTimeouts())
+	want := wantMachineHealthCheck(
+		"test-cluster-kcp-unhealthy",
+		map[string]string{"cluster.x-k8s.io/control-plane": ""},
+		"100%",
+		10*time.Minute,
+		5*time.Minute,
+	)
+	g.Expect(got).To(Equal(want))
+}
+
+func TestWorkerMachineHealthCheck(t *testing.T) {
+	g := NewWithT(t)
+	timeouts := clusterapi.MachineHealthCheckTimeouts{
+		NodeStartup:      20 * time.Minute,
+		UnhealthyMachine: 8 * time.Minute,
+	}
+	got := clusterapi.WorkerMachineHealthCheck("test-cluster", v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}, timeouts)
+	want := wantMachineHealthCheck(
+		"test-cluster-md-0-worker-unhealthy",
+		map[string]string{"cluster.x-k8s.io/deployment-name": "md-0"},
+		"40%",
+		20*time.Minute,
+		8*time.Minute,
+	)
+	g.Expect(got).To(Equal(want))
+}
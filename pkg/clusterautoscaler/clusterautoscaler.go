@@ -0,0 +1,41 @@
+package clusterautoscaler
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+//go:embed config/cluster-autoscaler.yaml
+var clusterAutoscalerTemplate string
+
+// Name returns the name of the cluster-autoscaler deployment for a cluster in the management cluster
+func Name(clusterName string) string {
+	return fmt.Sprintf("%s-cluster-autoscaler", clusterName)
+}
+
+// GenerateManifest renders the cluster-autoscaler that runs in the management cluster and scales the
+// MachineDeployments of the workload cluster using the clusterapi cloud provider. The image is the one in the bundle
+// for the cluster kubernetes version, since cluster-autoscaler is released for each kubernetes minor version
+func GenerateManifest(clusterSpec *cluster.Spec) ([]byte, error) {
+	image := clusterSpec.VersionsBundle.ClusterAutoscaler.Image
+	if image.URI == "" {
+		return nil, fmt.Errorf("cluster-autoscaler image is not available in bundle %d for kubernetes %s", clusterSpec.Bundles.Spec.Number, clusterSpec.Cluster.Spec.KubernetesVersion)
+	}
+
+	data := map[string]interface{}{
+		"name":        Name(clusterSpec.Cluster.Name),
+		"namespace":   constants.EksaSystemNamespace,
+		"clusterName": clusterSpec.Cluster.Name,
+		"image":       image.VersionedImage(),
+	}
+
+	manifest, err := templater.Execute(clusterAutoscalerTemplate, data)
+	if err != nil {
+		return nil, fmt.Errorf("error generating cluster-autoscaler manifest: %v", err)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,35 @@
+package clusterautoscaler_test
+
+import (
+	"testing"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterautoscaler"
+)
+
+func TestGenerateManifest(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube122
+		s.VersionsBundle.ClusterAutoscaler.Image.URI = "public.ecr.aws/l0g8r8j6/kubernetes/autoscaler:v1.22.2-eks-a-v0.0.0-dev-build.1"
+	})
+
+	manifest, err := clusterautoscaler.GenerateManifest(clusterSpec)
+	if err != nil {
+		t.Fatalf("GenerateManifest() error = %v", err)
+	}
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_cluster_autoscaler.yaml")
+}
+
+func TestGenerateManifestImageNotInBundle(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube122
+	})
+
+	if _, err := clusterautoscaler.GenerateManifest(clusterSpec); err == nil {
+		t.Fatal("GenerateManifest() error = nil, want error")
+	}
+}
@@ -0,0 +1,127 @@
+package clustermanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/clusterautoscaler"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifestdiff"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// InstallClusterAutoscaler deploys the cluster-autoscaler for the workload cluster in the management cluster,
+// where the MachineDeployments it scales live. It's a no-op unless the cluster spec opts in to install it and at least
+// one worker node group has autoscaling configured
+func (c *ClusterManager) InstallClusterAutoscaler(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if !clusterSpec.Cluster.Spec.ClusterAutoscalerConfiguration.InstallClusterAutoscaler() {
+		logger.V(4).Info("Skipping cluster-autoscaler, not enabled in the cluster spec")
+		return nil
+	}
+	if !clusterapi.AutoscalingEnabled(clusterSpec.Cluster) {
+		logger.V(4).Info("Skipping cluster-autoscaler, no worker node group has autoscaling configured")
+		return nil
+	}
+
+	manifest, err := clusterautoscaler.GenerateManifest(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	err = c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, managementCluster, manifest)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error applying cluster-autoscaler manifest: %v", err)
+	}
+	return nil
+}
+
+// preserveAutoscaledReplicas keeps the replicas the autoscaler set in the existing MachineDeployments
+// so applying the new spec doesn't reset them to the worker node group count. The replicas are only
+// kept while the autoscaling bounds stay the same and the current count is within them
+func (c *ClusterManager) preserveAutoscaledReplicas(ctx context.Context, managementCluster *types.Cluster, mdContent []byte) ([]byte, error) {
+	objs, err := manifestdiff.ParseObjects(mdContent)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	for _, obj := range objs {
+		if obj.GetKind() != "MachineDeployment" {
+			continue
+		}
+		min, max, ok := autoscalingBounds(obj)
+		if !ok {
+			continue
+		}
+
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = constants.EksaSystemNamespace
+		}
+		current, err := c.clusterClient.GetUnstructuredObject(ctx, resourceType(obj), obj.GetName(), namespace, managementCluster.KubeconfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("error getting current machine deployment %s: %v", obj.GetName(), err)
+		}
+		if current == nil {
+			continue
+		}
+		currentMin, currentMax, ok := autoscalingBounds(current)
+		if !ok || currentMin != min || currentMax != max {
+			continue
+		}
+		replicas, found, err := unstructured.NestedInt64(current.Object, "spec", "replicas")
+		if err != nil || !found || replicas < min || replicas > max {
+			continue
+		}
+
+		logger.V(4).Info("Preserving machine deployment replicas set by cluster-autoscaler", "machineDeployment", obj.GetName(), "replicas", replicas)
+		if err = unstructured.SetNestedField(obj.Object, replicas, "spec", "replicas"); err != nil {
+			return nil, fmt.Errorf("error setting replicas for machine deployment %s: %v", obj.GetName(), err)
+		}
+		changed = true
+	}
+
+	if !changed {
+		return mdContent, nil
+	}
+
+	resources := make([][]byte, 0, len(objs))
+	for _, obj := range objs {
+		content, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		resources = append(resources, content)
+	}
+	return templater.AppendYamlResources(resources...), nil
+}
+
+func autoscalingBounds(obj *unstructured.Unstructured) (min, max int64, ok bool) {
+	annotations := obj.GetAnnotations()
+	minValue, minOk := annotations[clusterapi.NodeGroupMinSizeAnnotation]
+	maxValue, maxOk := annotations[clusterapi.NodeGroupMaxSizeAnnotation]
+	if !minOk || !maxOk {
+		return 0, 0, false
+	}
+	min, err := strconv.ParseInt(minValue, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	max, err = strconv.ParseInt(maxValue, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return min, max, true
+}
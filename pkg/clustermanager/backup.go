@@ -0,0 +1,187 @@
+package clustermanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifestdiff"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// eksaBackupResourceTypes are the resources saved with a management cluster backup, in the order they are restored.
+// Clusters go last so everything they reference already exists when the controller resumes reconciling them
+var eksaBackupResourceTypes = []string{
+	fmt.Sprintf("bundles.%s", releasev1alpha1.GroupVersion.Group),
+	fmt.Sprintf("releases.%s", eksdv1alpha1.GroupVersion.Group),
+	fmt.Sprintf("awsdatacenterconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("cloudstackdatacenterconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("cloudstackmachineconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("dockerdatacenterconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("snowdatacenterconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("snowmachineconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("tinkerbelldatacenterconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("tinkerbellmachineconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("tinkerbelltemplateconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("vspheredatacenterconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("vspheremachineconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("gitopsconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("oidcconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("awsiamconfigs.%s", v1alpha1.GroupVersion.Group),
+	fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group),
+}
+
+// resourceTypeNotFound is the kubectl error for resource types whose CRD is not installed in the cluster
+const resourceTypeNotFound = "the server doesn't have a resource type"
+
+// BackupCAPI saves the CAPI objects of the management cluster in directory, without removing them from the cluster
+func (c *ClusterManager) BackupCAPI(ctx context.Context, managementCluster *types.Cluster, directory string) error {
+	err := c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.BackupManagement(ctx, managementCluster, directory)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error backing up CAPI objects: %v", err)
+	}
+	return nil
+}
+
+// RestoreCAPI creates in the cluster the CAPI objects saved in directory by BackupCAPI
+func (c *ClusterManager) RestoreCAPI(ctx context.Context, cluster *types.Cluster, directory string) error {
+	if err := c.clusterClient.RestoreManagement(ctx, cluster, directory); err != nil {
+		return fmt.Errorf("error restoring CAPI objects: %v", err)
+	}
+	return nil
+}
+
+// GetEKSAResources returns a manifest with all the EKS-A resources in the management cluster, for every cluster it manages,
+// without the fields set by the api server. Resource types whose CRD is not installed in the cluster are skipped
+func (c *ClusterManager) GetEKSAResources(ctx context.Context, managementCluster *types.Cluster) ([]byte, error) {
+	var resources [][]byte
+	for _, resource := range eksaBackupResourceTypes {
+		var objs []unstructured.Unstructured
+		err := c.Retrier.Retry(
+			func() error {
+				var err error
+				objs, err = c.clusterClient.GetUnstructuredObjects(ctx, resource, executables.WithCluster(managementCluster), executables.WithAllNamespaces())
+				if err != nil && strings.Contains(err.Error(), resourceTypeNotFound) {
+					logger.V(4).Info("Resource type not installed in the cluster, skipping it in the backup", "resource", resource)
+					objs = nil
+					return nil
+				}
+				return err
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting %s: %v", resource, err)
+		}
+
+		for i := range objs {
+			obj := &objs[i]
+			removeServerFields(obj)
+			content, err := yaml.Marshal(obj.Object)
+			if err != nil {
+				return nil, fmt.Errorf("error marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+			}
+			resources = append(resources, content)
+		}
+	}
+
+	return templater.AppendYamlResources(resources...), nil
+}
+
+// RestoreEKSAResources applies the EKS-A resources returned by GetEKSAResources to the cluster. The resources are created
+// paused, so the webhooks accept them and the controller doesn't act on them until they are all in place, and then
+// resumed unless they were already paused when the backup was taken
+func (c *ClusterManager) RestoreEKSAResources(ctx context.Context, cluster *types.Cluster, resources []byte) error {
+	objs, err := manifestdiff.ParseObjects(resources)
+	if err != nil {
+		return err
+	}
+
+	pausedAnnotation := (&v1alpha1.Cluster{}).PausedAnnotation()
+	var toResume []*unstructured.Unstructured
+	namespaces := map[string]bool{}
+	content := make([][]byte, 0, len(objs))
+	for _, obj := range objs {
+		if namespace := obj.GetNamespace(); namespace != "" && !namespaces[namespace] {
+			if err = c.ensureNamespace(ctx, cluster, namespace); err != nil {
+				return err
+			}
+			namespaces[namespace] = true
+		}
+
+		if pausable(obj) {
+			annotations := obj.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			if _, paused := annotations[pausedAnnotation]; !paused {
+				toResume = append(toResume, obj)
+			}
+			annotations[pausedAnnotation] = "true"
+			obj.SetAnnotations(annotations)
+		}
+
+		objContent, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("error marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		content = append(content, objContent)
+	}
+
+	logger.V(4).Info("Applying backed up eksa resources to cluster")
+	if err = c.applyResource(ctx, cluster, templater.AppendYamlResources(content...)); err != nil {
+		return err
+	}
+
+	for _, obj := range toResume {
+		err = c.Retrier.Retry(
+			func() error {
+				return c.clusterClient.RemoveAnnotationInNamespace(ctx, resourceType(obj), obj.GetName(), pausedAnnotation, cluster, obj.GetNamespace())
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error resuming reconciliation for %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) ensureNamespace(ctx context.Context, cluster *types.Cluster, namespace string) error {
+	if err := c.clusterClient.GetNamespace(ctx, cluster.KubeconfigFile, namespace); err != nil {
+		return c.clusterClient.CreateNamespace(ctx, cluster.KubeconfigFile, namespace)
+	}
+	return nil
+}
+
+// pausable returns true for the EKS-A objects the controller and webhooks stop acting on when they have the paused annotation
+func pausable(obj *unstructured.Unstructured) bool {
+	return obj.GroupVersionKind().Group == v1alpha1.GroupVersion.Group && obj.GetKind() != releasev1alpha1.BundlesKind
+}
+
+func removeServerFields(obj *unstructured.Unstructured) {
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetGeneration(0)
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	obj.SetManagedFields(nil)
+	obj.SetSelfLink("")
+	obj.SetOwnerReferences(nil)
+	annotations := obj.GetAnnotations()
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	obj.SetAnnotations(annotations)
+	unstructured.RemoveNestedField(obj.Object, "status")
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/yaml"
 
@@ -55,6 +57,8 @@ type ClusterManager struct {
 
 type ClusterClient interface {
 	MoveManagement(ctx context.Context, org, target *types.Cluster) error
+	BackupManagement(ctx context.Context, cluster *types.Cluster, directory string) error
+	RestoreManagement(ctx context.Context, cluster *types.Cluster, directory string) error
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, cluster *types.Cluster, data []byte, namespace string) error
 	ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error
@@ -90,6 +94,12 @@ type ClusterClient interface {
 	DeleteOldWorkerNodeGroup(ctx context.Context, machineDeployment *clusterv1.MachineDeployment, kubeconfig string) error
 	GetMachineDeployment(ctx context.Context, workerNodeGroupName string, opts ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error)
 	GetEksdRelease(ctx context.Context, name, namespace, kubeconfigFile string) (*eksdv1alpha1.Release, error)
+	GetUnstructuredObject(ctx context.Context, resourceType, name, namespace, kubeconfig string) (*unstructured.Unstructured, error)
+	GetUnstructuredObjects(ctx context.Context, resourceType string, opts ...executables.KubectlOpt) ([]unstructured.Unstructured, error)
+	GetNodes(ctx context.Context, kubeconfig string) ([]corev1.Node, error)
+	DrainNode(ctx context.Context, nodeName, kubeconfig string) error
+	UncordonNode(ctx context.Context, nodeName, kubeconfig string) error
+	DeletePodsOnNode(ctx context.Context, nodeName, kubeconfig string) error
 }
 
 type Networking interface {
@@ -97,6 +107,15 @@ type Networking interface {
 	Upgrade(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
 }
 
+// NetworkingMigrator is implemented by the Networking that can replace the CNI running in an existing cluster.
+// The new CNI is installed first, then each node is moved to it with MigrateNode, and the previous CNI is
+// removed once all nodes have been migrated
+type NetworkingMigrator interface {
+	InstallForMigration(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) error
+	MigrateNode(ctx context.Context, cluster *types.Cluster, nodeName string) error
+	CompleteMigration(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
+}
+
 type AwsIamAuth interface {
 	GenerateManifest(clusterSpec *cluster.Spec) ([]byte, error)
 	GenerateCertKeyPairSecret() ([]byte, error)
@@ -342,6 +361,11 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return fmt.Errorf("error generating capi spec: %v", err)
 	}
 
+	mdContent, err = c.preserveAutoscaledReplicas(ctx, managementCluster, mdContent)
+	if err != nil {
+		return err
+	}
+
 	if err = c.writeCAPISpecFile(newClusterSpec.Cluster.Name, templater.AppendYamlResources(cpContent, mdContent)); err != nil {
 		return err
 	}
@@ -394,13 +418,8 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return fmt.Errorf("error waiting for workload cluster control plane replicas to be ready: %v", err)
 	}
 
-	err = c.Retrier.Retry(
-		func() error {
-			return c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, mdContent, constants.EksaSystemNamespace)
-		},
-	)
-	if err != nil {
-		return fmt.Errorf("error applying capi machine deployment spec: %v", err)
+	if err = c.applyWorkerNodeGroups(ctx, managementCluster, newClusterSpec, mdContent); err != nil {
+		return err
 	}
 
 	if err = c.removeOldWorkerNodeGroups(ctx, managementCluster, provider, currentSpec, newClusterSpec); err != nil {
@@ -566,9 +585,90 @@ func (c *ClusterManager) InstallNetworking(ctx context.Context, cluster *types.C
 }
 
 func (c *ClusterManager) UpgradeNetworking(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error) {
+	if v1alpha1.KindnetdToCiliumMigration(newSpec.Cluster.Spec.ClusterNetwork, currentSpec.Cluster.Spec.ClusterNetwork) {
+		return c.migrateNetworking(ctx, cluster, currentSpec, newSpec)
+	}
 	return c.networking.Upgrade(ctx, cluster, currentSpec, newSpec)
 }
 
+// migrateNetworking installs the new CNI next to the current one, rolls the nodes so every pod is recreated
+// with an address from the new CNI and then removes the old one
+func (c *ClusterManager) migrateNetworking(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error) {
+	migrator, ok := c.networking.(NetworkingMigrator)
+	if !ok {
+		return nil, errors.New("networking doesn't support migrating the CNI of an existing cluster")
+	}
+
+	logger.V(1).Info("Migrating cluster networking from Kindnetd to Cilium")
+	if err := migrator.InstallForMigration(ctx, cluster, currentSpec, newSpec); err != nil {
+		return nil, fmt.Errorf("error installing new CNI: %v", err)
+	}
+
+	if err := c.migrateNodes(ctx, cluster, migrator); err != nil {
+		return nil, err
+	}
+
+	changeDiff, err := migrator.CompleteMigration(ctx, cluster, currentSpec, newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("error removing previous CNI: %v", err)
+	}
+
+	return changeDiff, nil
+}
+
+// migrateNodes moves the nodes of a cluster to the new CNI one at a time. Each node is drained, so its pods are
+// rescheduled in nodes not being migrated, then moved to the new CNI, and the pods still running in it, the ones
+// managed by a DaemonSet, are recreated to get an address from the new CNI before the node is uncordoned
+func (c *ClusterManager) migrateNodes(ctx context.Context, cluster *types.Cluster, migrator NetworkingMigrator) error {
+	var nodes []corev1.Node
+	err := c.Retrier.Retry(
+		func() error {
+			var err error
+			nodes, err = c.clusterClient.GetNodes(ctx, cluster.KubeconfigFile)
+			return err
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error getting nodes to migrate: %v", err)
+	}
+
+	for _, node := range nodes {
+		logger.V(3).Info("Migrating node", "node", node.Name)
+		migrateErr := c.migrateNode(ctx, cluster, migrator, node.Name)
+
+		// Uncordon the node even if the migration failed so it isn't left unschedulable
+		err := c.Retrier.Retry(
+			func() error {
+				return c.clusterClient.UncordonNode(ctx, node.Name, cluster.KubeconfigFile)
+			},
+		)
+		if migrateErr != nil {
+			return fmt.Errorf("error migrating node %s: %v", node.Name, migrateErr)
+		}
+		if err != nil {
+			return fmt.Errorf("error migrating node %s: %v", node.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) migrateNode(ctx context.Context, cluster *types.Cluster, migrator NetworkingMigrator, nodeName string) error {
+	if err := c.clusterClient.DrainNode(ctx, nodeName, cluster.KubeconfigFile); err != nil {
+		return err
+	}
+
+	if err := migrator.MigrateNode(ctx, cluster, nodeName); err != nil {
+		return err
+	}
+
+	return c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.DeletePodsOnNode(ctx, nodeName, cluster.KubeconfigFile)
+		},
+	)
+}
+
 func getProviderNamespaces(providerDeployments map[string][]string) []string {
 	namespaces := make([]string, 0, len(providerDeployments))
 	for namespace := range providerDeployments {
@@ -652,6 +752,17 @@ func (c *ClusterManager) CreateAwsIamAuthCaSecret(ctx context.Context, cluster *
 	return nil
 }
 
+// RefreshWorkloadKubeconfig writes again the kubeconfig file of the workload cluster from its CAPI kubeconfig secret,
+// so it picks up a client certificate renewed after the cluster certificates have been rotated
+func (c *ClusterManager) RefreshWorkloadKubeconfig(ctx context.Context, managementCluster, workloadCluster *types.Cluster, provider providers.Provider) error {
+	kubeconfigFile, err := c.generateWorkloadKubeconfig(ctx, workloadCluster.Name, managementCluster, provider)
+	if err != nil {
+		return err
+	}
+	logger.V(3).Info("Refreshed workload cluster kubeconfig", "kubeconfig", kubeconfigFile)
+	return nil
+}
+
 func (c *ClusterManager) generateAwsIamAuthKubeconfig(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	fileName := fmt.Sprintf("%s-aws.kubeconfig", workloadCluster.Name)
 	serverUrl, err := c.clusterClient.GetApiServerUrl(ctx, workloadCluster)
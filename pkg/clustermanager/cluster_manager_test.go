@@ -11,7 +11,9 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -23,9 +25,11 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	mocksdiagnostics "github.com/aws/eks-anywhere/pkg/diagnostics/interfaces/mocks"
 	mockswriter "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/manifestdiff"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	mocksprovider "github.com/aws/eks-anywhere/pkg/providers/mocks"
 	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -493,6 +497,91 @@ func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerUpgradeWorkloadClusterStagedWorkerNodeGroups(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	md0 := []byte(`apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: cluster-name-md-0
+spec:
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          kind: KubeadmConfigTemplate
+          name: cluster-name-md-0-1
+      infrastructureRef:
+        kind: VSphereMachineTemplate
+        name: cluster-name-md-0-1
+`)
+	md1 := []byte(`apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: cluster-name-md-1
+spec:
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          kind: KubeadmConfigTemplate
+          name: cluster-name-md-1-1
+      infrastructureRef:
+        kind: VSphereMachineTemplate
+        name: cluster-name-md-1-1
+`)
+	md0Config := []byte(`apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: cluster-name-md-0-1
+`)
+	md1Config := []byte(`apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: cluster-name-md-1-1
+`)
+	md1MachineTemplate := []byte(`apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: VSphereMachineTemplate
+metadata:
+  name: cluster-name-md-1-1
+`)
+	mdContent := templater.AppendYamlResources(md0, md1, md0Config, md1Config, md1MachineTemplate)
+	md0Content := templater.AppendYamlResources(md0, md0Config)
+	md1Content := templater.AppendYamlResources(md1, md1Config, md1MachineTemplate)
+
+	tt := newSpecChangedTest(t)
+	kube118 := v1alpha1.Kube118
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubernetesVersion = &kube118
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any())
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, gomock.Any(), tt.clusterSpec).Return([]byte("cp"), mdContent, nil)
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, []byte("cp"), constants.EksaSystemNamespace),
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, md0Content, constants.EksaSystemNamespace),
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, md1Content, constants.EksaSystemNamespace),
+	)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, gomock.Any(), tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "60m", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(4)
+	tt.mocks.provider.EXPECT().MachineDeploymentsToDelete(wCluster, gomock.Any(), tt.clusterSpec).Return([]string{})
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, wCluster.Name, mCluster.KubeconfigFile).Return(nil).Times(3)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
 func TestClusterManagerUpgradeWorkloadClusterWaitForMachinesTimeout(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "cluster-name"
@@ -1190,3 +1279,473 @@ func TestClusterManagerGetCurrentClusterSpecGetBundlesError(t *testing.T) {
 	_, err := tt.clusterManager.GetCurrentClusterSpec(tt.ctx, tt.cluster, tt.clusterName)
 	tt.Expect(err).ToNot(BeNil())
 }
+
+func TestClusterManagerDryRunUpgradeCluster(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	cpContent := []byte(`apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: cluster-name
+  namespace: eksa-system
+spec:
+  version: v1.22.6-eks-1-22-1
+`)
+	mdContent := []byte(`apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: VSphereMachineTemplate
+metadata:
+  name: cluster-name-md-0-2
+  namespace: eksa-system
+`)
+	currentKCP := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "controlplane.cluster.x-k8s.io/v1beta1",
+		"kind":       "KubeadmControlPlane",
+		"metadata":   map[string]interface{}{"name": "cluster-name", "namespace": "eksa-system"},
+		"spec":       map[string]interface{}{"version": "v1.21.2-eks-1-21-4"},
+	}}
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.VSphereDatacenterKind},
+		ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName},
+	}
+	tt.clusterSpec.Cluster.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.ClusterKind}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any())
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, gomock.Any(), tt.clusterSpec).Return(cpContent, mdContent, nil)
+	tt.mocks.provider.EXPECT().DatacenterConfig(tt.clusterSpec).Return(datacenterConfig)
+	tt.mocks.provider.EXPECT().MachineConfigs(tt.clusterSpec).Return(nil)
+	tt.mocks.client.EXPECT().GetUnstructuredObject(tt.ctx, "kubeadmcontrolplane.controlplane.cluster.x-k8s.io", "cluster-name", "eksa-system", tt.cluster.KubeconfigFile).Return(currentKCP, nil)
+	tt.mocks.client.EXPECT().GetUnstructuredObject(tt.ctx, "vspheremachinetemplate.infrastructure.cluster.x-k8s.io", "cluster-name-md-0-2", "eksa-system", tt.cluster.KubeconfigFile).Return(nil, nil)
+	tt.mocks.client.EXPECT().GetUnstructuredObject(tt.ctx, "cluster.anywhere.eks.amazonaws.com", tt.clusterName, "default", tt.cluster.KubeconfigFile).Return(nil, nil)
+	tt.mocks.client.EXPECT().GetUnstructuredObject(tt.ctx, "vspheredatacenterconfig.anywhere.eks.amazonaws.com", tt.clusterName, "default", tt.cluster.KubeconfigFile).Return(nil, nil)
+
+	diffs, err := tt.clusterManager.DryRunUpgradeCluster(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(diffs).To(HaveLen(4))
+	tt.Expect(diffs[0].Operation).To(Equal(manifestdiff.Update))
+	tt.Expect(diffs[0].RolloutReasons).To(ConsistOf("spec.version changed"))
+	tt.Expect(diffs[1].Operation).To(Equal(manifestdiff.Create))
+	tt.Expect(diffs[1].RollingReplacement).To(BeTrue())
+	tt.Expect(diffs[2].Kind).To(Equal(v1alpha1.ClusterKind))
+}
+
+func TestClusterManagerInstallClusterAutoscaler(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+		{
+			Name:                     "md-0",
+			Count:                    1,
+			AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+		},
+	}
+	tt.clusterSpec.Cluster.Spec.ClusterAutoscalerConfiguration = &v1alpha1.ClusterAutoscalerConfiguration{Install: true}
+	tt.clusterSpec.VersionsBundle.ClusterAutoscaler.Image.URI = "public.ecr.aws/l0g8r8j6/kubernetes/autoscaler:v1.22.2-eks-a-v0.0.0-dev-build.1"
+
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any())
+
+	tt.Expect(tt.clusterManager.InstallClusterAutoscaler(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerInstallClusterAutoscalerNotEnabled(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+		{
+			Name:                     "md-0",
+			Count:                    1,
+			AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+		},
+	}
+
+	tt.Expect(tt.clusterManager.InstallClusterAutoscaler(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerInstallClusterAutoscalerNoAutoscaling(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+		{Name: "md-0", Count: 1},
+	}
+	tt.clusterSpec.Cluster.Spec.ClusterAutoscalerConfiguration = &v1alpha1.ClusterAutoscalerConfiguration{Install: true}
+
+	tt.Expect(tt.clusterManager.InstallClusterAutoscaler(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerInstallClusterAutoscalerMissingImage(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+		{
+			Name:                     "md-0",
+			Count:                    1,
+			AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3},
+		},
+	}
+	tt.clusterSpec.Cluster.Spec.ClusterAutoscalerConfiguration = &v1alpha1.ClusterAutoscalerConfiguration{Install: true}
+
+	tt.Expect(tt.clusterManager.InstallClusterAutoscaler(tt.ctx, tt.cluster, tt.clusterSpec)).To(MatchError(ContainSubstring("cluster-autoscaler image is not available")))
+}
+
+func TestClusterManagerDryRunUpgradeClusterPreservesAutoscaledReplicas(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	mdContent := []byte(`apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  annotations:
+    cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size: "5"
+    cluster.x-k8s.io/cluster-api-autoscaler-node-group-min-size: "1"
+  name: cluster-name-md-0
+  namespace: eksa-system
+spec:
+  replicas: 1
+`)
+	currentMD := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "cluster.x-k8s.io/v1beta1",
+			"kind":       "MachineDeployment",
+			"metadata": map[string]interface{}{
+				"name":      "cluster-name-md-0",
+				"namespace": "eksa-system",
+				"annotations": map[string]interface{}{
+					"cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size": "5",
+					"cluster.x-k8s.io/cluster-api-autoscaler-node-group-min-size": "1",
+				},
+			},
+			"spec": map[string]interface{}{"replicas": int64(4)},
+		}}
+	}
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.VSphereDatacenterKind},
+		ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName},
+	}
+	tt.clusterSpec.Cluster.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.ClusterKind}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any())
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, gomock.Any(), tt.clusterSpec).Return(nil, mdContent, nil)
+	tt.mocks.provider.EXPECT().DatacenterConfig(tt.clusterSpec).Return(datacenterConfig)
+	tt.mocks.provider.EXPECT().MachineConfigs(tt.clusterSpec).Return(nil)
+	tt.mocks.client.EXPECT().GetUnstructuredObject(tt.ctx, "machinedeployment.cluster.x-k8s.io", "cluster-name-md-0", "eksa-system", tt.cluster.KubeconfigFile).Return(currentMD(), nil).Times(2)
+	tt.mocks.client.EXPECT().GetUnstructuredObject(tt.ctx, "cluster.anywhere.eks.amazonaws.com", tt.clusterName, "default", tt.cluster.KubeconfigFile).Return(nil, nil)
+	tt.mocks.client.EXPECT().GetUnstructuredObject(tt.ctx, "vspheredatacenterconfig.anywhere.eks.amazonaws.com", tt.clusterName, "default", tt.cluster.KubeconfigFile).Return(nil, nil)
+
+	diffs, err := tt.clusterManager.DryRunUpgradeCluster(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(diffs[0].Kind).To(Equal("MachineDeployment"))
+	tt.Expect(diffs[0].Operation).To(Equal(manifestdiff.Unchanged))
+}
+
+func TestClusterManagerBackupCAPI(t *testing.T) {
+	tt := newTest(t)
+	tt.mocks.client.EXPECT().BackupManagement(tt.ctx, tt.cluster, "backup/capi")
+
+	tt.Expect(tt.clusterManager.BackupCAPI(tt.ctx, tt.cluster, "backup/capi")).To(Succeed())
+}
+
+func TestClusterManagerBackupCAPIError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	tt.mocks.client.EXPECT().BackupManagement(tt.ctx, tt.cluster, "backup/capi").Return(errors.New("error from client"))
+
+	tt.Expect(tt.clusterManager.BackupCAPI(tt.ctx, tt.cluster, "backup/capi")).To(MatchError(ContainSubstring("error backing up CAPI objects: error from client")))
+}
+
+func TestClusterManagerRestoreCAPI(t *testing.T) {
+	tt := newTest(t)
+	tt.mocks.client.EXPECT().RestoreManagement(tt.ctx, tt.cluster, "backup/capi")
+
+	tt.Expect(tt.clusterManager.RestoreCAPI(tt.ctx, tt.cluster, "backup/capi")).To(Succeed())
+}
+
+func TestClusterManagerGetEKSAResources(t *testing.T) {
+	tt := newTest(t)
+	tt.cluster.KubeconfigFile = "kubeconfig"
+	cluster := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "anywhere.eks.amazonaws.com/v1alpha1",
+		"kind":       "Cluster",
+		"metadata": map[string]interface{}{
+			"name":              "mgmt",
+			"namespace":         "default",
+			"resourceVersion":   "1234",
+			"uid":               "2a4fd9d4-f5a4-4a9c-a1b7-4a4c5a1d1ec8",
+			"generation":        int64(2),
+			"creationTimestamp": "2022-03-01T10:00:00Z",
+			"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"anywhere.eks.amazonaws.com/managed-by-cli":        "true",
+			},
+		},
+		"spec":   map[string]interface{}{"kubernetesVersion": "1.22"},
+		"status": map[string]interface{}{"failureMessage": "failed"},
+	}}
+
+	tt.mocks.client.EXPECT().GetUnstructuredObjects(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, resourceType string, _ ...interface{}) ([]unstructured.Unstructured, error) {
+			if resourceType == eksaClusterResourceType {
+				return []unstructured.Unstructured{cluster}, nil
+			}
+			return nil, nil
+		},
+	).Times(17)
+
+	resources, err := tt.clusterManager.GetEKSAResources(tt.ctx, tt.cluster)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(string(resources)).To(Equal(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  annotations:
+    anywhere.eks.amazonaws.com/managed-by-cli: "true"
+  name: mgmt
+  namespace: default
+spec:
+  kubernetesVersion: "1.22"
+
+---
+`))
+}
+
+func TestClusterManagerGetEKSAResourcesSkipsMissingResourceTypes(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	tt.mocks.client.EXPECT().GetUnstructuredObjects(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, resourceType string, _ ...interface{}) ([]unstructured.Unstructured, error) {
+			if resourceType == "snowmachineconfigs.anywhere.eks.amazonaws.com" {
+				return nil, errors.New(`error getting snowmachineconfigs.anywhere.eks.amazonaws.com with kubectl: error: the server doesn't have a resource type "snowmachineconfigs"`)
+			}
+			return nil, nil
+		},
+	).Times(17)
+
+	resources, err := tt.clusterManager.GetEKSAResources(tt.ctx, tt.cluster)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(resources).To(BeEmpty())
+}
+
+func TestClusterManagerGetEKSAResourcesError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	tt.mocks.client.EXPECT().GetUnstructuredObjects(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error from client"))
+
+	_, err := tt.clusterManager.GetEKSAResources(tt.ctx, tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("error getting bundles.anywhere.eks.amazonaws.com: error from client")))
+}
+
+func TestClusterManagerRestoreEKSAResources(t *testing.T) {
+	tt := newTest(t)
+	tt.cluster.KubeconfigFile = "kubeconfig"
+	resources := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Bundles
+metadata:
+  name: mgmt
+  namespace: default
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereDatacenterConfig
+metadata:
+  name: mgmt
+  namespace: default
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  annotations:
+    anywhere.eks.amazonaws.com/paused: "true"
+  name: workload
+  namespace: workloads
+`)
+
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().GetNamespace(tt.ctx, "kubeconfig", "default"),
+		tt.mocks.client.EXPECT().GetNamespace(tt.ctx, "kubeconfig", "workloads").Return(errors.New("not found")),
+		tt.mocks.client.EXPECT().CreateNamespace(tt.ctx, "kubeconfig", "workloads"),
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesForce(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *types.Cluster, data []byte) error {
+				objs, err := manifestdiff.ParseObjects(data)
+				tt.Expect(err).To(Succeed())
+				tt.Expect(objs).To(HaveLen(3))
+				tt.Expect(objs[0].GetAnnotations()).To(BeEmpty())
+				tt.Expect(objs[1].GetAnnotations()).To(HaveKeyWithValue("anywhere.eks.amazonaws.com/paused", "true"))
+				tt.Expect(objs[2].GetAnnotations()).To(HaveKeyWithValue("anywhere.eks.amazonaws.com/paused", "true"))
+				return nil
+			},
+		),
+		tt.mocks.client.EXPECT().RemoveAnnotationInNamespace(
+			tt.ctx, "vspheredatacenterconfig.anywhere.eks.amazonaws.com", "mgmt", "anywhere.eks.amazonaws.com/paused", tt.cluster, "default",
+		),
+	)
+
+	tt.Expect(tt.clusterManager.RestoreEKSAResources(tt.ctx, tt.cluster, resources)).To(Succeed())
+}
+
+func TestClusterManagerRestoreEKSAResourcesApplyError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	resources := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: mgmt
+`)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesForce(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("error from client"))
+
+	tt.Expect(tt.clusterManager.RestoreEKSAResources(tt.ctx, tt.cluster, resources)).To(MatchError(ContainSubstring("error applying eks-a spec: error from client")))
+}
+
+type migratingNetworking struct {
+	*mocksmanager.MockNetworking
+	*mocksmanager.MockNetworkingMigrator
+}
+
+type migrationTest struct {
+	*testSetup
+	migrator             *mocksmanager.MockNetworkingMigrator
+	currentSpec, newSpec *cluster.Spec
+	nodes                []corev1.Node
+}
+
+func newMigrationTest(t *testing.T) *migrationTest {
+	mockCtrl := gomock.NewController(t)
+	m := &clusterManagerMocks{
+		writer:             mockswriter.NewMockFileWriter(mockCtrl),
+		networking:         mocksmanager.NewMockNetworking(mockCtrl),
+		awsIamAuth:         mocksmanager.NewMockAwsIamAuth(mockCtrl),
+		client:             mocksmanager.NewMockClusterClient(mockCtrl),
+		provider:           mocksprovider.NewMockProvider(mockCtrl),
+		diagnosticsFactory: mocksdiagnostics.NewMockDiagnosticBundleFactory(mockCtrl),
+		diagnosticsBundle:  mocksdiagnostics.NewMockDiagnosticBundle(mockCtrl),
+	}
+	migrator := mocksmanager.NewMockNetworkingMigrator(mockCtrl)
+	networking := &migratingNetworking{MockNetworking: m.networking, MockNetworkingMigrator: migrator}
+	c := clustermanager.New(m.client, networking, m.writer, m.diagnosticsFactory, m.awsIamAuth, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+
+	return &migrationTest{
+		testSetup: &testSetup{
+			WithT:          NewWithT(t),
+			clusterManager: c,
+			mocks:          m,
+			ctx:            context.Background(),
+			cluster: &types.Cluster{
+				Name:           "cluster-name",
+				KubeconfigFile: "kubeconfig",
+			},
+			clusterName: "cluster-name",
+		},
+		migrator: migrator,
+		currentSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Kindnetd: &v1alpha1.KindnetdConfig{}}
+		}),
+		newSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}
+		}),
+		nodes: []corev1.Node{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+		},
+	}
+}
+
+func TestClusterManagerUpgradeNetworkingNoMigration(t *testing.T) {
+	tt := newTest(t)
+	currentSpec := tt.clusterSpec.DeepCopy()
+	wantDiff := types.NewChangeDiff(&types.ComponentChangeDiff{ComponentName: "cilium"})
+	tt.mocks.networking.EXPECT().Upgrade(tt.ctx, tt.cluster, currentSpec, tt.clusterSpec).Return(wantDiff, nil)
+
+	tt.Expect(tt.clusterManager.UpgradeNetworking(tt.ctx, tt.cluster, currentSpec, tt.clusterSpec)).To(Equal(wantDiff))
+}
+
+func TestClusterManagerUpgradeNetworkingMigrationSuccess(t *testing.T) {
+	tt := newMigrationTest(t)
+	wantDiff := types.NewChangeDiff(&types.ComponentChangeDiff{ComponentName: "cilium", NewVersion: "v1.9.13-eksa.2"})
+	gomock.InOrder(
+		tt.migrator.EXPECT().InstallForMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec),
+		tt.mocks.client.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(tt.nodes, nil),
+		tt.mocks.client.EXPECT().DrainNode(tt.ctx, "node-1", tt.cluster.KubeconfigFile),
+		tt.migrator.EXPECT().MigrateNode(tt.ctx, tt.cluster, "node-1"),
+		tt.mocks.client.EXPECT().DeletePodsOnNode(tt.ctx, "node-1", tt.cluster.KubeconfigFile),
+		tt.mocks.client.EXPECT().UncordonNode(tt.ctx, "node-1", tt.cluster.KubeconfigFile),
+		tt.mocks.client.EXPECT().DrainNode(tt.ctx, "node-2", tt.cluster.KubeconfigFile),
+		tt.migrator.EXPECT().MigrateNode(tt.ctx, tt.cluster, "node-2"),
+		tt.mocks.client.EXPECT().DeletePodsOnNode(tt.ctx, "node-2", tt.cluster.KubeconfigFile),
+		tt.mocks.client.EXPECT().UncordonNode(tt.ctx, "node-2", tt.cluster.KubeconfigFile),
+		tt.migrator.EXPECT().CompleteMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec).Return(wantDiff, nil),
+	)
+
+	tt.Expect(tt.clusterManager.UpgradeNetworking(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(wantDiff))
+}
+
+func TestClusterManagerUpgradeNetworkingMigrationDrainError(t *testing.T) {
+	tt := newMigrationTest(t)
+	gomock.InOrder(
+		tt.migrator.EXPECT().InstallForMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec),
+		tt.mocks.client.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(tt.nodes, nil),
+		tt.mocks.client.EXPECT().DrainNode(tt.ctx, "node-1", tt.cluster.KubeconfigFile).Return(errors.New("error draining")),
+		tt.mocks.client.EXPECT().UncordonNode(tt.ctx, "node-1", tt.cluster.KubeconfigFile),
+	)
+
+	_, err := tt.clusterManager.UpgradeNetworking(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("error migrating node node-1: error draining")))
+}
+
+func TestClusterManagerUpgradeNetworkingMigrationMigrateNodeError(t *testing.T) {
+	tt := newMigrationTest(t)
+	gomock.InOrder(
+		tt.migrator.EXPECT().InstallForMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec),
+		tt.mocks.client.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(tt.nodes, nil),
+		tt.mocks.client.EXPECT().DrainNode(tt.ctx, "node-1", tt.cluster.KubeconfigFile),
+		tt.migrator.EXPECT().MigrateNode(tt.ctx, tt.cluster, "node-1").Return(errors.New("agent not ready")),
+		tt.mocks.client.EXPECT().UncordonNode(tt.ctx, "node-1", tt.cluster.KubeconfigFile),
+	)
+
+	_, err := tt.clusterManager.UpgradeNetworking(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("error migrating node node-1: agent not ready")))
+}
+
+func TestClusterManagerUpgradeNetworkingMigrationDeletePodsError(t *testing.T) {
+	tt := newMigrationTest(t)
+	gomock.InOrder(
+		tt.migrator.EXPECT().InstallForMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec),
+		tt.mocks.client.EXPECT().GetNodes(tt.ctx, tt.cluster.KubeconfigFile).Return(tt.nodes, nil),
+		tt.mocks.client.EXPECT().DrainNode(tt.ctx, "node-1", tt.cluster.KubeconfigFile),
+		tt.migrator.EXPECT().MigrateNode(tt.ctx, tt.cluster, "node-1"),
+		tt.mocks.client.EXPECT().DeletePodsOnNode(tt.ctx, "node-1", tt.cluster.KubeconfigFile).Return(errors.New("error deleting pods")),
+		tt.mocks.client.EXPECT().UncordonNode(tt.ctx, "node-1", tt.cluster.KubeconfigFile),
+	)
+
+	_, err := tt.clusterManager.UpgradeNetworking(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("error migrating node node-1: error deleting pods")))
+}
+
+func TestClusterManagerUpgradeNetworkingMigrationInstallError(t *testing.T) {
+	tt := newMigrationTest(t)
+	tt.migrator.EXPECT().InstallForMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec).Return(errors.New("error installing"))
+
+	_, err := tt.clusterManager.UpgradeNetworking(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("error installing new CNI")))
+}
+
+func TestClusterManagerUpgradeNetworkingMigrationNotSupported(t *testing.T) {
+	tt := newTest(t)
+	currentSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Kindnetd: &v1alpha1.KindnetdConfig{}}
+	})
+	newSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}
+	})
+
+	_, err := tt.clusterManager.UpgradeNetworking(tt.ctx, tt.cluster, currentSpec, newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("doesn't support migrating the CNI")))
+}
+
+func TestClusterManagerRefreshWorkloadKubeconfigSuccess(t *testing.T) {
+	tt := newTest(t)
+	managementCluster := &types.Cluster{Name: "management-cluster", KubeconfigFile: "mgmt.kubeconfig"}
+	kubeconfig := []byte("content")
+	tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, tt.clusterName, managementCluster).Return(kubeconfig, nil)
+	tt.mocks.provider.EXPECT().UpdateKubeConfig(&kubeconfig, tt.clusterName)
+	tt.mocks.writer.EXPECT().Write(tt.clusterName+"-eks-a-cluster.kubeconfig", kubeconfig, gomock.Not(gomock.Nil())).Return("kubeconfig", nil)
+
+	tt.Expect(tt.clusterManager.RefreshWorkloadKubeconfig(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider)).To(Succeed())
+}
+
+func TestClusterManagerRefreshWorkloadKubeconfigError(t *testing.T) {
+	tt := newTest(t)
+	managementCluster := &types.Cluster{Name: "management-cluster", KubeconfigFile: "mgmt.kubeconfig"}
+	tt.mocks.client.EXPECT().GetWorkloadKubeconfig(tt.ctx, tt.clusterName, managementCluster).Return(nil, errors.New("error getting kubeconfig"))
+
+	tt.Expect(tt.clusterManager.RefreshWorkloadKubeconfig(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider)).To(MatchError(ContainSubstring("error getting workload kubeconfig")))
+}
@@ -0,0 +1,93 @@
+package clustermanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/manifestdiff"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// DryRunUpgradeCluster renders the CAPI and EKS-A objects UpgradeCluster would apply and compares them
+// with the live objects in the management cluster, without changing anything
+func (c *ClusterManager) DryRunUpgradeCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) ([]manifestdiff.ObjectDiff, error) {
+	currentSpec, err := c.GetCurrentClusterSpec(ctx, workloadCluster, newClusterSpec.Cluster.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting current cluster spec: %v", err)
+	}
+
+	cpContent, mdContent, err := provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, workloadCluster, currentSpec, newClusterSpec)
+	if err != nil {
+		return nil, fmt.Errorf("error generating capi spec: %v", err)
+	}
+
+	mdContent, err = c.preserveAutoscaledReplicas(ctx, managementCluster, mdContent)
+	if err != nil {
+		return nil, err
+	}
+
+	capiObjs, err := manifestdiff.ParseObjects(templater.AppendYamlResources(cpContent, mdContent))
+	if err != nil {
+		return nil, err
+	}
+
+	eksaContent, err := clustermarshaller.MarshalClusterSpec(newClusterSpec, provider.DatacenterConfig(newClusterSpec), provider.MachineConfigs(newClusterSpec))
+	if err != nil {
+		return nil, err
+	}
+	eksaObjs, err := manifestdiff.ParseObjects(eksaContent)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]manifestdiff.ObjectDiff, 0, len(capiObjs)+len(eksaObjs))
+	for _, obj := range capiObjs {
+		diff, err := c.diffObject(ctx, managementCluster, obj, constants.EksaSystemNamespace)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+
+	eksaNamespace := newClusterSpec.Cluster.Namespace
+	if eksaNamespace == "" {
+		eksaNamespace = constants.DefaultNamespace
+	}
+	for _, obj := range eksaObjs {
+		diff, err := c.diffObject(ctx, managementCluster, obj, eksaNamespace)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return diffs, nil
+}
+
+func (c *ClusterManager) diffObject(ctx context.Context, cluster *types.Cluster, desired *unstructured.Unstructured, defaultNamespace string) (manifestdiff.ObjectDiff, error) {
+	if desired.GetNamespace() == "" {
+		desired.SetNamespace(defaultNamespace)
+	}
+
+	current, err := c.clusterClient.GetUnstructuredObject(ctx, resourceType(desired), desired.GetName(), desired.GetNamespace(), cluster.KubeconfigFile)
+	if err != nil {
+		return manifestdiff.ObjectDiff{}, fmt.Errorf("error getting current %s %s: %v", desired.GetKind(), desired.GetName(), err)
+	}
+
+	return manifestdiff.Objects(desired, current), nil
+}
+
+// resourceType returns the fully qualified kubectl resource type for the object, ex. kubeadmcontrolplane.controlplane.cluster.x-k8s.io
+func resourceType(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	return schema.GroupKind{Group: gvk.Group, Kind: strings.ToLower(gvk.Kind)}.String()
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/clustermanager (interfaces: ClusterClient,Networking,AwsIamAuth)
+// Source: github.com/aws/eks-anywhere/pkg/clustermanager (interfaces: ClusterClient,Networking,NetworkingMigrator,AwsIamAuth)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -17,6 +17,8 @@ import (
 	v1alpha10 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 	v1alpha11 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	v1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -85,6 +87,20 @@ func (mr *MockClusterClientMockRecorder) ApplyKubeSpecFromBytesWithNamespace(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytesWithNamespace", reflect.TypeOf((*MockClusterClient)(nil).ApplyKubeSpecFromBytesWithNamespace), arg0, arg1, arg2, arg3)
 }
 
+// BackupManagement mocks base method.
+func (m *MockClusterClient) BackupManagement(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupManagement", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackupManagement indicates an expected call of BackupManagement.
+func (mr *MockClusterClientMockRecorder) BackupManagement(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupManagement", reflect.TypeOf((*MockClusterClient)(nil).BackupManagement), arg0, arg1, arg2)
+}
+
 // CreateNamespace mocks base method.
 func (m *MockClusterClient) CreateNamespace(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -183,6 +199,34 @@ func (mr *MockClusterClientMockRecorder) DeleteOldWorkerNodeGroup(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldWorkerNodeGroup", reflect.TypeOf((*MockClusterClient)(nil).DeleteOldWorkerNodeGroup), arg0, arg1, arg2)
 }
 
+// DeletePodsOnNode mocks base method.
+func (m *MockClusterClient) DeletePodsOnNode(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePodsOnNode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePodsOnNode indicates an expected call of DeletePodsOnNode.
+func (mr *MockClusterClientMockRecorder) DeletePodsOnNode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePodsOnNode", reflect.TypeOf((*MockClusterClient)(nil).DeletePodsOnNode), arg0, arg1, arg2)
+}
+
+// DrainNode mocks base method.
+func (m *MockClusterClient) DrainNode(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DrainNode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DrainNode indicates an expected call of DrainNode.
+func (mr *MockClusterClientMockRecorder) DrainNode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrainNode", reflect.TypeOf((*MockClusterClient)(nil).DrainNode), arg0, arg1, arg2)
+}
+
 // GetApiServerUrl mocks base method.
 func (m *MockClusterClient) GetApiServerUrl(arg0 context.Context, arg1 *types.Cluster) (string, error) {
 	m.ctrl.T.Helper()
@@ -382,6 +426,56 @@ func (mr *MockClusterClientMockRecorder) GetNamespace(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespace", reflect.TypeOf((*MockClusterClient)(nil).GetNamespace), arg0, arg1, arg2)
 }
 
+// GetNodes mocks base method.
+func (m *MockClusterClient) GetNodes(arg0 context.Context, arg1 string) ([]v1.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodes", arg0, arg1)
+	ret0, _ := ret[0].([]v1.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodes indicates an expected call of GetNodes.
+func (mr *MockClusterClientMockRecorder) GetNodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockClusterClient)(nil).GetNodes), arg0, arg1)
+}
+
+// GetUnstructuredObject mocks base method.
+func (m *MockClusterClient) GetUnstructuredObject(arg0 context.Context, arg1, arg2, arg3, arg4 string) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnstructuredObject", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnstructuredObject indicates an expected call of GetUnstructuredObject.
+func (mr *MockClusterClientMockRecorder) GetUnstructuredObject(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnstructuredObject", reflect.TypeOf((*MockClusterClient)(nil).GetUnstructuredObject), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetUnstructuredObjects mocks base method.
+func (m *MockClusterClient) GetUnstructuredObjects(arg0 context.Context, arg1 string, arg2 ...executables.KubectlOpt) ([]unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUnstructuredObjects", varargs...)
+	ret0, _ := ret[0].([]unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnstructuredObjects indicates an expected call of GetUnstructuredObjects.
+func (mr *MockClusterClientMockRecorder) GetUnstructuredObjects(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnstructuredObjects", reflect.TypeOf((*MockClusterClient)(nil).GetUnstructuredObjects), varargs...)
+}
+
 // GetWorkloadKubeconfig mocks base method.
 func (m *MockClusterClient) GetWorkloadKubeconfig(arg0 context.Context, arg1 string, arg2 *types.Cluster) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -454,6 +548,20 @@ func (mr *MockClusterClientMockRecorder) RemoveAnnotationInNamespace(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAnnotationInNamespace", reflect.TypeOf((*MockClusterClient)(nil).RemoveAnnotationInNamespace), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// RestoreManagement mocks base method.
+func (m *MockClusterClient) RestoreManagement(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreManagement", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreManagement indicates an expected call of RestoreManagement.
+func (mr *MockClusterClientMockRecorder) RestoreManagement(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreManagement", reflect.TypeOf((*MockClusterClient)(nil).RestoreManagement), arg0, arg1, arg2)
+}
+
 // SaveLog mocks base method.
 func (m *MockClusterClient) SaveLog(arg0 context.Context, arg1 *types.Cluster, arg2 *types.Deployment, arg3 string, arg4 filewriter.FileWriter) error {
 	m.ctrl.T.Helper()
@@ -468,6 +576,20 @@ func (mr *MockClusterClientMockRecorder) SaveLog(arg0, arg1, arg2, arg3, arg4 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLog", reflect.TypeOf((*MockClusterClient)(nil).SaveLog), arg0, arg1, arg2, arg3, arg4)
 }
 
+// UncordonNode mocks base method.
+func (m *MockClusterClient) UncordonNode(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UncordonNode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UncordonNode indicates an expected call of UncordonNode.
+func (mr *MockClusterClientMockRecorder) UncordonNode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UncordonNode", reflect.TypeOf((*MockClusterClient)(nil).UncordonNode), arg0, arg1, arg2)
+}
+
 // UpdateAnnotationInNamespace mocks base method.
 func (m *MockClusterClient) UpdateAnnotationInNamespace(arg0 context.Context, arg1, arg2 string, arg3 map[string]string, arg4 *types.Cluster, arg5 string) error {
 	m.ctrl.T.Helper()
@@ -619,6 +741,72 @@ func (mr *MockNetworkingMockRecorder) Upgrade(arg0, arg1, arg2, arg3 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upgrade", reflect.TypeOf((*MockNetworking)(nil).Upgrade), arg0, arg1, arg2, arg3)
 }
 
+// MockNetworkingMigrator is a mock of NetworkingMigrator interface.
+type MockNetworkingMigrator struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkingMigratorMockRecorder
+}
+
+// MockNetworkingMigratorMockRecorder is the mock recorder for MockNetworkingMigrator.
+type MockNetworkingMigratorMockRecorder struct {
+	mock *MockNetworkingMigrator
+}
+
+// NewMockNetworkingMigrator creates a new mock instance.
+func NewMockNetworkingMigrator(ctrl *gomock.Controller) *MockNetworkingMigrator {
+	mock := &MockNetworkingMigrator{ctrl: ctrl}
+	mock.recorder = &MockNetworkingMigratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetworkingMigrator) EXPECT() *MockNetworkingMigratorMockRecorder {
+	return m.recorder
+}
+
+// CompleteMigration mocks base method.
+func (m *MockNetworkingMigrator) CompleteMigration(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 *cluster.Spec) (*types.ChangeDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMigration", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types.ChangeDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMigration indicates an expected call of CompleteMigration.
+func (mr *MockNetworkingMigratorMockRecorder) CompleteMigration(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMigration", reflect.TypeOf((*MockNetworkingMigrator)(nil).CompleteMigration), arg0, arg1, arg2, arg3)
+}
+
+// InstallForMigration mocks base method.
+func (m *MockNetworkingMigrator) InstallForMigration(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallForMigration", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallForMigration indicates an expected call of InstallForMigration.
+func (mr *MockNetworkingMigratorMockRecorder) InstallForMigration(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallForMigration", reflect.TypeOf((*MockNetworkingMigrator)(nil).InstallForMigration), arg0, arg1, arg2, arg3)
+}
+
+// MigrateNode mocks base method.
+func (m *MockNetworkingMigrator) MigrateNode(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateNode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MigrateNode indicates an expected call of MigrateNode.
+func (mr *MockNetworkingMigratorMockRecorder) MigrateNode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateNode", reflect.TypeOf((*MockNetworkingMigrator)(nil).MigrateNode), arg0, arg1, arg2)
+}
+
 // MockAwsIamAuth is a mock of AwsIamAuth interface.
 type MockAwsIamAuth struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,302 @@
+package clustermanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/version"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifestdiff"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// rollbackDir is the folder, inside the cluster folder, where the state of the cluster before an upgrade is saved
+	rollbackDir              = "rollback"
+	rollbackClusterFile      = "cluster.yaml"
+	rollbackControlPlaneFile = "capi-control-plane.yaml"
+	rollbackWorkersFile      = "capi-workers.yaml"
+)
+
+// ErrNoRollbackPoint is returned when there is no saved pre-upgrade state to roll back to
+var ErrNoRollbackPoint = errors.New("no rollback point found, the cluster hasn't been upgraded from this folder")
+
+// etcdVersionFields are the fields with the etcd version in the CAPI objects that manage etcd
+var etcdVersionFields = map[string][]string{
+	"KubeadmControlPlane": {"spec", "kubeadmConfigSpec", "clusterConfiguration", "etcd", "local", "imageTag"},
+	"EtcdadmCluster":      {"spec", "etcdadmConfigSpec", "cloudInitConfig", "version"},
+}
+
+type rollbackPoint struct {
+	cluster          *v1alpha1.Cluster
+	controlPlaneSpec []byte
+	workersSpec      []byte
+}
+
+// SaveRollbackPoint saves the EKS-A Cluster of currentSpec and the CAPI specs rendered for it, so the cluster can be taken
+// back to that state with RollbackCluster if the upgrade fails. It must be called before the new spec is applied, since
+// the providers render the machine templates in use in the cluster. An existing rollback point for the same pre-upgrade
+// spec is kept, since the cluster might already be half way through an upgrade that is being retried
+func (c *ClusterManager) SaveRollbackPoint(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentSpec *cluster.Spec, provider providers.Provider) error {
+	if point, err := c.loadRollbackPoint(); err == nil && point.cluster.Name == currentSpec.Cluster.Name && point.cluster.Equal(currentSpec.Cluster) {
+		logger.V(3).Info("Keeping existing rollback point for the same cluster spec")
+		return nil
+	}
+
+	cpContent, mdContent, err := provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, workloadCluster, currentSpec, currentSpec.DeepCopy())
+	if err != nil {
+		return fmt.Errorf("error generating capi spec for rollback point: %v", err)
+	}
+
+	clusterContent, err := yaml.Marshal(currentSpec.Cluster)
+	if err != nil {
+		return fmt.Errorf("error marshalling cluster for rollback point: %v", err)
+	}
+
+	writer, err := c.writer.WithDir(rollbackDir)
+	if err != nil {
+		return fmt.Errorf("error creating rollback point directory: %v", err)
+	}
+
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{name: rollbackClusterFile, content: clusterContent},
+		{name: rollbackControlPlaneFile, content: cpContent},
+		{name: rollbackWorkersFile, content: mdContent},
+	}
+	for _, f := range files {
+		if _, err = writer.Write(f.name, f.content, filewriter.PersistentFile); err != nil {
+			return fmt.Errorf("error saving rollback point: %v", err)
+		}
+	}
+	logger.V(3).Info("Saved rollback point", "directory", writer.Dir())
+
+	return nil
+}
+
+// ValidateRollback checks that the cluster can be rolled back to the rollback point saved before its last upgrade.
+// Rolling back is not safe once the new spec has been applied to the EKS-A Cluster, since the controller would
+// upgrade it again, or once etcd has been upgraded to a newer minor version, since etcd doesn't support downgrades
+func (c *ClusterManager) ValidateRollback(ctx context.Context, managementCluster *types.Cluster, clusterName string) error {
+	point, err := c.loadRollbackPoint()
+	if err != nil {
+		return err
+	}
+
+	if point.cluster.Name != clusterName {
+		return fmt.Errorf("rollback point belongs to cluster %s, not %s", point.cluster.Name, clusterName)
+	}
+
+	currentCluster, err := c.clusterClient.GetEksaCluster(ctx, managementCluster, clusterName)
+	if err != nil {
+		return fmt.Errorf("error getting EKS-A cluster to validate rollback: %v", err)
+	}
+	if !point.cluster.Equal(currentCluster) {
+		return fmt.Errorf("the new spec was already applied to the EKS-A cluster %s, rolling back is not safe", clusterName)
+	}
+
+	return c.validateRollbackEtcdVersion(ctx, managementCluster, point)
+}
+
+func (c *ClusterManager) validateRollbackEtcdVersion(ctx context.Context, managementCluster *types.Cluster, point *rollbackPoint) error {
+	objs, err := manifestdiff.ParseObjects(point.controlPlaneSpec)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objs {
+		path, ok := etcdVersionFields[obj.GetKind()]
+		if !ok {
+			continue
+		}
+		savedVersion, found, err := unstructured.NestedString(obj.Object, path...)
+		if err != nil || !found {
+			continue
+		}
+
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = constants.EksaSystemNamespace
+		}
+		current, err := c.clusterClient.GetUnstructuredObject(ctx, resourceType(obj), obj.GetName(), namespace, managementCluster.KubeconfigFile)
+		if err != nil {
+			return fmt.Errorf("error getting %s %s to validate rollback: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		if current == nil {
+			continue
+		}
+		currentVersion, found, err := unstructured.NestedString(current.Object, path...)
+		if err != nil || !found {
+			continue
+		}
+
+		newer, err := newerMinorVersion(currentVersion, savedVersion)
+		if err != nil {
+			return fmt.Errorf("error comparing etcd versions for %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		if newer {
+			return fmt.Errorf("etcd in %s %s was already upgraded from %s to %s, rolling back to an older etcd minor version is not supported",
+				obj.GetKind(), obj.GetName(), savedVersion, currentVersion)
+		}
+	}
+
+	return nil
+}
+
+// RollbackCluster applies the CAPI specs saved by SaveRollbackPoint and waits for the control plane and the workers to
+// converge to them. Worker node groups in clusterSpec that didn't exist before the upgrade are removed. The rollback point
+// is deleted once the cluster is rolled back
+func (c *ClusterManager) RollbackCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	point, err := c.loadRollbackPoint()
+	if err != nil {
+		return err
+	}
+	previousSpec := &cluster.Spec{Config: &cluster.Config{Cluster: point.cluster}}
+
+	logger.V(3).Info("Applying pre-upgrade capi control plane spec")
+	err = c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, point.controlPlaneSpec, constants.EksaSystemNamespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error applying capi control plane spec: %v", err)
+	}
+
+	if point.cluster.Spec.ExternalEtcdConfiguration != nil {
+		logger.V(3).Info("Waiting for external etcd to be ready after rollback")
+		if err = c.clusterClient.WaitForManagedExternalEtcdReady(ctx, managementCluster, etcdWaitStr, point.cluster.Name); err != nil {
+			return fmt.Errorf("error waiting for external etcd to be ready: %v", err)
+		}
+	}
+
+	logger.V(3).Info("Waiting for control plane to be ready after rollback")
+	if err = c.clusterClient.WaitForControlPlaneReady(ctx, managementCluster, ctrlPlaneWaitStr, point.cluster.Name); err != nil {
+		return fmt.Errorf("error waiting for control plane to be ready: %v", err)
+	}
+
+	if err = c.waitForNodesReady(ctx, managementCluster, point.cluster.Name, []string{clusterv1.MachineControlPlaneLabelName}, types.WithNodeRef(), types.WithNodeHealthy()); err != nil {
+		return err
+	}
+
+	if err = c.waitForControlPlaneReplicasReady(ctx, managementCluster, previousSpec); err != nil {
+		return fmt.Errorf("error waiting for control plane replicas to be ready: %v", err)
+	}
+
+	logger.V(3).Info("Applying pre-upgrade capi machine deployments spec")
+	if err = c.applyMachineDeploymentsSpec(ctx, managementCluster, point.workersSpec); err != nil {
+		return err
+	}
+
+	if err = c.removeWorkerNodeGroupsAddedByUpgrade(ctx, managementCluster, provider, clusterSpec, previousSpec); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Waiting for machine deployment replicas to be ready after rollback")
+	if err = c.waitForMachineDeploymentReplicasReady(ctx, managementCluster, previousSpec); err != nil {
+		return fmt.Errorf("error waiting for machinedeployment replicas to be ready: %v", err)
+	}
+
+	if err = c.waitForNodesReady(ctx, managementCluster, point.cluster.Name, []string{clusterv1.MachineDeploymentLabelName}, types.WithNodeRef(), types.WithNodeHealthy()); err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(c.rollbackDir()); err != nil {
+		return fmt.Errorf("error deleting rollback point: %v", err)
+	}
+
+	return nil
+}
+
+// removeWorkerNodeGroupsAddedByUpgrade deletes the machine deployments of the worker node groups in upgradeSpec
+// that are not in previousSpec. The upgrade might have failed before creating them, so missing ones are skipped
+func (c *ClusterManager) removeWorkerNodeGroupsAddedByUpgrade(ctx context.Context, managementCluster *types.Cluster, provider providers.Provider, upgradeSpec, previousSpec *cluster.Spec) error {
+	workloadCluster := &types.Cluster{Name: previousSpec.Cluster.Name}
+	for _, name := range provider.MachineDeploymentsToDelete(workloadCluster, upgradeSpec, previousSpec) {
+		obj, err := c.clusterClient.GetUnstructuredObject(ctx, fmt.Sprintf("machinedeployments.%s", clusterv1.GroupVersion.Group), name, constants.EksaSystemNamespace, managementCluster.KubeconfigFile)
+		if err != nil {
+			return fmt.Errorf("error getting machine deployment %s: %v", name, err)
+		}
+		if obj == nil {
+			continue
+		}
+
+		machineDeployment := &clusterv1.MachineDeployment{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, machineDeployment); err != nil {
+			return fmt.Errorf("error parsing machine deployment %s: %v", name, err)
+		}
+
+		logger.V(3).Info("Removing worker node group added by the upgrade", "machineDeployment", name)
+		if err = c.clusterClient.DeleteOldWorkerNodeGroup(ctx, machineDeployment, managementCluster.KubeconfigFile); err != nil {
+			return fmt.Errorf("error removing worker node group %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) rollbackDir() string {
+	return filepath.Join(c.writer.Dir(), rollbackDir)
+}
+
+func (c *ClusterManager) loadRollbackPoint() (*rollbackPoint, error) {
+	read := func(name string) ([]byte, error) {
+		content, err := ioutil.ReadFile(filepath.Join(c.rollbackDir(), name))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNoRollbackPoint
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading rollback point: %v", err)
+		}
+		return content, nil
+	}
+
+	clusterContent, err := read(rollbackClusterFile)
+	if err != nil {
+		return nil, err
+	}
+	point := &rollbackPoint{cluster: &v1alpha1.Cluster{}}
+	if err = yaml.Unmarshal(clusterContent, point.cluster); err != nil {
+		return nil, fmt.Errorf("error parsing rollback point cluster: %v", err)
+	}
+	if point.controlPlaneSpec, err = read(rollbackControlPlaneFile); err != nil {
+		return nil, err
+	}
+	if point.workersSpec, err = read(rollbackWorkersFile); err != nil {
+		return nil, err
+	}
+
+	return point, nil
+}
+
+// newerMinorVersion returns true if version a has a newer minor version than b
+func newerMinorVersion(a, b string) (bool, error) {
+	parsedA, err := version.ParseGeneric(a)
+	if err != nil {
+		return false, err
+	}
+	parsedB, err := version.ParseGeneric(b)
+	if err != nil {
+		return false, err
+	}
+	if parsedA.Major() != parsedB.Major() {
+		return parsedA.Major() > parsedB.Major(), nil
+	}
+	return parsedA.Minor() > parsedB.Minor(), nil
+}
@@ -0,0 +1,149 @@
+package clustermanager_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const rollbackControlPlaneSpec = `apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: cluster-name
+  namespace: eksa-system
+spec:
+  kubeadmConfigSpec:
+    clusterConfiguration:
+      etcd:
+        local:
+          imageTag: v3.4.14-eks-1-19-2
+`
+
+type rollbackTest struct {
+	*testSetup
+	dir               string
+	managementCluster *types.Cluster
+}
+
+func newRollbackTest(t *testing.T) *rollbackTest {
+	tt := newTest(t)
+	dir := t.TempDir()
+	tt.mocks.writer.EXPECT().Dir().Return(dir).AnyTimes()
+	tt.mocks.writer.EXPECT().WithDir("rollback").DoAndReturn(func(d string) (filewriter.FileWriter, error) {
+		return filewriter.NewWriter(filepath.Join(dir, d))
+	}).AnyTimes()
+	tt.clusterSpec.Cluster.Name = tt.clusterName
+
+	return &rollbackTest{
+		testSetup:         tt,
+		dir:               dir,
+		managementCluster: &types.Cluster{Name: "management", KubeconfigFile: "management.kubeconfig"},
+	}
+}
+
+func (tt *rollbackTest) saveRollbackPoint(cpContent string) {
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.managementCluster, tt.cluster, tt.clusterSpec, tt.clusterSpec.DeepCopy()).Return([]byte(cpContent), []byte("workers"), nil)
+	tt.Expect(tt.clusterManager.SaveRollbackPoint(tt.ctx, tt.managementCluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+}
+
+func (tt *rollbackTest) kcpWithEtcdVersion(version string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	tt.Expect(yaml.Unmarshal([]byte(rollbackControlPlaneSpec), &obj.Object)).To(Succeed())
+	tt.Expect(unstructured.SetNestedField(obj.Object, version, "spec", "kubeadmConfigSpec", "clusterConfiguration", "etcd", "local", "imageTag")).To(Succeed())
+	return obj
+}
+
+func TestClusterManagerSaveRollbackPoint(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.saveRollbackPoint("cp")
+
+	cp, err := ioutil.ReadFile(filepath.Join(tt.dir, "rollback", "capi-control-plane.yaml"))
+	tt.Expect(err).To(Succeed())
+	tt.Expect(string(cp)).To(Equal("cp"))
+	workers, err := ioutil.ReadFile(filepath.Join(tt.dir, "rollback", "capi-workers.yaml"))
+	tt.Expect(err).To(Succeed())
+	tt.Expect(string(workers)).To(Equal("workers"))
+	tt.Expect(filepath.Join(tt.dir, "rollback", "cluster.yaml")).To(BeARegularFile())
+}
+
+func TestClusterManagerSaveRollbackPointKeepsExistingPoint(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.saveRollbackPoint("cp")
+
+	// the provider is not called again for the same pre-upgrade spec
+	tt.Expect(tt.clusterManager.SaveRollbackPoint(tt.ctx, tt.managementCluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+}
+
+func TestClusterManagerSaveRollbackPointProviderError(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.managementCluster, tt.cluster, tt.clusterSpec, tt.clusterSpec.DeepCopy()).Return(nil, nil, errors.New("error templating"))
+
+	tt.Expect(tt.clusterManager.SaveRollbackPoint(tt.ctx, tt.managementCluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)).To(MatchError(ContainSubstring("error templating")))
+}
+
+func TestClusterManagerValidateRollbackNoRollbackPoint(t *testing.T) {
+	tt := newRollbackTest(t)
+
+	tt.Expect(tt.clusterManager.ValidateRollback(tt.ctx, tt.managementCluster, tt.clusterName)).To(MatchError(clustermanager.ErrNoRollbackPoint))
+}
+
+func TestClusterManagerValidateRollbackSuccess(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.saveRollbackPoint(rollbackControlPlaneSpec)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.managementCluster, tt.clusterName).Return(tt.clusterSpec.Cluster.DeepCopy(), nil)
+	tt.mocks.client.EXPECT().GetUnstructuredObject(tt.ctx, "kubeadmcontrolplane.controlplane.cluster.x-k8s.io", tt.clusterName, constants.EksaSystemNamespace, tt.managementCluster.KubeconfigFile).
+		Return(tt.kcpWithEtcdVersion("v3.4.16-eks-1-19-4"), nil)
+
+	tt.Expect(tt.clusterManager.ValidateRollback(tt.ctx, tt.managementCluster, tt.clusterName)).To(Succeed())
+}
+
+func TestClusterManagerValidateRollbackEksaClusterChanged(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.saveRollbackPoint(rollbackControlPlaneSpec)
+	upgraded := tt.clusterSpec.Cluster.DeepCopy()
+	upgraded.Spec.KubernetesVersion = "1.20"
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.managementCluster, tt.clusterName).Return(upgraded, nil)
+
+	tt.Expect(tt.clusterManager.ValidateRollback(tt.ctx, tt.managementCluster, tt.clusterName)).To(MatchError(ContainSubstring("rolling back is not safe")))
+}
+
+func TestClusterManagerValidateRollbackEtcdUpgraded(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.saveRollbackPoint(rollbackControlPlaneSpec)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.managementCluster, tt.clusterName).Return(tt.clusterSpec.Cluster.DeepCopy(), nil)
+	tt.mocks.client.EXPECT().GetUnstructuredObject(tt.ctx, "kubeadmcontrolplane.controlplane.cluster.x-k8s.io", tt.clusterName, constants.EksaSystemNamespace, tt.managementCluster.KubeconfigFile).
+		Return(tt.kcpWithEtcdVersion("v3.5.0-eks-1-21-1"), nil)
+
+	tt.Expect(tt.clusterManager.ValidateRollback(tt.ctx, tt.managementCluster, tt.clusterName)).To(MatchError(ContainSubstring("rolling back to an older etcd minor version is not supported")))
+}
+
+func TestClusterManagerRollbackCluster(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.saveRollbackPoint("cp")
+	upgradeSpec := tt.clusterSpec.DeepCopy()
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.managementCluster, []byte("cp"), constants.EksaSystemNamespace),
+		tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, tt.managementCluster, "60m", tt.clusterName),
+		tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.clusterName).Return([]types.Machine{}, nil),
+		tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, tt.managementCluster, tt.clusterName),
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.managementCluster, []byte("workers"), constants.EksaSystemNamespace),
+		tt.mocks.provider.EXPECT().MachineDeploymentsToDelete(&types.Cluster{Name: tt.clusterName}, upgradeSpec, gomock.Any()).Return([]string{"cluster-name-md-1"}),
+		tt.mocks.client.EXPECT().GetUnstructuredObject(tt.ctx, "machinedeployments.cluster.x-k8s.io", "cluster-name-md-1", constants.EksaSystemNamespace, tt.managementCluster.KubeconfigFile),
+		tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, tt.clusterName, tt.managementCluster.KubeconfigFile),
+		tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.managementCluster, tt.clusterName).Return([]types.Machine{}, nil),
+	)
+
+	tt.Expect(tt.clusterManager.RollbackCluster(tt.ctx, tt.managementCluster, upgradeSpec, tt.mocks.provider)).To(Succeed())
+	tt.Expect(filepath.Join(tt.dir, "rollback")).NotTo(BeADirectory())
+}
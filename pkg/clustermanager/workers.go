@@ -0,0 +1,135 @@
+package clustermanager
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifestdiff"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// applyWorkerNodeGroups applies the machine deployments spec. When any worker node group pins its own
+// kubernetes version, the groups are rolled out one at a time, waiting for each one to be ready before
+// moving to the next, so a staged upgrade never has more than one group being replaced
+func (c *ClusterManager) applyWorkerNodeGroups(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, mdContent []byte) error {
+	if !stagedWorkerNodeGroupsUpgrade(clusterSpec) {
+		return c.applyMachineDeploymentsSpec(ctx, managementCluster, mdContent)
+	}
+
+	groups, err := splitWorkerNodeGroupsContent(mdContent)
+	if err != nil {
+		return fmt.Errorf("error splitting capi machine deployment spec: %v", err)
+	}
+
+	for _, group := range groups {
+		logger.V(3).Info("Upgrading worker node group", "machineDeployment", group.name)
+		if err = c.applyMachineDeploymentsSpec(ctx, managementCluster, group.content); err != nil {
+			return err
+		}
+
+		logger.V(3).Info("Waiting for worker node group replicas to be ready", "machineDeployment", group.name)
+		if err = c.waitForMachineDeploymentReplicasReady(ctx, managementCluster, clusterSpec); err != nil {
+			return fmt.Errorf("error waiting for worker node group %s replicas to be ready: %v", group.name, err)
+		}
+
+		if err = c.waitForNodesReady(ctx, managementCluster, clusterSpec.Cluster.Name, []string{clusterv1.MachineDeploymentLabelName}, types.WithNodeRef(), types.WithNodeHealthy()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) applyMachineDeploymentsSpec(ctx context.Context, managementCluster *types.Cluster, content []byte) error {
+	err := c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, content, constants.EksaSystemNamespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error applying capi machine deployment spec: %v", err)
+	}
+	return nil
+}
+
+func stagedWorkerNodeGroupsUpgrade(clusterSpec *cluster.Spec) bool {
+	for _, w := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if w.KubernetesVersion != nil {
+			return true
+		}
+	}
+	return false
+}
+
+type workerNodeGroupContent struct {
+	name    string
+	content []byte
+}
+
+// splitWorkerNodeGroupsContent groups each MachineDeployment in the manifest with the bootstrap config and
+// infrastructure templates it references, keeping the order of the MachineDeployments in the manifest.
+// Objects not referenced by any MachineDeployment are applied with the first group
+func splitWorkerNodeGroupsContent(mdContent []byte) ([]workerNodeGroupContent, error) {
+	objs, err := manifestdiff.ParseObjects(mdContent)
+	if err != nil {
+		return nil, err
+	}
+
+	var machineDeployments []*unstructured.Unstructured
+	owners := map[string]int{}
+	for _, obj := range objs {
+		if obj.GetKind() != "MachineDeployment" {
+			continue
+		}
+		index := len(machineDeployments)
+		machineDeployments = append(machineDeployments, obj)
+		owners[objectKey(obj.GetKind(), obj.GetName())] = index
+		for _, refPath := range [][]string{
+			{"spec", "template", "spec", "bootstrap", "configRef"},
+			{"spec", "template", "spec", "infrastructureRef"},
+		} {
+			ref, found, err := unstructured.NestedStringMap(obj.Object, refPath...)
+			if err != nil || !found {
+				continue
+			}
+			owners[objectKey(ref["kind"], ref["name"])] = index
+		}
+	}
+
+	if len(machineDeployments) == 0 {
+		return []workerNodeGroupContent{{content: mdContent}}, nil
+	}
+
+	resources := make([][][]byte, len(machineDeployments))
+	for _, obj := range objs {
+		// objects not referenced by any MachineDeployment default to the first group
+		index := owners[objectKey(obj.GetKind(), obj.GetName())]
+
+		content, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		resources[index] = append(resources[index], content)
+	}
+
+	groups := make([]workerNodeGroupContent, 0, len(machineDeployments))
+	for i, md := range machineDeployments {
+		groups = append(groups, workerNodeGroupContent{
+			name:    md.GetName(),
+			content: templater.AppendYamlResources(resources[i]...),
+		})
+	}
+	return groups, nil
+}
+
+func objectKey(kind, name string) string {
+	return kind + "/" + name
+}
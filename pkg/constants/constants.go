@@ -33,7 +33,8 @@ const (
 	TinkerbellProviderName = "tinkerbell"
 	CloudStackProviderName = "cloudstack"
 
-	VSphereCredentialsName = "vsphere-credentials"
-	EksaLicenseName        = "eksa-license"
-	EksaPackagesName       = "eksa-packages"
+	VSphereCredentialsName    = "vsphere-credentials"
+	CloudStackCredentialsName = "cloudstack-credentials"
+	EksaLicenseName           = "eksa-license"
+	EksaPackagesName          = "eksa-packages"
 )
@@ -18,13 +18,16 @@ import (
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/networking/calico"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/providers/factory"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/pbnj"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/redfish"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -35,8 +38,10 @@ type Dependencies struct {
 	Kubectl                   *executables.Kubectl
 	Govc                      *executables.Govc
 	Cmk                       *executables.Cmk
+	CmkBuilder                func(execConfig decoder.CloudStackExecConfig) *executables.Cmk
 	Tink                      *executables.Tink
 	Pbnj                      *pbnj.Pbnj
+	Bmc                       *bmc.VendorClient
 	TinkerbellClients         tinkerbell.TinkerbellClients
 	Writer                    filewriter.FileWriter
 	Kind                      *executables.Kind
@@ -175,7 +180,7 @@ func (f *Factory) WithProviderFactory(clusterConfigFile string, clusterConfig *v
 	case v1alpha1.DockerDatacenterKind:
 		f.WithDocker().WithKubectl()
 	case v1alpha1.TinkerbellDatacenterKind:
-		f.WithKubectl().WithTink(clusterConfigFile).WithPbnj(clusterConfigFile)
+		f.WithKubectl().WithTink(clusterConfigFile).WithBmc(clusterConfigFile)
 	}
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -192,7 +197,7 @@ func (f *Factory) WithProviderFactory(clusterConfigFile string, clusterConfig *v
 			VSphereKubectlClient:      f.dependencies.Kubectl,
 			SnowKubectlClient:         f.dependencies.Kubectl,
 			TinkerbellKubectlClient:   f.dependencies.Kubectl,
-			TinkerbellClients:         tinkerbell.TinkerbellClients{ProviderTinkClient: f.dependencies.Tink, ProviderPbnjClient: f.dependencies.Pbnj},
+			TinkerbellClients:         tinkerbell.TinkerbellClients{ProviderTinkClient: f.dependencies.Tink, ProviderBmcClient: f.dependencies.Bmc},
 			Writer:                    f.dependencies.Writer,
 			ClusterResourceSetManager: f.dependencies.ResourceSetManager,
 		}
@@ -286,6 +291,26 @@ func (f *Factory) WithCmk() *Factory {
 	return f
 }
 
+// WithCmkBuilder builds a function to create cmk executables on demand, for callers that only
+// get the CloudStack credentials after the dependencies are built
+func (f *Factory) WithCmkBuilder() *Factory {
+	f.WithExecutableBuilder().WithWriter()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.CmkBuilder != nil {
+			return nil
+		}
+
+		f.dependencies.CmkBuilder = func(execConfig decoder.CloudStackExecConfig) *executables.Cmk {
+			return f.executableBuilder.BuildCmkExecutable(f.dependencies.Writer, execConfig)
+		}
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithTink(clusterConfigFile string) *Factory {
 	f.WithExecutableBuilder()
 
@@ -328,6 +353,23 @@ func (f *Factory) WithPbnj(clusterConfigFile string) *Factory {
 	return f
 }
 
+// WithBmc builds a BMC client that manages the machines with the redfish vendor through Redfish and
+// the other machines through PBnJ.
+func (f *Factory) WithBmc(clusterConfigFile string) *Factory {
+	f.WithPbnj(clusterConfigFile)
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.Bmc != nil {
+			return nil
+		}
+
+		f.dependencies.Bmc = bmc.NewVendorClient(f.dependencies.Pbnj, redfish.NewRedfishClient())
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithWriter() *Factory {
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Writer != nil {
@@ -428,6 +470,11 @@ func (f *Factory) WithNetworking(clusterConfig *v1alpha1.Cluster) *Factory {
 		networkingBuilder = func() clustermanager.Networking {
 			return kindnetd.NewKindnetd(f.dependencies.Kubectl)
 		}
+	} else if clusterConfig.Spec.ClusterNetwork.CNIConfig.Calico != nil {
+		f.WithKubectl()
+		networkingBuilder = func() clustermanager.Networking {
+			return calico.NewCalico(f.dependencies.Kubectl)
+		}
 	} else {
 		f.WithKubectl().WithHelm()
 		networkingBuilder = func() clustermanager.Networking {
@@ -69,6 +69,7 @@ func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 		WithCollectorFactory().
 		WithTroubleshoot().
 		WithCAPIManager().
+		WithCmkBuilder().
 		Build(context.Background())
 
 	tt.Expect(err).To(BeNil())
@@ -81,4 +82,5 @@ func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 	tt.Expect(deps.CollectorFactory).NotTo(BeNil())
 	tt.Expect(deps.Troubleshoot).NotTo(BeNil())
 	tt.Expect(deps.CAPIManager).NotTo(BeNil())
+	tt.Expect(deps.CmkBuilder).NotTo(BeNil())
 }
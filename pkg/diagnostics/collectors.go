@@ -100,6 +100,27 @@ func (c *collectorFactory) ManagementClusterCollectors() []*Collect {
 	return collectors
 }
 
+// CiliumPolicyAuditCollectors collects from every Cilium agent the recent flows that network policies denied,
+// or would have denied in policy audit mode, as reported by Hubble.
+func (c *collectorFactory) CiliumPolicyAuditCollectors() []*Collect {
+	return []*Collect{
+		{
+			Exec: &exec{
+				collectorMeta: collectorMeta{
+					CollectorName: "cilium-policy-denied-flows",
+				},
+				Name:          "cilium/policy-denied-flows",
+				Selector:      []string{"k8s-app=cilium"},
+				Namespace:     constants.KubeSystemNamespace,
+				ContainerName: "cilium-agent",
+				Command:       []string{"hubble"},
+				Args:          []string{"observe", "--verdict", "DROPPED", "--verdict", "AUDIT", "--last", "10000", "-o", "json"},
+				Timeout:       "60s",
+			},
+		},
+	}
+}
+
 func (c *collectorFactory) getCollectorsMap() map[v1alpha1.OSFamily][]*Collect {
 	return map[v1alpha1.OSFamily][]*Collect{
 		v1alpha1.Ubuntu:       c.ubuntuHostCollectors(),
@@ -109,6 +109,7 @@ func newDiagnosticBundleFromSpec(af AnalyzerFactory, cf CollectorFactory, spec *
 		WithExternalEtcd(spec.Cluster.Spec.ExternalEtcdConfiguration).
 		WithDatacenterConfig(spec.Cluster.Spec.DatacenterRef).
 		WithMachineConfigs(provider.MachineConfigs(spec)).
+		WithCniConfig(spec.Cluster.Spec.ClusterNetwork.CNIConfig).
 		WithManagementCluster(spec.Cluster.IsSelfManaged()).
 		WithDefaultAnalyzers().
 		WithDefaultCollectors().
@@ -282,6 +283,14 @@ func (e *EksaDiagnosticBundle) WithGitOpsConfig(config *v1alpha1.GitOpsConfig) *
 	return e
 }
 
+// WithCniConfig collects the flows denied by network policies when Cilium runs in policy audit mode.
+func (e *EksaDiagnosticBundle) WithCniConfig(config *v1alpha1.CNIConfig) *EksaDiagnosticBundle {
+	if config != nil && config.Cilium != nil && config.Cilium.PolicyAuditMode {
+		e.bundle.Spec.Collectors = append(e.bundle.Spec.Collectors, e.collectorFactory.CiliumPolicyAuditCollectors()...)
+	}
+	return e
+}
+
 func (e *EksaDiagnosticBundle) WithMachineConfigs(configs []providers.MachineConfig) *EksaDiagnosticBundle {
 	e.bundle.Spec.Collectors = append(e.bundle.Spec.Collectors, e.collectorFactory.EksaHostCollectors(configs)...)
 	return e
@@ -189,6 +189,57 @@ func TestGenerateBundleConfigWithOidc(t *testing.T) {
 	})
 }
 
+func TestGenerateBundleConfigWithCiliumPolicyAuditMode(t *testing.T) {
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster = &eksav1alpha1.Cluster{
+			TypeMeta:   metav1.TypeMeta{},
+			ObjectMeta: metav1.ObjectMeta{},
+			Spec: eksav1alpha1.ClusterSpec{
+				DatacenterRef: eksav1alpha1.Ref{
+					Kind: eksav1alpha1.VSphereDatacenterKind,
+					Name: "testRef",
+				},
+				ClusterNetwork: eksav1alpha1.ClusterNetwork{
+					CNIConfig: &eksav1alpha1.CNIConfig{
+						Cilium: &eksav1alpha1.CiliumConfig{PolicyAuditMode: true},
+					},
+				},
+			},
+			Status: eksav1alpha1.ClusterStatus{},
+		}
+	})
+
+	t.Run(t.Name(), func(t *testing.T) {
+		p := givenProvider(t)
+		p.EXPECT().MachineConfigs(spec).Return(machineConfigs())
+
+		a := givenMockAnalyzerFactory(t)
+		a.EXPECT().DataCenterConfigAnalyzers(spec.Cluster.Spec.DatacenterRef).Return(nil)
+		a.EXPECT().DefaultAnalyzers().Return(nil)
+		a.EXPECT().EksaLogTextAnalyzers(gomock.Any()).Return(nil)
+		a.EXPECT().ManagementClusterAnalyzers().Return(nil)
+
+		w := givenWriter(t)
+		w.EXPECT().Write(gomock.Any(), gomock.Any())
+
+		c := givenMockCollectorsFactory(t)
+		c.EXPECT().DefaultCollectors().Return(nil)
+		c.EXPECT().EksaHostCollectors(gomock.Any()).Return(nil)
+		c.EXPECT().CiliumPolicyAuditCollectors().Return(nil)
+		c.EXPECT().ManagementClusterCollectors().Return(nil)
+		c.EXPECT().DataCenterConfigCollectors(spec.Cluster.Spec.DatacenterRef).Return(nil)
+
+		opts := diagnostics.EksaDiagnosticBundleFactoryOpts{
+			AnalyzerFactory:  a,
+			CollectorFactory: c,
+			Writer:           w,
+		}
+
+		f := diagnostics.NewFactory(opts)
+		_, _ = f.DiagnosticBundleFromSpec(spec, p, "")
+	})
+}
+
 func TestGenerateBundleConfigWithGitOps(t *testing.T) {
 	spec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster = &eksav1alpha1.Cluster{
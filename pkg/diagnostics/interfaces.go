@@ -36,6 +36,7 @@ type DiagnosticBundle interface {
 	WithExternalEtcd(config *v1alpha1.ExternalEtcdConfiguration) *EksaDiagnosticBundle
 	WithGitOpsConfig(config *v1alpha1.GitOpsConfig) *EksaDiagnosticBundle
 	WithMachineConfigs(configs []providers.MachineConfig) *EksaDiagnosticBundle
+	WithCniConfig(config *v1alpha1.CNIConfig) *EksaDiagnosticBundle
 	WithLogTextAnalyzers() *EksaDiagnosticBundle
 }
 
@@ -54,4 +55,5 @@ type CollectorFactory interface {
 	ManagementClusterCollectors() []*Collect
 	EksaHostCollectors(configs []providers.MachineConfig) []*Collect
 	DataCenterConfigCollectors(datacenter v1alpha1.Ref) []*Collect
+	CiliumPolicyAuditCollectors() []*Collect
 }
@@ -231,6 +231,20 @@ func (mr *MockDiagnosticBundleMockRecorder) PrintBundleConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrintBundleConfig", reflect.TypeOf((*MockDiagnosticBundle)(nil).PrintBundleConfig))
 }
 
+// WithCniConfig mocks base method.
+func (m *MockDiagnosticBundle) WithCniConfig(config *v1alpha1.CNIConfig) *diagnostics.EksaDiagnosticBundle {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithCniConfig", config)
+	ret0, _ := ret[0].(*diagnostics.EksaDiagnosticBundle)
+	return ret0
+}
+
+// WithCniConfig indicates an expected call of WithCniConfig.
+func (mr *MockDiagnosticBundleMockRecorder) WithCniConfig(config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithCniConfig", reflect.TypeOf((*MockDiagnosticBundle)(nil).WithCniConfig), config)
+}
+
 // WithDatacenterConfig mocks base method.
 func (m *MockDiagnosticBundle) WithDatacenterConfig(config v1alpha1.Ref) *diagnostics.EksaDiagnosticBundle {
 	m.ctrl.T.Helper()
@@ -516,6 +530,20 @@ func (m *MockCollectorFactory) EXPECT() *MockCollectorFactoryMockRecorder {
 	return m.recorder
 }
 
+// CiliumPolicyAuditCollectors mocks base method.
+func (m *MockCollectorFactory) CiliumPolicyAuditCollectors() []*diagnostics.Collect {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CiliumPolicyAuditCollectors")
+	ret0, _ := ret[0].([]*diagnostics.Collect)
+	return ret0
+}
+
+// CiliumPolicyAuditCollectors indicates an expected call of CiliumPolicyAuditCollectors.
+func (mr *MockCollectorFactoryMockRecorder) CiliumPolicyAuditCollectors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CiliumPolicyAuditCollectors", reflect.TypeOf((*MockCollectorFactory)(nil).CiliumPolicyAuditCollectors))
+}
+
 // DataCenterConfigCollectors mocks base method.
 func (m *MockCollectorFactory) DataCenterConfigCollectors(datacenter v1alpha1.Ref) []*diagnostics.Collect {
 	m.ctrl.T.Helper()
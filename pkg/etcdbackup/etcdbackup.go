@@ -0,0 +1,286 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// MachinesClient reads the CAPI objects needed to find the etcd and control plane machines of a cluster
+type MachinesClient interface {
+	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
+	GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*etcdv1.EtcdadmCluster, error)
+}
+
+type KubectlClient interface {
+	MachinesClient
+	UpdateAnnotationInNamespace(ctx context.Context, resourceType, objectName string, annotations map[string]string, cluster *types.Cluster, namespace string) error
+	RemoveAnnotationInNamespace(ctx context.Context, resourceType, objectName, key string, cluster *types.Cluster, namespace string) error
+}
+
+var capiClusterResourceType = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+
+// NodeRunner runs shell commands with root privileges in the cluster machines
+type NodeRunner interface {
+	Run(ctx context.Context, node Node, command string, stdin []byte) (stdout []byte, err error)
+}
+
+// Node is a machine running an etcd member or a control plane
+type Node struct {
+	Name    string
+	Address string
+}
+
+type EtcdBackup struct {
+	kubectl KubectlClient
+	runner  NodeRunner
+	store   Store
+}
+
+func New(kubectl KubectlClient, runner NodeRunner, store Store) *EtcdBackup {
+	return &EtcdBackup{
+		kubectl: kubectl,
+		runner:  runner,
+		store:   store,
+	}
+}
+
+// Backup takes a snapshot from the first etcd member that succeeds and saves it in the store with the given name
+func (e *EtcdBackup) Backup(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, snapshotName string) error {
+	topology := topologyFor(clusterSpec)
+	nodes, err := GetClusterNodes(ctx, e.kubectl, managementCluster, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	var snapshot []byte
+	for _, node := range nodes.Etcd {
+		logger.V(3).Info("Taking etcd snapshot", "node", node.Name)
+		snapshot, err = e.takeSnapshot(ctx, topology, node)
+		if err == nil {
+			break
+		}
+		logger.V(2).Info("Failed taking etcd snapshot, trying next member", "node", node.Name, "error", err)
+	}
+	if err != nil {
+		return fmt.Errorf("taking etcd snapshot: %v", err)
+	}
+
+	if err = e.store.Put(ctx, snapshotName, snapshot); err != nil {
+		return fmt.Errorf("saving etcd snapshot %s: %v", snapshotName, err)
+	}
+	logger.Info("etcd snapshot saved", "snapshot", snapshotName, "location", e.store.Location(snapshotName))
+
+	return nil
+}
+
+func (e *EtcdBackup) takeSnapshot(ctx context.Context, topology *topology, node Node) ([]byte, error) {
+	if _, err := e.runner.Run(ctx, node, topology.snapshotSaveCommand(), nil); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, err := e.runner.Run(ctx, node, topology.cleanSnapshotCommand(), nil); err != nil {
+			logger.V(3).Info("Failed removing etcd snapshot from node", "node", node.Name, "error", err)
+		}
+	}()
+
+	return e.runner.Run(ctx, node, topology.readSnapshotCommand(), nil)
+}
+
+// Restore replaces the data of every etcd member with the snapshot saved in the store with the given name.
+// The control plane components and all members are stopped before any of their data is replaced, so the cluster
+// comes back with the restored data only. The CAPI cluster is paused meanwhile so the stopped machines are not remediated
+func (e *EtcdBackup) Restore(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, snapshotName string) (err error) {
+	snapshot, err := e.store.Get(ctx, snapshotName)
+	if err != nil {
+		return fmt.Errorf("reading etcd snapshot %s: %v", snapshotName, err)
+	}
+
+	nodes, err := GetClusterNodes(ctx, e.kubectl, managementCluster, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	clusterName := clusterSpec.Cluster.Name
+	logger.V(3).Info("Pausing CAPI cluster reconciliation", "cluster", clusterName)
+	pausedAnnotation := map[string]string{clusterv1.PausedAnnotation: "true"}
+	if err = e.kubectl.UpdateAnnotationInNamespace(ctx, capiClusterResourceType, clusterName, pausedAnnotation, managementCluster, constants.EksaSystemNamespace); err != nil {
+		return fmt.Errorf("pausing cluster %s: %v", clusterName, err)
+	}
+	defer func() {
+		logger.V(3).Info("Resuming CAPI cluster reconciliation", "cluster", clusterName)
+		if resumeErr := e.kubectl.RemoveAnnotationInNamespace(ctx, capiClusterResourceType, clusterName, clusterv1.PausedAnnotation, managementCluster, constants.EksaSystemNamespace); resumeErr != nil && err == nil {
+			err = fmt.Errorf("resuming cluster %s: %v", clusterName, resumeErr)
+		}
+	}()
+
+	if err = e.restoreMembers(ctx, topologyFor(clusterSpec), nodes, snapshot); err != nil {
+		return err
+	}
+	logger.Info("etcd snapshot restored", "snapshot", snapshotName)
+
+	return nil
+}
+
+func (e *EtcdBackup) restoreMembers(ctx context.Context, topology *topology, nodes *ClusterNodes, snapshot []byte) error {
+	members, err := e.members(ctx, topology, nodes.Etcd)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes.Etcd {
+		logger.V(3).Info("Restoring etcd snapshot in member", "node", node.Name)
+		if _, err = e.runner.Run(ctx, node, topology.writeSnapshotCommand(), snapshot); err != nil {
+			return fmt.Errorf("copying etcd snapshot to %s: %v", node.Name, err)
+		}
+		if _, err = e.runner.Run(ctx, node, topology.snapshotRestoreCommand(members[node.Name], members), nil); err != nil {
+			return fmt.Errorf("restoring etcd snapshot in %s: %v", node.Name, err)
+		}
+	}
+
+	logger.V(3).Info("Stopping control plane components")
+	if err = e.runInAll(ctx, nodes.ControlPlane, stopControlPlaneCommand, "stopping control plane"); err != nil {
+		return err
+	}
+
+	if stop := topology.stopCommand(); stop != "" {
+		logger.V(3).Info("Stopping etcd members")
+		if err = e.runInAll(ctx, nodes.Etcd, stop, "stopping etcd"); err != nil {
+			return err
+		}
+	}
+
+	logger.V(3).Info("Replacing etcd data")
+	if err = e.runInAll(ctx, nodes.Etcd, topology.replaceDataCommand(), "replacing etcd data"); err != nil {
+		return err
+	}
+
+	if start := topology.startCommand(); start != "" {
+		logger.V(3).Info("Starting etcd members")
+		if err = e.runInAll(ctx, nodes.Etcd, start, "starting etcd"); err != nil {
+			return err
+		}
+	}
+
+	logger.V(3).Info("Starting control plane components")
+	return e.runInAll(ctx, nodes.ControlPlane, startControlPlaneCommand, "starting control plane")
+}
+
+func (e *EtcdBackup) runInAll(ctx context.Context, nodes []Node, command, action string) error {
+	for _, node := range nodes {
+		if _, err := e.runner.Run(ctx, node, command, nil); err != nil {
+			return fmt.Errorf("%s in %s: %v", action, node.Name, err)
+		}
+	}
+	return nil
+}
+
+// members maps each node to the name and peer url of the etcd member it runs.
+// They are read from the running etcd cluster when possible, since the restored members need to keep the same
+// identity to rejoin each other. Otherwise they are built from the node hostname and address
+func (e *EtcdBackup) members(ctx context.Context, topology *topology, nodes []Node) (map[string]member, error) {
+	for _, node := range nodes {
+		out, err := e.runner.Run(ctx, node, topology.memberListCommand(), nil)
+		if err != nil {
+			logger.V(3).Info("Failed listing etcd members", "node", node.Name, "error", err)
+			continue
+		}
+		members, err := membersForNodes(out, nodes)
+		if err != nil {
+			return nil, err
+		}
+		return members, nil
+	}
+
+	logger.V(2).Info("Unable to list etcd members, using node hostnames as member names")
+	members := make(map[string]member, len(nodes))
+	for _, node := range nodes {
+		hostname, err := e.runner.Run(ctx, node, "hostname", nil)
+		if err != nil {
+			return nil, fmt.Errorf("getting hostname for %s: %v", node.Name, err)
+		}
+		members[node.Name] = newMember(string(hostname), node.Address)
+	}
+
+	return members, nil
+}
+
+// ClusterNodes are the machines running etcd and the control plane of a cluster
+type ClusterNodes struct {
+	Etcd         []Node
+	ControlPlane []Node
+}
+
+// GetClusterNodes finds the machines running etcd and the control plane. They are the same ones for stacked etcd.
+// With external etcd, the etcd machines are the ones owned by the cluster EtcdadmCluster
+func GetClusterNodes(ctx context.Context, client MachinesClient, managementCluster *types.Cluster, clusterSpec *cluster.Spec) (*ClusterNodes, error) {
+	isControlPlaneMachine := func(m types.Machine) bool {
+		_, ok := m.Metadata.Labels[clusterv1.MachineControlPlaneLabelName]
+		return ok
+	}
+	isEtcdMachine := isControlPlaneMachine
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdadmCluster, err := client.GetEtcdadmCluster(ctx, managementCluster, clusterSpec.Cluster.Name,
+			executables.WithCluster(managementCluster),
+			executables.WithNamespace(constants.EksaSystemNamespace),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("getting external etcd cluster: %v", err)
+		}
+		if !etcdadmCluster.Status.Ready {
+			logger.V(2).Info("External etcd cluster is not ready", "etcdadmCluster", etcdadmCluster.Name)
+		}
+		isEtcdMachine = func(m types.Machine) bool {
+			return m.Metadata.Labels[clusterv1.MachineEtcdClusterLabelName] == etcdadmCluster.Name
+		}
+	}
+
+	machines, err := client.GetMachines(ctx, managementCluster, clusterSpec.Cluster.Name)
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster machines: %v", err)
+	}
+
+	nodes := &ClusterNodes{}
+	for _, m := range machines {
+		etcd, controlPlane := isEtcdMachine(m), isControlPlaneMachine(m)
+		if !etcd && !controlPlane {
+			continue
+		}
+		address := machineAddress(m)
+		if address == "" {
+			return nil, fmt.Errorf("machine %s doesn't have an address", m.Metadata.Name)
+		}
+		node := Node{Name: m.Metadata.Name, Address: address}
+		if etcd {
+			nodes.Etcd = append(nodes.Etcd, node)
+		}
+		if controlPlane {
+			nodes.ControlPlane = append(nodes.ControlPlane, node)
+		}
+	}
+
+	if len(nodes.Etcd) == 0 {
+		return nil, fmt.Errorf("no etcd machines found for cluster %s", clusterSpec.Cluster.Name)
+	}
+
+	return nodes, nil
+}
+
+func machineAddress(m types.Machine) string {
+	for _, addressType := range []string{string(clusterv1.MachineInternalIP), string(clusterv1.MachineExternalIP)} {
+		for _, a := range m.Status.Addresses {
+			if a.Type == addressType {
+				return a.Address
+			}
+		}
+	}
+	return ""
+}
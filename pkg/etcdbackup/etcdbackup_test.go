@@ -0,0 +1,265 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	stackedEtcdctl           = "crictl exec $(crictl ps -q --name '^etcd$') etcdctl --endpoints=https://127.0.0.1:2379 --cacert=/etc/kubernetes/pki/etcd/ca.crt --cert=/etc/kubernetes/pki/etcd/server.crt --key=/etc/kubernetes/pki/etcd/server.key"
+	externalEtcdctl          = "ETCDCTL_API=3 /opt/bin/etcdctl --endpoints=https://127.0.0.1:2379 --cacert=/etc/etcd/pki/ca.crt --cert=/etc/etcd/pki/etcdctl-etcd-client.crt --key=/etc/etcd/pki/etcdctl-etcd-client.key"
+	stopControlPlaneCommand  = "mkdir -p /etc/kubernetes/eksa-etcd-restore-manifests && mv /etc/kubernetes/manifests/*.yaml /etc/kubernetes/eksa-etcd-restore-manifests/ && while crictl ps -q --name '^(kube-apiserver|etcd)$' | grep -q .; do sleep 1; done"
+	startControlPlaneCommand = "mv /etc/kubernetes/eksa-etcd-restore-manifests/*.yaml /etc/kubernetes/manifests/ && rmdir /etc/kubernetes/eksa-etcd-restore-manifests"
+)
+
+type etcdBackupTest struct {
+	*WithT
+	ctx               context.Context
+	kubectl           *mocks.MockKubectlClient
+	runner            *mocks.MockNodeRunner
+	store             etcdbackup.Store
+	managementCluster *types.Cluster
+	clusterSpec       *cluster.Spec
+	etcdBackup        *etcdbackup.EtcdBackup
+}
+
+func newEtcdBackupTest(t *testing.T) *etcdBackupTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	runner := mocks.NewMockNodeRunner(ctrl)
+	store := etcdbackup.NewLocalStore(t.TempDir())
+	return &etcdBackupTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: kubectl,
+		runner:  runner,
+		store:   store,
+		managementCluster: &types.Cluster{
+			Name:           "management-cluster",
+			KubeconfigFile: "kubeconfig",
+		},
+		clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "test-cluster"
+		}),
+		etcdBackup: etcdbackup.New(kubectl, runner, store),
+	}
+}
+
+func (tt *etcdBackupTest) withExternalEtcd() {
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+	tt.kubectl.EXPECT().GetEtcdadmCluster(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any(), gomock.Any()).Return(
+		&etcdv1.EtcdadmCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-etcd"},
+			Status:     etcdv1.EtcdadmClusterStatus{Ready: true},
+		}, nil,
+	)
+}
+
+func machine(name, address string, labels map[string]string) types.Machine {
+	return types.Machine{
+		Metadata: types.MachineMetadata{Name: name, Labels: labels},
+		Status: types.MachineStatus{
+			Addresses: []types.MachineAddress{
+				{Type: string(clusterv1.MachineExternalIP), Address: "1.1.1.1"},
+				{Type: string(clusterv1.MachineInternalIP), Address: address},
+			},
+		},
+	}
+}
+
+func controlPlaneMachine(name, address string) types.Machine {
+	return machine(name, address, map[string]string{clusterv1.MachineControlPlaneLabelName: ""})
+}
+
+func etcdMachine(name, address string) types.Machine {
+	return machine(name, address, map[string]string{clusterv1.MachineEtcdClusterLabelName: "test-cluster-etcd"})
+}
+
+func workerMachine(name, address string) types.Machine {
+	return machine(name, address, map[string]string{clusterv1.MachineDeploymentLabelName: "md-0"})
+}
+
+func TestEtcdBackupBackupStackedEtcd(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	node := etcdbackup.Node{Name: "cp-1", Address: "10.0.0.1"}
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test-cluster").Return(
+		[]types.Machine{controlPlaneMachine("cp-1", "10.0.0.1"), workerMachine("worker-1", "10.0.0.2")}, nil,
+	)
+
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, node, stackedEtcdctl+" snapshot save /var/lib/etcd/eksa-etcd-snapshot.db", nil),
+		tt.runner.EXPECT().Run(tt.ctx, node, "cat /var/lib/etcd/eksa-etcd-snapshot.db", nil).Return([]byte("snapshot"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, node, "rm -f /var/lib/etcd/eksa-etcd-snapshot.db", nil),
+	)
+
+	tt.Expect(tt.etcdBackup.Backup(tt.ctx, tt.managementCluster, tt.clusterSpec, "snapshot.db")).To(Succeed())
+	tt.Expect(tt.store.Get(tt.ctx, "snapshot.db")).To(Equal([]byte("snapshot")))
+}
+
+func TestEtcdBackupBackupExternalEtcdTriesNextMember(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.withExternalEtcd()
+	node1 := etcdbackup.Node{Name: "etcd-1", Address: "10.0.0.1"}
+	node2 := etcdbackup.Node{Name: "etcd-2", Address: "10.0.0.2"}
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test-cluster").Return(
+		[]types.Machine{controlPlaneMachine("cp-1", "10.0.0.3"), etcdMachine("etcd-1", "10.0.0.1"), etcdMachine("etcd-2", "10.0.0.2")}, nil,
+	)
+
+	saveCommand := externalEtcdctl + " snapshot save /var/lib/etcd/eksa-etcd-snapshot.db"
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, node1, saveCommand, nil).Return(nil, errors.New("member down")),
+		tt.runner.EXPECT().Run(tt.ctx, node2, saveCommand, nil),
+		tt.runner.EXPECT().Run(tt.ctx, node2, "cat /var/lib/etcd/eksa-etcd-snapshot.db", nil).Return([]byte("snapshot"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, node2, "rm -f /var/lib/etcd/eksa-etcd-snapshot.db", nil),
+	)
+
+	tt.Expect(tt.etcdBackup.Backup(tt.ctx, tt.managementCluster, tt.clusterSpec, "snapshot.db")).To(Succeed())
+	tt.Expect(tt.store.Get(tt.ctx, "snapshot.db")).To(Equal([]byte("snapshot")))
+}
+
+func TestEtcdBackupBackupNoEtcdMachines(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test-cluster").Return(
+		[]types.Machine{workerMachine("worker-1", "10.0.0.2")}, nil,
+	)
+
+	tt.Expect(tt.etcdBackup.Backup(tt.ctx, tt.managementCluster, tt.clusterSpec, "snapshot.db")).To(
+		MatchError(ContainSubstring("no etcd machines found for cluster test-cluster")),
+	)
+}
+
+func TestEtcdBackupBackupAllMembersFail(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test-cluster").Return(
+		[]types.Machine{controlPlaneMachine("cp-1", "10.0.0.1")}, nil,
+	)
+	tt.runner.EXPECT().Run(tt.ctx, gomock.Any(), gomock.Any(), nil).Return(nil, errors.New("member down"))
+
+	tt.Expect(tt.etcdBackup.Backup(tt.ctx, tt.managementCluster, tt.clusterSpec, "snapshot.db")).To(
+		MatchError(ContainSubstring("taking etcd snapshot: member down")),
+	)
+	_, err := tt.store.Get(tt.ctx, "snapshot.db")
+	tt.Expect(err).To(HaveOccurred())
+}
+
+func (tt *etcdBackupTest) expectPauseAndResume() (pause, resume *gomock.Call) {
+	pause = tt.kubectl.EXPECT().UpdateAnnotationInNamespace(
+		tt.ctx, "clusters.cluster.x-k8s.io", "test-cluster", map[string]string{clusterv1.PausedAnnotation: "true"}, tt.managementCluster, constants.EksaSystemNamespace,
+	)
+	resume = tt.kubectl.EXPECT().RemoveAnnotationInNamespace(
+		tt.ctx, "clusters.cluster.x-k8s.io", "test-cluster", clusterv1.PausedAnnotation, tt.managementCluster, constants.EksaSystemNamespace,
+	)
+	return pause, resume
+}
+
+func TestEtcdBackupRestoreExternalEtcd(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.withExternalEtcd()
+	tt.Expect(tt.store.Put(tt.ctx, "snapshot.db", []byte("snapshot"))).To(Succeed())
+	node1 := etcdbackup.Node{Name: "etcd-1", Address: "10.0.0.1"}
+	node2 := etcdbackup.Node{Name: "etcd-2", Address: "10.0.0.2"}
+	cpNode := etcdbackup.Node{Name: "cp-1", Address: "10.0.0.3"}
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test-cluster").Return(
+		[]types.Machine{etcdMachine("etcd-1", "10.0.0.1"), etcdMachine("etcd-2", "10.0.0.2"), controlPlaneMachine("cp-1", "10.0.0.3"), workerMachine("worker-1", "10.0.0.4")}, nil,
+	)
+	memberList := `{"members":[{"name":"host-2","peerURLs":["https://10.0.0.2:2380"]},{"name":"host-1","peerURLs":["https://10.0.0.1:2380"]}]}`
+	initialCluster := "'host-1=https://10.0.0.1:2380,host-2=https://10.0.0.2:2380'"
+	restoreCommand := func(name, peerURL string) string {
+		return "rm -rf /var/lib/etcd/eksa-etcd-restore/data && " + externalEtcdctl +
+			" snapshot restore /var/lib/etcd/eksa-etcd-restore/eksa-etcd-snapshot.db --data-dir /var/lib/etcd/eksa-etcd-restore/data" +
+			" --name '" + name + "' --initial-cluster " + initialCluster + " --initial-advertise-peer-urls '" + peerURL + "' --initial-cluster-token eksa-etcd-restore"
+	}
+	writeCommand := "mkdir -p /var/lib/etcd/eksa-etcd-restore && cat > /var/lib/etcd/eksa-etcd-restore/eksa-etcd-snapshot.db"
+	replaceCommand := "rm -rf /var/lib/etcd/member.eksa-backup && mv /var/lib/etcd/member /var/lib/etcd/member.eksa-backup && mv /var/lib/etcd/eksa-etcd-restore/data/member /var/lib/etcd/member && rm -rf /var/lib/etcd/eksa-etcd-restore"
+
+	pause, resume := tt.expectPauseAndResume()
+	gomock.InOrder(
+		pause,
+		tt.runner.EXPECT().Run(tt.ctx, node1, externalEtcdctl+" member list -w json", nil).Return([]byte(memberList), nil),
+		tt.runner.EXPECT().Run(tt.ctx, node1, writeCommand, []byte("snapshot")),
+		tt.runner.EXPECT().Run(tt.ctx, node1, restoreCommand("host-1", "https://10.0.0.1:2380"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, node2, writeCommand, []byte("snapshot")),
+		tt.runner.EXPECT().Run(tt.ctx, node2, restoreCommand("host-2", "https://10.0.0.2:2380"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, cpNode, stopControlPlaneCommand, nil),
+		tt.runner.EXPECT().Run(tt.ctx, node1, "systemctl stop etcd", nil),
+		tt.runner.EXPECT().Run(tt.ctx, node2, "systemctl stop etcd", nil),
+		tt.runner.EXPECT().Run(tt.ctx, node1, replaceCommand, nil),
+		tt.runner.EXPECT().Run(tt.ctx, node2, replaceCommand, nil),
+		tt.runner.EXPECT().Run(tt.ctx, node1, "systemctl start etcd", nil),
+		tt.runner.EXPECT().Run(tt.ctx, node2, "systemctl start etcd", nil),
+		tt.runner.EXPECT().Run(tt.ctx, cpNode, startControlPlaneCommand, nil),
+		resume,
+	)
+
+	tt.Expect(tt.etcdBackup.Restore(tt.ctx, tt.managementCluster, tt.clusterSpec, "snapshot.db")).To(Succeed())
+}
+
+func TestEtcdBackupRestoreStackedEtcdWithoutMemberList(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.Expect(tt.store.Put(tt.ctx, "snapshot.db", []byte("snapshot"))).To(Succeed())
+	node := etcdbackup.Node{Name: "cp-1", Address: "10.0.0.1"}
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test-cluster").Return(
+		[]types.Machine{controlPlaneMachine("cp-1", "10.0.0.1")}, nil,
+	)
+
+	var commands []string
+	tt.runner.EXPECT().Run(tt.ctx, node, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ etcdbackup.Node, command string, _ []byte) ([]byte, error) {
+			commands = append(commands, command)
+			switch {
+			case strings.HasSuffix(command, "member list -w json"):
+				return nil, errors.New("etcd is down")
+			case command == "hostname":
+				return []byte("cp-1-host\n"), nil
+			}
+			return nil, nil
+		},
+	).Times(7)
+	tt.expectPauseAndResume()
+
+	tt.Expect(tt.etcdBackup.Restore(tt.ctx, tt.managementCluster, tt.clusterSpec, "snapshot.db")).To(Succeed())
+	tt.Expect(commands[2]).To(ContainSubstring("cat > /var/lib/etcd/eksa-etcd-restore/eksa-etcd-snapshot.db"))
+	tt.Expect(commands[3]).To(ContainSubstring(stackedEtcdctl + " snapshot restore"))
+	tt.Expect(commands[3]).To(ContainSubstring("--name 'cp-1-host' --initial-cluster 'cp-1-host=https://10.0.0.1:2380'"))
+	tt.Expect(commands[4]).To(Equal(stopControlPlaneCommand))
+	tt.Expect(commands[5]).To(HavePrefix("rm -rf /var/lib/etcd/member.eksa-backup"))
+	tt.Expect(commands[6]).To(Equal(startControlPlaneCommand))
+}
+
+func TestEtcdBackupRestoreResumesClusterOnError(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.Expect(tt.store.Put(tt.ctx, "snapshot.db", []byte("snapshot"))).To(Succeed())
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test-cluster").Return(
+		[]types.Machine{controlPlaneMachine("cp-1", "10.0.0.1")}, nil,
+	)
+	tt.runner.EXPECT().Run(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused")).AnyTimes()
+	tt.expectPauseAndResume()
+
+	tt.Expect(tt.etcdBackup.Restore(tt.ctx, tt.managementCluster, tt.clusterSpec, "snapshot.db")).To(
+		MatchError(ContainSubstring("getting hostname for cp-1: connection refused")),
+	)
+}
+
+func TestEtcdBackupRestoreMissingSnapshot(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+
+	tt.Expect(tt.etcdBackup.Restore(tt.ctx, tt.managementCluster, tt.clusterSpec, "snapshot.db")).To(
+		MatchError(ContainSubstring("reading etcd snapshot snapshot.db")),
+	)
+}
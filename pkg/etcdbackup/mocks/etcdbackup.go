@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/etcdbackup/etcdbackup.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	etcdbackup "github.com/aws/eks-anywhere/pkg/etcdbackup"
+	executables "github.com/aws/eks-anywhere/pkg/executables"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+	v1beta1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+)
+
+// MockMachinesClient is a mock of MachinesClient interface.
+type MockMachinesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachinesClientMockRecorder
+}
+
+// MockMachinesClientMockRecorder is the mock recorder for MockMachinesClient.
+type MockMachinesClientMockRecorder struct {
+	mock *MockMachinesClient
+}
+
+// NewMockMachinesClient creates a new mock instance.
+func NewMockMachinesClient(ctrl *gomock.Controller) *MockMachinesClient {
+	mock := &MockMachinesClient{ctrl: ctrl}
+	mock.recorder = &MockMachinesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachinesClient) EXPECT() *MockMachinesClientMockRecorder {
+	return m.recorder
+}
+
+// GetEtcdadmCluster mocks base method.
+func (m *MockMachinesClient) GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*v1beta1.EtcdadmCluster, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, cluster, clusterName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetEtcdadmCluster", varargs...)
+	ret0, _ := ret[0].(*v1beta1.EtcdadmCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEtcdadmCluster indicates an expected call of GetEtcdadmCluster.
+func (mr *MockMachinesClientMockRecorder) GetEtcdadmCluster(ctx, cluster, clusterName interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, cluster, clusterName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEtcdadmCluster", reflect.TypeOf((*MockMachinesClient)(nil).GetEtcdadmCluster), varargs...)
+}
+
+// GetMachines mocks base method.
+func (m *MockMachinesClient) GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachines", ctx, cluster, clusterName)
+	ret0, _ := ret[0].([]types.Machine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachines indicates an expected call of GetMachines.
+func (mr *MockMachinesClientMockRecorder) GetMachines(ctx, cluster, clusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachines", reflect.TypeOf((*MockMachinesClient)(nil).GetMachines), ctx, cluster, clusterName)
+}
+
+// MockKubectlClient is a mock of KubectlClient interface.
+type MockKubectlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubectlClientMockRecorder
+}
+
+// MockKubectlClientMockRecorder is the mock recorder for MockKubectlClient.
+type MockKubectlClientMockRecorder struct {
+	mock *MockKubectlClient
+}
+
+// NewMockKubectlClient creates a new mock instance.
+func NewMockKubectlClient(ctrl *gomock.Controller) *MockKubectlClient {
+	mock := &MockKubectlClient{ctrl: ctrl}
+	mock.recorder = &MockKubectlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
+	return m.recorder
+}
+
+// GetEtcdadmCluster mocks base method.
+func (m *MockKubectlClient) GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*v1beta1.EtcdadmCluster, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, cluster, clusterName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetEtcdadmCluster", varargs...)
+	ret0, _ := ret[0].(*v1beta1.EtcdadmCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEtcdadmCluster indicates an expected call of GetEtcdadmCluster.
+func (mr *MockKubectlClientMockRecorder) GetEtcdadmCluster(ctx, cluster, clusterName interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, cluster, clusterName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEtcdadmCluster", reflect.TypeOf((*MockKubectlClient)(nil).GetEtcdadmCluster), varargs...)
+}
+
+// GetMachines mocks base method.
+func (m *MockKubectlClient) GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachines", ctx, cluster, clusterName)
+	ret0, _ := ret[0].([]types.Machine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachines indicates an expected call of GetMachines.
+func (mr *MockKubectlClientMockRecorder) GetMachines(ctx, cluster, clusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachines", reflect.TypeOf((*MockKubectlClient)(nil).GetMachines), ctx, cluster, clusterName)
+}
+
+// RemoveAnnotationInNamespace mocks base method.
+func (m *MockKubectlClient) RemoveAnnotationInNamespace(ctx context.Context, resourceType, objectName, key string, cluster *types.Cluster, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAnnotationInNamespace", ctx, resourceType, objectName, key, cluster, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAnnotationInNamespace indicates an expected call of RemoveAnnotationInNamespace.
+func (mr *MockKubectlClientMockRecorder) RemoveAnnotationInNamespace(ctx, resourceType, objectName, key, cluster, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAnnotationInNamespace", reflect.TypeOf((*MockKubectlClient)(nil).RemoveAnnotationInNamespace), ctx, resourceType, objectName, key, cluster, namespace)
+}
+
+// UpdateAnnotationInNamespace mocks base method.
+func (m *MockKubectlClient) UpdateAnnotationInNamespace(ctx context.Context, resourceType, objectName string, annotations map[string]string, cluster *types.Cluster, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnnotationInNamespace", ctx, resourceType, objectName, annotations, cluster, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnnotationInNamespace indicates an expected call of UpdateAnnotationInNamespace.
+func (mr *MockKubectlClientMockRecorder) UpdateAnnotationInNamespace(ctx, resourceType, objectName, annotations, cluster, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnnotationInNamespace", reflect.TypeOf((*MockKubectlClient)(nil).UpdateAnnotationInNamespace), ctx, resourceType, objectName, annotations, cluster, namespace)
+}
+
+// MockNodeRunner is a mock of NodeRunner interface.
+type MockNodeRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeRunnerMockRecorder
+}
+
+// MockNodeRunnerMockRecorder is the mock recorder for MockNodeRunner.
+type MockNodeRunnerMockRecorder struct {
+	mock *MockNodeRunner
+}
+
+// NewMockNodeRunner creates a new mock instance.
+func NewMockNodeRunner(ctrl *gomock.Controller) *MockNodeRunner {
+	mock := &MockNodeRunner{ctrl: ctrl}
+	mock.recorder = &MockNodeRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeRunner) EXPECT() *MockNodeRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockNodeRunner) Run(ctx context.Context, node etcdbackup.Node, command string, stdin []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, node, command, stdin)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockNodeRunnerMockRecorder) Run(ctx, node, command, stdin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockNodeRunner)(nil).Run), ctx, node, command, stdin)
+}
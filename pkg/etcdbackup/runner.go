@@ -0,0 +1,138 @@
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	sshPort        = "22"
+	sshDialTimeout = 30 * time.Second
+)
+
+type sshRunner struct {
+	config *ssh.ClientConfig
+}
+
+// NewSSHRunner returns a NodeRunner that connects to the machines with ssh, authenticating with the private key
+// in privateKeyFile, and runs the commands with sudo. The machine host keys are verified against knownHostsFile,
+// which is required unless insecureIgnoreHostKeys is set
+func NewSSHRunner(username, privateKeyFile, knownHostsFile string, insecureIgnoreHostKeys bool) (NodeRunner, error) {
+	key, err := ioutil.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading ssh private key: %v", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("parsing ssh private key: %v", err)
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	switch {
+	case knownHostsFile != "":
+		hostKeyCallback, err = knownhosts.New(knownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading ssh known hosts: %v", err)
+		}
+	case insecureIgnoreHostKeys:
+		logger.Info("Warning: machine host keys won't be verified")
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	default:
+		return nil, fmt.Errorf("a known hosts file is required to verify the machine host keys")
+	}
+
+	return &sshRunner{
+		config: &ssh.ClientConfig{
+			User:            username,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         sshDialTimeout,
+		},
+	}, nil
+}
+
+func (r *sshRunner) Run(ctx context.Context, node Node, command string, stdin []byte) ([]byte, error) {
+	address := net.JoinHostPort(node.Address, sshPort)
+	dialer := &net.Dialer{Timeout: sshDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %v", address, err)
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, r.config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("opening ssh connection to %s: %v", address, err)
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("opening ssh session to %s: %v", address, err)
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if len(stdin) != 0 {
+		session.Stdin = bytes.NewReader(stdin)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			client.Close()
+		case <-done:
+		}
+	}()
+
+	logger.V(6).Info("Running command in machine", "machine", node.Name, "cmd", command)
+	if err = session.Run("sudo sh -c " + shellQuote(command)); err != nil {
+		return nil, commandError(err, stderr)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+type DockerClient interface {
+	ExecInContainer(ctx context.Context, container string, stdin []byte, command ...string) (bytes.Buffer, error)
+}
+
+type dockerRunner struct {
+	docker DockerClient
+}
+
+// NewDockerRunner returns a NodeRunner for the docker provider, where each machine is a container with the same name
+func NewDockerRunner(docker DockerClient) NodeRunner {
+	return &dockerRunner{docker: docker}
+}
+
+func (r *dockerRunner) Run(ctx context.Context, node Node, command string, stdin []byte) ([]byte, error) {
+	out, err := r.docker.ExecInContainer(ctx, node.Name, stdin, "sh", "-c", command)
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func commandError(err error, stderr bytes.Buffer) error {
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("%v: %s", err, msg)
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+package etcdbackup_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+func writePrivateKey(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "id_rsa")
+	content := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err = ioutil.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewSSHRunnerKnownHosts(t *testing.T) {
+	g := NewWithT(t)
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	g.Expect(ioutil.WriteFile(knownHosts, nil, 0600)).To(Succeed())
+
+	runner, err := etcdbackup.NewSSHRunner("ec2-user", writePrivateKey(t), knownHosts, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(runner).NotTo(BeNil())
+}
+
+func TestNewSSHRunnerMissingKnownHosts(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := etcdbackup.NewSSHRunner("ec2-user", writePrivateKey(t), "", false)
+	g.Expect(err).To(MatchError(ContainSubstring("a known hosts file is required")))
+}
+
+func TestNewSSHRunnerInsecureIgnoreHostKeys(t *testing.T) {
+	g := NewWithT(t)
+
+	runner, err := etcdbackup.NewSSHRunner("ec2-user", writePrivateKey(t), "", true)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(runner).NotTo(BeNil())
+}
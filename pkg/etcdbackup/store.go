@@ -0,0 +1,109 @@
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Store saves and reads etcd snapshots by name
+type Store interface {
+	Put(ctx context.Context, name string, data []byte) error
+	Get(ctx context.Context, name string) ([]byte, error)
+	// Location describes where the snapshot with the given name is stored
+	Location(name string) string
+}
+
+type localStore struct {
+	dir string
+}
+
+// NewLocalStore returns a Store that keeps the snapshots as files in dir
+func NewLocalStore(dir string) Store {
+	return &localStore{dir: dir}
+}
+
+func (s *localStore) Put(_ context.Context, name string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("creating snapshots folder: %v", err)
+	}
+	return ioutil.WriteFile(s.Location(name), data, 0o600)
+}
+
+func (s *localStore) Get(_ context.Context, name string) ([]byte, error) {
+	return ioutil.ReadFile(s.Location(name))
+}
+
+func (s *localStore) Location(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+// S3Config configures an S3 compatible object store. Endpoint is only needed for stores other than AWS S3,
+// like MinIO. Credentials are read from the default AWS credential chain
+type S3Config struct {
+	Bucket   string
+	Prefix   string
+	Region   string
+	Endpoint string
+}
+
+type s3Store struct {
+	config S3Config
+	client *s3.S3
+}
+
+// NewS3Store returns a Store that keeps the snapshots as objects in an S3 compatible bucket
+func NewS3Store(config S3Config) (Store, error) {
+	awsConfig := aws.NewConfig().WithRegion(config.Region)
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(config.Endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating s3 session: %v", err)
+	}
+
+	return &s3Store{config: config, client: s3.New(sess)}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, name string, data []byte) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.config.Bucket),
+		Key:    aws.String(s.key(name)),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func (s *s3Store) Get(ctx context.Context, name string) ([]byte, error) {
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.config.Bucket),
+		Key:    aws.String(s.key(name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	return ioutil.ReadAll(out.Body)
+}
+
+func (s *s3Store) Location(name string) string {
+	return fmt.Sprintf("s3://%s/%s", s.config.Bucket, s.key(name))
+}
+
+func (s *s3Store) key(name string) string {
+	return path.Join(s.config.Prefix, name)
+}
@@ -0,0 +1,109 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+// fakeObjectStore is a minimal stand-in for an S3 compatible store like MinIO, serving path style requests
+type fakeObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeObjectStore(t *testing.T) (*fakeObjectStore, *httptest.Server) {
+	f := &fakeObjectStore{objects: map[string][]byte{}}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestLocalStorePutGet(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	store := etcdbackup.NewLocalStore(dir)
+
+	g.Expect(store.Put(ctx, "snapshot.db", []byte("snapshot"))).To(Succeed())
+	g.Expect(store.Location("snapshot.db")).To(Equal(filepath.Join(dir, "snapshot.db")))
+	g.Expect(store.Get(ctx, "snapshot.db")).To(Equal([]byte("snapshot")))
+}
+
+func TestLocalStoreGetMissingSnapshot(t *testing.T) {
+	g := NewWithT(t)
+	store := etcdbackup.NewLocalStore(t.TempDir())
+
+	_, err := store.Get(context.Background(), "snapshot.db")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestS3StorePutGet(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	t.Setenv("AWS_ACCESS_KEY_ID", "access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-key")
+	objects, server := newFakeObjectStore(t)
+
+	store, err := etcdbackup.NewS3Store(etcdbackup.S3Config{
+		Bucket:   "backups",
+		Prefix:   "etcd",
+		Region:   "us-west-2",
+		Endpoint: server.URL,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(store.Put(ctx, "snapshot.db", []byte("snapshot"))).To(Succeed())
+	g.Expect(objects.objects).To(HaveKeyWithValue("/backups/etcd/snapshot.db", []byte("snapshot")))
+	g.Expect(store.Location("snapshot.db")).To(Equal("s3://backups/etcd/snapshot.db"))
+	g.Expect(store.Get(ctx, "snapshot.db")).To(Equal([]byte("snapshot")))
+}
+
+func TestS3StoreGetMissingSnapshot(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-key")
+	_, server := newFakeObjectStore(t)
+
+	store, err := etcdbackup.NewS3Store(etcdbackup.S3Config{
+		Bucket:   "backups",
+		Region:   "us-west-2",
+		Endpoint: server.URL,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = store.Get(context.Background(), "snapshot.db")
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,168 @@
+package etcdbackup
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const (
+	etcdDataDir          = "/var/lib/etcd"
+	snapshotFileName     = "eksa-etcd-snapshot.db"
+	snapshotPath         = etcdDataDir + "/" + snapshotFileName
+	restoreDir           = etcdDataDir + "/eksa-etcd-restore"
+	backupMemberDirName  = "member.eksa-backup"
+	restoreClusterToken  = "eksa-etcd-restore"
+	etcdPeerPort         = 2380
+	staticPodManifestDir = "/etc/kubernetes/manifests"
+	stoppedManifestDir   = "/etc/kubernetes/eksa-etcd-restore-manifests"
+	stackedEtcdContainer = "'^etcd$'"
+)
+
+// stopControlPlaneCommand stops the control plane static pods, including etcd when stacked, so nothing
+// reads or writes etcd while its data is replaced
+var stopControlPlaneCommand = fmt.Sprintf("mkdir -p %[1]s && mv %[2]s/*.yaml %[1]s/ && while crictl ps -q --name '^(kube-apiserver|etcd)$' | grep -q .; do sleep 1; done",
+	stoppedManifestDir, staticPodManifestDir)
+
+var startControlPlaneCommand = fmt.Sprintf("mv %[1]s/*.yaml %[2]s/ && rmdir %[1]s", stoppedManifestDir, staticPodManifestDir)
+
+// topology holds how etcd runs in the machines, so the same backup and restore steps can be
+// used for etcd stacked in the control plane nodes and for external etcd machines created by etcdadm.
+// Stacked etcd doesn't need its own stop and start commands since it runs as a control plane static pod
+type topology struct {
+	etcdctl string
+	stop    string
+	start   string
+}
+
+func topologyFor(clusterSpec *cluster.Spec) *topology {
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		return externalEtcd()
+	}
+	return stackedEtcd()
+}
+
+// stackedEtcd runs etcdctl inside the etcd static pod container created by kubeadm.
+// The etcd data dir is a host path volume, so the files written there by etcdctl are visible from the host
+func stackedEtcd() *topology {
+	return &topology{
+		etcdctl: "crictl exec $(crictl ps -q --name " + stackedEtcdContainer + ") etcdctl" +
+			" --endpoints=https://127.0.0.1:2379" +
+			" --cacert=/etc/kubernetes/pki/etcd/ca.crt" +
+			" --cert=/etc/kubernetes/pki/etcd/server.crt" +
+			" --key=/etc/kubernetes/pki/etcd/server.key",
+	}
+}
+
+// externalEtcd runs the etcdctl binary and the systemd unit installed by etcdadm
+func externalEtcd() *topology {
+	return &topology{
+		etcdctl: "ETCDCTL_API=3 /opt/bin/etcdctl" +
+			" --endpoints=https://127.0.0.1:2379" +
+			" --cacert=/etc/etcd/pki/ca.crt" +
+			" --cert=/etc/etcd/pki/etcdctl-etcd-client.crt" +
+			" --key=/etc/etcd/pki/etcdctl-etcd-client.key",
+		stop:  "systemctl stop etcd",
+		start: "systemctl start etcd",
+	}
+}
+
+func (t *topology) snapshotSaveCommand() string {
+	return fmt.Sprintf("%s snapshot save %s", t.etcdctl, snapshotPath)
+}
+
+func (t *topology) readSnapshotCommand() string {
+	return "cat " + snapshotPath
+}
+
+func (t *topology) cleanSnapshotCommand() string {
+	return "rm -f " + snapshotPath
+}
+
+func (t *topology) writeSnapshotCommand() string {
+	return fmt.Sprintf("mkdir -p %[1]s && cat > %[1]s/%[2]s", restoreDir, snapshotFileName)
+}
+
+func (t *topology) memberListCommand() string {
+	return t.etcdctl + " member list -w json"
+}
+
+func (t *topology) snapshotRestoreCommand(m member, members map[string]member) string {
+	return fmt.Sprintf("rm -rf %[1]s/data && %[2]s snapshot restore %[1]s/%[3]s --data-dir %[1]s/data --name %[4]s --initial-cluster %[5]s --initial-advertise-peer-urls %[6]s --initial-cluster-token %[7]s",
+		restoreDir, t.etcdctl, snapshotFileName, shellQuote(m.name), shellQuote(initialCluster(members)), shellQuote(m.peerURL), restoreClusterToken)
+}
+
+// replaceDataCommand keeps the previous member data next to the restored one in case it's needed to recover manually
+func (t *topology) replaceDataCommand() string {
+	return fmt.Sprintf("rm -rf %[1]s/%[2]s && mv %[1]s/member %[1]s/%[2]s && mv %[3]s/data/member %[1]s/member && rm -rf %[3]s",
+		etcdDataDir, backupMemberDirName, restoreDir)
+}
+
+func (t *topology) stopCommand() string {
+	return t.stop
+}
+
+func (t *topology) startCommand() string {
+	return t.start
+}
+
+type member struct {
+	name    string
+	peerURL string
+}
+
+func newMember(name, address string) member {
+	return member{
+		name:    strings.TrimSpace(name),
+		peerURL: fmt.Sprintf("https://%s:%d", address, etcdPeerPort),
+	}
+}
+
+func initialCluster(members map[string]member) string {
+	peers := make([]string, 0, len(members))
+	for _, m := range members {
+		peers = append(peers, m.name+"="+m.peerURL)
+	}
+	sort.Strings(peers)
+	return strings.Join(peers, ",")
+}
+
+type memberList struct {
+	Members []struct {
+		Name     string   `json:"name"`
+		PeerURLs []string `json:"peerURLs"`
+	} `json:"members"`
+}
+
+// membersForNodes matches the output of etcdctl member list with the nodes using the host in the member peer urls
+func membersForNodes(memberListOutput []byte, nodes []Node) (map[string]member, error) {
+	list := &memberList{}
+	if err := json.Unmarshal(memberListOutput, list); err != nil {
+		return nil, fmt.Errorf("parsing etcd member list: %v", err)
+	}
+
+	members := make(map[string]member, len(nodes))
+	for _, node := range nodes {
+		for _, m := range list.Members {
+			for _, peerURL := range m.PeerURLs {
+				u, err := url.Parse(peerURL)
+				if err == nil && u.Hostname() == node.Address {
+					members[node.Name] = member{name: m.Name, peerURL: peerURL}
+				}
+			}
+		}
+		if _, ok := members[node.Name]; !ok {
+			return nil, fmt.Errorf("etcd member for machine %s with address %s not found in member list", node.Name, node.Address)
+		}
+	}
+
+	return members, nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
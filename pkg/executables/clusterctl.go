@@ -161,6 +161,28 @@ func (c *Clusterctl) MoveManagement(ctx context.Context, from, to *types.Cluster
 	return err
 }
 
+// BackupManagement saves the CAPI objects of the management cluster in directory, leaving them in the cluster
+func (c *Clusterctl) BackupManagement(ctx context.Context, cluster *types.Cluster, directory string) error {
+	params := []string{"backup", "--directory", directory, "--namespace", constants.EksaSystemNamespace}
+	if cluster.KubeconfigFile != "" {
+		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
+	}
+	_, err := c.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("failed backing up management cluster: %v", err)
+	}
+	return nil
+}
+
+// RestoreManagement creates in the cluster the CAPI objects saved in directory by BackupManagement
+func (c *Clusterctl) RestoreManagement(ctx context.Context, cluster *types.Cluster, directory string) error {
+	_, err := c.Execute(ctx, "restore", "--directory", directory, "--kubeconfig", cluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace)
+	if err != nil {
+		return fmt.Errorf("failed restoring management cluster: %v", err)
+	}
+	return nil
+}
+
 func (c *Clusterctl) GetWorkloadKubeconfig(ctx context.Context, clusterName string, cluster *types.Cluster) ([]byte, error) {
 	stdOut, err := c.Execute(
 		ctx, "get", "kubeconfig", clusterName,
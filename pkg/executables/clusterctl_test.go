@@ -291,6 +291,42 @@ func TestClusterctlMoveManagement(t *testing.T) {
 	}
 }
 
+func TestClusterctlBackupManagement(t *testing.T) {
+	tt := newClusterctlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx, "backup", "--directory", "backup/capi", "--namespace", constants.EksaSystemNamespace, "--kubeconfig", tt.cluster.KubeconfigFile,
+	)
+
+	tt.Expect(tt.clusterctl.BackupManagement(tt.ctx, tt.cluster, "backup/capi")).To(Succeed())
+}
+
+func TestClusterctlBackupManagementError(t *testing.T) {
+	tt := newClusterctlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx, "backup", "--directory", "backup/capi", "--namespace", constants.EksaSystemNamespace, "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, errors.New("error in exec"))
+
+	tt.Expect(tt.clusterctl.BackupManagement(tt.ctx, tt.cluster, "backup/capi")).To(MatchError(ContainSubstring("failed backing up management cluster: error in exec")))
+}
+
+func TestClusterctlRestoreManagement(t *testing.T) {
+	tt := newClusterctlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx, "restore", "--directory", "backup/capi", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace,
+	)
+
+	tt.Expect(tt.clusterctl.RestoreManagement(tt.ctx, tt.cluster, "backup/capi")).To(Succeed())
+}
+
+func TestClusterctlRestoreManagementError(t *testing.T) {
+	tt := newClusterctlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx, "restore", "--directory", "backup/capi", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace,
+	).Return(bytes.Buffer{}, errors.New("error in exec"))
+
+	tt.Expect(tt.clusterctl.RestoreManagement(tt.ctx, tt.cluster, "backup/capi")).To(MatchError(ContainSubstring("failed restoring management cluster: error in exec")))
+}
+
 func TestClusterctlUpgradeAllProvidersSucess(t *testing.T) {
 	tt := newClusterctlTest(t)
 
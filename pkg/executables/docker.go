@@ -1,6 +1,7 @@
 package executables
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strconv"
@@ -132,3 +133,12 @@ func (d *Docker) SaveToFile(ctx context.Context, filepath string, images ...stri
 
 	return nil
 }
+
+// ExecInContainer runs a command in a running container, passing stdin to it when not empty
+func (d *Docker) ExecInContainer(ctx context.Context, container string, stdin []byte, command ...string) (bytes.Buffer, error) {
+	params := make([]string, 0, 3+len(command))
+	params = append(params, "exec", "-i", container)
+	params = append(params, command...)
+
+	return d.ExecuteWithStdin(ctx, stdin, params...)
+}
@@ -143,3 +143,20 @@ func TestDockerSaveToFileNoImages(t *testing.T) {
 
 	g.Expect(d.SaveToFile(ctx, file)).To(Succeed())
 }
+
+func TestDockerExecInContainer(t *testing.T) {
+	container := "container"
+	stdin := []byte("data")
+
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().ExecuteWithStdin(ctx, stdin, "exec", "-i", container, "sh", "-c", "cat > file").Return(*bytes.NewBufferString("out"), nil)
+	d := executables.NewDocker(executable)
+
+	out, err := d.ExecInContainer(ctx, container, stdin, "sh", "-c", "cat > file")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.String()).To(Equal("out"))
+}
@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/version"
 	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -25,12 +27,14 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	"github.com/aws/eks-anywhere/pkg/types"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 const (
-	kubectlPath = "kubectl"
+	kubectlPath      = "kubectl"
+	drainNodeTimeout = "10m"
 )
 
 var (
@@ -49,6 +53,7 @@ var (
 	clusterResourceSetResourceType       = fmt.Sprintf("clusterresourcesets.%s", addons.GroupVersion.Group)
 	kubeadmControlPlaneResourceType      = fmt.Sprintf("kubeadmcontrolplanes.controlplane.%s", clusterv1.GroupVersion.Group)
 	eksdReleaseType                      = fmt.Sprintf("releases.%s", eksdv1alpha1.GroupVersion.Group)
+	snowMachineTemplateResourceType      = fmt.Sprintf("awssnowmachinetemplates.%s", snowv1.GroupVersion.Group)
 )
 
 type Kubectl struct {
@@ -369,6 +374,74 @@ func (k *Kubectl) GetControlPlaneNodes(ctx context.Context, kubeconfig string) (
 	return response.Items, err
 }
 
+// DrainNode evicts the pods running in a node, except the ones managed by a DaemonSet, and marks it as unschedulable
+func (k *Kubectl) DrainNode(ctx context.Context, nodeName, kubeconfig string) error {
+	params := []string{
+		"drain", nodeName, "--ignore-daemonsets", "--delete-emptydir-data", "--force",
+		"--timeout", drainNodeTimeout, "--kubeconfig", kubeconfig,
+	}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error draining node %s: %v", nodeName, err)
+	}
+	return nil
+}
+
+// UncordonNode marks a node as schedulable
+func (k *Kubectl) UncordonNode(ctx context.Context, nodeName, kubeconfig string) error {
+	params := []string{"uncordon", nodeName, "--kubeconfig", kubeconfig}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error uncordoning node %s: %v", nodeName, err)
+	}
+	return nil
+}
+
+// LabelNode sets a label in a node, overwriting its current value
+func (k *Kubectl) LabelNode(ctx context.Context, nodeName, key, value, kubeconfig string) error {
+	params := []string{"label", "node", nodeName, fmt.Sprintf("%s=%s", key, value), "--overwrite", "--kubeconfig", kubeconfig}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error labeling node %s: %v", nodeName, err)
+	}
+	return nil
+}
+
+// RemoveNodesLabel removes a label from all the nodes that have it
+func (k *Kubectl) RemoveNodesLabel(ctx context.Context, key, kubeconfig string) error {
+	params := []string{"label", "nodes", "--selector", key, key + "-", "--kubeconfig", kubeconfig}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error removing label %s from nodes: %v", key, err)
+	}
+	return nil
+}
+
+// DeletePodsOnNode deletes the pods running in a node that don't use the host network, so the ones managed
+// by a controller are recreated with an address from the CNI currently running in the node
+func (k *Kubectl) DeletePodsOnNode(ctx context.Context, nodeName, kubeconfig string) error {
+	pods, err := k.GetPods(ctx, WithAllNamespaces(), WithArgs([]string{"--field-selector", "spec.nodeName=" + nodeName}), WithKubeconfig(kubeconfig))
+	if err != nil {
+		return fmt.Errorf("error getting pods in node %s: %v", nodeName, err)
+	}
+
+	for _, pod := range pods {
+		if pod.Spec.HostNetwork {
+			continue
+		}
+		params := []string{"delete", "pod", pod.Name, "--namespace", pod.Namespace, "--ignore-not-found", "--kubeconfig", kubeconfig}
+		if _, err := k.Execute(ctx, params...); err != nil {
+			return fmt.Errorf("error deleting pod %s/%s in node %s: %v", pod.Namespace, pod.Name, nodeName, err)
+		}
+	}
+	return nil
+}
+
+// RolloutRestartDaemonSet recreates all the pods of a DaemonSet
+func (k *Kubectl) RolloutRestartDaemonSet(ctx context.Context, name, namespace, kubeconfig string) error {
+	params := []string{"rollout", "restart", "daemonset", name, "--namespace", namespace, "--kubeconfig", kubeconfig}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error restarting daemonset %s: %v", name, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) ValidateNodes(ctx context.Context, kubeconfig string) error {
 	template := "{{range .items}}{{.metadata.name}}\n{{end}}"
 	params := []string{"get", "nodes", "-o", "go-template", "--template", template, "--kubeconfig", kubeconfig}
@@ -815,6 +888,20 @@ func (k *Kubectl) GetKubeadmControlPlane(ctx context.Context, cluster *types.Clu
 	return response, nil
 }
 
+// RolloutKubeadmControlPlane sets the rolloutAfter of the cluster KubeadmControlPlane, so KCP replaces all
+// the control plane machines created before that time even if their spec didn't change
+func (k *Kubectl) RolloutKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, rolloutAfter time.Time) error {
+	patch := fmt.Sprintf(`{"spec":{"rolloutAfter":"%s"}}`, rolloutAfter.UTC().Format(time.RFC3339))
+	params := []string{
+		"patch", kubeadmControlPlaneResourceType, clusterName, "--type=merge", "-p", patch,
+		"--kubeconfig", cluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace,
+	}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error rolling out kubeadmcontrolplane %s: %v", clusterName, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) GetMachineDeployment(ctx context.Context, workerNodeGroupName string, opts ...KubectlOpt) (*clusterv1.MachineDeployment, error) {
 	params := []string{"get", fmt.Sprintf("machinedeployments.%s", clusterv1.GroupVersion.Group), workerNodeGroupName, "-o", "json"}
 	applyOpts(&params, opts...)
@@ -832,6 +919,23 @@ func (k *Kubectl) GetMachineDeployment(ctx context.Context, workerNodeGroupName
 	return response, nil
 }
 
+func (k *Kubectl) GetSnowMachineTemplate(ctx context.Context, name string, opts ...KubectlOpt) (*snowv1.AWSSnowMachineTemplate, error) {
+	params := []string{"get", snowMachineTemplateResourceType, name, "-o", "json"}
+	applyOpts(&params, opts...)
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting snow machine template: %v", err)
+	}
+
+	response := &snowv1.AWSSnowMachineTemplate{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing get snow machine template response: %v", err)
+	}
+
+	return response, nil
+}
+
 func (k *Kubectl) GetMachineDeployments(ctx context.Context, opts ...KubectlOpt) ([]clusterv1.MachineDeployment, error) {
 	params := []string{"get", fmt.Sprintf("machinedeployments.%s", clusterv1.GroupVersion.Group), "-o", "json"}
 	applyOpts(&params, opts...)
@@ -1314,6 +1418,42 @@ func (k *Kubectl) getObject(ctx context.Context, resourceType, name, namespace,
 	return nil
 }
 
+// GetUnstructuredObject returns the object as unstructured, or nil if it doesn't exist
+func (k *Kubectl) GetUnstructuredObject(ctx context.Context, resourceType, name, namespace, kubeconfig string) (*unstructured.Unstructured, error) {
+	stdOut, err := k.Execute(ctx, "get", "--namespace", namespace, resourceType, name, "--ignore-not-found", "-o", "json", "--kubeconfig", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s with kubectl: %v", resourceType, err)
+	}
+
+	if len(stdOut.Bytes()) == 0 {
+		return nil, nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err = json.Unmarshal(stdOut.Bytes(), obj); err != nil {
+		return nil, fmt.Errorf("error parsing %s response: %v", resourceType, err)
+	}
+
+	return obj, nil
+}
+
+// GetUnstructuredObjects returns all the objects of resourceType
+func (k *Kubectl) GetUnstructuredObjects(ctx context.Context, resourceType string, opts ...KubectlOpt) ([]unstructured.Unstructured, error) {
+	params := []string{"get", resourceType, "-o", "json"}
+	applyOpts(&params, opts...)
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s with kubectl: %v", resourceType, err)
+	}
+
+	list := &unstructured.UnstructuredList{}
+	if err = json.Unmarshal(stdOut.Bytes(), list); err != nil {
+		return nil, fmt.Errorf("error parsing %s response: %v", resourceType, err)
+	}
+
+	return list.Items, nil
+}
+
 func (k *Kubectl) GetEksdRelease(ctx context.Context, name, namespace, kubeconfigFile string) (*eksdv1alpha1.Release, error) {
 	obj := &eksdv1alpha1.Release{}
 	if err := k.getObject(ctx, eksdReleaseType, name, namespace, kubeconfigFile, obj); err != nil {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -24,6 +25,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -348,6 +350,9 @@ func TestKubectlGetMachines(t *testing.T) {
 			jsonResponseFile: "testdata/kubectl_machines_no_node_ref_no_labels.json",
 			wantMachines: []types.Machine{
 				{
+					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
@@ -390,6 +395,9 @@ func TestKubectlGetMachines(t *testing.T) {
 					},
 				},
 				{
+					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
@@ -419,6 +427,7 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":  "eksa-test-capd",
 							"cluster.x-k8s.io/control-plane": "",
@@ -434,6 +443,7 @@ func TestKubectlGetMachines(t *testing.T) {
 				},
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":    "eksa-test-capd",
 							"cluster.x-k8s.io/deployment-name": "eksa-test-capd-md-0",
@@ -456,6 +466,7 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":  "eksa-test-capd",
 							"cluster.x-k8s.io/control-plane": "",
@@ -509,6 +520,7 @@ func TestKubectlGetMachines(t *testing.T) {
 				},
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":    "eksa-test-capd",
 							"cluster.x-k8s.io/deployment-name": "eksa-test-capd-md-0",
@@ -549,6 +561,7 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name": "eksa-test-capd",
 							"cluster.x-k8s.io/etcd-cluster": "",
@@ -1413,6 +1426,51 @@ func TestKubectlGetBundles(t *testing.T) {
 	tt.Expect(gotBundles).To(Equal(wantBundles))
 }
 
+func TestKubectlGetSnowMachineTemplate(t *testing.T) {
+	tt := newKubectlTest(t)
+	templateName := "snow-test-control-plane"
+	instanceType := "sbe-c.large"
+	wantTemplate := &snowv1.AWSSnowMachineTemplate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+			Kind:       "AWSSnowMachineTemplate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      templateName,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: snowv1.AWSSnowMachineTemplateSpec{
+			Template: snowv1.AWSSnowMachineTemplateResource{
+				Spec: snowv1.AWSSnowMachineSpec{
+					InstanceType: instanceType,
+				},
+			},
+		},
+	}
+	templateJson, err := json.Marshal(wantTemplate)
+	tt.Expect(err).To(BeNil())
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "awssnowmachinetemplates.infrastructure.cluster.x-k8s.io", templateName, "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace,
+	).Return(*bytes.NewBuffer(templateJson), nil)
+
+	gotTemplate, err := tt.k.GetSnowMachineTemplate(tt.ctx, templateName, executables.WithCluster(tt.cluster), executables.WithNamespace(constants.EksaSystemNamespace))
+	tt.Expect(err).To(BeNil())
+	tt.Expect(gotTemplate).To(Equal(wantTemplate))
+}
+
+func TestKubectlGetSnowMachineTemplateError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "awssnowmachinetemplates.infrastructure.cluster.x-k8s.io", "template", "-o", "json", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, errors.New("not found"))
+
+	_, err := tt.k.GetSnowMachineTemplate(tt.ctx, "template", executables.WithCluster(tt.cluster))
+	tt.Expect(err).To(MatchError(ContainSubstring("error getting snow machine template")))
+}
+
 func TestKubectlGetClusterResourceSet(t *testing.T) {
 	tt := newKubectlTest(t)
 	resourceSetJson := test.ReadFile(t, "testdata/kubectl_clusterresourceset.json")
@@ -1622,3 +1680,177 @@ func TestApplyTolerationsFromTaints(t *testing.T) {
 	var taints []corev1.Taint
 	tt.Expect(tt.k.ApplyTolerationsFromTaints(tt.ctx, taints, taints, "ds", "test", tt.cluster.KubeconfigFile, "testNs", "/test")).To(Succeed())
 }
+
+func TestKubectlGetUnstructuredObjectSuccess(t *testing.T) {
+	tt := newKubectlTest(t)
+	params := []string{
+		"get", "--namespace", tt.namespace, "kubeadmcontrolplane.controlplane.cluster.x-k8s.io", "test",
+		"--ignore-not-found", "-o", "json", "--kubeconfig", tt.kubeconfig,
+	}
+	response := `{"apiVersion":"controlplane.cluster.x-k8s.io/v1beta1","kind":"KubeadmControlPlane","metadata":{"name":"test"},"spec":{"replicas":3}}`
+	tt.e.EXPECT().Execute(tt.ctx, params).Return(*bytes.NewBufferString(response), nil)
+
+	obj, err := tt.k.GetUnstructuredObject(tt.ctx, "kubeadmcontrolplane.controlplane.cluster.x-k8s.io", "test", tt.namespace, tt.kubeconfig)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(obj.GetKind()).To(Equal("KubeadmControlPlane"))
+	tt.Expect(obj.Object["spec"]).To(Equal(map[string]interface{}{"replicas": int64(3)}))
+}
+
+func TestKubectlGetUnstructuredObjectNotFound(t *testing.T) {
+	tt := newKubectlTest(t)
+	params := []string{
+		"get", "--namespace", tt.namespace, "machinedeployment.cluster.x-k8s.io", "test",
+		"--ignore-not-found", "-o", "json", "--kubeconfig", tt.kubeconfig,
+	}
+	tt.e.EXPECT().Execute(tt.ctx, params).Return(bytes.Buffer{}, nil)
+
+	obj, err := tt.k.GetUnstructuredObject(tt.ctx, "machinedeployment.cluster.x-k8s.io", "test", tt.namespace, tt.kubeconfig)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(obj).To(BeNil())
+}
+
+func TestKubectlGetUnstructuredObjects(t *testing.T) {
+	tt := newKubectlTest(t)
+	params := []string{"get", "clusters.anywhere.eks.amazonaws.com", "-o", "json", "--kubeconfig", tt.kubeconfig, "-A"}
+	response := `{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"anywhere.eks.amazonaws.com/v1alpha1","kind":"Cluster","metadata":{"name":"mgmt","namespace":"default"}},{"apiVersion":"anywhere.eks.amazonaws.com/v1alpha1","kind":"Cluster","metadata":{"name":"workload","namespace":"other"}}]}`
+	tt.e.EXPECT().Execute(tt.ctx, params).Return(*bytes.NewBufferString(response), nil)
+
+	objs, err := tt.k.GetUnstructuredObjects(tt.ctx, "clusters.anywhere.eks.amazonaws.com", executables.WithKubeconfig(tt.kubeconfig), executables.WithAllNamespaces())
+	tt.Expect(err).To(Succeed())
+	tt.Expect(objs).To(HaveLen(2))
+	tt.Expect(objs[0].GetName()).To(Equal("mgmt"))
+	tt.Expect(objs[1].GetNamespace()).To(Equal("other"))
+}
+
+func TestKubectlGetUnstructuredObjectsError(t *testing.T) {
+	tt := newKubectlTest(t)
+	params := []string{"get", "clusters.anywhere.eks.amazonaws.com", "-o", "json", "--kubeconfig", tt.kubeconfig}
+	tt.e.EXPECT().Execute(tt.ctx, params).Return(bytes.Buffer{}, errors.New("error in exec"))
+
+	_, err := tt.k.GetUnstructuredObjects(tt.ctx, "clusters.anywhere.eks.amazonaws.com", executables.WithKubeconfig(tt.kubeconfig))
+	tt.Expect(err).To(MatchError(ContainSubstring("error getting clusters.anywhere.eks.amazonaws.com with kubectl: error in exec")))
+}
+
+func TestKubectlDrainNodeSuccess(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"drain", "node-1", "--ignore-daemonsets", "--delete-emptydir-data", "--force",
+		"--timeout", "10m", "--kubeconfig", tt.kubeconfig,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.DrainNode(tt.ctx, "node-1", tt.kubeconfig)).To(Succeed())
+}
+
+func TestKubectlDrainNodeError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"drain", "node-1", "--ignore-daemonsets", "--delete-emptydir-data", "--force",
+		"--timeout", "10m", "--kubeconfig", tt.kubeconfig,
+	).Return(bytes.Buffer{}, errors.New("error in drain"))
+
+	tt.Expect(tt.k.DrainNode(tt.ctx, "node-1", tt.kubeconfig)).To(MatchError(ContainSubstring("error draining node node-1")))
+}
+
+func TestKubectlUncordonNodeSuccess(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "uncordon", "node-1", "--kubeconfig", tt.kubeconfig).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.UncordonNode(tt.ctx, "node-1", tt.kubeconfig)).To(Succeed())
+}
+
+func TestKubectlLabelNodeSuccess(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx, "label", "node", "node-1", "key=value", "--overwrite", "--kubeconfig", tt.kubeconfig,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.LabelNode(tt.ctx, "node-1", "key", "value", tt.kubeconfig)).To(Succeed())
+}
+
+func TestKubectlLabelNodeError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error in label"))
+
+	tt.Expect(tt.k.LabelNode(tt.ctx, "node-1", "key", "value", tt.kubeconfig)).To(MatchError(ContainSubstring("error labeling node node-1")))
+}
+
+func TestKubectlRemoveNodesLabelSuccess(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx, "label", "nodes", "--selector", "key", "key-", "--kubeconfig", tt.kubeconfig,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.RemoveNodesLabel(tt.ctx, "key", tt.kubeconfig)).To(Succeed())
+}
+
+func TestKubectlDeletePodsOnNodeSuccess(t *testing.T) {
+	tt := newKubectlTest(t)
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy-1", Namespace: "kube-system"},
+				Spec:       corev1.PodSpec{HostNetwork: true},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "fluentd-1", Namespace: "logging"},
+			},
+		},
+	}
+	podsJson, err := json.Marshal(pods)
+	tt.Expect(err).To(Succeed())
+	gomock.InOrder(
+		tt.e.EXPECT().Execute(
+			tt.ctx, "get", "pods", "-o", "json", "-A", "--field-selector", "spec.nodeName=node-1", "--kubeconfig", tt.kubeconfig,
+		).Return(*bytes.NewBuffer(podsJson), nil),
+		tt.e.EXPECT().Execute(
+			tt.ctx, "delete", "pod", "fluentd-1", "--namespace", "logging", "--ignore-not-found", "--kubeconfig", tt.kubeconfig,
+		).Return(bytes.Buffer{}, nil),
+	)
+
+	tt.Expect(tt.k.DeletePodsOnNode(tt.ctx, "node-1", tt.kubeconfig)).To(Succeed())
+}
+
+func TestKubectlDeletePodsOnNodeError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error in get"))
+
+	tt.Expect(tt.k.DeletePodsOnNode(tt.ctx, "node-1", tt.kubeconfig)).To(MatchError(ContainSubstring("error getting pods in node node-1")))
+}
+
+func TestKubectlRolloutKubeadmControlPlaneSuccess(t *testing.T) {
+	tt := newKubectlTest(t)
+	rolloutAfter := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"patch", "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "test-cluster", "--type=merge", "-p", `{"spec":{"rolloutAfter":"2022-03-01T10:00:00Z"}}`,
+		"--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", "eksa-system",
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.RolloutKubeadmControlPlane(tt.ctx, tt.cluster, "test-cluster", rolloutAfter)).To(Succeed())
+}
+
+func TestKubectlRolloutKubeadmControlPlaneError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error in patch"))
+
+	tt.Expect(tt.k.RolloutKubeadmControlPlane(tt.ctx, tt.cluster, "test-cluster", time.Now())).To(MatchError(ContainSubstring("error rolling out kubeadmcontrolplane test-cluster")))
+}
+
+func TestKubectlRolloutRestartDaemonSetSuccess(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"rollout", "restart", "daemonset", "aws-iam-authenticator", "--namespace", "kube-system", "--kubeconfig", tt.kubeconfig,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.RolloutRestartDaemonSet(tt.ctx, "aws-iam-authenticator", "kube-system", tt.kubeconfig)).To(Succeed())
+}
+
+func TestKubectlRolloutRestartDaemonSetError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error in rollout"))
+
+	tt.Expect(tt.k.RolloutRestartDaemonSet(tt.ctx, "aws-iam-authenticator", "kube-system", tt.kubeconfig)).To(MatchError(ContainSubstring("error restarting daemonset aws-iam-authenticator")))
+}
@@ -65,6 +65,14 @@ func (t *Tink) GetHardware(ctx context.Context) ([]*hardware.Hardware, error) {
 	return hardwareList, nil
 }
 
+func (t *Tink) DeleteHardware(ctx context.Context, id string) error {
+	params := []string{"hardware", "delete", id, "--tinkerbell-cert-url", t.tinkerbellCertUrl, "--tinkerbell-grpc-authority", t.tinkerbellGrpcAuthority}
+	if _, err := t.Command(ctx, params...).Run(); err != nil {
+		return fmt.Errorf("error deleting hardware %s: %v", id, err)
+	}
+	return nil
+}
+
 func (t *Tink) GetWorkflow(ctx context.Context) ([]*workflow.Workflow, error) {
 	params := []string{"workflow", "get", "--tinkerbell-cert-url", t.tinkerbellCertUrl, "--tinkerbell-grpc-authority", t.tinkerbellGrpcAuthority, "--format", "json"}
 	data, err := t.Command(ctx, params...).Run()
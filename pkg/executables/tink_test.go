@@ -53,6 +53,15 @@ func TestTinkGetHardware(t *testing.T) {
 	}
 }
 
+func TestTinkDeleteHardware(t *testing.T) {
+	tink, ctx, e := newTink(t)
+	expectedParam := []string{"hardware", "delete", "hw-1", "--tinkerbell-cert-url", tinkerbellCertUrl, "--tinkerbell-grpc-authority", tinkerbellGrpcAuthority}
+	expectCommand(e, ctx, expectedParam...).to().Return(bytes.Buffer{}, nil)
+	if err := tink.DeleteHardware(ctx, "hw-1"); err != nil {
+		t.Errorf("Tink.DeleteHardware() error = %v, want nil", err)
+	}
+}
+
 func TestTinkGetWorkflow(t *testing.T) {
 	tink, ctx, e := newTink(t)
 	expectedParam := []string{"workflow", "get", "--tinkerbell-cert-url", tinkerbellCertUrl, "--tinkerbell-grpc-authority", tinkerbellGrpcAuthority, "--format", "json"}
@@ -7,6 +7,7 @@ const (
 	FullLifecycleAPIEnvVar   = "FULL_LIFECYCLE_API"
 	FullLifecycleGate        = "FullLifecycleAPI"
 	CuratedPackagesEnvVar    = "CURATED_PACKAGES_SUPPORT"
+	CalicoCNIEnvVar          = "CALICO_CNI"
 )
 
 func FeedGates(featureGates []string) {
@@ -56,3 +57,10 @@ func CuratedPackagesSupport() Feature {
 		IsActive: globalFeatures.isActiveForEnvVar(CuratedPackagesEnvVar),
 	}
 }
+
+func CalicoCNI() Feature {
+	return Feature{
+		Name:     "Calico CNI support",
+		IsActive: globalFeatures.isActiveForEnvVar(CalicoCNIEnvVar),
+	}
+}
@@ -0,0 +1,179 @@
+package manifestdiff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+type Operation string
+
+const (
+	Create    Operation = "create"
+	Update    Operation = "update"
+	Unchanged Operation = "unchanged"
+)
+
+// ObjectDiff describes how applying a desired object would change the live one
+type ObjectDiff struct {
+	APIVersion         string        `json:"apiVersion"`
+	Kind               string        `json:"kind"`
+	Namespace          string        `json:"namespace,omitempty"`
+	Name               string        `json:"name"`
+	Operation          Operation     `json:"operation"`
+	Changes            []FieldChange `json:"changes,omitempty"`
+	RollingReplacement bool          `json:"rollingReplacement"`
+	RolloutReasons     []string      `json:"rolloutReasons,omitempty"`
+}
+
+// FieldChange is a single field that differs between the live and the desired object
+type FieldChange struct {
+	Path    string      `json:"path"`
+	Current interface{} `json:"current,omitempty"`
+	Desired interface{} `json:"desired,omitempty"`
+}
+
+// ignoredFields are set by the api server or the controllers, so they never match the desired object
+var ignoredFields = map[string]struct{}{
+	"apiVersion": {},
+	"kind":       {},
+	"metadata":   {},
+	"status":     {},
+}
+
+// ParseObjects splits a multi document yaml manifest into unstructured objects, skipping empty documents
+func ParseObjects(manifest []byte) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, doc := range strings.Split(string(manifest), v1alpha1.YamlSeparator) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), obj); err != nil {
+			return nil, fmt.Errorf("parsing manifest object: %v", err)
+		}
+		if obj.GetKind() == "" {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// Objects compares the desired object with the live one. current is nil when the object doesn't exist yet.
+// Only fields set in the desired object are compared, so defaults added by the api server are not reported as changes
+func Objects(desired, current *unstructured.Unstructured) ObjectDiff {
+	diff := ObjectDiff{
+		APIVersion: desired.GetAPIVersion(),
+		Kind:       desired.GetKind(),
+		Namespace:  desired.GetNamespace(),
+		Name:       desired.GetName(),
+	}
+
+	if current == nil {
+		diff.Operation = Create
+	} else {
+		diff.Changes = objectChanges(desired, current)
+		diff.Operation = Unchanged
+		if len(diff.Changes) > 0 {
+			diff.Operation = Update
+		}
+	}
+
+	diff.RolloutReasons = rolloutReasons(diff)
+	diff.RollingReplacement = len(diff.RolloutReasons) > 0
+
+	return diff
+}
+
+func objectChanges(desired, current *unstructured.Unstructured) []FieldChange {
+	var changes []FieldChange
+	for _, key := range sortedKeys(desired.Object) {
+		if _, ignored := ignoredFields[key]; ignored {
+			continue
+		}
+		changes = append(changes, fieldChanges(key, desired.Object[key], current.Object[key])...)
+	}
+
+	changes = append(changes, fieldChanges("metadata.labels", desired.GetLabels(), stringMap(current.GetLabels()))...)
+	changes = append(changes, fieldChanges("metadata.annotations", desired.GetAnnotations(), stringMap(current.GetAnnotations()))...)
+
+	return changes
+}
+
+func fieldChanges(path string, desired, current interface{}) []FieldChange {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		currentValue, ok := current.(map[string]interface{})
+		if !ok {
+			return []FieldChange{{Path: path, Current: current, Desired: desired}}
+		}
+		var changes []FieldChange
+		for _, key := range sortedKeys(desiredValue) {
+			changes = append(changes, fieldChanges(path+"."+key, desiredValue[key], currentValue[key])...)
+		}
+		return changes
+	case map[string]string:
+		currentValue, _ := current.(map[string]string)
+		var changes []FieldChange
+		for _, key := range sortedStringKeys(desiredValue) {
+			if currentValue[key] != desiredValue[key] {
+				changes = append(changes, FieldChange{Path: path + "." + key, Current: emptyAsNil(currentValue[key]), Desired: desiredValue[key]})
+			}
+		}
+		return changes
+	case []interface{}:
+		currentValue, ok := current.([]interface{})
+		if !ok || len(currentValue) != len(desiredValue) {
+			return []FieldChange{{Path: path, Current: current, Desired: desired}}
+		}
+		var changes []FieldChange
+		for i := range desiredValue {
+			changes = append(changes, fieldChanges(fmt.Sprintf("%s[%d]", path, i), desiredValue[i], currentValue[i])...)
+		}
+		return changes
+	default:
+		if desired == nil || reflect.DeepEqual(desired, current) {
+			return nil
+		}
+		return []FieldChange{{Path: path, Current: current, Desired: desired}}
+	}
+}
+
+func stringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+func emptyAsNil(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
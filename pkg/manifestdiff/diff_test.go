@@ -0,0 +1,120 @@
+package manifestdiff_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/manifestdiff"
+)
+
+const kcp = `apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: test
+  namespace: eksa-system
+spec:
+  replicas: %d
+  version: %s
+`
+
+func kubeadmControlPlane(t *testing.T, replicas int, version string) *unstructured.Unstructured {
+	objs, err := manifestdiff.ParseObjects([]byte(fmt.Sprintf(kcp, replicas, version)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return objs[0]
+}
+
+func TestParseObjects(t *testing.T) {
+	g := NewWithT(t)
+	manifest := []byte(`apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: test
+---
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: test-md-0
+`)
+
+	objs, err := manifestdiff.ParseObjects(manifest)
+	g.Expect(err).To(BeNil())
+	g.Expect(objs).To(HaveLen(2))
+	g.Expect(objs[0].GetKind()).To(Equal("Cluster"))
+	g.Expect(objs[1].GetName()).To(Equal("test-md-0"))
+}
+
+func TestObjectsCreate(t *testing.T) {
+	g := NewWithT(t)
+	desired := &unstructured.Unstructured{}
+	desired.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta1")
+	desired.SetKind("VSphereMachineTemplate")
+	desired.SetName("test-control-plane-template-2")
+
+	diff := manifestdiff.Objects(desired, nil)
+	g.Expect(diff.Operation).To(Equal(manifestdiff.Create))
+	g.Expect(diff.RollingReplacement).To(BeTrue())
+}
+
+func TestObjectsUnchanged(t *testing.T) {
+	g := NewWithT(t)
+	desired := kubeadmControlPlane(t, 3, "v1.21.2-eks-1-21-4")
+	current := kubeadmControlPlane(t, 3, "v1.21.2-eks-1-21-4")
+	current.Object["status"] = map[string]interface{}{"ready": true}
+	current.SetResourceVersion("1234")
+	_ = unstructured.SetNestedField(current.Object, "default", "spec", "rolloutStrategy", "type")
+
+	diff := manifestdiff.Objects(desired, current)
+	g.Expect(diff.Operation).To(Equal(manifestdiff.Unchanged))
+	g.Expect(diff.Changes).To(BeEmpty())
+	g.Expect(diff.RollingReplacement).To(BeFalse())
+}
+
+func TestObjectsScaleDoesNotRollout(t *testing.T) {
+	g := NewWithT(t)
+	diff := manifestdiff.Objects(kubeadmControlPlane(t, 5, "v1.21.2-eks-1-21-4"), kubeadmControlPlane(t, 3, "v1.21.2-eks-1-21-4"))
+
+	g.Expect(diff.Operation).To(Equal(manifestdiff.Update))
+	g.Expect(diff.Changes).To(ConsistOf(manifestdiff.FieldChange{Path: "spec.replicas", Current: int64(3), Desired: int64(5)}))
+	g.Expect(diff.RollingReplacement).To(BeFalse())
+}
+
+func TestObjectsVersionChangeRollsOut(t *testing.T) {
+	g := NewWithT(t)
+	diff := manifestdiff.Objects(kubeadmControlPlane(t, 3, "v1.22.6-eks-1-22-1"), kubeadmControlPlane(t, 3, "v1.21.2-eks-1-21-4"))
+
+	g.Expect(diff.Operation).To(Equal(manifestdiff.Update))
+	g.Expect(diff.RollingReplacement).To(BeTrue())
+	g.Expect(diff.RolloutReasons).To(ConsistOf("spec.version changed"))
+}
+
+func TestObjectsMachineDeploymentTemplateRollsOut(t *testing.T) {
+	g := NewWithT(t)
+	desired := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cluster.x-k8s.io/v1beta1",
+		"kind":       "MachineDeployment",
+		"metadata":   map[string]interface{}{"name": "test-md-0", "labels": map[string]interface{}{"pool": "md-0"}},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"infrastructureRef": map[string]interface{}{"name": "test-md-0-2"},
+				},
+			},
+		},
+	}}
+	current := desired.DeepCopy()
+	_ = unstructured.SetNestedField(current.Object, "test-md-0-1", "spec", "template", "spec", "infrastructureRef", "name")
+	current.SetLabels(nil)
+
+	diff := manifestdiff.Objects(desired, current)
+	g.Expect(diff.Changes).To(ConsistOf(
+		manifestdiff.FieldChange{Path: "spec.template.spec.infrastructureRef.name", Current: "test-md-0-1", Desired: "test-md-0-2"},
+		manifestdiff.FieldChange{Path: "metadata.labels.pool", Desired: "md-0"},
+	))
+	g.Expect(diff.RolloutReasons).To(ConsistOf("spec.template.spec.infrastructureRef.name changed"))
+}
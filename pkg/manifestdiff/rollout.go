@@ -0,0 +1,43 @@
+package manifestdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rolloutFields are the paths that make the cluster-api controllers replace machines when they change
+var rolloutFields = map[string][]string{
+	"KubeadmControlPlane": {
+		"spec.version",
+		"spec.machineTemplate",
+		"spec.kubeadmConfigSpec",
+		"spec.rolloutAfter",
+	},
+	"MachineDeployment": {
+		"spec.template",
+	},
+	"EtcdadmCluster": {
+		"spec.etcdadmConfigSpec",
+		"spec.infrastructureTemplate",
+	},
+}
+
+func rolloutReasons(diff ObjectDiff) []string {
+	if diff.Operation == Create {
+		if strings.HasSuffix(diff.Kind, "MachineTemplate") {
+			return []string{"new machine template, machines referencing it will be replaced"}
+		}
+		return nil
+	}
+
+	var reasons []string
+	for _, change := range diff.Changes {
+		for _, field := range rolloutFields[diff.Kind] {
+			if change.Path == field || strings.HasPrefix(change.Path, field+".") || strings.HasPrefix(change.Path, field+"[") {
+				reasons = append(reasons, fmt.Sprintf("%s changed", change.Path))
+				break
+			}
+		}
+	}
+	return reasons
+}
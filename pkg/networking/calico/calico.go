@@ -0,0 +1,235 @@
+package calico
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	networking "github.com/aws/eks-anywhere/pkg/networking/internal"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const (
+	calicoConfigMapName     = "calico-config"
+	calicoNodeName          = "calico-node"
+	calicoCRDAPIVersion     = "crd.projectcalico.org/v1"
+	calicoBackendVXLAN      = "vxlan"
+	calicoBackendBird       = "bird"
+	calicoPoolEncapAlways   = "Always"
+	calicoPoolEncapNever    = "Never"
+	birdLivenessProbeFlag   = "-bird-live"
+	birdReadinessProbeFlag  = "-bird-ready"
+	calicoPoolCIDREnv       = "CALICO_IPV4POOL_CIDR"
+	calicoPoolIPIPEnv       = "CALICO_IPV4POOL_IPIP"
+	calicoPoolVXLANEnv      = "CALICO_IPV4POOL_VXLAN"
+	calicoBGPConfigName     = "default"
+	calicoBGPPeerNamePrefix = "eksa-peer-"
+)
+
+type Calico struct {
+	*Upgrader
+}
+
+func NewCalico(client Client) *Calico {
+	return &Calico{
+		Upgrader: NewUpgrader(client),
+	}
+}
+
+func (c *Calico) GenerateManifest(ctx context.Context, clusterSpec *cluster.Spec, namespaces []string) ([]byte, error) {
+	return generateManifest(clusterSpec)
+}
+
+func generateManifest(clusterSpec *cluster.Spec) ([]byte, error) {
+	// Calico is only released in the dev bundles for now
+	if clusterSpec.VersionsBundle.Calico.Manifest.URI == "" {
+		return nil, fmt.Errorf("calico manifest is not available in bundle %d", clusterSpec.Bundles.Spec.Number)
+	}
+
+	config := calicoConfig(clusterSpec)
+	content, err := networking.LoadManifest(clusterSpec, clusterSpec.VersionsBundle.Calico.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("can't load calico manifest: %v", err)
+	}
+	templates := strings.Split(string(content), "---")
+	finalTemplates := make([][]byte, 0, len(templates))
+	for _, template := range templates {
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(template), u); err != nil {
+			return nil, fmt.Errorf("unmarshaling calico type [%s]: %v", template, err)
+		}
+		switch {
+		case u.GetKind() == "ConfigMap" && u.GetName() == calicoConfigMapName:
+			updated, err := updateBackend(config, u)
+			if err != nil {
+				return nil, fmt.Errorf("updating calico backend [%s]: %v", template, err)
+			}
+			finalTemplates = append(finalTemplates, updated)
+		case u.GetKind() == "DaemonSet" && u.GetName() == calicoNodeName:
+			updated, err := updateIPPool(clusterSpec, config, u)
+			if err != nil {
+				return nil, fmt.Errorf("updating calico ip pool [%s]: %v", template, err)
+			}
+			finalTemplates = append(finalTemplates, updated)
+		default:
+			finalTemplates = append(finalTemplates, []byte(template))
+		}
+	}
+
+	if backend(config) == calicoBackendBird {
+		bgpTemplates, err := bgpResources(config)
+		if err != nil {
+			return nil, err
+		}
+		finalTemplates = append(finalTemplates, bgpTemplates...)
+	}
+
+	return templater.AppendYamlResources(finalTemplates...), nil
+}
+
+// calicoConfig returns the calico config of spec, which is empty when calico is not configured
+func calicoConfig(spec *cluster.Spec) *v1alpha1.CalicoConfig {
+	if spec.Cluster.Spec.ClusterNetwork.CNIConfig == nil || spec.Cluster.Spec.ClusterNetwork.CNIConfig.Calico == nil {
+		return &v1alpha1.CalicoConfig{}
+	}
+	return spec.Cluster.Spec.ClusterNetwork.CNIConfig.Calico
+}
+
+// backend returns the calico networking backend, vxlan doesn't need BGP so it runs without bird
+func backend(config *v1alpha1.CalicoConfig) string {
+	if config.EncapsulationMode() == v1alpha1.CalicoEncapsulationVXLAN {
+		return calicoBackendVXLAN
+	}
+	return calicoBackendBird
+}
+
+func updateBackend(config *v1alpha1.CalicoConfig, u *unstructured.Unstructured) ([]byte, error) {
+	if err := unstructured.SetNestedField(u.Object, backend(config), "data", "calico_backend"); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(u.Object)
+}
+
+func updateIPPool(clusterSpec *cluster.Spec, config *v1alpha1.CalicoConfig, u *unstructured.Unstructured) ([]byte, error) {
+	var daemonSet appsv1.DaemonSet
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), &daemonSet); err != nil {
+		return nil, fmt.Errorf("unmarshaling calico daemonset: %v", err)
+	}
+
+	ipip, vxlan := calicoPoolEncapNever, calicoPoolEncapNever
+	switch config.EncapsulationMode() {
+	case v1alpha1.CalicoEncapsulationIPIP:
+		ipip = calicoPoolEncapAlways
+	case v1alpha1.CalicoEncapsulationVXLAN:
+		vxlan = calicoPoolEncapAlways
+	}
+
+	containers := daemonSet.Spec.Template.Spec.Containers
+	for idx := range containers {
+		if containers[idx].Name != calicoNodeName {
+			continue
+		}
+		container := &containers[idx]
+		container.Env = setEnv(container.Env, calicoPoolCIDREnv, clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks[0])
+		container.Env = setEnv(container.Env, calicoPoolIPIPEnv, ipip)
+		container.Env = setEnv(container.Env, calicoPoolVXLANEnv, vxlan)
+		if backend(config) == calicoBackendVXLAN {
+			removeProbeFlag(container.LivenessProbe, birdLivenessProbeFlag)
+			removeProbeFlag(container.ReadinessProbe, birdReadinessProbeFlag)
+		}
+		return yaml.Marshal(daemonSet)
+	}
+
+	return nil, fmt.Errorf("missing %s container in calico daemonset", calicoNodeName)
+}
+
+func setEnv(env []corev1.EnvVar, name, value string) []corev1.EnvVar {
+	for idx := range env {
+		if env[idx].Name == name {
+			env[idx].Value = value
+			env[idx].ValueFrom = nil
+			return env
+		}
+	}
+	return append(env, corev1.EnvVar{Name: name, Value: value})
+}
+
+func removeProbeFlag(probe *corev1.Probe, flag string) {
+	if probe == nil || probe.Exec == nil {
+		return
+	}
+	command := make([]string, 0, len(probe.Exec.Command))
+	for _, arg := range probe.Exec.Command {
+		if arg != flag {
+			command = append(command, arg)
+		}
+	}
+	probe.Exec.Command = command
+}
+
+func bgpResources(config *v1alpha1.CalicoConfig) ([][]byte, error) {
+	bgpConfig, err := yaml.Marshal(bgpConfiguration(config))
+	if err != nil {
+		return nil, fmt.Errorf("marshaling calico bgp configuration: %v", err)
+	}
+	resources := [][]byte{bgpConfig}
+
+	peers, err := bgpPeerResources(config.BGPPeers)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(resources, peers...), nil
+}
+
+func bgpPeerResources(peers []v1alpha1.CalicoBGPPeer) ([][]byte, error) {
+	resources := make([][]byte, 0, len(peers))
+	for _, peer := range peers {
+		p, err := yaml.Marshal(bgpPeer(peer))
+		if err != nil {
+			return nil, fmt.Errorf("marshaling calico bgp peer %s: %v", peer.PeerIP, err)
+		}
+		resources = append(resources, p)
+	}
+	return resources, nil
+}
+
+func bgpConfiguration(config *v1alpha1.CalicoConfig) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": calicoCRDAPIVersion,
+		"kind":       "BGPConfiguration",
+		"metadata": map[string]interface{}{
+			"name": calicoBGPConfigName,
+		},
+		"spec": map[string]interface{}{
+			"asNumber":              config.NodeASNumber(),
+			"nodeToNodeMeshEnabled": true,
+		},
+	}
+}
+
+func bgpPeer(peer v1alpha1.CalicoBGPPeer) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": calicoCRDAPIVersion,
+		"kind":       "BGPPeer",
+		"metadata": map[string]interface{}{
+			"name": bgpPeerName(peer),
+		},
+		"spec": map[string]interface{}{
+			"peerIP":   peer.PeerIP,
+			"asNumber": peer.ASNumber,
+		},
+	}
+}
+
+func bgpPeerName(peer v1alpha1.CalicoBGPPeer) string {
+	return calicoBGPPeerNamePrefix + strings.NewReplacer(".", "-", ":", "-").Replace(peer.PeerIP)
+}
@@ -0,0 +1,105 @@
+package calico_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/networking/calico"
+	"github.com/aws/eks-anywhere/pkg/networking/calico/mocks"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type calicoTest struct {
+	*WithT
+	c      *calico.Calico
+	client *mocks.MockClient
+}
+
+func newCalicoTest(t *testing.T) *calicoTest {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	return &calicoTest{
+		WithT:  NewWithT(t),
+		client: client,
+		c:      calico.NewCalico(client),
+	}
+}
+
+var CalicoBundle = releasev1alpha1.CalicoBundle{
+	Manifest: releasev1alpha1.Manifest{
+		URI: "testdata/calico_manifest.yaml",
+	},
+}
+
+func newCalicoSpec(config *v1alpha1.CalicoConfig) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.1.0/24"}
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Calico: config}
+		s.VersionsBundle.Calico = *CalicoBundle.DeepCopy()
+	})
+}
+
+func TestCalicoGenerateManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *v1alpha1.CalicoConfig
+		wantFile string
+	}{
+		{
+			name:     "default vxlan",
+			config:   &v1alpha1.CalicoConfig{},
+			wantFile: "testdata/expected_calico_vxlan_manifest.yaml",
+		},
+		{
+			name: "ipip with bgp peers",
+			config: &v1alpha1.CalicoConfig{
+				Encapsulation: v1alpha1.CalicoEncapsulationIPIP,
+				BGPPeers: []v1alpha1.CalicoBGPPeer{
+					{PeerIP: "10.0.0.1", ASNumber: 65001},
+					{PeerIP: "10.0.0.2", ASNumber: 65001},
+				},
+			},
+			wantFile: "testdata/expected_calico_ipip_bgp_manifest.yaml",
+		},
+		{
+			name: "no encapsulation with custom as number",
+			config: &v1alpha1.CalicoConfig{
+				Encapsulation: v1alpha1.CalicoEncapsulationNone,
+				ASNumber:      65010,
+			},
+			wantFile: "testdata/expected_calico_none_manifest.yaml",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newCalicoTest(t)
+			gotFileContent, err := tt.c.GenerateManifest(context.Background(), newCalicoSpec(tc.config), []string{})
+			tt.Expect(err).To(Not(HaveOccurred()))
+			test.AssertContentToFile(t, string(gotFileContent), tc.wantFile)
+		})
+	}
+}
+
+func TestCalicoGenerateManifestError(t *testing.T) {
+	tt := newCalicoTest(t)
+	clusterSpec := newCalicoSpec(&v1alpha1.CalicoConfig{})
+	clusterSpec.VersionsBundle.Calico.Manifest.URI = "testdata/missing_manifest.yaml"
+
+	_, err := tt.c.GenerateManifest(context.Background(), clusterSpec, []string{})
+	tt.Expect(err).To(HaveOccurred())
+}
+
+func TestCalicoGenerateManifestNotInBundle(t *testing.T) {
+	tt := newCalicoTest(t)
+	clusterSpec := newCalicoSpec(&v1alpha1.CalicoConfig{})
+	clusterSpec.VersionsBundle.Calico.Manifest.URI = ""
+
+	_, err := tt.c.GenerateManifest(context.Background(), clusterSpec, []string{})
+	tt.Expect(err).To(MatchError(ContainSubstring("calico manifest is not available in bundle")))
+}
@@ -0,0 +1,99 @@
+package calico
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type Client interface {
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+type Upgrader struct {
+	client Client
+}
+
+func NewUpgrader(client Client) *Upgrader {
+	return &Upgrader{
+		client: client,
+	}
+}
+
+func (u Upgrader) Upgrade(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error) {
+	diff := calicoChangeDiff(currentSpec, newSpec)
+	if diff == nil {
+		logger.V(1).Info("Nothing to upgrade for Calico")
+		return nil, nil
+	}
+
+	manifest, err := generateManifest(newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.client.ApplyKubeSpecFromBytes(ctx, cluster, manifest); err != nil {
+		return nil, fmt.Errorf("failed applying calico manifest during upgrade: %v", err)
+	}
+
+	// apply doesn't prune, so the peers removed from the cluster spec need to be deleted explicitly
+	removedPeers := removedBGPPeers(calicoConfig(currentSpec), calicoConfig(newSpec))
+	if len(removedPeers) > 0 {
+		logger.V(3).Info("Deleting removed Calico BGP peers", "peers", len(removedPeers))
+		peers, err := bgpPeerResources(removedPeers)
+		if err != nil {
+			return nil, err
+		}
+		if err := u.client.DeleteKubeSpecFromBytes(ctx, cluster, templater.AppendYamlResources(peers...)); err != nil {
+			return nil, fmt.Errorf("failed deleting calico bgp peers during upgrade: %v", err)
+		}
+	}
+
+	return diff, nil
+}
+
+func removedBGPPeers(currentConfig, newConfig *v1alpha1.CalicoConfig) []v1alpha1.CalicoBGPPeer {
+	newPeers := make(map[string]struct{}, len(newConfig.BGPPeers))
+	for _, peer := range newConfig.BGPPeers {
+		newPeers[bgpPeerName(peer)] = struct{}{}
+	}
+
+	removed := make([]v1alpha1.CalicoBGPPeer, 0, len(currentConfig.BGPPeers))
+	for _, peer := range currentConfig.BGPPeers {
+		if _, ok := newPeers[bgpPeerName(peer)]; !ok {
+			removed = append(removed, peer)
+		}
+	}
+	return removed
+}
+
+func usesCalico(spec *cluster.Spec) bool {
+	return spec.Cluster.Spec.ClusterNetwork.CNIConfig != nil && spec.Cluster.Spec.ClusterNetwork.CNIConfig.Calico != nil
+}
+
+func calicoChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
+	if !usesCalico(currentSpec) && !usesCalico(newSpec) {
+		return nil
+	}
+
+	if currentSpec.VersionsBundle.Calico.Version == newSpec.VersionsBundle.Calico.Version &&
+		calicoConfig(currentSpec).Equal(calicoConfig(newSpec)) {
+		return nil
+	}
+
+	return types.NewChangeDiff(&types.ComponentChangeDiff{
+		ComponentName: "calico",
+		OldVersion:    currentSpec.VersionsBundle.Calico.Version,
+		NewVersion:    newSpec.VersionsBundle.Calico.Version,
+	})
+}
+
+func ChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
+	return calicoChangeDiff(currentSpec, newSpec)
+}
@@ -0,0 +1,110 @@
+package calico_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/networking/calico"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type upgraderTest struct {
+	*calicoTest
+	ctx                  context.Context
+	u                    *calico.Upgrader
+	currentSpec, newSpec *cluster.Spec
+	cluster              *types.Cluster
+}
+
+func newUpgraderTest(t *testing.T) *upgraderTest {
+	ct := newCalicoTest(t)
+	currentSpec := newCalicoSpec(&v1alpha1.CalicoConfig{})
+	currentSpec.VersionsBundle.Calico.Version = "v3.22.1"
+	newSpec := newCalicoSpec(&v1alpha1.CalicoConfig{})
+	newSpec.VersionsBundle.Calico.Version = "v3.22.2"
+	return &upgraderTest{
+		calicoTest:  ct,
+		ctx:         context.Background(),
+		u:           calico.NewUpgrader(ct.client),
+		currentSpec: currentSpec,
+		newSpec:     newSpec,
+		cluster: &types.Cluster{
+			KubeconfigFile: "kubeconfig",
+		},
+	}
+}
+
+func (tt *upgraderTest) wantChangeDiff() *types.ChangeDiff {
+	return types.NewChangeDiff(&types.ComponentChangeDiff{
+		ComponentName: "calico",
+		OldVersion:    tt.currentSpec.VersionsBundle.Calico.Version,
+		NewVersion:    tt.newSpec.VersionsBundle.Calico.Version,
+	})
+}
+
+func TestUpgraderUpgradeSuccess(t *testing.T) {
+	tt := newUpgraderTest(t)
+	manifest := []byte(test.ReadFile(t, "testdata/expected_calico_vxlan_manifest.yaml"))
+	tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, manifest)
+
+	tt.Expect(tt.u.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(tt.wantChangeDiff()), "upgrader.Upgrade() should succeed and return correct ChangeDiff")
+}
+
+func TestUpgraderUpgradeConfigChangedDeletesRemovedPeers(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.newSpec.VersionsBundle.Calico.Version = tt.currentSpec.VersionsBundle.Calico.Version
+	tt.currentSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Calico = &v1alpha1.CalicoConfig{
+		Encapsulation: v1alpha1.CalicoEncapsulationIPIP,
+		BGPPeers: []v1alpha1.CalicoBGPPeer{
+			{PeerIP: "10.0.0.1", ASNumber: 65001},
+			{PeerIP: "10.0.0.2", ASNumber: 65001},
+			{PeerIP: "10.0.0.3", ASNumber: 65001},
+		},
+	}
+	tt.newSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Calico = &v1alpha1.CalicoConfig{
+		Encapsulation: v1alpha1.CalicoEncapsulationIPIP,
+		BGPPeers: []v1alpha1.CalicoBGPPeer{
+			{PeerIP: "10.0.0.1", ASNumber: 65001},
+			{PeerIP: "10.0.0.2", ASNumber: 65001},
+		},
+	}
+	manifest := []byte(test.ReadFile(t, "testdata/expected_calico_ipip_bgp_manifest.yaml"))
+	removedPeer := []byte(`apiVersion: crd.projectcalico.org/v1
+kind: BGPPeer
+metadata:
+  name: eksa-peer-10-0-0-3
+spec:
+  asNumber: 65001
+  peerIP: 10.0.0.3
+
+---
+`)
+
+	gomock.InOrder(
+		tt.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, manifest),
+		tt.client.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, removedPeer),
+	)
+
+	tt.Expect(tt.u.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(tt.wantChangeDiff()), "upgrader.Upgrade() should succeed and return correct ChangeDiff")
+}
+
+func TestUpgraderUpgradeNotNeeded(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.newSpec.VersionsBundle.Calico.Version = tt.currentSpec.VersionsBundle.Calico.Version
+
+	tt.Expect(tt.u.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(BeNil(), "upgrader.Upgrade() should succeed and return nil ChangeDiff")
+}
+
+func TestChangeDiffNotCalico(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.currentSpec.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}
+	tt.newSpec.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}
+
+	tt.Expect(calico.ChangeDiff(tt.currentSpec, tt.newSpec)).To(BeNil())
+}
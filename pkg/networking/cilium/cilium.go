@@ -27,7 +27,7 @@ func (c *Cilium) GenerateManifest(ctx context.Context, clusterSpec *cluster.Spec
 		return nil, err
 	}
 
-	if clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode != v1alpha1.CiliumPolicyModeAlways {
+	if !installNetworkPolicies(clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium) {
 		return ciliumManifest, nil
 	}
 
@@ -38,3 +38,9 @@ func (c *Cilium) GenerateManifest(ctx context.Context, clusterSpec *cluster.Spec
 
 	return templater.AppendYamlResources(ciliumManifest, networkPolicyManifest), nil
 }
+
+// installNetworkPolicies returns true if the EKS Anywhere network policies are needed, either to allow the traffic
+// of its components when all traffic is denied by default or to report their denied flows in audit mode.
+func installNetworkPolicies(config *v1alpha1.CiliumConfig) bool {
+	return config.PolicyEnforcementMode == v1alpha1.CiliumPolicyModeAlways || config.PolicyAuditMode
+}
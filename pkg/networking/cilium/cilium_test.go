@@ -83,3 +83,16 @@ func TestCiliumGenerateManifestAndGenerateNetworkPolicySuccess(t *testing.T) {
 	tt.Expect(err).To(Not(HaveOccurred()), "GenerateManifest() should succeed")
 	test.AssertContentToFile(t, string(content), "testdata/manifest_network_policy.yaml")
 }
+
+func TestCiliumGenerateManifestPolicyAuditModeGeneratesNetworkPolicy(t *testing.T) {
+	tt := newCiliumTest(t)
+	tt.h.EXPECT().Template(
+		tt.ctx, gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf(map[string]interface{}{}),
+	).Return(tt.ciliumValues, nil)
+
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyAuditMode = true
+	tt.spec.Cluster.Spec.ManagementCluster.Name = "managed"
+	content, err := tt.cilium.GenerateManifest(tt.ctx, tt.spec, []string{})
+	tt.Expect(err).To(Not(HaveOccurred()), "GenerateManifest() should succeed")
+	test.AssertContentToFile(t, string(content), "testdata/manifest_network_policy.yaml")
+}
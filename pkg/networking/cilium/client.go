@@ -2,10 +2,13 @@ package cilium
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -15,6 +18,7 @@ const (
 	ciliumPreflightDaemonSetName  = "cilium-pre-flight-check"
 	ciliumDeploymentName          = "cilium-operator"
 	ciliumPreflightDeploymentName = "cilium-pre-flight-check"
+	ciliumAgentSelector           = "k8s-app=cilium"
 )
 
 type Client interface {
@@ -22,6 +26,9 @@ type Client interface {
 	DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	GetDaemonSet(ctx context.Context, name, namespace, kubeconfig string) (*v1.DaemonSet, error)
 	GetDeployment(ctx context.Context, name, namespace, kubeconfig string) (*v1.Deployment, error)
+	GetPods(ctx context.Context, opts ...executables.KubectlOpt) ([]corev1.Pod, error)
+	LabelNode(ctx context.Context, nodeName, key, value, kubeconfig string) error
+	RemoveNodesLabel(ctx context.Context, key, kubeconfig string) error
 }
 
 type retrierClient struct {
@@ -144,3 +151,50 @@ func (c *retrierClient) checkCiliumDeploymentReady(ctx context.Context, cluster
 
 	return nil
 }
+
+func (c *retrierClient) LabelMigratedNode(ctx context.Context, cluster *types.Cluster, nodeName string) error {
+	return c.Retry(
+		func() error {
+			return c.LabelNode(ctx, nodeName, migratedNodeLabel, "true", cluster.KubeconfigFile)
+		},
+	)
+}
+
+func (c *retrierClient) RemoveMigratedNodesLabel(ctx context.Context, cluster *types.Cluster) error {
+	return c.Retry(
+		func() error {
+			return c.RemoveNodesLabel(ctx, migratedNodeLabel, cluster.KubeconfigFile)
+		},
+	)
+}
+
+func (c *retrierClient) WaitForCiliumAgent(ctx context.Context, cluster *types.Cluster, nodeName string) error {
+	return c.Retry(
+		func() error {
+			return c.checkCiliumAgentReady(ctx, cluster, nodeName)
+		},
+	)
+}
+
+func (c *retrierClient) checkCiliumAgentReady(ctx context.Context, cluster *types.Cluster, nodeName string) error {
+	pods, err := c.GetPods(ctx,
+		executables.WithNamespace(namespace),
+		executables.WithArgs([]string{"--selector", ciliumAgentSelector, "--field-selector", "spec.nodeName=" + nodeName}),
+		executables.WithKubeconfig(cluster.KubeconfigFile),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(pods) == 0 {
+		return fmt.Errorf("cilium agent not scheduled in node %s yet", nodeName)
+	}
+
+	for _, pod := range pods {
+		if err := checkPodReady(&pod); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/pkg/networking/cilium/mocks"
@@ -192,3 +193,47 @@ func TestRetrierClientWaitForCiliumDeploymentError(t *testing.T) {
 
 	tt.Expect(tt.r.WaitForCiliumDeployment(tt.ctx, tt.cluster)).To(MatchError(ContainSubstring("error in get")), "retrierClient.waitForCiliumDeployment() should fail after 5 tries")
 }
+
+func TestRetrierClientLabelMigratedNodeSuccess(t *testing.T) {
+	tt := newRetrierTest(t)
+	tt.c.EXPECT().LabelNode(tt.ctx, "node-1", "anywhere.eks.amazonaws.com/cilium-migrated", "true", tt.cluster.KubeconfigFile).Return(errors.New("error in label")).Times(5)
+	tt.c.EXPECT().LabelNode(tt.ctx, "node-1", "anywhere.eks.amazonaws.com/cilium-migrated", "true", tt.cluster.KubeconfigFile).Return(nil)
+
+	tt.Expect(tt.r.LabelMigratedNode(tt.ctx, tt.cluster, "node-1")).To(Succeed(), "retrierClient.LabelMigratedNode() should succeed after 6 tries")
+}
+
+func TestRetrierClientRemoveMigratedNodesLabelSuccess(t *testing.T) {
+	tt := newRetrierTest(t)
+	tt.c.EXPECT().RemoveNodesLabel(tt.ctx, "anywhere.eks.amazonaws.com/cilium-migrated", tt.cluster.KubeconfigFile).Return(errors.New("error in label")).Times(5)
+	tt.c.EXPECT().RemoveNodesLabel(tt.ctx, "anywhere.eks.amazonaws.com/cilium-migrated", tt.cluster.KubeconfigFile).Return(nil)
+
+	tt.Expect(tt.r.RemoveMigratedNodesLabel(tt.ctx, tt.cluster)).To(Succeed(), "retrierClient.RemoveMigratedNodesLabel() should succeed after 6 tries")
+}
+
+func ciliumAgentPod(ready corev1.ConditionStatus) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "cilium-abcde"},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+		},
+	}
+}
+
+func TestRetrierClientWaitForCiliumAgentSuccess(t *testing.T) {
+	tt := newRetrierTest(t)
+	gomock.InOrder(
+		tt.c.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil),
+		tt.c.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]corev1.Pod{ciliumAgentPod(corev1.ConditionFalse)}, nil),
+		tt.c.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]corev1.Pod{ciliumAgentPod(corev1.ConditionTrue)}, nil),
+	)
+
+	tt.Expect(tt.r.WaitForCiliumAgent(tt.ctx, tt.cluster, "node-1")).To(Succeed(), "retrierClient.WaitForCiliumAgent() should succeed once the agent pod is ready")
+}
+
+func TestRetrierClientWaitForCiliumAgentError(t *testing.T) {
+	tt := newRetrierTest(t)
+	tt.r.Retrier = retrier.NewWithMaxRetries(5, 0)
+	tt.c.EXPECT().GetPods(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(5)
+
+	tt.Expect(tt.r.WaitForCiliumAgent(tt.ctx, tt.cluster, "node-1")).To(MatchError(ContainSubstring("cilium agent not scheduled in node node-1")))
+}
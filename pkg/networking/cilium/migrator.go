@@ -0,0 +1,104 @@
+package cilium
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type kindnetdUninstaller interface {
+	Uninstall(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+}
+
+// migratedNodeLabel marks the nodes whose pod network has been moved to cilium during a migration from kindnetd.
+// Both CNIs allocate pod addresses from the node pod CIDR, so the cilium agents only run in the labeled nodes
+// until the migration completes to avoid handing out the same address twice in a node
+const migratedNodeLabel = "anywhere.eks.amazonaws.com/cilium-migrated"
+
+// InstallForMigration installs cilium in a cluster running kindnetd and waits for it to be ready. The cilium
+// agents are only scheduled in the nodes migrated with MigrateNode, the rest keep running kindnetd
+func (u *Upgrader) InstallForMigration(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) error {
+	if !isKindnetdMigration(currentSpec, newSpec) {
+		return errors.New("cilium only supports migrating clusters running kindnetd")
+	}
+
+	logger.V(3).Info("Generating Cilium migration manifest")
+	manifest, err := u.templater.GenerateMigrationManifest(ctx, newSpec)
+	if err != nil {
+		return err
+	}
+
+	logger.V(2).Info("Installing Cilium", "version", newSpec.VersionsBundle.Cilium.Version)
+	if err := u.client.Apply(ctx, cluster, manifest); err != nil {
+		return fmt.Errorf("failed applying cilium manifest: %v", err)
+	}
+
+	logger.V(3).Info("Waiting for Cilium to be ready")
+	return u.waitForCilium(ctx, cluster)
+}
+
+// MigrateNode moves the pod network of a node to cilium and waits for the cilium agent in the node to be ready.
+// The node should be drained first, and the pods still running in it recreated afterwards, so none of them
+// keeps an address assigned by kindnetd
+func (u *Upgrader) MigrateNode(ctx context.Context, cluster *types.Cluster, nodeName string) error {
+	logger.V(3).Info("Moving node to Cilium", "node", nodeName)
+	if err := u.client.LabelMigratedNode(ctx, cluster, nodeName); err != nil {
+		return fmt.Errorf("failed labeling node %s for cilium: %v", nodeName, err)
+	}
+
+	logger.V(3).Info("Waiting for Cilium agent to be ready", "node", nodeName)
+	return u.client.WaitForCiliumAgent(ctx, cluster, nodeName)
+}
+
+// CompleteMigration schedules cilium in all the nodes and removes kindnetd from a cluster once all its nodes
+// have been migrated
+func (u *Upgrader) CompleteMigration(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error) {
+	logger.V(3).Info("Generating Cilium manifest")
+	manifest, err := u.templater.GenerateManifest(ctx, newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.V(2).Info("Scheduling Cilium in all nodes")
+	if err := u.client.Apply(ctx, cluster, manifest); err != nil {
+		return nil, fmt.Errorf("failed applying cilium manifest: %v", err)
+	}
+
+	logger.V(3).Info("Waiting for Cilium to be ready")
+	if err := u.waitForCilium(ctx, cluster); err != nil {
+		return nil, err
+	}
+
+	logger.V(2).Info("Removing Kindnetd")
+	if err := u.kindnetd.Uninstall(ctx, cluster, currentSpec); err != nil {
+		return nil, err
+	}
+
+	if err := u.client.RemoveMigratedNodesLabel(ctx, cluster); err != nil {
+		return nil, fmt.Errorf("failed removing cilium migration label from nodes: %v", err)
+	}
+
+	return migrationChangeDiff(currentSpec, newSpec), nil
+}
+
+func isKindnetdMigration(currentSpec, newSpec *cluster.Spec) bool {
+	return v1alpha1.KindnetdToCiliumMigration(newSpec.Cluster.Spec.ClusterNetwork, currentSpec.Cluster.Spec.ClusterNetwork)
+}
+
+func migrationChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
+	return types.NewChangeDiff(
+		&types.ComponentChangeDiff{
+			ComponentName: "kindnetd",
+			OldVersion:    currentSpec.VersionsBundle.Kindnetd.Version,
+		},
+		&types.ComponentChangeDiff{
+			ComponentName: "cilium",
+			NewVersion:    newSpec.VersionsBundle.Cilium.Version,
+		},
+	)
+}
@@ -0,0 +1,137 @@
+package cilium
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type migratorTest struct {
+	*upgraderTest
+	kindnetd *mocks.MockkindnetdUninstaller
+}
+
+func newMigratorTest(t *testing.T) *migratorTest {
+	tt := newUpgraderTest(t)
+	kindnetd := mocks.NewMockkindnetdUninstaller(gomock.NewController(t))
+	tt.u.kindnetd = kindnetd
+	tt.currentSpec.VersionsBundle.Kindnetd.Version = "v0.11.1-eksa.1"
+	tt.currentSpec.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Kindnetd: &v1alpha1.KindnetdConfig{}}
+	return &migratorTest{
+		upgraderTest: tt,
+		kindnetd:     kindnetd,
+	}
+}
+
+func TestUpgraderInstallForMigrationSuccess(t *testing.T) {
+	tt := newMigratorTest(t)
+	gomock.InOrder(
+		tt.expectTemplateManifest(),
+		tt.client.EXPECT().Apply(tt.ctx, tt.cluster, tt.manifest),
+		tt.client.EXPECT().WaitForCiliumDaemonSet(tt.ctx, tt.cluster),
+		tt.client.EXPECT().WaitForCiliumDeployment(tt.ctx, tt.cluster),
+	)
+
+	tt.Expect(tt.u.InstallForMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Succeed())
+}
+
+func TestUpgraderInstallForMigrationSchedulesCiliumInMigratedNodes(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.h.EXPECT().Template(
+		tt.ctx, gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf(map[string]interface{}{}),
+	).DoAndReturn(func(_ context.Context, _, _, _ string, v map[string]interface{}) ([]byte, error) {
+		tt.Expect(v["nodeSelector"]).To(BeEquivalentTo(map[string]interface{}{migratedNodeLabel: "true"}))
+		return tt.manifest, nil
+	})
+	tt.client.EXPECT().Apply(tt.ctx, tt.cluster, tt.manifest)
+	tt.client.EXPECT().WaitForCiliumDaemonSet(tt.ctx, tt.cluster)
+	tt.client.EXPECT().WaitForCiliumDeployment(tt.ctx, tt.cluster)
+
+	tt.Expect(tt.u.InstallForMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Succeed())
+}
+
+func TestUpgraderInstallForMigrationNotKindnetd(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.currentSpec.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}
+
+	tt.Expect(tt.u.InstallForMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(MatchError(ContainSubstring("only supports migrating clusters running kindnetd")))
+}
+
+func TestUpgraderInstallForMigrationApplyError(t *testing.T) {
+	tt := newMigratorTest(t)
+	gomock.InOrder(
+		tt.expectTemplateManifest(),
+		tt.client.EXPECT().Apply(tt.ctx, tt.cluster, tt.manifest).Return(errors.New("error applying")),
+	)
+
+	tt.Expect(tt.u.InstallForMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(MatchError(ContainSubstring("failed applying cilium manifest")))
+}
+
+func TestUpgraderMigrateNodeSuccess(t *testing.T) {
+	tt := newMigratorTest(t)
+	gomock.InOrder(
+		tt.client.EXPECT().LabelMigratedNode(tt.ctx, tt.cluster, "node-1"),
+		tt.client.EXPECT().WaitForCiliumAgent(tt.ctx, tt.cluster, "node-1"),
+	)
+
+	tt.Expect(tt.u.MigrateNode(tt.ctx, tt.cluster, "node-1")).To(Succeed())
+}
+
+func TestUpgraderMigrateNodeLabelError(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.client.EXPECT().LabelMigratedNode(tt.ctx, tt.cluster, "node-1").Return(errors.New("error labeling"))
+
+	tt.Expect(tt.u.MigrateNode(tt.ctx, tt.cluster, "node-1")).To(MatchError(ContainSubstring("failed labeling node node-1 for cilium")))
+}
+
+func TestUpgraderMigrateNodeWaitError(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.client.EXPECT().LabelMigratedNode(tt.ctx, tt.cluster, "node-1")
+	tt.client.EXPECT().WaitForCiliumAgent(tt.ctx, tt.cluster, "node-1").Return(errors.New("agent not ready"))
+
+	tt.Expect(tt.u.MigrateNode(tt.ctx, tt.cluster, "node-1")).To(MatchError(ContainSubstring("agent not ready")))
+}
+
+func TestUpgraderCompleteMigrationSuccess(t *testing.T) {
+	tt := newMigratorTest(t)
+	wantChangeDiff := types.NewChangeDiff(
+		&types.ComponentChangeDiff{
+			ComponentName: "kindnetd",
+			OldVersion:    "v0.11.1-eksa.1",
+		},
+		&types.ComponentChangeDiff{
+			ComponentName: "cilium",
+			NewVersion:    "v1.9.11-eksa.1",
+		},
+	)
+	gomock.InOrder(
+		tt.expectTemplateManifest(),
+		tt.client.EXPECT().Apply(tt.ctx, tt.cluster, tt.manifest),
+		tt.client.EXPECT().WaitForCiliumDaemonSet(tt.ctx, tt.cluster),
+		tt.client.EXPECT().WaitForCiliumDeployment(tt.ctx, tt.cluster),
+		tt.kindnetd.EXPECT().Uninstall(tt.ctx, tt.cluster, tt.currentSpec),
+		tt.client.EXPECT().RemoveMigratedNodesLabel(tt.ctx, tt.cluster),
+	)
+
+	tt.Expect(tt.u.CompleteMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(wantChangeDiff))
+	tt.Expect(ChangeDiff(tt.currentSpec, tt.newSpec)).To(Equal(wantChangeDiff))
+}
+
+func TestUpgraderCompleteMigrationError(t *testing.T) {
+	tt := newMigratorTest(t)
+	tt.expectTemplateManifest()
+	tt.client.EXPECT().Apply(tt.ctx, tt.cluster, tt.manifest)
+	tt.client.EXPECT().WaitForCiliumDaemonSet(tt.ctx, tt.cluster)
+	tt.client.EXPECT().WaitForCiliumDeployment(tt.ctx, tt.cluster)
+	tt.kindnetd.EXPECT().Uninstall(tt.ctx, tt.cluster, tt.currentSpec).Return(errors.New("error deleting"))
+
+	_, err := tt.u.CompleteMigration(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("error deleting")))
+}
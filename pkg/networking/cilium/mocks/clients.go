@@ -8,9 +8,11 @@ import (
 	context "context"
 	reflect "reflect"
 
+	executables "github.com/aws/eks-anywhere/pkg/executables"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
 	v1 "k8s.io/api/apps/v1"
+	v10 "k8s.io/api/core/v1"
 )
 
 // MockClient is a mock of Client interface.
@@ -93,3 +95,51 @@ func (mr *MockClientMockRecorder) GetDeployment(ctx, name, namespace, kubeconfig
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployment", reflect.TypeOf((*MockClient)(nil).GetDeployment), ctx, name, namespace, kubeconfig)
 }
+
+// GetPods mocks base method.
+func (m *MockClient) GetPods(ctx context.Context, opts ...executables.KubectlOpt) ([]v10.Pod, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPods", varargs...)
+	ret0, _ := ret[0].([]v10.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPods indicates an expected call of GetPods.
+func (mr *MockClientMockRecorder) GetPods(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPods", reflect.TypeOf((*MockClient)(nil).GetPods), varargs...)
+}
+
+// LabelNode mocks base method.
+func (m *MockClient) LabelNode(ctx context.Context, nodeName, key, value, kubeconfig string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LabelNode", ctx, nodeName, key, value, kubeconfig)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LabelNode indicates an expected call of LabelNode.
+func (mr *MockClientMockRecorder) LabelNode(ctx, nodeName, key, value, kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LabelNode", reflect.TypeOf((*MockClient)(nil).LabelNode), ctx, nodeName, key, value, kubeconfig)
+}
+
+// RemoveNodesLabel mocks base method.
+func (m *MockClient) RemoveNodesLabel(ctx context.Context, key, kubeconfig string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveNodesLabel", ctx, key, kubeconfig)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveNodesLabel indicates an expected call of RemoveNodesLabel.
+func (mr *MockClientMockRecorder) RemoveNodesLabel(ctx, key, kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNodesLabel", reflect.TypeOf((*MockClient)(nil).RemoveNodesLabel), ctx, key, kubeconfig)
+}
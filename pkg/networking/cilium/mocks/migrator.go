@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/networking/cilium/migrator.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockkindnetdUninstaller is a mock of kindnetdUninstaller interface.
+type MockkindnetdUninstaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockkindnetdUninstallerMockRecorder
+}
+
+// MockkindnetdUninstallerMockRecorder is the mock recorder for MockkindnetdUninstaller.
+type MockkindnetdUninstallerMockRecorder struct {
+	mock *MockkindnetdUninstaller
+}
+
+// NewMockkindnetdUninstaller creates a new mock instance.
+func NewMockkindnetdUninstaller(ctrl *gomock.Controller) *MockkindnetdUninstaller {
+	mock := &MockkindnetdUninstaller{ctrl: ctrl}
+	mock.recorder = &MockkindnetdUninstallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockkindnetdUninstaller) EXPECT() *MockkindnetdUninstallerMockRecorder {
+	return m.recorder
+}
+
+// Uninstall mocks base method.
+func (m *MockkindnetdUninstaller) Uninstall(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Uninstall", ctx, cluster, clusterSpec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Uninstall indicates an expected call of Uninstall.
+func (mr *MockkindnetdUninstallerMockRecorder) Uninstall(ctx, cluster, clusterSpec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Uninstall", reflect.TypeOf((*MockkindnetdUninstaller)(nil).Uninstall), ctx, cluster, clusterSpec)
+}
@@ -63,6 +63,48 @@ func (mr *MockupgraderClientMockRecorder) Delete(ctx, cluster, data interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockupgraderClient)(nil).Delete), ctx, cluster, data)
 }
 
+// LabelMigratedNode mocks base method.
+func (m *MockupgraderClient) LabelMigratedNode(ctx context.Context, cluster *types.Cluster, nodeName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LabelMigratedNode", ctx, cluster, nodeName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LabelMigratedNode indicates an expected call of LabelMigratedNode.
+func (mr *MockupgraderClientMockRecorder) LabelMigratedNode(ctx, cluster, nodeName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LabelMigratedNode", reflect.TypeOf((*MockupgraderClient)(nil).LabelMigratedNode), ctx, cluster, nodeName)
+}
+
+// RemoveMigratedNodesLabel mocks base method.
+func (m *MockupgraderClient) RemoveMigratedNodesLabel(ctx context.Context, cluster *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMigratedNodesLabel", ctx, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMigratedNodesLabel indicates an expected call of RemoveMigratedNodesLabel.
+func (mr *MockupgraderClientMockRecorder) RemoveMigratedNodesLabel(ctx, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMigratedNodesLabel", reflect.TypeOf((*MockupgraderClient)(nil).RemoveMigratedNodesLabel), ctx, cluster)
+}
+
+// WaitForCiliumAgent mocks base method.
+func (m *MockupgraderClient) WaitForCiliumAgent(ctx context.Context, cluster *types.Cluster, nodeName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForCiliumAgent", ctx, cluster, nodeName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForCiliumAgent indicates an expected call of WaitForCiliumAgent.
+func (mr *MockupgraderClientMockRecorder) WaitForCiliumAgent(ctx, cluster, nodeName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForCiliumAgent", reflect.TypeOf((*MockupgraderClient)(nil).WaitForCiliumAgent), ctx, cluster, nodeName)
+}
+
 // WaitForCiliumDaemonSet mocks base method.
 func (m *MockupgraderClient) WaitForCiliumDaemonSet(ctx context.Context, cluster *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func checkDaemonSetReady(daemonSet *v1.DaemonSet) error {
@@ -42,6 +43,15 @@ func checkDeploymentReady(deployment *v1.Deployment) error {
 	return nil
 }
 
+func checkPodReady(pod *corev1.Pod) error {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+			return nil
+		}
+	}
+	return fmt.Errorf("pod %s is not ready", pod.Name)
+}
+
 func checkDaemonSetObservedGeneration(daemonSet *v1.DaemonSet) error {
 	observedGeneration := daemonSet.Status.ObservedGeneration
 	generation := daemonSet.Generation
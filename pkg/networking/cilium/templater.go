@@ -4,15 +4,20 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/semver"
 	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
 //go:embed network_policy.yaml
-var networkPolicyAllowAll string
+var networkPolicyTemplate string
+
+const controlPlaneEndpointPort = "6443"
 
 type Helm interface {
 	Template(ctx context.Context, ociURI, version, namespace string, values interface{}) ([]byte, error)
@@ -29,7 +34,10 @@ func NewTemplater(helm Helm) *Templater {
 }
 
 func (c *Templater) GenerateUpgradePreflightManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error) {
-	v := templateValues(spec)
+	v, err := withHelmValues(templateValues(spec), spec)
+	if err != nil {
+		return nil, err
+	}
 	v.set(true, "preflight", "enabled")
 	v.set(spec.VersionsBundle.Cilium.Cilium.Image(), "preflight", "image", "repository")
 	v.set(spec.VersionsBundle.Cilium.Cilium.Tag(), "preflight", "image", "tag")
@@ -52,7 +60,10 @@ func (c *Templater) GenerateUpgradeManifest(ctx context.Context, currentSpec, ne
 		return nil, fmt.Errorf("invalid version for Cilium in current spec: %v", err)
 	}
 
-	v := templateValues(newSpec)
+	v, err := withHelmValues(templateValues(newSpec), newSpec)
+	if err != nil {
+		return nil, err
+	}
 	v.set(fmt.Sprintf("%d.%d", currentVersion.Major, currentVersion.Minor), "upgradeCompatibility")
 
 	uri, version := getChartUriAndVersion(newSpec)
@@ -66,7 +77,10 @@ func (c *Templater) GenerateUpgradeManifest(ctx context.Context, currentSpec, ne
 }
 
 func (c *Templater) GenerateManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error) {
-	v := templateValues(spec)
+	v, err := withHelmValues(templateValues(spec), spec)
+	if err != nil {
+		return nil, err
+	}
 
 	uri, version := getChartUriAndVersion(spec)
 
@@ -78,34 +92,78 @@ func (c *Templater) GenerateManifest(ctx context.Context, spec *cluster.Spec) ([
 	return manifest, nil
 }
 
-func (c *Templater) GenerateNetworkPolicyManifest(spec *cluster.Spec, namespaces []string) ([]byte, error) {
-	values := map[string]interface{}{
-		"managementCluster":  spec.Cluster.IsSelfManaged(),
-		"providerNamespaces": namespaces,
+// GenerateMigrationManifest generates the cilium manifest installed in a cluster migrating from kindnetd, with
+// the cilium agents restricted to the nodes already migrated, so both CNIs never assign addresses in the same node
+func (c *Templater) GenerateMigrationManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error) {
+	v, err := withHelmValues(templateValues(spec), spec)
+	if err != nil {
+		return nil, err
+	}
+	v.set("true", "nodeSelector", migratedNodeLabel)
+
+	uri, version := getChartUriAndVersion(spec)
+
+	manifest, err := c.helm.Template(ctx, uri, version, namespace, v)
+	if err != nil {
+		return nil, fmt.Errorf("failed generating cilium migration manifest: %v", err)
+	}
+
+	return manifest, nil
+}
+
+// GenerateNetworkPolicyManifest generates the CiliumNetworkPolicies for the namespaces managed by EKS Anywhere.
+// Workload clusters only get the kube-system policies, management clusters also get one policy per
+// controller namespace, including the provider namespaces.
+func (c *Templater) GenerateNetworkPolicyManifest(spec *cluster.Spec, providerNamespaces []string) ([]byte, error) {
+	values := map[string]interface{}{}
+	if spec.Cluster.IsSelfManaged() {
+		values["namespaces"] = policyNamespaces(spec, providerNamespaces)
+	}
+
+	return templater.Execute(networkPolicyTemplate, values)
+}
+
+// policyNamespaces returns the controller namespaces of a management cluster and whether their pods need
+// to reach endpoints outside the cluster, like the infrastructure APIs, the workload clusters or the git repo.
+// CABPK creates the bootstrap tokens of the joining nodes in the workload clusters, so it needs to reach their API servers.
+func policyNamespaces(spec *cluster.Spec, providerNamespaces []string) []map[string]interface{} {
+	external := map[string]bool{
+		constants.CapiKubeadmBootstrapSystemNamespace:     true,
+		constants.CapiKubeadmControlPlaneSystemNamespace:  true,
+		constants.CapiSystemNamespace:                     true,
+		constants.CertManagerNamespace:                    false,
+		constants.EksaSystemNamespace:                     true,
+		constants.EtcdAdmBootstrapProviderSystemNamespace: false,
+		constants.EtcdAdmControllerSystemNamespace:        true,
 	}
 
 	if spec.Cluster.Spec.GitOpsRef != nil {
-		values["gitopsEnabled"] = true
-		if spec.GitOpsConfig != nil {
-			values["fluxNamespace"] = spec.GitOpsConfig.Spec.Flux.Github.FluxSystemNamespace
+		fluxNamespace := v1alpha1.FluxDefaultNamespace
+		if spec.GitOpsConfig != nil && spec.GitOpsConfig.Spec.Flux.Github.FluxSystemNamespace != "" {
+			fluxNamespace = spec.GitOpsConfig.Spec.Flux.Github.FluxSystemNamespace
 		}
+		external[fluxNamespace] = true
 	}
 
-	/* k8s versions 1.21 and higher label each namespace with key `kubernetes.io/metadata.name:` and value is the namespace's name.
-	This can be used to create a networkPolicy that allows traffic only between pods within kube-system ns, which is ideal for workload clusters. (not needed
-	for mgmt clusters).
-	So we will create networkPolicy using this default label as namespaceSelector for all versions 1.21 and higher
-	For 1.20 we will create a networkPolicy that allows allow traffic to/from kube-system pods, and document this. Users can still modify it and add new policies
-	as needed*/
-	k8sVersion, err := semver.New(spec.VersionsBundle.KubeDistro.Kubernetes.Tag)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing kubernetes version %v: %v", spec.Cluster.Spec.KubernetesVersion, err)
+	for _, ns := range providerNamespaces {
+		external[ns] = true
 	}
-	if k8sVersion.Major == 1 && k8sVersion.Minor >= 21 {
-		values["kubeSystemNSHasLabel"] = true
+
+	names := make([]string, 0, len(external))
+	for ns := range external {
+		names = append(names, ns)
 	}
+	sort.Strings(names)
 
-	return templater.Execute(networkPolicyAllowAll, values)
+	namespaces := make([]map[string]interface{}, 0, len(names))
+	for _, ns := range names {
+		namespaces = append(namespaces, map[string]interface{}{
+			"name":     ns,
+			"external": external[ns],
+		})
+	}
+
+	return namespaces
 }
 
 type values map[string]interface{}
@@ -154,12 +212,82 @@ func templateValues(spec *cluster.Spec) values {
 		},
 	}
 
-	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode != "" {
-		val["policyEnforcementMode"] = spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode
+	ciliumConfig := spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium
+	if ciliumConfig.PolicyEnforcementMode != "" {
+		val["policyEnforcementMode"] = ciliumConfig.PolicyEnforcementMode
 	}
+
+	if ciliumConfig.PolicyAuditMode {
+		val["policyAuditMode"] = true
+		// Denied flows are reported through Hubble
+		val.set(true, "hubble", "enabled")
+	}
+
+	podCidr := ""
+	if len(spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks) > 0 {
+		podCidr = spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks[0]
+	}
+
+	if ciliumConfig.RoutingMode == v1alpha1.CiliumRoutingModeNative {
+		val["tunnel"] = "disabled"
+		val["autoDirectNodeRoutes"] = true
+		val["nativeRoutingCIDR"] = podCidr
+	}
+
+	if ciliumConfig.IPAMMode == v1alpha1.CiliumIPAMModeClusterPool {
+		val.set(string(v1alpha1.CiliumIPAMModeClusterPool), "ipam", "mode")
+		val.set(podCidr, "ipam", "operator", "clusterPoolIPv4PodCIDR")
+	}
+
+	if ciliumConfig.Hubble != nil {
+		val.set(true, "hubble", "enabled")
+		val.set(ciliumConfig.Hubble.Relay, "hubble", "relay", "enabled")
+		val.set(ciliumConfig.Hubble.UI, "hubble", "ui", "enabled")
+	}
+
+	if ciliumConfig.KubeProxyReplacement != "" {
+		val["kubeProxyReplacement"] = ciliumConfig.KubeProxyReplacement
+		// Without kube-proxy, cilium can't reach the API server through the kubernetes service
+		if ciliumConfig.KubeProxyReplacement == v1alpha1.CiliumKubeProxyReplacementStrict && spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint != nil {
+			val["k8sServiceHost"] = spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
+			val["k8sServicePort"] = controlPlaneEndpointPort
+		}
+	}
+
+	if ciliumConfig.MTU != 0 {
+		val["mtu"] = ciliumConfig.MTU
+	}
+
 	return val
 }
 
+// withHelmValues merges the extra Helm values of the cilium config into v, overriding the existing ones
+func withHelmValues(v values, spec *cluster.Spec) (values, error) {
+	extra, err := spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.ParseHelmValues()
+	if err != nil {
+		return nil, err
+	}
+	v.merge(extra)
+	return v, nil
+}
+
+func (c values) merge(extra map[string]interface{}) {
+	for key, value := range extra {
+		extraMap, ok := value.(map[string]interface{})
+		if !ok {
+			c[key] = value
+			continue
+		}
+
+		current, ok := c[key].(values)
+		if !ok {
+			current = values{}
+			c[key] = current
+		}
+		current.merge(extraMap)
+	}
+}
+
 func getChartUriAndVersion(spec *cluster.Spec) (uri, version string) {
 	chart := spec.VersionsBundle.Cilium.HelmChart
 	uri = fmt.Sprintf("oci://%s", chart.Image())
@@ -10,10 +10,12 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/manifestdiff"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium/mocks"
 )
@@ -213,6 +215,176 @@ func TestTemplaterGenerateManifestPolicyEnforcementModeSuccess(t *testing.T) {
 	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
 }
 
+func TestTemplaterGenerateManifestPolicyAuditModeSuccess(t *testing.T) {
+	wantValues := map[string]interface{}{
+		"cni": map[string]interface{}{
+			"chainingMode": "portmap",
+		},
+		"ipam": map[string]interface{}{
+			"mode": "kubernetes",
+		},
+		"identityAllocationMode": "crd",
+		"prometheus": map[string]interface{}{
+			"enabled": true,
+		},
+		"rollOutCiliumPods": true,
+		"tunnel":            "geneve",
+		"image": map[string]interface{}{
+			"repository": "public.ecr.aws/isovalent/cilium",
+			"tag":        "v1.9.11-eksa.1",
+		},
+		"operator": map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "public.ecr.aws/isovalent/operator",
+				"tag":        "v1.9.11-eksa.1",
+			},
+			"prometheus": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+		"policyEnforcementMode": "always",
+		"policyAuditMode":       true,
+		"hubble": map[string]interface{}{
+			"enabled": true,
+			"relay": map[string]interface{}{
+				"enabled": true,
+			},
+			"ui": map[string]interface{}{
+				"enabled": false,
+			},
+		},
+	}
+
+	tt := newtemplaterTest(t)
+	tt.expectHelmTemplateWith(eqMap(wantValues)).Return(tt.manifest, nil)
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode = v1alpha1.CiliumPolicyModeAlways
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyAuditMode = true
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.Hubble = &v1alpha1.CiliumHubbleConfig{Relay: true}
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
+func TestTemplaterGenerateManifestNetworkOptionsSuccess(t *testing.T) {
+	wantValues := map[string]interface{}{
+		"cni": map[string]interface{}{
+			"chainingMode": "portmap",
+		},
+		"ipam": map[string]interface{}{
+			"mode": "cluster-pool",
+			"operator": map[string]interface{}{
+				"clusterPoolIPv4PodCIDR": "192.168.0.0/16",
+			},
+		},
+		"identityAllocationMode": "crd",
+		"prometheus": map[string]interface{}{
+			"enabled": true,
+		},
+		"rollOutCiliumPods":    true,
+		"tunnel":               "disabled",
+		"autoDirectNodeRoutes": true,
+		"nativeRoutingCIDR":    "192.168.0.0/16",
+		"image": map[string]interface{}{
+			"repository": "public.ecr.aws/isovalent/cilium",
+			"tag":        "v1.9.11-eksa.1",
+		},
+		"operator": map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "public.ecr.aws/isovalent/operator",
+				"tag":        "v1.9.11-eksa.1",
+			},
+			"prometheus": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+		"hubble": map[string]interface{}{
+			"enabled": true,
+			"relay": map[string]interface{}{
+				"enabled": true,
+			},
+			"ui": map[string]interface{}{
+				"enabled": false,
+			},
+		},
+		"kubeProxyReplacement": "strict",
+		"k8sServiceHost":       "1.2.3.4",
+		"k8sServicePort":       "6443",
+		"mtu":                  float64(9000),
+	}
+
+	tt := newtemplaterTest(t)
+	tt.expectHelmTemplateWith(eqMap(wantValues)).Return(tt.manifest, nil)
+	tt.spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+	tt.spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.2.3.4"}
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium = &v1alpha1.CiliumConfig{
+		RoutingMode:          v1alpha1.CiliumRoutingModeNative,
+		IPAMMode:             v1alpha1.CiliumIPAMModeClusterPool,
+		Hubble:               &v1alpha1.CiliumHubbleConfig{Relay: true},
+		KubeProxyReplacement: v1alpha1.CiliumKubeProxyReplacementStrict,
+		MTU:                  9000,
+	}
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
+func TestTemplaterGenerateManifestHelmValuesSuccess(t *testing.T) {
+	wantValues := map[string]interface{}{
+		"cni": map[string]interface{}{
+			"chainingMode": "portmap",
+		},
+		"ipam": map[string]interface{}{
+			"mode": "kubernetes",
+		},
+		"identityAllocationMode": "crd",
+		"prometheus": map[string]interface{}{
+			"enabled": false,
+			"port":    float64(9091),
+		},
+		"rollOutCiliumPods": true,
+		"tunnel":            "vxlan",
+		"image": map[string]interface{}{
+			"repository": "public.ecr.aws/isovalent/cilium",
+			"tag":        "v1.9.11-eksa.1",
+		},
+		"operator": map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "public.ecr.aws/isovalent/operator",
+				"tag":        "v1.9.11-eksa.1",
+			},
+			"prometheus": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+	}
+
+	tt := newtemplaterTest(t)
+	tt.expectHelmTemplateWith(eqMap(wantValues)).Return(tt.manifest, nil)
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.HelmValues = `
+tunnel: vxlan
+prometheus:
+  enabled: false
+  port: 9091
+`
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
+func TestTemplaterGenerateManifestInvalidHelmValues(t *testing.T) {
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.HelmValues = "- not a map"
+
+	_, err := tt.t.GenerateManifest(tt.ctx, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("cilium helmValues must be a yaml map")))
+}
+
+func TestTemplaterGenerateMigrationManifestSuccess(t *testing.T) {
+	tt := newtemplaterTest(t)
+	tt.h.EXPECT().Template(tt.ctx, tt.uri, tt.version, tt.namespace, gomock.AssignableToTypeOf(map[string]interface{}{})).DoAndReturn(
+		func(_ context.Context, _, _, _ string, v map[string]interface{}) ([]byte, error) {
+			tt.Expect(v["nodeSelector"]).To(BeEquivalentTo(map[string]interface{}{"anywhere.eks.amazonaws.com/cilium-migrated": "true"}))
+			return tt.manifest, nil
+		},
+	)
+
+	tt.Expect(tt.t.GenerateMigrationManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateMigrationManifest() should return right manifest")
+}
+
 func TestTemplaterGenerateManifestError(t *testing.T) {
 	tt := newtemplaterTest(t)
 	tt.expectHelmTemplateWith(gomock.Any()).Return(nil, errors.New("error from helm")) // Using any because we only want to test the returned error
@@ -270,15 +442,14 @@ func TestTemplaterGenerateUpgradeManifestError(t *testing.T) {
 func TestTemplaterGenerateNetworkPolicy(t *testing.T) {
 	tests := []struct {
 		name                    string
-		k8sVersion              string
 		selfManaged             bool
 		gitopsEnabled           bool
+		fluxNamespace           string
 		infraProviderNamespaces []string
 		wantNetworkPolicyFile   string
 	}{
 		{
 			name:                    "CAPV mgmt cluster",
-			k8sVersion:              "v1.21.9-eks-1-21-10",
 			selfManaged:             true,
 			gitopsEnabled:           false,
 			infraProviderNamespaces: []string{"capv-system"},
@@ -286,34 +457,31 @@ func TestTemplaterGenerateNetworkPolicy(t *testing.T) {
 		},
 		{
 			name:                    "CAPT mgmt cluster with flux",
-			k8sVersion:              "v1.21.9-eks-1-21-10",
 			selfManaged:             true,
 			gitopsEnabled:           true,
+			fluxNamespace:           "flux-system",
 			infraProviderNamespaces: []string{"capt-system"},
 			wantNetworkPolicyFile:   "testdata/network_policy_mgmt_capt_flux.yaml",
 		},
 		{
-			name:                    "workload cluster 1.21",
-			k8sVersion:              "v1.21.9-eks-1-21-10",
-			selfManaged:             false,
-			gitopsEnabled:           false,
-			infraProviderNamespaces: []string{},
-			wantNetworkPolicyFile:   "testdata/network_policy_workload_121.yaml",
+			name:                    "CAPT mgmt cluster with flux in default namespace",
+			selfManaged:             true,
+			gitopsEnabled:           true,
+			infraProviderNamespaces: []string{"capt-system"},
+			wantNetworkPolicyFile:   "testdata/network_policy_mgmt_capt_flux.yaml",
 		},
 		{
-			name:                    "workload cluster 1.20",
-			k8sVersion:              "v1.20.9-eks-1-20-10",
+			name:                    "workload cluster",
 			selfManaged:             false,
 			gitopsEnabled:           false,
 			infraProviderNamespaces: []string{},
-			wantNetworkPolicyFile:   "testdata/network_policy_workload_120.yaml",
+			wantNetworkPolicyFile:   "testdata/network_policy_workload.yaml",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			temp := newtemplaterTest(t)
-			temp.spec.VersionsBundle.KubeDistro.Kubernetes.Tag = tt.k8sVersion
 			if !tt.selfManaged {
 				temp.spec.Cluster.Spec.ManagementCluster.Name = "managed"
 			}
@@ -324,7 +492,7 @@ func TestTemplaterGenerateNetworkPolicy(t *testing.T) {
 				}
 				temp.spec.Config.GitOpsConfig = &v1alpha1.GitOpsConfig{
 					Spec: v1alpha1.GitOpsConfigSpec{
-						Flux: v1alpha1.Flux{Github: v1alpha1.Github{FluxSystemNamespace: "flux-system"}},
+						Flux: v1alpha1.Flux{Github: v1alpha1.Github{FluxSystemNamespace: tt.fluxNamespace}},
 					},
 				}
 			}
@@ -336,3 +504,60 @@ func TestTemplaterGenerateNetworkPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplaterGenerateNetworkPolicyBootstrapProviderEgress(t *testing.T) {
+	tt := newtemplaterTest(t)
+	networkPolicy, err := tt.t.GenerateNetworkPolicyManifest(tt.spec, []string{"capv-system"})
+	tt.Expect(err).To(Succeed())
+
+	objs, err := manifestdiff.ParseObjects(networkPolicy)
+	tt.Expect(err).To(Succeed())
+
+	var egress []interface{}
+	for _, obj := range objs {
+		if obj.GetKind() == "CiliumNetworkPolicy" && obj.GetNamespace() == "capi-kubeadm-bootstrap-system" {
+			egress, _, _ = unstructured.NestedSlice(obj.Object, "spec", "egress")
+		}
+	}
+	// CABPK creates bootstrap tokens in the workload clusters, so it needs to reach their API servers
+	tt.Expect(egress).To(ContainElement(HaveKeyWithValue("toEntities", ContainElement("world"))))
+}
+
+func TestTemplaterGenerateNetworkPolicyKubeSystemEgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		selfManaged bool
+	}{
+		{
+			name:        "mgmt cluster",
+			selfManaged: true,
+		},
+		{
+			name:        "workload cluster",
+			selfManaged: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newtemplaterTest(t)
+			if !tc.selfManaged {
+				tt.spec.Cluster.Spec.ManagementCluster.Name = "managed"
+			}
+			networkPolicy, err := tt.t.GenerateNetworkPolicyManifest(tt.spec, []string{"capv-system"})
+			tt.Expect(err).To(Succeed())
+
+			objs, err := manifestdiff.ParseObjects(networkPolicy)
+			tt.Expect(err).To(Succeed())
+
+			var egress []interface{}
+			for _, obj := range objs {
+				if obj.GetKind() == "CiliumNetworkPolicy" && obj.GetName() == "eksa-kube-system" {
+					egress, _, _ = unstructured.NestedSlice(obj.Object, "spec", "egress")
+				}
+			}
+			// The vsphere-csi-controller doesn't run in the host network and needs to reach vCenter
+			tt.Expect(egress).To(ContainElement(HaveKeyWithValue("toEntities", ContainElement("world"))))
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -16,17 +18,22 @@ type upgraderClient interface {
 	WaitForPreflightDeployment(ctx context.Context, cluster *types.Cluster) error
 	WaitForCiliumDaemonSet(ctx context.Context, cluster *types.Cluster) error
 	WaitForCiliumDeployment(ctx context.Context, cluster *types.Cluster) error
+	WaitForCiliumAgent(ctx context.Context, cluster *types.Cluster, nodeName string) error
+	LabelMigratedNode(ctx context.Context, cluster *types.Cluster, nodeName string) error
+	RemoveMigratedNodesLabel(ctx context.Context, cluster *types.Cluster) error
 }
 
 type Upgrader struct {
 	templater *Templater
 	client    upgraderClient
+	kindnetd  kindnetdUninstaller
 }
 
 func NewUpgrader(client Client, helm Helm) *Upgrader {
 	return &Upgrader{
 		templater: NewTemplater(helm),
 		client:    newRetrier(client),
+		kindnetd:  kindnetd.NewKindnetd(client),
 	}
 }
 
@@ -37,26 +44,13 @@ func (u *Upgrader) Upgrade(ctx context.Context, cluster *types.Cluster, currentS
 		return nil, nil
 	}
 
-	logger.V(1).Info("Upgrading Cilium", "oldVersion", diff.ComponentReports[0].OldVersion, "newVersion", diff.ComponentReports[0].NewVersion)
-	logger.V(4).Info("Generating Cilium upgrade preflight manifest")
-	preflight, err := u.templater.GenerateUpgradePreflightManifest(ctx, newSpec)
-	if err != nil {
-		return nil, err
-	}
-
-	logger.V(2).Info("Installing Cilium upgrade preflight manifest")
-	if err := u.client.Apply(ctx, cluster, preflight); err != nil {
-		return nil, fmt.Errorf("failed applying cilium preflight check: %v", err)
-	}
-
-	logger.V(3).Info("Waiting for Cilium upgrade preflight checks to be up")
-	if err := u.waitForPreflight(ctx, cluster); err != nil {
-		return nil, err
-	}
-
-	logger.V(3).Info("Deleting Cilium upgrade preflight")
-	if err := u.client.Delete(ctx, cluster, preflight); err != nil {
-		return nil, fmt.Errorf("failed deleting cilium preflight check: %v", err)
+	if versionChanged(currentSpec, newSpec) {
+		logger.V(1).Info("Upgrading Cilium", "oldVersion", diff.ComponentReports[0].OldVersion, "newVersion", diff.ComponentReports[0].NewVersion)
+		if err := u.runPreflight(ctx, cluster, newSpec); err != nil {
+			return nil, err
+		}
+	} else {
+		logger.V(1).Info("Updating Cilium config", "version", newSpec.VersionsBundle.Cilium.Version)
 	}
 
 	logger.V(3).Info("Generating Cilium upgrade manifest")
@@ -78,6 +72,32 @@ func (u *Upgrader) Upgrade(ctx context.Context, cluster *types.Cluster, currentS
 	return diff, nil
 }
 
+// runPreflight runs the cilium preflight checks, which pre-pull the new cilium images on every node
+func (u *Upgrader) runPreflight(ctx context.Context, cluster *types.Cluster, newSpec *cluster.Spec) error {
+	logger.V(4).Info("Generating Cilium upgrade preflight manifest")
+	preflight, err := u.templater.GenerateUpgradePreflightManifest(ctx, newSpec)
+	if err != nil {
+		return err
+	}
+
+	logger.V(2).Info("Installing Cilium upgrade preflight manifest")
+	if err := u.client.Apply(ctx, cluster, preflight); err != nil {
+		return fmt.Errorf("failed applying cilium preflight check: %v", err)
+	}
+
+	logger.V(3).Info("Waiting for Cilium upgrade preflight checks to be up")
+	if err := u.waitForPreflight(ctx, cluster); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Deleting Cilium upgrade preflight")
+	if err := u.client.Delete(ctx, cluster, preflight); err != nil {
+		return fmt.Errorf("failed deleting cilium preflight check: %v", err)
+	}
+
+	return nil
+}
+
 func (u *Upgrader) waitForPreflight(ctx context.Context, cluster *types.Cluster) error {
 	if err := u.client.WaitForPreflightDaemonSet(ctx, cluster); err != nil {
 		return err
@@ -102,8 +122,24 @@ func (u *Upgrader) waitForCilium(ctx context.Context, cluster *types.Cluster) er
 	return nil
 }
 
+func versionChanged(currentSpec, newSpec *cluster.Spec) bool {
+	return currentSpec.VersionsBundle.Cilium.Version != newSpec.VersionsBundle.Cilium.Version
+}
+
+func configChanged(currentSpec, newSpec *cluster.Spec) bool {
+	return !ciliumConfig(currentSpec).Equal(ciliumConfig(newSpec))
+}
+
+// ciliumConfig returns the cilium config of spec, which is empty for clusters created with the deprecated CNI field
+func ciliumConfig(spec *cluster.Spec) *v1alpha1.CiliumConfig {
+	if spec.Cluster.Spec.ClusterNetwork.CNIConfig == nil || spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium == nil {
+		return &v1alpha1.CiliumConfig{}
+	}
+	return spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium
+}
+
 func ciliumChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
-	if currentSpec.VersionsBundle.Cilium.Version == newSpec.VersionsBundle.Cilium.Version {
+	if !versionChanged(currentSpec, newSpec) && !configChanged(currentSpec, newSpec) {
 		return nil
 	}
 
@@ -119,5 +155,8 @@ func ciliumChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
 }
 
 func ChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
+	if isKindnetdMigration(currentSpec, newSpec) {
+		return migrationChangeDiff(currentSpec, newSpec)
+	}
 	return ciliumChangeDiff(currentSpec, newSpec)
 }
@@ -98,3 +98,35 @@ func TestUpgraderUpgradeNotNeeded(t *testing.T) {
 
 	tt.Expect(tt.u.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(BeNil(), "upgrader.Upgrade() should succeed and return nil ChangeDiff")
 }
+
+func TestUpgraderUpgradeConfigChanged(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.currentSpec.VersionsBundle.Cilium.Version = "v1.0.0"
+	tt.newSpec.VersionsBundle.Cilium.Version = "v1.0.0"
+	tt.newSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.Hubble = &v1alpha1.CiliumHubbleConfig{Relay: true}
+	wantChangeDiff := types.NewChangeDiff(&types.ComponentChangeDiff{
+		ComponentName: "cilium",
+		OldVersion:    "v1.0.0",
+		NewVersion:    "v1.0.0",
+	})
+
+	// No preflight since the cilium images don't change
+	gomock.InOrder(
+		tt.expectTemplateManifest(),
+		tt.client.EXPECT().Apply(tt.ctx, tt.cluster, tt.manifest),
+		tt.client.EXPECT().WaitForCiliumDaemonSet(tt.ctx, tt.cluster),
+		tt.client.EXPECT().WaitForCiliumDeployment(tt.ctx, tt.cluster),
+	)
+
+	tt.Expect(tt.u.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(wantChangeDiff), "upgrader.Upgrade() should succeed and return correct ChangeDiff")
+}
+
+func TestUpgraderUpgradeNotNeededDeprecatedCNIField(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.currentSpec.VersionsBundle.Cilium.Version = "v1.0.0"
+	tt.currentSpec.Cluster.Spec.ClusterNetwork.CNIConfig = nil
+	tt.currentSpec.Cluster.Spec.ClusterNetwork.CNI = v1alpha1.Cilium
+	tt.newSpec.VersionsBundle.Cilium.Version = "v1.0.0"
+
+	tt.Expect(tt.u.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(BeNil(), "upgrader.Upgrade() should succeed and return nil ChangeDiff")
+}
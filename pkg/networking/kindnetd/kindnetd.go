@@ -14,6 +14,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	networking "github.com/aws/eks-anywhere/pkg/networking/internal"
 	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
 )
 
 type Kindnetd struct {
@@ -30,6 +31,20 @@ func (c *Kindnetd) GenerateManifest(ctx context.Context, clusterSpec *cluster.Sp
 	return generateManifest(clusterSpec)
 }
 
+// Uninstall deletes the kindnetd components from the cluster, used when migrating it to a different CNI
+func (c *Kindnetd) Uninstall(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	manifest, err := generateManifest(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.DeleteKubeSpecFromBytes(ctx, cluster, manifest); err != nil {
+		return fmt.Errorf("failed deleting kindnetd manifest: %v", err)
+	}
+
+	return nil
+}
+
 func generateManifest(clusterSpec *cluster.Spec) ([]byte, error) {
 	content, err := networking.LoadManifest(clusterSpec, clusterSpec.VersionsBundle.Kindnetd.Manifest)
 	if err != nil {
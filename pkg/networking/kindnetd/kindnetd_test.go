@@ -2,6 +2,7 @@ package kindnetd_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -11,6 +12,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
 	"github.com/aws/eks-anywhere/pkg/networking/kindnetd/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -61,3 +63,30 @@ func TestKindnetdGenerateManifestWriterError(t *testing.T) {
 		t.Fatalf("Kindnetd.GenerateManifestFile() error = nil, want not nil")
 	}
 }
+
+func TestKindnetdUninstallSuccess(t *testing.T) {
+	tt := newKindnetdTest(t)
+	ctx := context.Background()
+	c := &types.Cluster{KubeconfigFile: "kubeconfig"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.1.0/24"}
+		s.VersionsBundle.Kindnetd = KindnetdBundle
+	})
+	manifest := []byte(test.ReadFile(t, "testdata/expected_kindnetd_manifest.yaml"))
+	tt.client.EXPECT().DeleteKubeSpecFromBytes(ctx, c, manifest)
+
+	tt.Expect(tt.k.Uninstall(ctx, c, clusterSpec)).To(Succeed())
+}
+
+func TestKindnetdUninstallError(t *testing.T) {
+	tt := newKindnetdTest(t)
+	ctx := context.Background()
+	c := &types.Cluster{KubeconfigFile: "kubeconfig"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.1.0/24"}
+		s.VersionsBundle.Kindnetd = KindnetdBundle
+	})
+	tt.client.EXPECT().DeleteKubeSpecFromBytes(ctx, c, gomock.Any()).Return(errors.New("error deleting"))
+
+	tt.Expect(tt.k.Uninstall(ctx, c, clusterSpec)).To(MatchError(ContainSubstring("failed deleting kindnetd manifest")))
+}
@@ -48,3 +48,17 @@ func (mr *MockClientMockRecorder) ApplyKubeSpecFromBytes(ctx, cluster, data inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytes", reflect.TypeOf((*MockClient)(nil).ApplyKubeSpecFromBytes), ctx, cluster, data)
 }
+
+// DeleteKubeSpecFromBytes mocks base method.
+func (m *MockClient) DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKubeSpecFromBytes", ctx, cluster, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKubeSpecFromBytes indicates an expected call of DeleteKubeSpecFromBytes.
+func (mr *MockClientMockRecorder) DeleteKubeSpecFromBytes(ctx, cluster, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKubeSpecFromBytes", reflect.TypeOf((*MockClient)(nil).DeleteKubeSpecFromBytes), ctx, cluster, data)
+}
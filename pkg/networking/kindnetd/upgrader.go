@@ -11,6 +11,7 @@ import (
 
 type Client interface {
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 }
 
 type Upgrader struct {
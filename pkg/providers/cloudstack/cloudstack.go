@@ -34,6 +34,8 @@ import (
 const (
 	eksaLicense                = "EKSA_LICENSE"
 	controlEndpointDefaultPort = "6443"
+	// CloudConfigSecretKey is the key of the credentials secret that holds the CloudStack cloud config
+	CloudConfigSecretKey = "cloud-config"
 )
 
 //go:embed config/template-cp.yaml
@@ -45,6 +47,9 @@ var defaultClusterConfigMD string
 //go:embed config/machine-health-check-template.yaml
 var mhcTemplate []byte
 
+//go:embed config/secret.yaml
+var defaultSecretObject string
+
 var requiredEnvs = []string{decoder.CloudStackCloudConfigB64SecretKey}
 
 var (
@@ -76,9 +81,40 @@ func (p *cloudstackProvider) PostBootstrapSetup(ctx context.Context, clusterConf
 }
 
 func (p *cloudstackProvider) UpdateSecrets(ctx context.Context, cluster *types.Cluster) error {
+	contents, err := p.createSecret(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	err = p.providerKubectlClient.ApplyKubeSpecFromBytes(ctx, cluster, contents)
+	if err != nil {
+		return fmt.Errorf("error loading secrets object: %v", err)
+	}
 	return nil
 }
 
+// createSecret stores the CloudStack credentials in the cluster, so the eks-a controller can reach
+// the CloudStack management api when it reconciles the cluster
+func (p *cloudstackProvider) createSecret(ctx context.Context, cluster *types.Cluster) ([]byte, error) {
+	if err := p.providerKubectlClient.GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace); err != nil {
+		if err := p.providerKubectlClient.CreateNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace); err != nil {
+			return nil, err
+		}
+	}
+
+	values := map[string]interface{}{
+		"cloudstackCredentialsName": constants.CloudStackCredentialsName,
+		"eksaSystemNamespace":       constants.EksaSystemNamespace,
+		"cloudConfigSecretKey":      CloudConfigSecretKey,
+		"cloudstackCloudConfig":     os.Getenv(decoder.EksacloudStackCloudConfigB64SecretKey),
+	}
+	contents, err := templater.Execute(defaultSecretObject, values)
+	if err != nil {
+		return nil, fmt.Errorf("error substituting values for secret object template: %v", err)
+	}
+	return contents, nil
+}
+
 func (p *cloudstackProvider) ValidateNewSpec(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	return fmt.Errorf("cloudstack provider does not support this functionality currently")
 }
@@ -104,6 +140,7 @@ func (p *cloudstackProvider) RunPostControlPlaneCreation(ctx context.Context, cl
 type ProviderKubectlClient interface {
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	CreateNamespace(ctx context.Context, kubeconfig string, namespace string) error
+	GetNamespace(ctx context.Context, kubeconfig string, namespace string) error
 	LoadSecret(ctx context.Context, secretObject string, secretObjType string, secretObjectName string, kubeConfFile string) error
 	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
 	GetEksaCloudStackDatacenterConfig(ctx context.Context, cloudstackDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.CloudStackDatacenterConfig, error)
@@ -152,15 +189,9 @@ func NewProviderCustomNet(datacenterConfig *v1alpha1.CloudStackDatacenterConfig,
 		providerKubectlClient: providerKubectlClient,
 		writer:                writer,
 		selfSigned:            false,
-		templateBuilder: &CloudStackTemplateBuilder{
-			datacenterConfigSpec:       &datacenterConfig.Spec,
-			controlPlaneMachineSpec:    controlPlaneMachineSpec,
-			workerNodeGroupMachineSpec: workerNodeGroupMachineSpec,
-			etcdMachineSpec:            etcdMachineSpec,
-			now:                        now,
-		},
-		skipIpCheck: skipIpCheck,
-		validator:   NewValidator(providerCmkClient),
+		templateBuilder:       NewCloudStackTemplateBuilder(&datacenterConfig.Spec, controlPlaneMachineSpec, workerNodeGroupMachineSpec, etcdMachineSpec, now),
+		skipIpCheck:           skipIpCheck,
+		validator:             NewValidator(providerCmkClient),
 	}
 }
 
@@ -371,6 +402,16 @@ type CloudStackTemplateBuilder struct {
 	now                        types.NowFunc
 }
 
+func NewCloudStackTemplateBuilder(datacenterConfigSpec *v1alpha1.CloudStackDatacenterConfigSpec, controlPlaneMachineSpec, workerNodeGroupMachineSpec, etcdMachineSpec *v1alpha1.CloudStackMachineConfigSpec, now types.NowFunc) *CloudStackTemplateBuilder {
+	return &CloudStackTemplateBuilder{
+		datacenterConfigSpec:       datacenterConfigSpec,
+		controlPlaneMachineSpec:    controlPlaneMachineSpec,
+		workerNodeGroupMachineSpec: workerNodeGroupMachineSpec,
+		etcdMachineSpec:            etcdMachineSpec,
+		now:                        now,
+	}
+}
+
 func (cs *CloudStackTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spec, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
 	var etcdMachineSpec v1alpha1.CloudStackMachineConfigSpec
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
@@ -535,6 +576,7 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterConfigSpec v1alpha1
 		"cloudstackCustomDetails":    workerNodeGroupMachineSpec.UserCustomDetails,
 		"cloudstackAffinityGroupIds": workerNodeGroupMachineSpec.AffinityGroupIds,
 		"workerReplicas":             clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count,
+		"autoscalingConfig":          clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration,
 		"workerSshUsername":          workerNodeGroupMachineSpec.Users[0].Name,
 		"format":                     format,
 		"eksaSystemNamespace":        constants.EksaSystemNamespace,
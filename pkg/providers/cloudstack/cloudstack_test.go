@@ -3,6 +3,7 @@ package cloudstack
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -39,6 +40,16 @@ func givenClusterConfig(t *testing.T, fileName string) *v1alpha1.Cluster {
 	return givenClusterSpec(t, fileName).Cluster
 }
 
+// givenClusterConfigFromFile reads the cluster config without building the full cluster spec,
+// which downloads the EKS-D release manifest
+func givenClusterConfigFromFile(t *testing.T, fileName string) *v1alpha1.Cluster {
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, fileName))
+	if err != nil {
+		t.Fatalf("unable to get cluster config from file: %v", err)
+	}
+	return clusterConfig
+}
+
 func givenClusterSpec(t *testing.T, fileName string) *cluster.Spec {
 	return test.NewFullClusterSpec(t, path.Join(testDataDir, fileName))
 }
@@ -762,3 +773,52 @@ spec:
 	assert.NoError(t, err, "Expected successful execution of GenerateMHC() but got an error", "error", err)
 	assert.Equal(t, string(mch), mhcTemplate, "generated MachineHealthCheck is different from the expected one")
 }
+
+func TestProviderUpdateSecrets(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	clusterConfig := givenClusterConfigFromFile(t, testClusterConfigMainFilename)
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterConfig, kubectl, givenWildcardCmk(mockCtrl))
+	cluster := &types.Cluster{
+		Name:           "test",
+		KubeconfigFile: "test.kubeconfig",
+	}
+	setupContext()
+
+	kubectl.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(errors.New("not found"))
+	kubectl.EXPECT().CreateNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace)
+	kubectl.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			test.AssertContentToFile(t, string(data), "testdata/expected_results_secret.yaml")
+			return nil
+		},
+	)
+
+	if err := provider.UpdateSecrets(ctx, cluster); err != nil {
+		t.Fatalf("provider.UpdateSecrets() err = %v, want err = nil", err)
+	}
+}
+
+func TestProviderUpdateSecretsApplyError(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	clusterConfig := givenClusterConfigFromFile(t, testClusterConfigMainFilename)
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterConfig, kubectl, givenWildcardCmk(mockCtrl))
+	cluster := &types.Cluster{
+		Name:           "test",
+		KubeconfigFile: "test.kubeconfig",
+	}
+	setupContext()
+
+	kubectl.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace)
+	kubectl.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).Return(errors.New("apply error"))
+
+	err := provider.UpdateSecrets(ctx, cluster)
+	assert.EqualError(t, err, "error loading secrets object: apply error")
+}
@@ -202,6 +202,26 @@ func TestProviderGenerateDeploymentFileSuccessUpdateMachineTemplate(t *testing.T
 			wantCPFile: "testdata/valid_deployment_cp_expected.yaml",
 			wantMDFile: "testdata/valid_deployment_node_labels_md_expected.yaml",
 		},
+		{
+			testName: "valid config with autoscaling",
+			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = "test-cluster"
+				s.Cluster.Spec.KubernetesVersion = "1.19"
+				s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+				s.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+				s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+				s.VersionsBundle = versionsBundle
+				s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{
+					Count:                    3,
+					MachineGroupRef:          &v1alpha1.Ref{Name: "test-cluster"},
+					Name:                     "md-0",
+					AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 5},
+				}}
+			}),
+			wantCPFile: "testdata/valid_deployment_cp_expected.yaml",
+			wantMDFile: "testdata/valid_deployment_autoscaling_md_expected.yaml",
+		},
 		{
 			testName: "valid config with cp node labels",
 			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
@@ -569,3 +589,43 @@ func TestProviderGenerateCAPISpecForCreateWithStackedEtcd(t *testing.T) {
 	}
 	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_stacked_etcd_expected.yaml")
 }
+
+func TestProviderGenerateCAPISpecForCreateWithWorkerNodeGroupKubernetesVersion(t *testing.T) {
+	tt := newTest(t)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	kube118 := v1alpha1.Kube118
+	workerVersionsBundle := &cluster.VersionsBundle{
+		KubeDistro: &cluster.KubeDistro{
+			Kubernetes: cluster.VersionedRepository{
+				Repository: "public.ecr.aws/eks-distro/kubernetes",
+				Tag:        "v1.18.16-eks-1-18-4",
+			},
+		},
+		VersionsBundle: &releasev1alpha1.VersionsBundle{
+			EksD: releasev1alpha1.EksDRelease{
+				KindNode: releasev1alpha1.Image{
+					URI: "public.ecr.aws/eks-distro/kubernetes-sigs/kind/node:v1.18.16-eks-1-18-4",
+				},
+			},
+		},
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.KubernetesVersion = "1.19"
+		s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+		s.VersionsBundle = versionsBundle
+		s.WorkerVersionsBundles = map[v1alpha1.KubernetesVersion]*cluster.VersionsBundle{kube118: workerVersionsBundle}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+			{Name: "md-0", Count: 3, KubernetesVersion: &kube118, MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}},
+		}
+	})
+
+	_, md, err := tt.provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(string(md)).To(ContainSubstring("version: v1.18.16-eks-1-18-4"))
+	tt.Expect(string(md)).To(ContainSubstring("customImage: public.ecr.aws/eks-distro/kubernetes-sigs/kind/node:v1.18.16-eks-1-18-4"))
+}
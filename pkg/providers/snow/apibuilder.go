@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
@@ -83,7 +84,7 @@ func MachineDeployments(clusterSpec *cluster.Spec, kubeadmConfigTemplates map[st
 	for _, workerNodeGroupConfig := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
 		deployment := machineDeployment(clusterSpec, workerNodeGroupConfig,
 			kubeadmConfigTemplates[workerNodeGroupConfig.Name],
-			machineTemplates[workerNodeGroupConfig.MachineGroupRef.Name],
+			machineTemplates[workerNodeGroupConfig.Name],
 		)
 		m[workerNodeGroupConfig.Name] = &deployment
 	}
@@ -111,12 +112,14 @@ func SnowCluster(clusterSpec *cluster.Spec) *snowv1.AWSSnowCluster {
 	return cluster
 }
 
+// SnowMachineTemplates builds the machine template of each worker node group, keyed by worker node group name,
+// so the template of a group can be renamed on upgrade without affecting other groups using the same machine config
 func SnowMachineTemplates(clusterSpec *cluster.Spec, machineConfigs map[string]*v1alpha1.SnowMachineConfig) map[string]*snowv1.AWSSnowMachineTemplate {
 	m := map[string]*snowv1.AWSSnowMachineTemplate{}
 
 	for _, workerNodeGroupConfig := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
 		smt := SnowMachineTemplate(machineConfigs[workerNodeGroupConfig.MachineGroupRef.Name])
-		m[workerNodeGroupConfig.MachineGroupRef.Name] = smt
+		m[workerNodeGroupConfig.Name] = smt
 	}
 	return m
 }
@@ -150,3 +153,33 @@ func SnowMachineTemplate(machineConfig *v1alpha1.SnowMachineConfig) *snowv1.AWSS
 		},
 	}
 }
+
+// MachineHealthChecks builds the health checks of the control plane and of the MachineDeployment of each worker node group,
+// using the timeouts from the snow datacenter config if set
+func MachineHealthChecks(clusterSpec *cluster.Spec) []runtime.Object {
+	clusterName := clusterSpec.Cluster.GetName()
+	timeouts := machineHealthCheckTimeouts(clusterSpec.SnowDatacenter)
+
+	mhcs := make([]runtime.Object, 0, len(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations)+1)
+	mhcs = append(mhcs, clusterapi.ControlPlaneMachineHealthCheck(clusterName, timeouts))
+	for _, workerNodeGroupConfig := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		mhcs = append(mhcs, clusterapi.WorkerMachineHealthCheck(clusterName, workerNodeGroupConfig, timeouts))
+	}
+	return mhcs
+}
+
+func machineHealthCheckTimeouts(datacenterConfig *v1alpha1.SnowDatacenterConfig) clusterapi.MachineHealthCheckTimeouts {
+	timeouts := clusterapi.DefaultMachineHealthCheckTimeouts()
+	if datacenterConfig == nil || datacenterConfig.Spec.MachineHealthCheck == nil {
+		return timeouts
+	}
+
+	mhc := datacenterConfig.Spec.MachineHealthCheck
+	if mhc.NodeStartupTimeout != nil {
+		timeouts.NodeStartup = mhc.NodeStartupTimeout.Duration
+	}
+	if mhc.UnhealthyMachineTimeout != nil {
+		timeouts.UnhealthyMachine = mhc.UnhealthyMachineTimeout.Duration
+	}
+	return timeouts
+}
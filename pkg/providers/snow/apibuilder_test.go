@@ -320,7 +320,7 @@ func TestSnowMachineTemplates(t *testing.T) {
 	wantSSHKey := "default"
 	wantPhysicalNetworkConnector := "SFP_PLUS"
 	want := map[string]*snowv1.AWSSnowMachineTemplate{
-		"test-wn": {
+		"md-0": {
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
 				Kind:       "AWSSnowMachineTemplate",
@@ -2,17 +2,22 @@ package snow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -38,11 +43,17 @@ type snowProvider struct {
 	writer                filewriter.FileWriter
 	retrier               *retrier.Retrier
 	bootstrapCreds        bootstrapCreds
+	now                   types.NowFunc
+	clusterSpec           *cluster.Spec
+	storageManifest       []byte
 }
 
 type ProviderKubectlClient interface {
 	DeleteEksaDatacenterConfig(ctx context.Context, snowDatacenterResourceType string, snowDatacenterConfigName string, kubeconfigFile string, namespace string) error
 	DeleteEksaMachineConfig(ctx context.Context, snowMachineResourceType string, snowMachineConfigName string, kubeconfigFile string, namespace string) error
+	GetKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*controlplanev1.KubeadmControlPlane, error)
+	GetMachineDeployment(ctx context.Context, workerNodeGroupName string, opts ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error)
+	GetSnowMachineTemplate(ctx context.Context, name string, opts ...executables.KubectlOpt) (*snowv1.AWSSnowMachineTemplate, error)
 }
 
 func NewProvider(providerKubectlClient ProviderKubectlClient, writer filewriter.FileWriter, now types.NowFunc) *snowProvider {
@@ -51,6 +62,7 @@ func NewProvider(providerKubectlClient ProviderKubectlClient, writer filewriter.
 		providerKubectlClient: providerKubectlClient,
 		writer:                writer,
 		retrier:               retrier,
+		now:                   now,
 	}
 }
 
@@ -74,15 +86,33 @@ func (p *snowProvider) setupMachineConfigs(clusterSpec *cluster.Spec) {
 	}
 }
 
+func (p *snowProvider) setupStorage(clusterSpec *cluster.Spec) error {
+	storageManifest, err := LocalPathProvisioner(clusterSpec)
+	if err != nil {
+		return fmt.Errorf("failed generating storage manifest: %v", err)
+	}
+	p.storageManifest = storageManifest
+	return nil
+}
+
 func (p *snowProvider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error {
 	if err := p.setupBootstrapCreds(); err != nil {
 		return fmt.Errorf("failed setting up credentials: %v", err)
 	}
 	p.setupMachineConfigs(clusterSpec)
+	if err := p.setupStorage(clusterSpec); err != nil {
+		return err
+	}
+	p.clusterSpec = clusterSpec
 	return nil
 }
 
 func (p *snowProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if err := p.setupBootstrapCreds(); err != nil {
+		return fmt.Errorf("failed setting up credentials: %v", err)
+	}
+	p.setupMachineConfigs(clusterSpec)
+	p.clusterSpec = clusterSpec
 	return nil
 }
 
@@ -98,8 +128,12 @@ func (p *snowProvider) UpdateSecrets(ctx context.Context, cluster *types.Cluster
 }
 
 func ControlPlaneObjects(clusterSpec *cluster.Spec, machineConfigs map[string]*v1alpha1.SnowMachineConfig) []runtime.Object {
-	snowCluster := SnowCluster(clusterSpec)
 	controlPlaneMachineTemplate := SnowMachineTemplate(machineConfigs[clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
+	return controlPlaneObjects(clusterSpec, controlPlaneMachineTemplate)
+}
+
+func controlPlaneObjects(clusterSpec *cluster.Spec, controlPlaneMachineTemplate *snowv1.AWSSnowMachineTemplate) []runtime.Object {
+	snowCluster := SnowCluster(clusterSpec)
 	kubeadmControlPlane := KubeadmControlPlane(clusterSpec, controlPlaneMachineTemplate)
 	capiCluster := CAPICluster(clusterSpec, snowCluster, kubeadmControlPlane)
 
@@ -107,8 +141,11 @@ func ControlPlaneObjects(clusterSpec *cluster.Spec, machineConfigs map[string]*v
 }
 
 func WorkersObjects(clusterSpec *cluster.Spec, machineConfigs map[string]*v1alpha1.SnowMachineConfig) []runtime.Object {
+	return workersObjects(clusterSpec, SnowMachineTemplates(clusterSpec, machineConfigs))
+}
+
+func workersObjects(clusterSpec *cluster.Spec, workerMachineTemplates map[string]*snowv1.AWSSnowMachineTemplate) []runtime.Object {
 	kubeadmConfigTemplates := KubeadmConfigTemplates(clusterSpec)
-	workerMachineTemplates := SnowMachineTemplates(clusterSpec, machineConfigs)
 	machineDeployments := MachineDeployments(clusterSpec, kubeadmConfigTemplates, workerMachineTemplates)
 
 	workersObjs := make([]runtime.Object, 0, len(machineDeployments)+len(kubeadmConfigTemplates)+len(workerMachineTemplates))
@@ -118,7 +155,13 @@ func WorkersObjects(clusterSpec *cluster.Spec, machineConfigs map[string]*v1alph
 	for _, item := range kubeadmConfigTemplates {
 		workersObjs = append(workersObjs, item)
 	}
+	// worker node groups with the same machine config share the machine template until they are upgraded
+	templateNames := map[string]struct{}{}
 	for _, item := range workerMachineTemplates {
+		if _, ok := templateNames[item.GetName()]; ok {
+			continue
+		}
+		templateNames[item.GetName()] = struct{}{}
 		workersObjs = append(workersObjs, item)
 	}
 
@@ -139,12 +182,8 @@ func (p *snowProvider) GenerateCAPISpecForCreate(ctx context.Context, _ *types.C
 	return controlPlaneSpec, workersSpec, nil
 }
 
-func (p *snowProvider) GenerateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currrentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
-	return nil, nil, nil
-}
-
 func (p *snowProvider) GenerateStorageClass() []byte {
-	return nil
+	return p.storageManifest
 }
 
 func (p *snowProvider) PostBootstrapSetup(ctx context.Context, clusterConfig *v1alpha1.Cluster, cluster *types.Cluster) error {
@@ -214,15 +253,35 @@ func (p *snowProvider) MachineConfigs(clusterSpec *cluster.Spec) []providers.Mac
 }
 
 func (p *snowProvider) ValidateNewSpec(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	for _, machineConfigRef := range clusterSpec.Cluster.MachineConfigRefs() {
+		machineConfig, ok := clusterSpec.SnowMachineConfigs[machineConfigRef.Name]
+		if !ok {
+			return fmt.Errorf("cannot find machine config %s in snow provider machine configs", machineConfigRef.Name)
+		}
+		if machineConfig.Spec.AMIID == "" {
+			return fmt.Errorf("spec.amiID is required for snow machine config %s", machineConfig.Name)
+		}
+	}
 	return nil
 }
 
 func (p *snowProvider) GenerateMHC() ([]byte, error) {
-	return nil, nil
+	if p.clusterSpec == nil {
+		return nil, errors.New("cluster spec not set up, can't generate machine health checks")
+	}
+	return templater.ObjectsToYaml(MachineHealthChecks(p.clusterSpec)...)
 }
 
 func (p *snowProvider) ChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ComponentChangeDiff {
-	return nil
+	if currentSpec.VersionsBundle.Snow.Version == newSpec.VersionsBundle.Snow.Version {
+		return nil
+	}
+
+	return &types.ComponentChangeDiff{
+		ComponentName: constants.SnowProviderName,
+		NewVersion:    newSpec.VersionsBundle.Snow.Version,
+		OldVersion:    currentSpec.VersionsBundle.Snow.Version,
+	}
 }
 
 func (p *snowProvider) RunPostControlPlaneUpgrade(ctx context.Context, oldClusterSpec *cluster.Spec, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, managementCluster *types.Cluster) error {
@@ -230,7 +289,10 @@ func (p *snowProvider) RunPostControlPlaneUpgrade(ctx context.Context, oldCluste
 }
 
 func (p *snowProvider) UpgradeNeeded(ctx context.Context, newSpec, currentSpec *cluster.Spec) (bool, error) {
-	return false, nil
+	newV, oldV := newSpec.VersionsBundle.Snow, currentSpec.VersionsBundle.Snow
+
+	return newV.Manager.ImageDigest != oldV.Manager.ImageDigest ||
+		newV.KubeVip.ImageDigest != oldV.KubeVip.ImageDigest, nil
 }
 
 func (p *snowProvider) DeleteResources(ctx context.Context, clusterSpec *cluster.Spec) error {
@@ -247,5 +309,10 @@ func (p *snowProvider) RunPostControlPlaneCreation(ctx context.Context, clusterS
 }
 
 func (p *snowProvider) MachineDeploymentsToDelete(workloadCluster *types.Cluster, currentSpec, newSpec *cluster.Spec) []string {
-	return nil
+	nodeGroupsToDelete := cluster.NodeGroupsToDelete(currentSpec, newSpec)
+	machineDeployments := make([]string, 0, len(nodeGroupsToDelete))
+	for _, group := range nodeGroupsToDelete {
+		machineDeployments = append(machineDeployments, machineDeploymentName(group.Name))
+	}
+	return machineDeployments
 }
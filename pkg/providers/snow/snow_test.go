@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -272,6 +273,76 @@ func TestSetupAndValidateDeleteClusterNoCertsEnv(t *testing.T) {
 	tt.Expect(err).To(MatchError(ContainSubstring("EKSA_SNOW_DEVICES_CA_BUNDLES_FILE is not set or is empty")))
 }
 
+func TestSetupAndValidateUpgradeClusterSuccess(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	err := tt.provider.SetupAndValidateUpgradeCluster(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(tt.clusterSpec.SnowMachineConfigs["test-cp"].Annotations).To(HaveKeyWithValue(tt.clusterSpec.Cluster.ControlPlaneAnnotation(), "true"))
+}
+
+func TestSetupAndValidateUpgradeClusterNoCredsEnv(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	os.Unsetenv(credsFileEnvVar)
+	err := tt.provider.SetupAndValidateUpgradeCluster(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("EKSA_SNOW_DEVICES_CREDENTIALS_FILE is not set or is empty")))
+}
+
+func TestValidateNewSpecSuccess(t *testing.T) {
+	tt := newSnowTest(t)
+	tt.Expect(tt.provider.ValidateNewSpec(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestValidateNewSpecMissingMachineConfig(t *testing.T) {
+	tt := newSnowTest(t)
+	delete(tt.clusterSpec.SnowMachineConfigs, "test-wn")
+	tt.Expect(tt.provider.ValidateNewSpec(tt.ctx, tt.cluster, tt.clusterSpec)).To(MatchError(ContainSubstring("cannot find machine config test-wn")))
+}
+
+func TestValidateNewSpecMissingAMI(t *testing.T) {
+	tt := newSnowTest(t)
+	tt.clusterSpec.SnowMachineConfigs["test-cp"].Spec.AMIID = ""
+	tt.Expect(tt.provider.ValidateNewSpec(tt.ctx, tt.cluster, tt.clusterSpec)).To(MatchError(ContainSubstring("spec.amiID is required for snow machine config test-cp")))
+}
+
+func TestChangeDiffNoChange(t *testing.T) {
+	tt := newSnowTest(t)
+	tt.Expect(tt.provider.ChangeDiff(tt.clusterSpec, givenClusterSpec())).To(BeNil())
+}
+
+func TestChangeDiffWithChange(t *testing.T) {
+	tt := newSnowTest(t)
+	newSpec := givenClusterSpec()
+	newSpec.VersionsBundle.Snow.Version = "v1.0.3"
+	want := &types.ComponentChangeDiff{
+		ComponentName: "snow",
+		NewVersion:    "v1.0.3",
+		OldVersion:    "v1.0.2",
+	}
+	tt.Expect(tt.provider.ChangeDiff(tt.clusterSpec, newSpec)).To(Equal(want))
+}
+
+func TestUpgradeNeeded(t *testing.T) {
+	tt := newSnowTest(t)
+	newSpec := givenClusterSpec()
+	needed, err := tt.provider.UpgradeNeeded(tt.ctx, newSpec, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(needed).To(BeFalse())
+
+	newSpec.VersionsBundle.Snow.KubeVip.ImageDigest = "sha256:new"
+	needed, err = tt.provider.UpgradeNeeded(tt.ctx, newSpec, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(needed).To(BeTrue())
+}
+
+func TestMachineDeploymentsToDelete(t *testing.T) {
+	tt := newSnowTest(t)
+	newSpec := givenClusterSpec()
+	newSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name = "md-1"
+	tt.Expect(tt.provider.MachineDeploymentsToDelete(tt.cluster, tt.clusterSpec, newSpec)).To(ConsistOf("md-0"))
+}
+
 // TODO: add more tests (multi worker node groups, unstacked etcd, etc.)
 func TestGenerateCAPISpecForCreate(t *testing.T) {
 	tt := newSnowTest(t)
@@ -281,6 +352,76 @@ func TestGenerateCAPISpecForCreate(t *testing.T) {
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_main_md.yaml")
 }
 
+func TestGenerateMHC(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	tt.Expect(tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)).To(Succeed())
+
+	mhc, err := tt.provider.GenerateMHC()
+	tt.Expect(err).To(Succeed())
+	test.AssertContentToFile(t, string(mhc), "testdata/expected_results_mhc.yaml")
+}
+
+func TestGenerateMHCCustomTimeouts(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	tt.clusterSpec.SnowDatacenter.Spec.MachineHealthCheck = &v1alpha1.SnowMachineHealthCheck{
+		NodeStartupTimeout:      &metav1.Duration{Duration: 20 * time.Minute},
+		UnhealthyMachineTimeout: &metav1.Duration{Duration: 8 * time.Minute},
+	}
+	tt.Expect(tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)).To(Succeed())
+
+	mhc, err := tt.provider.GenerateMHC()
+	tt.Expect(err).To(Succeed())
+	tt.Expect(string(mhc)).To(ContainSubstring("nodeStartupTimeout: 20m0s"))
+	tt.Expect(string(mhc)).To(ContainSubstring("timeout: 8m0s"))
+	tt.Expect(string(mhc)).NotTo(ContainSubstring("timeout: 5m0s"))
+}
+
+func TestGenerateMHCNoClusterSpec(t *testing.T) {
+	tt := newSnowTest(t)
+	_, err := tt.provider.GenerateMHC()
+	tt.Expect(err).To(MatchError(ContainSubstring("cluster spec not set up")))
+}
+
+func TestGenerateStorageClassNoStorage(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	tt.Expect(tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)).To(Succeed())
+	tt.Expect(tt.provider.GenerateStorageClass()).To(BeNil())
+}
+
+func TestGenerateStorageClassLocalPathProvisioner(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	tt.clusterSpec.SnowDatacenter.Spec.Storage = &v1alpha1.SnowStorage{}
+	tt.clusterSpec.VersionsBundle.Snow.LocalPathProvisioner = releasev1alpha1.Image{
+		Name: "local-path-provisioner",
+		URI:  "public.ecr.aws/l0g8r8j6/rancher/local-path-provisioner:v0.0.22-eks-a-v0.0.0-dev-build.1433",
+	}
+	tt.Expect(tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)).To(Succeed())
+	test.AssertContentToFile(t, string(tt.provider.GenerateStorageClass()), "testdata/expected_results_storage.yaml")
+}
+
+func TestGenerateStorageClassCustomLocalVolumePath(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	tt.clusterSpec.SnowDatacenter.Spec.Storage = &v1alpha1.SnowStorage{LocalVolumePath: "/data/volumes"}
+	tt.clusterSpec.VersionsBundle.Snow.LocalPathProvisioner = releasev1alpha1.Image{
+		URI: "public.ecr.aws/l0g8r8j6/rancher/local-path-provisioner:v0.0.22-eks-a-v0.0.0-dev-build.1433",
+	}
+	tt.Expect(tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)).To(Succeed())
+	tt.Expect(string(tt.provider.GenerateStorageClass())).To(ContainSubstring(`"paths":["/data/volumes"]`))
+}
+
+func TestSetupAndValidateCreateClusterStorageNotInBundle(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	tt.clusterSpec.SnowDatacenter.Spec.Storage = &v1alpha1.SnowStorage{}
+	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("local path provisioner image is not available")))
+}
+
 func TestVersion(t *testing.T) {
 	snowVersion := "v1.0.2"
 	provider := givenProvider(t)
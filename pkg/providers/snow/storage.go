@@ -0,0 +1,42 @@
+package snow
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const defaultLocalVolumePath = "/opt/local-path-provisioner"
+
+//go:embed config/local-path-provisioner.yaml
+var localPathProvisionerTemplate string
+
+// LocalPathProvisioner generates the manifest of the local volume provisioner configured in the storage section
+// of the snow datacenter config, which also sets its StorageClass as the default one.
+// It returns nil if the cluster has no storage configured
+func LocalPathProvisioner(clusterSpec *cluster.Spec) ([]byte, error) {
+	if clusterSpec.SnowDatacenter == nil || clusterSpec.SnowDatacenter.Spec.Storage == nil {
+		return nil, nil
+	}
+
+	image := clusterSpec.VersionsBundle.Snow.LocalPathProvisioner
+	if image.URI == "" {
+		return nil, fmt.Errorf("local path provisioner image is not available in bundle %d, snow storage is not supported", clusterSpec.Bundles.Spec.Number)
+	}
+
+	path := clusterSpec.SnowDatacenter.Spec.Storage.LocalVolumePath
+	if path == "" {
+		path = defaultLocalVolumePath
+	}
+
+	values := map[string]interface{}{
+		"namespace": constants.LocalPathStorageNamespace,
+		"image":     image.VersionedImage(),
+		"path":      path,
+	}
+
+	return templater.Execute(localPathProvisionerTemplate, values)
+}
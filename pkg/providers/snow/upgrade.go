@@ -0,0 +1,143 @@
+package snow
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func (p *snowProvider) GenerateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
+	oldControlPlaneTemplate, err := p.controlPlaneMachineTemplateInCluster(ctx, bootstrapCluster, workloadCluster, newClusterSpec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating snow control plane machine template for upgrade: %v", err)
+	}
+
+	controlPlaneSpec, err = templater.ObjectsToYaml(ControlPlaneObjectsForUpgrade(currentSpec, newClusterSpec, oldControlPlaneTemplate, p.now)...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oldWorkerTemplates, err := p.workerMachineTemplatesInCluster(ctx, bootstrapCluster, currentSpec, newClusterSpec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating snow worker machine templates for upgrade: %v", err)
+	}
+
+	workersSpec, err = templater.ObjectsToYaml(WorkersObjectsForUpgrade(currentSpec, newClusterSpec, oldWorkerTemplates, p.now)...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return controlPlaneSpec, workersSpec, nil
+}
+
+// ControlPlaneObjectsForUpgrade builds the control plane objects of newClusterSpec. Machine templates are immutable, so
+// oldTemplate, the control plane machine template in the cluster, is reused if it doesn't change and a template with a
+// new name is created otherwise
+func ControlPlaneObjectsForUpgrade(currentSpec, newClusterSpec *cluster.Spec, oldTemplate *snowv1.AWSSnowMachineTemplate, now types.NowFunc) []runtime.Object {
+	newTemplate := SnowMachineTemplate(newClusterSpec.SnowMachineConfigs[newClusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
+	if NeedsNewControlPlaneTemplate(currentSpec, newClusterSpec, oldTemplate, newTemplate) {
+		newTemplate.SetName(common.CPMachineTemplateName(newClusterSpec.Cluster.Name, now))
+	} else {
+		newTemplate.SetName(oldTemplate.GetName())
+	}
+
+	return controlPlaneObjects(newClusterSpec, newTemplate)
+}
+
+// WorkersObjectsForUpgrade builds the worker objects of newClusterSpec, reusing the machine template of each worker node group
+// in oldTemplates, keyed by worker node group name, if it doesn't change. Groups without a template in oldTemplates, the ones
+// added in newClusterSpec, always get a new template
+func WorkersObjectsForUpgrade(currentSpec, newClusterSpec *cluster.Spec, oldTemplates map[string]*snowv1.AWSSnowMachineTemplate, now types.NowFunc) []runtime.Object {
+	templates := SnowMachineTemplates(newClusterSpec, newClusterSpec.SnowMachineConfigs)
+	for _, workerNodeGroupConfig := range newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		newTemplate := templates[workerNodeGroupConfig.Name]
+		oldTemplate, ok := oldTemplates[workerNodeGroupConfig.Name]
+		if !ok || NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, workerNodeGroupConfig, oldTemplate, newTemplate) {
+			newTemplate.SetName(common.WorkerMachineTemplateName(newClusterSpec.Cluster.Name, workerNodeGroupConfig.Name, now))
+		} else {
+			newTemplate.SetName(oldTemplate.GetName())
+		}
+	}
+
+	return workersObjects(newClusterSpec, templates)
+}
+
+// controlPlaneMachineTemplateInCluster returns the machine template of the cluster KubeadmControlPlane
+func (p *snowProvider) controlPlaneMachineTemplateInCluster(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec) (*snowv1.AWSSnowMachineTemplate, error) {
+	kcp, err := p.providerKubectlClient.GetKubeadmControlPlane(ctx, workloadCluster, newClusterSpec.Cluster.Name, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		return nil, err
+	}
+
+	return p.providerKubectlClient.GetSnowMachineTemplate(ctx, kcp.Spec.MachineTemplate.InfrastructureRef.Name, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+}
+
+// workerMachineTemplatesInCluster returns the machine template of the machine deployment of each worker node group in both
+// currentSpec and newClusterSpec, keyed by worker node group name
+func (p *snowProvider) workerMachineTemplatesInCluster(ctx context.Context, bootstrapCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (map[string]*snowv1.AWSSnowMachineTemplate, error) {
+	previousWorkerNodeGroupConfigs := cluster.BuildMapForWorkerNodeGroupsByName(currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations)
+
+	templates := map[string]*snowv1.AWSSnowMachineTemplate{}
+	for _, workerNodeGroupConfig := range newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if _, ok := previousWorkerNodeGroupConfigs[workerNodeGroupConfig.Name]; !ok {
+			continue
+		}
+
+		md, err := p.providerKubectlClient.GetMachineDeployment(ctx, machineDeploymentName(workerNodeGroupConfig.Name), executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+		if err != nil {
+			return nil, err
+		}
+
+		template, err := p.providerKubectlClient.GetSnowMachineTemplate(ctx, md.Spec.Template.Spec.InfrastructureRef.Name, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+		if err != nil {
+			return nil, err
+		}
+		templates[workerNodeGroupConfig.Name] = template
+	}
+
+	return templates, nil
+}
+
+func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec, oldTemplate, newTemplate *snowv1.AWSSnowMachineTemplate) bool {
+	if oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion {
+		return true
+	}
+	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
+		return true
+	}
+	return MachineTemplateChanged(oldTemplate, newTemplate)
+}
+
+func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, workerNodeGroupConfig v1alpha1.WorkerNodeGroupConfiguration, oldTemplate, newTemplate *snowv1.AWSSnowMachineTemplate) bool {
+	if cluster.WorkerNodeGroupKubernetesVersionChanged(oldSpec, newSpec, workerNodeGroupConfig) {
+		return true
+	}
+	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
+		return true
+	}
+	return MachineTemplateChanged(oldTemplate, newTemplate)
+}
+
+// MachineTemplateChanged returns true if the machine spec of newTemplate differs from the one in oldTemplate,
+// for example when the AMI or the instance type of the machine config changed. Fields not set in newTemplate
+// are ignored, since they might have been defaulted in the cluster
+func MachineTemplateChanged(oldTemplate, newTemplate *snowv1.AWSSnowMachineTemplate) bool {
+	return !equality.Semantic.DeepDerivative(newTemplate.Spec.Template.Spec, oldTemplate.Spec.Template.Spec)
+}
+
+// machineDeploymentName returns the name of the machine deployment of a worker node group, which is
+// the worker node group name for snow
+func machineDeploymentName(workerNodeGroupName string) string {
+	return workerNodeGroupName
+}
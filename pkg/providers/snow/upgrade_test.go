@@ -0,0 +1,197 @@
+package snow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+)
+
+type upgradeTest struct {
+	snowTest
+	currentSpec *cluster.Spec
+}
+
+func newUpgradeTest(t *testing.T) upgradeTest {
+	tt := upgradeTest{
+		snowTest:    newSnowTest(t),
+		currentSpec: givenClusterSpec(),
+	}
+	tt.cluster.Name = tt.clusterSpec.Cluster.Name
+	return tt
+}
+
+func (tt upgradeTest) expectGetControlPlaneTemplate(template *snowv1.AWSSnowMachineTemplate) {
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{Name: template.Name},
+			},
+		},
+	}
+	tt.kubectl.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.cluster, tt.cluster.Name, kubectlOpts()...).Return(kcp, nil)
+	tt.kubectl.EXPECT().GetSnowMachineTemplate(tt.ctx, template.Name, kubectlOpts()...).Return(template, nil)
+}
+
+func (tt upgradeTest) expectGetWorkerTemplate(mdName string, template *snowv1.AWSSnowMachineTemplate) {
+	md := &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					InfrastructureRef: corev1.ObjectReference{Name: template.Name},
+				},
+			},
+		},
+	}
+	tt.kubectl.EXPECT().GetMachineDeployment(tt.ctx, mdName, kubectlOpts()...).Return(md, nil)
+	tt.kubectl.EXPECT().GetSnowMachineTemplate(tt.ctx, template.Name, kubectlOpts()...).Return(template, nil)
+}
+
+// kubectlOpts matches the cluster and namespace options of the kubectl calls
+func kubectlOpts() []interface{} {
+	opt := gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))
+	return []interface{}{opt, opt}
+}
+
+func currentTemplate(machineConfig *v1alpha1.SnowMachineConfig) *snowv1.AWSSnowMachineTemplate {
+	return SnowMachineTemplate(machineConfig.DeepCopy())
+}
+
+func TestGenerateCAPISpecForUpgradeNoChanges(t *testing.T) {
+	tt := newUpgradeTest(t)
+	tt.expectGetControlPlaneTemplate(currentTemplate(tt.clusterSpec.SnowMachineConfigs["test-cp"]))
+	tt.expectGetWorkerTemplate("md-0", currentTemplate(tt.clusterSpec.SnowMachineConfigs["test-wn"]))
+
+	cp, md, err := tt.provider.GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.currentSpec, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_main_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_main_md.yaml")
+}
+
+func TestGenerateCAPISpecForUpgradeMachineConfigsChanged(t *testing.T) {
+	tt := newUpgradeTest(t)
+	tt.expectGetControlPlaneTemplate(currentTemplate(tt.clusterSpec.SnowMachineConfigs["test-cp"]))
+	tt.expectGetWorkerTemplate("md-0", currentTemplate(tt.clusterSpec.SnowMachineConfigs["test-wn"]))
+	tt.clusterSpec.SnowMachineConfigs["test-cp"].Spec.AMIID = "eks-d-v1-21-5-ubuntu-ami-new"
+	tt.clusterSpec.SnowMachineConfigs["test-wn"].Spec.InstanceType = v1alpha1.SbeC2XLarge
+
+	cp, md, err := tt.provider.GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.currentSpec, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_upgrade_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_upgrade_md.yaml")
+}
+
+func TestGenerateCAPISpecForUpgradeBundlesChanged(t *testing.T) {
+	tt := newUpgradeTest(t)
+	tt.expectGetControlPlaneTemplate(currentTemplate(tt.clusterSpec.SnowMachineConfigs["test-cp"]))
+	tt.expectGetWorkerTemplate("md-0", currentTemplate(tt.clusterSpec.SnowMachineConfigs["test-wn"]))
+	tt.clusterSpec.Bundles.Spec.Number = tt.currentSpec.Bundles.Spec.Number + 1
+
+	cp, md, err := tt.provider.GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.currentSpec, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(string(cp)).To(ContainSubstring("name: snow-test-control-plane-template-"))
+	tt.Expect(string(md)).To(ContainSubstring("name: snow-test-md-0-"))
+}
+
+func TestGenerateCAPISpecForUpgradeNewWorkerNodeGroup(t *testing.T) {
+	tt := newUpgradeTest(t)
+	tt.expectGetControlPlaneTemplate(currentTemplate(tt.clusterSpec.SnowMachineConfigs["test-cp"]))
+	tt.expectGetWorkerTemplate("md-0", currentTemplate(tt.clusterSpec.SnowMachineConfigs["test-wn"]))
+	newGroup := tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0]
+	newGroup.Name = "md-1"
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = append(tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newGroup)
+
+	_, md, err := tt.provider.GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.currentSpec, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(string(md)).To(ContainSubstring("name: test-wn\n"))
+	tt.Expect(string(md)).To(ContainSubstring("name: snow-test-md-1-"))
+}
+
+func TestGenerateCAPISpecForUpgradeGetKubeadmControlPlaneError(t *testing.T) {
+	tt := newUpgradeTest(t)
+	tt.kubectl.EXPECT().GetKubeadmControlPlane(tt.ctx, tt.cluster, tt.cluster.Name, kubectlOpts()...).Return(nil, errors.New("kcp not found"))
+
+	_, _, err := tt.provider.GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.currentSpec, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("kcp not found")))
+}
+
+func TestGenerateCAPISpecForUpgradeGetMachineDeploymentError(t *testing.T) {
+	tt := newUpgradeTest(t)
+	tt.expectGetControlPlaneTemplate(currentTemplate(tt.clusterSpec.SnowMachineConfigs["test-cp"]))
+	tt.kubectl.EXPECT().GetMachineDeployment(tt.ctx, "md-0", kubectlOpts()...).Return(nil, errors.New("md not found"))
+
+	_, _, err := tt.provider.GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.currentSpec, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("md not found")))
+}
+
+func TestNeedsNewControlPlaneTemplateKubernetesVersionChanged(t *testing.T) {
+	g := NewWithT(t)
+	oldSpec := givenClusterSpec()
+	newSpec := givenClusterSpec()
+	newSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube122
+	template := currentTemplate(newSpec.SnowMachineConfigs["test-cp"])
+
+	g.Expect(NeedsNewControlPlaneTemplate(oldSpec, newSpec, template, template)).To(BeTrue())
+}
+
+func TestNeedsNewWorkloadTemplateKubernetesVersionChanged(t *testing.T) {
+	g := NewWithT(t)
+	oldSpec := givenClusterSpec()
+	newSpec := givenClusterSpec()
+	kube120 := v1alpha1.Kube120
+	newSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubernetesVersion = &kube120
+	template := currentTemplate(newSpec.SnowMachineConfigs["test-wn"])
+
+	g.Expect(NeedsNewWorkloadTemplate(oldSpec, newSpec, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0], template, template)).To(BeTrue())
+}
+
+func TestMachineTemplateChanged(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*v1alpha1.SnowMachineConfig)
+		want   bool
+	}{
+		{
+			name:   "no changes",
+			modify: func(*v1alpha1.SnowMachineConfig) {},
+			want:   false,
+		},
+		{
+			name:   "ami changed",
+			modify: func(m *v1alpha1.SnowMachineConfig) { m.Spec.AMIID = "new-ami" },
+			want:   true,
+		},
+		{
+			name:   "instance type changed",
+			modify: func(m *v1alpha1.SnowMachineConfig) { m.Spec.InstanceType = v1alpha1.SbeC4XLarge },
+			want:   true,
+		},
+		{
+			name:   "ssh key changed",
+			modify: func(m *v1alpha1.SnowMachineConfig) { m.Spec.SshKeyName = "new-key" },
+			want:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineConfig := givenMachineConfigs()["test-wn"]
+			oldTemplate := currentTemplate(machineConfig)
+			tc.modify(machineConfig)
+			newTemplate := currentTemplate(machineConfig)
+
+			g.Expect(MachineTemplateChanged(oldTemplate, newTemplate)).To(Equal(tc.want))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/pbnj"
+)
+
+// VendorRedfish is the BMC vendor of the machines managed through the Redfish API instead of PBnJ.
+const VendorRedfish = "redfish"
+
+// PowerClient manages the power of a machine through its BMC.
+type PowerClient interface {
+	GetPowerState(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) (pbnj.PowerState, error)
+	PowerOn(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error
+	PowerOff(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error
+	SetPxeBootOnce(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error
+	EjectVirtualMedia(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error
+}
+
+// VendorClient is a PowerClient that manages machines through Redfish when their BMC vendor is VendorRedfish
+// and through PBnJ otherwise.
+type VendorClient struct {
+	pbnj    PowerClient
+	redfish PowerClient
+}
+
+// NewVendorClient returns a VendorClient. pbnjClient can be nil if no machine is managed through PBnJ.
+func NewVendorClient(pbnjClient, redfishClient PowerClient) *VendorClient {
+	return &VendorClient{pbnj: pbnjClient, redfish: redfishClient}
+}
+
+// IsRedfish returns true if vendor is VendorRedfish.
+func IsRedfish(vendor string) bool {
+	return strings.EqualFold(vendor, VendorRedfish)
+}
+
+func (c *VendorClient) GetPowerState(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) (pbnj.PowerState, error) {
+	client, err := c.clientFor(bmcInfo)
+	if err != nil {
+		return pbnj.PowerStateUnknown, err
+	}
+	return client.GetPowerState(ctx, bmcInfo)
+}
+
+func (c *VendorClient) PowerOn(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error {
+	client, err := c.clientFor(bmcInfo)
+	if err != nil {
+		return err
+	}
+	return client.PowerOn(ctx, bmcInfo)
+}
+
+func (c *VendorClient) PowerOff(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error {
+	client, err := c.clientFor(bmcInfo)
+	if err != nil {
+		return err
+	}
+	return client.PowerOff(ctx, bmcInfo)
+}
+
+func (c *VendorClient) SetPxeBootOnce(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error {
+	client, err := c.clientFor(bmcInfo)
+	if err != nil {
+		return err
+	}
+	return client.SetPxeBootOnce(ctx, bmcInfo)
+}
+
+func (c *VendorClient) EjectVirtualMedia(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error {
+	client, err := c.clientFor(bmcInfo)
+	if err != nil {
+		return err
+	}
+	return client.EjectVirtualMedia(ctx, bmcInfo)
+}
+
+func (c *VendorClient) clientFor(bmcInfo pbnj.BmcSecretConfig) (PowerClient, error) {
+	if IsRedfish(bmcInfo.Vendor) {
+		if c.redfish == nil {
+			return nil, errors.New("redfish client is not configured")
+		}
+		return c.redfish, nil
+	}
+
+	if c.pbnj == nil {
+		return nil, errors.New("pbnj client is not configured")
+	}
+	return c.pbnj, nil
+}
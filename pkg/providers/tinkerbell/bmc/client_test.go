@@ -0,0 +1,97 @@
+package bmc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/pbnj"
+)
+
+type vendorClientTest struct {
+	*WithT
+	ctx     context.Context
+	pbnj    *mocks.MockPowerClient
+	redfish *mocks.MockPowerClient
+	client  *bmc.VendorClient
+}
+
+func newVendorClientTest(t *testing.T) *vendorClientTest {
+	ctrl := gomock.NewController(t)
+	tt := &vendorClientTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		pbnj:    mocks.NewMockPowerClient(ctrl),
+		redfish: mocks.NewMockPowerClient(ctrl),
+	}
+	tt.client = bmc.NewVendorClient(tt.pbnj, tt.redfish)
+	return tt
+}
+
+func TestVendorClientGetPowerStateRedfish(t *testing.T) {
+	tt := newVendorClientTest(t)
+	bmcInfo := pbnj.BmcSecretConfig{Host: "10.0.0.1", Vendor: "Redfish"}
+	tt.redfish.EXPECT().GetPowerState(tt.ctx, bmcInfo).Return(pbnj.PowerStateOn, nil)
+
+	state, err := tt.client.GetPowerState(tt.ctx, bmcInfo)
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(state).To(Equal(pbnj.PowerStateOn))
+}
+
+func TestVendorClientGetPowerStatePbnj(t *testing.T) {
+	tt := newVendorClientTest(t)
+	bmcInfo := pbnj.BmcSecretConfig{Host: "10.0.0.1", Vendor: "dell"}
+	tt.pbnj.EXPECT().GetPowerState(tt.ctx, bmcInfo).Return(pbnj.PowerStateOff, nil)
+
+	state, err := tt.client.GetPowerState(tt.ctx, bmcInfo)
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(state).To(Equal(pbnj.PowerStateOff))
+}
+
+func TestVendorClientPowerOnRedfish(t *testing.T) {
+	tt := newVendorClientTest(t)
+	bmcInfo := pbnj.BmcSecretConfig{Host: "10.0.0.1", Vendor: bmc.VendorRedfish}
+	tt.redfish.EXPECT().PowerOn(tt.ctx, bmcInfo)
+
+	tt.Expect(tt.client.PowerOn(tt.ctx, bmcInfo)).To(Succeed())
+}
+
+func TestVendorClientPowerOffPbnjError(t *testing.T) {
+	tt := newVendorClientTest(t)
+	bmcInfo := pbnj.BmcSecretConfig{Host: "10.0.0.1", Vendor: "supermicro"}
+	tt.pbnj.EXPECT().PowerOff(tt.ctx, bmcInfo).Return(errors.New("power off failed"))
+
+	tt.Expect(tt.client.PowerOff(tt.ctx, bmcInfo)).To(MatchError("power off failed"))
+}
+
+func TestVendorClientSetPxeBootOnceRedfish(t *testing.T) {
+	tt := newVendorClientTest(t)
+	bmcInfo := pbnj.BmcSecretConfig{Host: "10.0.0.1", Vendor: bmc.VendorRedfish}
+	tt.redfish.EXPECT().SetPxeBootOnce(tt.ctx, bmcInfo)
+
+	tt.Expect(tt.client.SetPxeBootOnce(tt.ctx, bmcInfo)).To(Succeed())
+}
+
+func TestVendorClientEjectVirtualMediaPbnjNotSupported(t *testing.T) {
+	tt := newVendorClientTest(t)
+	bmcInfo := pbnj.BmcSecretConfig{Host: "10.0.0.1", Vendor: "dell"}
+	tt.pbnj.EXPECT().EjectVirtualMedia(tt.ctx, bmcInfo).Return(pbnj.ErrNotSupported)
+
+	tt.Expect(tt.client.EjectVirtualMedia(tt.ctx, bmcInfo)).To(MatchError(pbnj.ErrNotSupported))
+}
+
+func TestVendorClientPbnjNotConfigured(t *testing.T) {
+	g := NewWithT(t)
+	client := bmc.NewVendorClient(nil, nil)
+
+	_, err := client.GetPowerState(context.Background(), pbnj.BmcSecretConfig{Vendor: "dell"})
+	g.Expect(err).To(MatchError("pbnj client is not configured"))
+
+	err = client.PowerOn(context.Background(), pbnj.BmcSecretConfig{Vendor: bmc.VendorRedfish})
+	g.Expect(err).To(MatchError("redfish client is not configured"))
+}
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc (interfaces: PowerClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pbnj "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/pbnj"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPowerClient is a mock of PowerClient interface.
+type MockPowerClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPowerClientMockRecorder
+}
+
+// MockPowerClientMockRecorder is the mock recorder for MockPowerClient.
+type MockPowerClientMockRecorder struct {
+	mock *MockPowerClient
+}
+
+// NewMockPowerClient creates a new mock instance.
+func NewMockPowerClient(ctrl *gomock.Controller) *MockPowerClient {
+	mock := &MockPowerClient{ctrl: ctrl}
+	mock.recorder = &MockPowerClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPowerClient) EXPECT() *MockPowerClientMockRecorder {
+	return m.recorder
+}
+
+// EjectVirtualMedia mocks base method.
+func (m *MockPowerClient) EjectVirtualMedia(arg0 context.Context, arg1 pbnj.BmcSecretConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EjectVirtualMedia", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EjectVirtualMedia indicates an expected call of EjectVirtualMedia.
+func (mr *MockPowerClientMockRecorder) EjectVirtualMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EjectVirtualMedia", reflect.TypeOf((*MockPowerClient)(nil).EjectVirtualMedia), arg0, arg1)
+}
+
+// GetPowerState mocks base method.
+func (m *MockPowerClient) GetPowerState(arg0 context.Context, arg1 pbnj.BmcSecretConfig) (pbnj.PowerState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPowerState", arg0, arg1)
+	ret0, _ := ret[0].(pbnj.PowerState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPowerState indicates an expected call of GetPowerState.
+func (mr *MockPowerClientMockRecorder) GetPowerState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPowerState", reflect.TypeOf((*MockPowerClient)(nil).GetPowerState), arg0, arg1)
+}
+
+// PowerOff mocks base method.
+func (m *MockPowerClient) PowerOff(arg0 context.Context, arg1 pbnj.BmcSecretConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOff", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOff indicates an expected call of PowerOff.
+func (mr *MockPowerClientMockRecorder) PowerOff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOff", reflect.TypeOf((*MockPowerClient)(nil).PowerOff), arg0, arg1)
+}
+
+// PowerOn mocks base method.
+func (m *MockPowerClient) PowerOn(arg0 context.Context, arg1 pbnj.BmcSecretConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOn", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOn indicates an expected call of PowerOn.
+func (mr *MockPowerClientMockRecorder) PowerOn(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOn", reflect.TypeOf((*MockPowerClient)(nil).PowerOn), arg0, arg1)
+}
+
+// SetPxeBootOnce mocks base method.
+func (m *MockPowerClient) SetPxeBootOnce(arg0 context.Context, arg1 pbnj.BmcSecretConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPxeBootOnce", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPxeBootOnce indicates an expected call of SetPxeBootOnce.
+func (mr *MockPowerClientMockRecorder) SetPxeBootOnce(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPxeBootOnce", reflect.TypeOf((*MockPowerClient)(nil).SetPxeBootOnce), arg0, arg1)
+}
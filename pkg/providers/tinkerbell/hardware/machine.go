@@ -3,6 +3,7 @@ package hardware
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 
 	apimachineryvalidation "k8s.io/apimachinery/pkg/util/validation"
@@ -19,6 +20,7 @@ type Machine struct {
 	Netmask     string      `csv:"netmask"`
 	MacAddress  string      `csv:"mac"`
 	Hostname    string      `csv:"hostname"`
+	Labels      Labels      `csv:"labels"`
 
 	BmcIpAddress string `csv:"bmc_ip"`
 	BmcUsername  string `csv:"bmc_username"`
@@ -84,6 +86,15 @@ func (m *Machine) Validate() error {
 		return fmt.Errorf("invalid hostname: %v: %v", m.Hostname, errs)
 	}
 
+	for key, value := range m.Labels {
+		if errs := apimachineryvalidation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid label key: %v: %v", key, errs)
+		}
+		if errs := apimachineryvalidation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid label value: %v: %v", value, errs)
+		}
+	}
+
 	if m.HasBmc() {
 		if m.BmcIpAddress == "" {
 			return newEmptyFieldError("BmcIpAddress")
@@ -131,6 +142,52 @@ func (n *Nameservers) MarshalCSV() (string, error) {
 	return n.String(), nil
 }
 
+// LabelsSeparator is used to separate the key-value pairs when unmarshalling Labels.
+const LabelsSeparator = "|"
+
+// LabelSeparator is used to separate a label key from its value when unmarshalling Labels.
+const LabelSeparator = "="
+
+// Labels is a custom type that can unmarshal a CSV representation of labels such as rack=1|type=gpu.
+type Labels map[string]string
+
+func (l *Labels) String() string {
+	keys := make([]string, 0, len(*l))
+	for key := range *l {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+LabelSeparator+(*l)[key])
+	}
+	return strings.Join(pairs, LabelsSeparator)
+}
+
+// UnmarshalCSV unmarshalls s where s is a list of key=value pairs separated by LabelsSeparator.
+func (l *Labels) UnmarshalCSV(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	labels := Labels{}
+	for _, pair := range strings.Split(s, LabelsSeparator) {
+		kv := strings.SplitN(pair, LabelSeparator, 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid label %q, labels must be of the form key%svalue", pair, LabelSeparator)
+		}
+		labels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	*l = labels
+	return nil
+}
+
+// MarshalCSV marshalls Labels into a string list of key=value pairs, sorted by key, separated by LabelsSeparator.
+func (l *Labels) MarshalCSV() (string, error) {
+	return l.String(), nil
+}
+
 func newEmptyFieldError(s string) error {
 	return newMachineError(fmt.Sprintf("%v is empty", s))
 }
@@ -255,6 +255,26 @@ func TestInvalidMachine(t *testing.T) {
 			BmcPassword:  "password",
 			BmcVendor:    "",
 		},
+		"InvalidLabelKey": {
+			Id:          "unique string",
+			IpAddress:   "10.10.10.10",
+			Gateway:     "10.10.10.1",
+			Nameservers: []string{"nameserver1"},
+			Netmask:     "255.255.255.255",
+			MacAddress:  "00:00:00:00:00:00",
+			Hostname:    "localhost",
+			Labels:      map[string]string{"not a key": "gpu"},
+		},
+		"InvalidLabelValue": {
+			Id:          "unique string",
+			IpAddress:   "10.10.10.10",
+			Gateway:     "10.10.10.1",
+			Nameservers: []string{"nameserver1"},
+			Netmask:     "255.255.255.255",
+			MacAddress:  "00:00:00:00:00:00",
+			Hostname:    "localhost",
+			Labels:      map[string]string{"type": "not a value"},
+		},
 	}
 
 	for name, machine := range cases {
@@ -264,3 +284,42 @@ func TestInvalidMachine(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelsUnmarshalCSV(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var labels hardware.Labels
+	err := labels.UnmarshalCSV("rack=1|disk=ssd|gpu=")
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(labels).To(gomega.Equal(hardware.Labels{"rack": "1", "disk": "ssd", "gpu": ""}))
+}
+
+func TestLabelsUnmarshalCSVEmpty(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var labels hardware.Labels
+	err := labels.UnmarshalCSV("")
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(labels).To(gomega.BeNil())
+}
+
+func TestLabelsUnmarshalCSVMissingValue(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var labels hardware.Labels
+	err := labels.UnmarshalCSV("rack=1|gpu")
+
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`invalid label "gpu"`)))
+}
+
+func TestLabelsMarshalCSVSortsKeys(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	labels := hardware.Labels{"rack": "1", "disk": "ssd"}
+	s, err := labels.MarshalCSV()
+
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(s).To(gomega.Equal("disk=ssd|rack=1"))
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/providers/tinkerbell/hardware/reconcile.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	hardware "github.com/tinkerbell/tink/protos/hardware"
+	workflow "github.com/tinkerbell/tink/protos/workflow"
+)
+
+// MockTinkerbellHardwareClient is a mock of TinkerbellHardwareClient interface.
+type MockTinkerbellHardwareClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTinkerbellHardwareClientMockRecorder
+}
+
+// MockTinkerbellHardwareClientMockRecorder is the mock recorder for MockTinkerbellHardwareClient.
+type MockTinkerbellHardwareClientMockRecorder struct {
+	mock *MockTinkerbellHardwareClient
+}
+
+// NewMockTinkerbellHardwareClient creates a new mock instance.
+func NewMockTinkerbellHardwareClient(ctrl *gomock.Controller) *MockTinkerbellHardwareClient {
+	mock := &MockTinkerbellHardwareClient{ctrl: ctrl}
+	mock.recorder = &MockTinkerbellHardwareClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTinkerbellHardwareClient) EXPECT() *MockTinkerbellHardwareClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteHardware mocks base method.
+func (m *MockTinkerbellHardwareClient) DeleteHardware(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHardware", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHardware indicates an expected call of DeleteHardware.
+func (mr *MockTinkerbellHardwareClientMockRecorder) DeleteHardware(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHardware", reflect.TypeOf((*MockTinkerbellHardwareClient)(nil).DeleteHardware), ctx, id)
+}
+
+// GetHardware mocks base method.
+func (m *MockTinkerbellHardwareClient) GetHardware(ctx context.Context) ([]*hardware.Hardware, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHardware", ctx)
+	ret0, _ := ret[0].([]*hardware.Hardware)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHardware indicates an expected call of GetHardware.
+func (mr *MockTinkerbellHardwareClientMockRecorder) GetHardware(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHardware", reflect.TypeOf((*MockTinkerbellHardwareClient)(nil).GetHardware), ctx)
+}
+
+// GetWorkflow mocks base method.
+func (m *MockTinkerbellHardwareClient) GetWorkflow(ctx context.Context) ([]*workflow.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkflow", ctx)
+	ret0, _ := ret[0].([]*workflow.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkflow indicates an expected call of GetWorkflow.
+func (mr *MockTinkerbellHardwareClientMockRecorder) GetWorkflow(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflow", reflect.TypeOf((*MockTinkerbellHardwareClient)(nil).GetWorkflow), ctx)
+}
+
+// PushHardware mocks base method.
+func (m *MockTinkerbellHardwareClient) PushHardware(ctx context.Context, hardware []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushHardware", ctx, hardware)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushHardware indicates an expected call of PushHardware.
+func (mr *MockTinkerbellHardwareClientMockRecorder) PushHardware(ctx, hardware interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushHardware", reflect.TypeOf((*MockTinkerbellHardwareClient)(nil).PushHardware), ctx, hardware)
+}
@@ -0,0 +1,234 @@
+package hardware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tinkhardware "github.com/tinkerbell/tink/protos/hardware"
+	"github.com/tinkerbell/tink/protos/workflow"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// TinkerbellHardwareClient manages the hardware registered with a Tinkerbell stack.
+type TinkerbellHardwareClient interface {
+	PushHardware(ctx context.Context, hardware []byte) error
+	GetHardware(ctx context.Context) ([]*tinkhardware.Hardware, error)
+	DeleteHardware(ctx context.Context, id string) error
+	GetWorkflow(ctx context.Context) ([]*workflow.Workflow, error)
+}
+
+// MachineCollector is a MachineWriter that keeps all the machines written to it in memory.
+type MachineCollector []Machine
+
+// Write appends m to the collected machines. It never returns an error.
+func (c *MachineCollector) Write(m Machine) error {
+	*c = append(*c, m)
+	return nil
+}
+
+// ReconcilePlan describes the changes needed to make the hardware registered with Tinkerbell match an inventory.
+type ReconcilePlan struct {
+	// Create contains the machines in the inventory that aren't registered.
+	Create []Machine
+	// Update contains the machines in the inventory whose registered network configuration differs.
+	Update []Machine
+	// UpdateBmc contains the machines in the inventory whose BMC configuration differs from the applied one.
+	// They are applied with the hardware manifest rather than pushed to Tinkerbell.
+	UpdateBmc []Machine
+	// Delete contains the registered hardware that is no longer in the inventory.
+	Delete []*tinkhardware.Hardware
+	// Skipped contains the ids of the hardware that should change but is bound to an active workflow.
+	Skipped []string
+	// Machines contains the machines in the inventory with the id of the hardware they match, without the skipped
+	// ones. They are the machines to write and apply in the hardware manifest.
+	Machines []Machine
+}
+
+// IsEmpty returns true if the plan has no changes to apply.
+func (p *ReconcilePlan) IsEmpty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.UpdateBmc) == 0 && len(p.Delete) == 0
+}
+
+// NewReconcilePlan compares the machines in inventory with the registered hardware. Machines are matched with
+// registered hardware by id and, if the id isn't registered, by MAC address, in which case the registered id is
+// kept so the hardware is updated in place. Tinkerbell doesn't store BMC configuration, so the BMC configuration of
+// the matched machines is compared with appliedBmc, the configuration last applied for each machine id as read by
+// ReadBmcConfigs. Registered hardware not matched by any machine is only planned for deletion if
+// deleteRemoved is true. Hardware bound to an active workflow is never planned for update or delete,
+// and its machine is left out of the plan Machines.
+func NewReconcilePlan(inventory []Machine, registered []*tinkhardware.Hardware, workflows []*workflow.Workflow, appliedBmc map[string]Machine, deleteRemoved bool) (*ReconcilePlan, error) {
+	busyMacs, err := macsInActiveWorkflows(workflows)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[string]*tinkhardware.Hardware, len(registered))
+	byMac := make(map[string]*tinkhardware.Hardware, len(registered))
+	for _, h := range registered {
+		byId[h.Id] = h
+		for _, mac := range hardwareMacs(h) {
+			byMac[mac] = h
+		}
+	}
+
+	plan := &ReconcilePlan{}
+	matched := make(map[string]struct{}, len(inventory))
+	for _, m := range inventory {
+		h, ok := byId[m.Id]
+		if !ok {
+			h, ok = byMac[normalizeMac(m.MacAddress)]
+		}
+		if !ok {
+			plan.Create = append(plan.Create, m)
+			plan.Machines = append(plan.Machines, m)
+			continue
+		}
+
+		if _, ok := matched[h.Id]; ok {
+			return nil, fmt.Errorf("machines %s and %s in inventory match the same registered hardware %s", m.Hostname, hardwareHostname(h), h.Id)
+		}
+		matched[h.Id] = struct{}{}
+		m.Id = h.Id
+
+		updateNetwork := networkChanged(m, h)
+		updateBmc := bmcChanged(m, appliedBmc[m.Id])
+		if (updateNetwork || updateBmc) && isBusy(h, busyMacs) {
+			plan.Skipped = append(plan.Skipped, h.Id)
+			continue
+		}
+		if updateNetwork {
+			plan.Update = append(plan.Update, m)
+		}
+		if updateBmc {
+			plan.UpdateBmc = append(plan.UpdateBmc, m)
+		}
+		plan.Machines = append(plan.Machines, m)
+	}
+
+	if !deleteRemoved {
+		return plan, nil
+	}
+
+	for _, h := range registered {
+		if _, ok := matched[h.Id]; ok {
+			continue
+		}
+		if isBusy(h, busyMacs) {
+			plan.Skipped = append(plan.Skipped, h.Id)
+			continue
+		}
+		plan.Delete = append(plan.Delete, h)
+	}
+
+	return plan, nil
+}
+
+// ReconcileTinkerbellHardware applies plan using client. Machines to create and update are pushed, since Tinkerbell
+// replaces the hardware with the same id on push.
+func ReconcileTinkerbellHardware(ctx context.Context, client TinkerbellHardwareClient, plan *ReconcilePlan) error {
+	for _, m := range append(append([]Machine{}, plan.Create...), plan.Update...) {
+		hardwareJson, err := marshalTinkerbellHardwareJson(m)
+		if err != nil {
+			return fmt.Errorf("marshalling tinkerbell hardware json (id=%v): %v", m.Id, err)
+		}
+		if err := client.PushHardware(ctx, hardwareJson); err != nil {
+			return err
+		}
+		logger.V(4).Info("Pushed hardware", "id", m.Id, "hostname", m.Hostname)
+	}
+
+	for _, h := range plan.Delete {
+		if err := client.DeleteHardware(ctx, h.Id); err != nil {
+			return err
+		}
+		logger.V(4).Info("Deleted hardware", "id", h.Id)
+	}
+
+	return nil
+}
+
+func networkChanged(m Machine, h *tinkhardware.Hardware) bool {
+	dhcp := hardwareDhcp(h)
+	if dhcp == nil {
+		return true
+	}
+
+	ip := dhcp.GetIp()
+	return normalizeMac(dhcp.GetMac()) != normalizeMac(m.MacAddress) ||
+		dhcp.GetHostname() != m.Hostname ||
+		ip.GetAddress() != m.IpAddress ||
+		ip.GetGateway() != m.Gateway ||
+		ip.GetNetmask() != m.Netmask ||
+		!reflect.DeepEqual(dhcp.GetNameServers(), []string(m.Nameservers))
+}
+
+func bmcChanged(m, applied Machine) bool {
+	return m.BmcIpAddress != applied.BmcIpAddress ||
+		m.BmcUsername != applied.BmcUsername ||
+		m.BmcPassword != applied.BmcPassword ||
+		m.BmcVendor != applied.BmcVendor
+}
+
+// hardwareDhcp returns the DHCP configuration of the first network interface, which is the only one
+// the hardware generated from the inventory has
+func hardwareDhcp(h *tinkhardware.Hardware) *tinkhardware.Hardware_DHCP {
+	interfaces := h.GetNetwork().GetInterfaces()
+	if len(interfaces) == 0 {
+		return nil
+	}
+	return interfaces[0].GetDhcp()
+}
+
+func hardwareHostname(h *tinkhardware.Hardware) string {
+	return hardwareDhcp(h).GetHostname()
+}
+
+func hardwareMacs(h *tinkhardware.Hardware) []string {
+	var macs []string
+	for _, i := range h.GetNetwork().GetInterfaces() {
+		if mac := i.GetDhcp().GetMac(); mac != "" {
+			macs = append(macs, normalizeMac(mac))
+		}
+	}
+	return macs
+}
+
+func isBusy(h *tinkhardware.Hardware, busyMacs map[string]struct{}) bool {
+	for _, mac := range hardwareMacs(h) {
+		if _, ok := busyMacs[mac]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// macsInActiveWorkflows returns the MAC addresses of the devices of the pending and running workflows.
+// Tinkerbell stores the devices of a workflow as a json map from device name to MAC address
+func macsInActiveWorkflows(workflows []*workflow.Workflow) (map[string]struct{}, error) {
+	macs := map[string]struct{}{}
+	for _, w := range workflows {
+		if w.GetState() != workflow.State_STATE_PENDING && w.GetState() != workflow.State_STATE_RUNNING {
+			continue
+		}
+		if w.GetHardware() == "" {
+			continue
+		}
+
+		devices := map[string]string{}
+		if err := json.Unmarshal([]byte(w.GetHardware()), &devices); err != nil {
+			return nil, fmt.Errorf("unmarshalling hardware of workflow %s: %v", w.GetId(), err)
+		}
+		for _, mac := range devices {
+			macs[normalizeMac(mac)] = struct{}{}
+		}
+	}
+	return macs, nil
+}
+
+func normalizeMac(mac string) string {
+	return strings.ToLower(mac)
+}
@@ -0,0 +1,296 @@
+package hardware_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
+	tinkhardware "github.com/tinkerbell/tink/protos/hardware"
+	"github.com/tinkerbell/tink/protos/workflow"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware/mocks"
+)
+
+func newMachine(id, hostname, mac string) hardware.Machine {
+	m := NewValidMachine()
+	m.Id = id
+	m.Hostname = hostname
+	m.MacAddress = mac
+	return m
+}
+
+func registeredHardware(m hardware.Machine) *tinkhardware.Hardware {
+	return &tinkhardware.Hardware{
+		Id: m.Id,
+		Network: &tinkhardware.Hardware_Network{
+			Interfaces: []*tinkhardware.Hardware_Network_Interface{
+				{
+					Dhcp: &tinkhardware.Hardware_DHCP{
+						Mac:         m.MacAddress,
+						Hostname:    m.Hostname,
+						NameServers: m.Nameservers,
+						Ip: &tinkhardware.Hardware_DHCP_IP{
+							Address: m.IpAddress,
+							Gateway: m.Gateway,
+							Netmask: m.Netmask,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func appliedBmc(machines ...hardware.Machine) map[string]hardware.Machine {
+	applied := make(map[string]hardware.Machine, len(machines))
+	for _, m := range machines {
+		applied[m.Id] = m
+	}
+	return applied
+}
+
+func activeWorkflow(state workflow.State, mac string) *workflow.Workflow {
+	return &workflow.Workflow{
+		Id:       "workflow-1",
+		State:    state,
+		Hardware: `{"device_1":"` + mac + `"}`,
+	}
+}
+
+func TestNewReconcilePlanNoChanges(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{m}, []*tinkhardware.Hardware{registeredHardware(m)}, nil, appliedBmc(m), true)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.IsEmpty()).To(gomega.BeTrue())
+	g.Expect(plan.Skipped).To(gomega.BeEmpty())
+}
+
+func TestNewReconcilePlanCreate(t *testing.T) {
+	g := gomega.NewWithT(t)
+	existing := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+	added := newMachine("hw-2", "node-2", "00:00:00:00:00:02")
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{existing, added}, []*tinkhardware.Hardware{registeredHardware(existing)}, nil, appliedBmc(existing), false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.Create).To(gomega.Equal([]hardware.Machine{added}))
+	g.Expect(plan.Update).To(gomega.BeEmpty())
+	g.Expect(plan.Delete).To(gomega.BeEmpty())
+}
+
+func TestNewReconcilePlanUpdateMatchedById(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+	registered := registeredHardware(m)
+	m.IpAddress = "10.10.10.20"
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{m}, []*tinkhardware.Hardware{registered}, nil, appliedBmc(m), false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.Update).To(gomega.Equal([]hardware.Machine{m}))
+	g.Expect(plan.Create).To(gomega.BeEmpty())
+}
+
+func TestNewReconcilePlanUpdateMatchedByMacKeepsRegisteredId(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+	registered := registeredHardware(m)
+	m.Id = "generated-id"
+	m.MacAddress = "00:00:00:00:00:01"
+	m.Nameservers = []string{"ns1", "ns2"}
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{m}, []*tinkhardware.Hardware{registered}, nil, nil, false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.Create).To(gomega.BeEmpty())
+	g.Expect(plan.Update).To(gomega.HaveLen(1))
+	g.Expect(plan.Update[0].Id).To(gomega.Equal("hw-1"))
+}
+
+func TestNewReconcilePlanMatchedByMacIgnoresCase(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newMachine("hw-1", "node-1", "AA:BB:CC:DD:EE:FF")
+	registered := registeredHardware(m)
+	applied := appliedBmc(m)
+	m.Id = "generated-id"
+	m.MacAddress = "aa:bb:cc:dd:ee:ff"
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{m}, []*tinkhardware.Hardware{registered}, nil, applied, false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.IsEmpty()).To(gomega.BeTrue())
+}
+
+func TestNewReconcilePlanDeleteRemoved(t *testing.T) {
+	g := gomega.NewWithT(t)
+	kept := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+	removed := registeredHardware(newMachine("hw-2", "node-2", "00:00:00:00:00:02"))
+	registered := []*tinkhardware.Hardware{registeredHardware(kept), removed}
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{kept}, registered, nil, appliedBmc(kept), false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.IsEmpty()).To(gomega.BeTrue())
+
+	plan, err = hardware.NewReconcilePlan([]hardware.Machine{kept}, registered, nil, appliedBmc(kept), true)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.Delete).To(gomega.Equal([]*tinkhardware.Hardware{removed}))
+}
+
+func TestNewReconcilePlanSkipsHardwareInActiveWorkflows(t *testing.T) {
+	g := gomega.NewWithT(t)
+	changed := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+	registeredChanged := registeredHardware(changed)
+	changed.Gateway = "10.10.10.254"
+	removed := registeredHardware(newMachine("hw-2", "node-2", "00:00:00:00:00:02"))
+	workflows := []*workflow.Workflow{
+		activeWorkflow(workflow.State_STATE_RUNNING, "00:00:00:00:00:01"),
+		activeWorkflow(workflow.State_STATE_PENDING, "00:00:00:00:00:02"),
+	}
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{changed}, []*tinkhardware.Hardware{registeredChanged, removed}, workflows, appliedBmc(changed), true)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.IsEmpty()).To(gomega.BeTrue())
+	g.Expect(plan.Skipped).To(gomega.ConsistOf("hw-1", "hw-2"))
+	g.Expect(plan.Machines).To(gomega.BeEmpty())
+}
+
+func TestNewReconcilePlanSkipsBmcUpdateInActiveWorkflows(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+	applied := appliedBmc(m)
+	m.BmcPassword = "new-password"
+	workflows := []*workflow.Workflow{activeWorkflow(workflow.State_STATE_RUNNING, "00:00:00:00:00:01")}
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{m}, []*tinkhardware.Hardware{registeredHardware(m)}, workflows, applied, false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.IsEmpty()).To(gomega.BeTrue())
+	g.Expect(plan.Skipped).To(gomega.ConsistOf("hw-1"))
+	g.Expect(plan.Machines).To(gomega.BeEmpty())
+}
+
+func TestNewReconcilePlanAppliedPlanHasNoChanges(t *testing.T) {
+	g := gomega.NewWithT(t)
+	registeredMachine := newMachine("tink-1", "node-1", "00:00:00:00:00:01")
+	matchedByMac := newMachine("csv-1", "node-1", "00:00:00:00:00:01")
+	matchedByMac.Gateway = "10.10.10.254"
+	matchedByMac.BmcPassword = "new-password"
+	added := newMachine("csv-2", "node-2", "00:00:00:00:00:02")
+	inventory := []hardware.Machine{matchedByMac, added}
+
+	plan, err := hardware.NewReconcilePlan(inventory, []*tinkhardware.Hardware{registeredHardware(registeredMachine)}, nil, appliedBmc(registeredMachine), false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.IsEmpty()).To(gomega.BeFalse())
+	g.Expect(plan.Machines).To(gomega.HaveLen(2))
+	g.Expect(plan.Machines[0].Id).To(gomega.Equal("tink-1"))
+
+	// Tinkerbell keeps the pushed hardware and the manifest written from the plan machines holds the applied BMC configs
+	var registered []*tinkhardware.Hardware
+	for _, m := range plan.Machines {
+		registered = append(registered, registeredHardware(m))
+	}
+
+	plan, err = hardware.NewReconcilePlan(inventory, registered, nil, appliedBmc(plan.Machines...), false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.IsEmpty()).To(gomega.BeTrue())
+	g.Expect(plan.Skipped).To(gomega.BeEmpty())
+}
+
+func TestNewReconcilePlanIgnoresFinishedWorkflows(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+	registered := registeredHardware(m)
+	m.Netmask = "255.255.255.0"
+	workflows := []*workflow.Workflow{activeWorkflow(workflow.State_STATE_SUCCESS, "00:00:00:00:00:01")}
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{m}, []*tinkhardware.Hardware{registered}, workflows, nil, false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.Update).To(gomega.HaveLen(1))
+	g.Expect(plan.Skipped).To(gomega.BeEmpty())
+}
+
+func TestNewReconcilePlanUpdateBmc(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+	applied := appliedBmc(m)
+	m.BmcPassword = "new-password"
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{m}, []*tinkhardware.Hardware{registeredHardware(m)}, nil, applied, false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.IsEmpty()).To(gomega.BeFalse())
+	g.Expect(plan.UpdateBmc).To(gomega.Equal([]hardware.Machine{m}))
+	g.Expect(plan.Update).To(gomega.BeEmpty())
+}
+
+func TestNewReconcilePlanUpdateBmcNotApplied(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+	withoutBmc := newMachine("hw-2", "node-2", "00:00:00:00:00:02")
+	withoutBmc.BmcIpAddress, withoutBmc.BmcUsername, withoutBmc.BmcPassword, withoutBmc.BmcVendor = "", "", "", ""
+	registered := []*tinkhardware.Hardware{registeredHardware(m), registeredHardware(withoutBmc)}
+
+	plan, err := hardware.NewReconcilePlan([]hardware.Machine{m, withoutBmc}, registered, nil, nil, false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan.UpdateBmc).To(gomega.Equal([]hardware.Machine{m}))
+}
+
+func TestNewReconcilePlanDuplicateMatch(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newMachine("hw-1", "node-1", "00:00:00:00:00:01")
+	duplicate := newMachine("generated-id", "node-1-copy", "00:00:00:00:00:01")
+
+	_, err := hardware.NewReconcilePlan([]hardware.Machine{m, duplicate}, []*tinkhardware.Hardware{registeredHardware(m)}, nil, nil, false)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("match the same registered hardware hw-1")))
+}
+
+func TestNewReconcilePlanInvalidWorkflowHardware(t *testing.T) {
+	g := gomega.NewWithT(t)
+	workflows := []*workflow.Workflow{{Id: "workflow-1", State: workflow.State_STATE_RUNNING, Hardware: "not json"}}
+
+	_, err := hardware.NewReconcilePlan(nil, nil, workflows, nil, false)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unmarshalling hardware of workflow workflow-1")))
+}
+
+func TestReconcileTinkerbellHardware(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockTinkerbellHardwareClient(ctrl)
+	ctx := context.Background()
+	plan := &hardware.ReconcilePlan{
+		Create: []hardware.Machine{newMachine("hw-1", "node-1", "00:00:00:00:00:01")},
+		Update: []hardware.Machine{newMachine("hw-2", "node-2", "00:00:00:00:00:02")},
+		Delete: []*tinkhardware.Hardware{{Id: "hw-3"}},
+	}
+
+	gomock.InOrder(
+		client.EXPECT().PushHardware(ctx, gomock.Any()),
+		client.EXPECT().PushHardware(ctx, gomock.Any()),
+		client.EXPECT().DeleteHardware(ctx, "hw-3"),
+	)
+
+	g.Expect(hardware.ReconcileTinkerbellHardware(ctx, client, plan)).To(gomega.Succeed())
+}
+
+func TestReconcileTinkerbellHardwarePushError(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockTinkerbellHardwareClient(ctrl)
+	ctx := context.Background()
+	plan := &hardware.ReconcilePlan{
+		Create: []hardware.Machine{newMachine("hw-1", "node-1", "00:00:00:00:00:01")},
+		Delete: []*tinkhardware.Hardware{{Id: "hw-3"}},
+	}
+
+	client.EXPECT().PushHardware(ctx, gomock.Any()).Return(errors.New("push failed"))
+
+	g.Expect(hardware.ReconcileTinkerbellHardware(ctx, client, plan)).To(gomega.MatchError("push failed"))
+}
+
+func TestMachineCollector(t *testing.T) {
+	g := gomega.NewWithT(t)
+	var collector hardware.MachineCollector
+	m := NewValidMachine()
+
+	g.Expect(collector.Write(m)).To(gomega.Succeed())
+	g.Expect([]hardware.Machine(collector)).To(gomega.Equal([]hardware.Machine{m}))
+}
@@ -159,6 +159,7 @@ func NewValidMachine() hardware.Machine {
 		MacAddress:   "00:00:00:00:00:00",
 		Netmask:      "255.255.255.255",
 		Hostname:     "localhost",
+		Labels:       map[string]string{"type": "cp"},
 		BmcIpAddress: "10.10.10.11",
 		BmcUsername:  "username",
 		BmcPassword:  "password",
@@ -1,6 +1,8 @@
 package hardware
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
 
@@ -8,6 +10,7 @@ import (
 	tinkv1alpha1 "github.com/tinkerbell/cluster-api-provider-tinkerbell/tink/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryyaml "k8s.io/apimachinery/pkg/util/yaml"
 	clusterctlv1alpha3 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/yaml"
 
@@ -94,9 +97,7 @@ func marshalTinkerbellHardwareYaml(m Machine) ([]byte, error) {
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      m.Hostname,
 				Namespace: constants.EksaSystemNamespace,
-				Labels: map[string]string{
-					clusterctlv1alpha3.ClusterctlMoveLabelName: "true",
-				},
+				Labels:    hardwareLabels(m),
 			},
 			Spec: tinkv1alpha1.HardwareSpec{
 				ID:     m.Id,
@@ -155,6 +156,17 @@ func marshalSecretYaml(m Machine) ([]byte, error) {
 	)
 }
 
+// hardwareLabels returns the labels of m along with the clusterctl move label. The move label always wins
+// so the hardware is moved along with the cluster.
+func hardwareLabels(m Machine) map[string]string {
+	labels := make(map[string]string, len(m.Labels)+1)
+	for key, value := range m.Labels {
+		labels[key] = value
+	}
+	labels[clusterctlv1alpha3.ClusterctlMoveLabelName] = "true"
+	return labels
+}
+
 func formatBmcRef(m Machine) string {
 	return fmt.Sprintf("bmc-%s", m.Hostname)
 }
@@ -162,3 +174,63 @@ func formatBmcRef(m Machine) string {
 func formatBmcSecretRef(m Machine) string {
 	return fmt.Sprintf("%s-auth", formatBmcRef(m))
 }
+
+// ReadBmcConfigs reads the BMC configuration of the machines in a manifest written by TinkerbellManifestYaml, keyed
+// by machine id. Only the Id, Hostname and Bmc fields of the machines are set.
+func ReadBmcConfigs(r io.Reader) (map[string]Machine, error) {
+	var hardwares []tinkv1alpha1.Hardware
+	bmcs := map[string]pbnjv1alpha1.BMC{}
+	secrets := map[string]corev1.Secret{}
+
+	reader := apimachineryyaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		raw, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading tinkerbell manifest yaml: %v", err)
+		}
+
+		var meta metav1.TypeMeta
+		if err := yaml.Unmarshal(raw, &meta); err != nil {
+			return nil, fmt.Errorf("unmarshalling tinkerbell manifest yaml: %v", err)
+		}
+
+		switch meta.Kind {
+		case hardwareKind:
+			var hardware tinkv1alpha1.Hardware
+			if err := yaml.Unmarshal(raw, &hardware); err != nil {
+				return nil, fmt.Errorf("unmarshalling tinkerbell hardware yaml: %v", err)
+			}
+			hardwares = append(hardwares, hardware)
+		case bmcKind:
+			var bmc pbnjv1alpha1.BMC
+			if err := yaml.Unmarshal(raw, &bmc); err != nil {
+				return nil, fmt.Errorf("unmarshalling tinkerbell bmc yaml: %v", err)
+			}
+			bmcs[bmc.Name] = bmc
+		case secretKind:
+			var secret corev1.Secret
+			if err := yaml.Unmarshal(raw, &secret); err != nil {
+				return nil, fmt.Errorf("unmarshalling bmc secret yaml: %v", err)
+			}
+			secrets[secret.Name] = secret
+		}
+	}
+
+	machines := make(map[string]Machine, len(hardwares))
+	for _, hardware := range hardwares {
+		m := Machine{Id: hardware.Spec.ID, Hostname: hardware.Name}
+		if bmc, ok := bmcs[hardware.Spec.BmcRef]; ok {
+			m.BmcIpAddress = bmc.Spec.Host
+			m.BmcVendor = bmc.Spec.Vendor
+			secret := secrets[bmc.Spec.AuthSecretRef.Name]
+			m.BmcUsername = string(secret.Data["username"])
+			m.BmcPassword = string(secret.Data["password"])
+		}
+		machines[m.Id] = m
+	}
+
+	return machines, nil
+}
@@ -63,9 +63,46 @@ func TestTinkerbellManifestYamlWriteErrors(t *testing.T) {
 	g.Expect(err).To(gomega.HaveOccurred())
 }
 
+func TestReadBmcConfigs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var buf bytes.Buffer
+	writer := hardware.NewTinkerbellManifestYaml(&buf)
+	machines := []hardware.Machine{NewValidMachine(), NewValidMachine()}
+	machines[1].Hostname = "node-2"
+	machines[1].BmcPassword = "other-password"
+	for _, m := range machines {
+		g.Expect(writer.Write(m)).To(gomega.Succeed())
+	}
+
+	bmcConfigs, err := hardware.ReadBmcConfigs(&buf)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(bmcConfigs).To(gomega.HaveLen(len(machines)))
+	for _, m := range machines {
+		g.Expect(bmcConfigs).To(gomega.HaveKeyWithValue(m.Id, hardware.Machine{
+			Id:           m.Id,
+			Hostname:     m.Hostname,
+			BmcIpAddress: m.BmcIpAddress,
+			BmcUsername:  m.BmcUsername,
+			BmcPassword:  m.BmcPassword,
+			BmcVendor:    m.BmcVendor,
+		}))
+	}
+}
+
+func TestReadBmcConfigsInvalidYaml(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	_, err := hardware.ReadBmcConfigs(bytes.NewBufferString("kind: [invalid"))
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
 func AssertTinkerbellHardwareRepresentsMachine(g *gomega.WithT, h tinkv1alpha1.Hardware, m hardware.Machine) {
 	g.Expect(h.ObjectMeta.Name).To(gomega.Equal(m.Hostname))
 	g.Expect(h.Spec.ID).To(gomega.Equal(m.Id))
+	for key, value := range m.Labels {
+		g.Expect(h.ObjectMeta.Labels).To(gomega.HaveKeyWithValue(key, value))
+	}
 }
 
 func AssertTinkerbellBMCRepresentsMachine(g *gomega.WithT, b pbnjv1alpha1.BMC, m hardware.Machine) {
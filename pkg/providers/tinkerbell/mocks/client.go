@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/providers/tinkerbell (interfaces: ProviderKubectlClient,ProviderTinkClient,ProviderPbnjClient,SSHAuthKeyGenerator)
+// Source: github.com/aws/eks-anywhere/pkg/providers/tinkerbell (interfaces: ProviderKubectlClient,ProviderTinkClient,ProviderBmcClient,SSHAuthKeyGenerator)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -155,31 +155,45 @@ func (mr *MockProviderTinkClientMockRecorder) GetWorkflow(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflow", reflect.TypeOf((*MockProviderTinkClient)(nil).GetWorkflow), arg0)
 }
 
-// MockProviderPbnjClient is a mock of ProviderPbnjClient interface.
-type MockProviderPbnjClient struct {
+// MockProviderBmcClient is a mock of ProviderBmcClient interface.
+type MockProviderBmcClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockProviderPbnjClientMockRecorder
+	recorder *MockProviderBmcClientMockRecorder
 }
 
-// MockProviderPbnjClientMockRecorder is the mock recorder for MockProviderPbnjClient.
-type MockProviderPbnjClientMockRecorder struct {
-	mock *MockProviderPbnjClient
+// MockProviderBmcClientMockRecorder is the mock recorder for MockProviderBmcClient.
+type MockProviderBmcClientMockRecorder struct {
+	mock *MockProviderBmcClient
 }
 
-// NewMockProviderPbnjClient creates a new mock instance.
-func NewMockProviderPbnjClient(ctrl *gomock.Controller) *MockProviderPbnjClient {
-	mock := &MockProviderPbnjClient{ctrl: ctrl}
-	mock.recorder = &MockProviderPbnjClientMockRecorder{mock}
+// NewMockProviderBmcClient creates a new mock instance.
+func NewMockProviderBmcClient(ctrl *gomock.Controller) *MockProviderBmcClient {
+	mock := &MockProviderBmcClient{ctrl: ctrl}
+	mock.recorder = &MockProviderBmcClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockProviderPbnjClient) EXPECT() *MockProviderPbnjClientMockRecorder {
+func (m *MockProviderBmcClient) EXPECT() *MockProviderBmcClientMockRecorder {
 	return m.recorder
 }
 
+// EjectVirtualMedia mocks base method.
+func (m *MockProviderBmcClient) EjectVirtualMedia(arg0 context.Context, arg1 pbnj.BmcSecretConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EjectVirtualMedia", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EjectVirtualMedia indicates an expected call of EjectVirtualMedia.
+func (mr *MockProviderBmcClientMockRecorder) EjectVirtualMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EjectVirtualMedia", reflect.TypeOf((*MockProviderBmcClient)(nil).EjectVirtualMedia), arg0, arg1)
+}
+
 // GetPowerState mocks base method.
-func (m *MockProviderPbnjClient) GetPowerState(arg0 context.Context, arg1 pbnj.BmcSecretConfig) (pbnj.PowerState, error) {
+func (m *MockProviderBmcClient) GetPowerState(arg0 context.Context, arg1 pbnj.BmcSecretConfig) (pbnj.PowerState, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPowerState", arg0, arg1)
 	ret0, _ := ret[0].(pbnj.PowerState)
@@ -188,9 +202,23 @@ func (m *MockProviderPbnjClient) GetPowerState(arg0 context.Context, arg1 pbnj.B
 }
 
 // GetPowerState indicates an expected call of GetPowerState.
-func (mr *MockProviderPbnjClientMockRecorder) GetPowerState(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockProviderBmcClientMockRecorder) GetPowerState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPowerState", reflect.TypeOf((*MockProviderBmcClient)(nil).GetPowerState), arg0, arg1)
+}
+
+// SetPxeBootOnce mocks base method.
+func (m *MockProviderBmcClient) SetPxeBootOnce(arg0 context.Context, arg1 pbnj.BmcSecretConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPxeBootOnce", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPxeBootOnce indicates an expected call of SetPxeBootOnce.
+func (mr *MockProviderBmcClientMockRecorder) SetPxeBootOnce(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPowerState", reflect.TypeOf((*MockProviderPbnjClient)(nil).GetPowerState), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPxeBootOnce", reflect.TypeOf((*MockProviderBmcClient)(nil).SetPxeBootOnce), arg0, arg1)
 }
 
 // MockSSHAuthKeyGenerator is a mock of SSHAuthKeyGenerator interface.
@@ -2,6 +2,7 @@ package pbnj
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -28,6 +29,9 @@ const (
 	machineAlreadyPoweredOffErrorMsg = "server is already off"
 )
 
+// ErrNotSupported is returned for the BMC operations PBnJ doesn't provide.
+var ErrNotSupported = errors.New("not supported by pbnj")
+
 type Pbnj struct {
 	pbnj *client.PbnjClient
 }
@@ -95,22 +99,51 @@ func (p *Pbnj) PowerOn(ctx context.Context, bmcInfo BmcSecretConfig) error {
 	return nil
 }
 
+// SetPxeBootOnce makes the machine boot from the network on its next boot only.
+func (p *Pbnj) SetPxeBootOnce(ctx context.Context, bmcInfo BmcSecretConfig) error {
+	_, err := p.pbnj.MachineBootDev(ctx, NewDeviceRequest(bmcInfo, v1.BootDevice_BOOT_DEVICE_PXE))
+	if err != nil {
+		return fmt.Errorf("failed to set pxe boot on machine: %s %v", bmcInfo.Host, err)
+	}
+
+	return nil
+}
+
+// EjectVirtualMedia always fails with ErrNotSupported, PBnJ can't manage virtual media.
+func (p *Pbnj) EjectVirtualMedia(_ context.Context, bmcInfo BmcSecretConfig) error {
+	return fmt.Errorf("failed to eject virtual media from machine: %s: %w", bmcInfo.Host, ErrNotSupported)
+}
+
+// NewDeviceRequest returns a request to boot the machine from bootDevice on its next boot only.
+func NewDeviceRequest(bmcInfo BmcSecretConfig, bootDevice v1.BootDevice) *v1.DeviceRequest {
+	return &v1.DeviceRequest{
+		Authn:      newAuthn(bmcInfo),
+		Vendor:     &v1.Vendor{Name: bmcInfo.Vendor},
+		BootDevice: bootDevice,
+		Persistent: false,
+	}
+}
+
 func NewPowerRequest(bmcInfo BmcSecretConfig, powerAction v1.PowerAction) *v1.PowerRequest {
 	return &v1.PowerRequest{
-		Authn: &v1.Authn{
-			Authn: &v1.Authn_DirectAuthn{
-				DirectAuthn: &v1.DirectAuthn{
-					Host: &v1.Host{
-						Host: bmcInfo.Host,
-					},
-					Username: bmcInfo.Username,
-					Password: bmcInfo.Password,
-				},
-			},
-		},
+		Authn: newAuthn(bmcInfo),
 		Vendor: &v1.Vendor{
 			Name: bmcInfo.Vendor,
 		},
 		PowerAction: powerAction,
 	}
 }
+
+func newAuthn(bmcInfo BmcSecretConfig) *v1.Authn {
+	return &v1.Authn{
+		Authn: &v1.Authn_DirectAuthn{
+			DirectAuthn: &v1.DirectAuthn{
+				Host: &v1.Host{
+					Host: bmcInfo.Host,
+				},
+				Username: bmcInfo.Username,
+				Password: bmcInfo.Password,
+			},
+		},
+	}
+}
@@ -0,0 +1,268 @@
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/pbnj"
+)
+
+const (
+	serviceRoot     = "/redfish/v1"
+	systemsPath     = serviceRoot + "/Systems"
+	managersPath    = serviceRoot + "/Managers"
+	resetAction     = "#ComputerSystem.Reset"
+	ejectAction     = "#VirtualMedia.EjectMedia"
+	defaultTimeout  = 30 * time.Second
+	redfishPowerOn  = "On"
+	redfishPowerOff = "Off"
+)
+
+// Redfish drives BMCs through the DMTF Redfish REST API over HTTPS. It manages the first computer system and
+// the first manager exposed by a BMC, which are the only ones on the servers Tinkerbell provisions.
+type Redfish struct {
+	client *http.Client
+}
+
+// NewRedfishClient returns a Redfish client. BMCs ship with self-signed certificates, so like PBnJ the client
+// doesn't verify the BMC certificate.
+func NewRedfishClient() *Redfish {
+	return NewRedfishClientWithHttpClient(&http.Client{
+		Timeout: defaultTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec G402
+		},
+	})
+}
+
+// NewRedfishClientWithHttpClient returns a Redfish client that sends its requests with client.
+func NewRedfishClientWithHttpClient(client *http.Client) *Redfish {
+	return &Redfish{client: client}
+}
+
+type odataRef struct {
+	Id string `json:"@odata.id"`
+}
+
+type collection struct {
+	Members []odataRef `json:"Members"`
+}
+
+type action struct {
+	Target string `json:"target"`
+}
+
+type computerSystem struct {
+	PowerState string            `json:"PowerState"`
+	Actions    map[string]action `json:"Actions"`
+}
+
+type manager struct {
+	VirtualMedia odataRef `json:"VirtualMedia"`
+}
+
+type virtualMedia struct {
+	Inserted bool              `json:"Inserted"`
+	Actions  map[string]action `json:"Actions"`
+}
+
+func (r *Redfish) GetPowerState(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) (pbnj.PowerState, error) {
+	_, system, err := r.getSystem(ctx, bmcInfo)
+	if err != nil {
+		return pbnj.PowerStateUnknown, err
+	}
+
+	switch system.PowerState {
+	case redfishPowerOn:
+		return pbnj.PowerStateOn, nil
+	case redfishPowerOff:
+		return pbnj.PowerStateOff, nil
+	}
+
+	return pbnj.PowerStateUnknown, nil
+}
+
+func (r *Redfish) PowerOff(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error {
+	logger.V(4).Info("Attempting to power off machine", "machine", bmcInfo.Host)
+	path, system, err := r.getSystem(ctx, bmcInfo)
+	if err != nil {
+		return fmt.Errorf("failed to power off machine: %s %v", bmcInfo.Host, err)
+	}
+
+	if system.PowerState == redfishPowerOff {
+		logger.Info("WARNING: Machine is already powered off", "machine", bmcInfo.Host)
+		return nil
+	}
+
+	if err := r.reset(ctx, bmcInfo, path, system, "ForceOff"); err != nil {
+		return fmt.Errorf("failed to power off machine: %s %v", bmcInfo.Host, err)
+	}
+
+	logger.V(4).Info("Successfully powered off machine", "machine", bmcInfo.Host)
+	return nil
+}
+
+func (r *Redfish) PowerOn(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error {
+	path, system, err := r.getSystem(ctx, bmcInfo)
+	if err != nil {
+		return fmt.Errorf("failed to power on machine: %s %v", bmcInfo.Host, err)
+	}
+
+	if system.PowerState == redfishPowerOn {
+		return nil
+	}
+
+	if err := r.reset(ctx, bmcInfo, path, system, "On"); err != nil {
+		return fmt.Errorf("failed to power on machine: %s %v", bmcInfo.Host, err)
+	}
+
+	return nil
+}
+
+// SetPxeBootOnce makes the machine boot from the network on its next boot only.
+func (r *Redfish) SetPxeBootOnce(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error {
+	path, _, err := r.getSystem(ctx, bmcInfo)
+	if err != nil {
+		return fmt.Errorf("failed to set pxe boot on machine: %s %v", bmcInfo.Host, err)
+	}
+
+	boot := map[string]interface{}{
+		"Boot": map[string]string{
+			"BootSourceOverrideTarget":  "Pxe",
+			"BootSourceOverrideEnabled": "Once",
+		},
+	}
+	if err := r.do(ctx, bmcInfo, http.MethodPatch, path, boot, nil); err != nil {
+		return fmt.Errorf("failed to set pxe boot on machine: %s %v", bmcInfo.Host, err)
+	}
+
+	return nil
+}
+
+// EjectVirtualMedia ejects all the virtual media inserted in the machine.
+func (r *Redfish) EjectVirtualMedia(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) error {
+	managerPath, err := r.firstMember(ctx, bmcInfo, managersPath)
+	if err != nil {
+		return fmt.Errorf("failed to eject virtual media from machine: %s %v", bmcInfo.Host, err)
+	}
+
+	var m manager
+	if err := r.do(ctx, bmcInfo, http.MethodGet, managerPath, nil, &m); err != nil {
+		return fmt.Errorf("failed to eject virtual media from machine: %s %v", bmcInfo.Host, err)
+	}
+	if m.VirtualMedia.Id == "" {
+		return nil
+	}
+
+	var media collection
+	if err := r.do(ctx, bmcInfo, http.MethodGet, m.VirtualMedia.Id, nil, &media); err != nil {
+		return fmt.Errorf("failed to eject virtual media from machine: %s %v", bmcInfo.Host, err)
+	}
+
+	for _, member := range media.Members {
+		var vm virtualMedia
+		if err := r.do(ctx, bmcInfo, http.MethodGet, member.Id, nil, &vm); err != nil {
+			return fmt.Errorf("failed to eject virtual media from machine: %s %v", bmcInfo.Host, err)
+		}
+		if !vm.Inserted {
+			continue
+		}
+
+		target := member.Id + "/Actions/VirtualMedia.EjectMedia"
+		if a, ok := vm.Actions[ejectAction]; ok && a.Target != "" {
+			target = a.Target
+		}
+		if err := r.do(ctx, bmcInfo, http.MethodPost, target, map[string]string{}, nil); err != nil {
+			return fmt.Errorf("failed to eject virtual media %s from machine: %s %v", member.Id, bmcInfo.Host, err)
+		}
+		logger.V(4).Info("Ejected virtual media", "machine", bmcInfo.Host, "media", member.Id)
+	}
+
+	return nil
+}
+
+func (r *Redfish) getSystem(ctx context.Context, bmcInfo pbnj.BmcSecretConfig) (string, *computerSystem, error) {
+	path, err := r.firstMember(ctx, bmcInfo, systemsPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	system := &computerSystem{}
+	if err := r.do(ctx, bmcInfo, http.MethodGet, path, nil, system); err != nil {
+		return "", nil, err
+	}
+
+	return path, system, nil
+}
+
+func (r *Redfish) reset(ctx context.Context, bmcInfo pbnj.BmcSecretConfig, path string, system *computerSystem, resetType string) error {
+	target := path + "/Actions/ComputerSystem.Reset"
+	if a, ok := system.Actions[resetAction]; ok && a.Target != "" {
+		target = a.Target
+	}
+
+	return r.do(ctx, bmcInfo, http.MethodPost, target, map[string]string{"ResetType": resetType}, nil)
+}
+
+func (r *Redfish) firstMember(ctx context.Context, bmcInfo pbnj.BmcSecretConfig, path string) (string, error) {
+	var c collection
+	if err := r.do(ctx, bmcInfo, http.MethodGet, path, nil, &c); err != nil {
+		return "", err
+	}
+
+	if len(c.Members) == 0 || c.Members[0].Id == "" {
+		return "", fmt.Errorf("no members in %s", path)
+	}
+
+	return c.Members[0].Id, nil
+}
+
+// do sends a request with body marshalled as json to path on the BMC and unmarshalls the response into out,
+// if not nil.
+func (r *Redfish) do(ctx context.Context, bmcInfo pbnj.BmcSecretConfig, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshalling redfish request: %v", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, "https://"+bmcInfo.Host+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(bmcInfo.Username, bmcInfo.Password)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("redfish %s %s: unexpected status %s", method, path, resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("unmarshalling redfish response from %s: %v", path, err)
+	}
+
+	return nil
+}
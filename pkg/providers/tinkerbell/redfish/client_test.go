@@ -0,0 +1,213 @@
+package redfish_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/pbnj"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/redfish"
+)
+
+const (
+	username = "admin"
+	password = "password"
+)
+
+// fakeBmc is a minimal stand-in for the Redfish API of a BMC with a single system, manager and virtual media.
+type fakeBmc struct {
+	sync.Mutex
+	powerState    string
+	mediaInserted bool
+	boot          map[string]string
+	requests      []string
+}
+
+func (b *fakeBmc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.Lock()
+	defer b.Unlock()
+
+	if u, p, ok := r.BasicAuth(); !ok || u != username || p != password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	b.requests = append(b.requests, r.Method+" "+r.URL.Path)
+
+	switch r.Method + " " + r.URL.Path {
+	case "GET /redfish/v1/Systems":
+		writeJson(w, map[string]interface{}{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1"}}})
+	case "GET /redfish/v1/Systems/1":
+		writeJson(w, map[string]interface{}{
+			"PowerState": b.powerState,
+			"Actions": map[string]interface{}{
+				"#ComputerSystem.Reset": map[string]string{"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"},
+			},
+		})
+	case "PATCH /redfish/v1/Systems/1":
+		body := map[string]map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		b.boot = body["Boot"]
+		w.WriteHeader(http.StatusNoContent)
+	case "POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset":
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch body["ResetType"] {
+		case "On":
+			b.powerState = "On"
+		case "ForceOff":
+			b.powerState = "Off"
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "GET /redfish/v1/Managers":
+		writeJson(w, map[string]interface{}{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Managers/1"}}})
+	case "GET /redfish/v1/Managers/1":
+		writeJson(w, map[string]interface{}{"VirtualMedia": map[string]string{"@odata.id": "/redfish/v1/Managers/1/VirtualMedia"}})
+	case "GET /redfish/v1/Managers/1/VirtualMedia":
+		writeJson(w, map[string]interface{}{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Managers/1/VirtualMedia/CD"}}})
+	case "GET /redfish/v1/Managers/1/VirtualMedia/CD":
+		writeJson(w, map[string]interface{}{"Inserted": b.mediaInserted})
+	case "POST /redfish/v1/Managers/1/VirtualMedia/CD/Actions/VirtualMedia.EjectMedia":
+		b.mediaInserted = false
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (b *fakeBmc) posts() []string {
+	b.Lock()
+	defer b.Unlock()
+	var posts []string
+	for _, r := range b.requests {
+		if !strings.HasPrefix(r, http.MethodGet) {
+			posts = append(posts, r)
+		}
+	}
+	return posts
+}
+
+func writeJson(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type redfishTest struct {
+	*WithT
+	ctx     context.Context
+	bmc     *fakeBmc
+	bmcInfo pbnj.BmcSecretConfig
+	client  *redfish.Redfish
+}
+
+func newRedfishTest(t *testing.T, powerState string) *redfishTest {
+	bmc := &fakeBmc{powerState: powerState}
+	server := httptest.NewTLSServer(bmc)
+	t.Cleanup(server.Close)
+
+	return &redfishTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		bmc:   bmc,
+		bmcInfo: pbnj.BmcSecretConfig{
+			Host:     strings.TrimPrefix(server.URL, "https://"),
+			Username: username,
+			Password: password,
+			Vendor:   "redfish",
+		},
+		client: redfish.NewRedfishClientWithHttpClient(server.Client()),
+	}
+}
+
+func TestRedfishGetPowerState(t *testing.T) {
+	for state, want := range map[string]pbnj.PowerState{
+		"On":         pbnj.PowerStateOn,
+		"Off":        pbnj.PowerStateOff,
+		"PoweringOn": pbnj.PowerStateUnknown,
+	} {
+		t.Run(state, func(t *testing.T) {
+			tt := newRedfishTest(t, state)
+
+			got, err := tt.client.GetPowerState(tt.ctx, tt.bmcInfo)
+			tt.Expect(err).ToNot(HaveOccurred())
+			tt.Expect(got).To(Equal(want))
+		})
+	}
+}
+
+func TestRedfishGetPowerStateUnauthorized(t *testing.T) {
+	tt := newRedfishTest(t, "On")
+	tt.bmcInfo.Password = "wrong"
+
+	state, err := tt.client.GetPowerState(tt.ctx, tt.bmcInfo)
+	tt.Expect(err).To(MatchError(ContainSubstring("unexpected status 401 Unauthorized")))
+	tt.Expect(state).To(Equal(pbnj.PowerStateUnknown))
+}
+
+func TestRedfishPowerOn(t *testing.T) {
+	tt := newRedfishTest(t, "Off")
+
+	tt.Expect(tt.client.PowerOn(tt.ctx, tt.bmcInfo)).To(Succeed())
+	tt.Expect(tt.bmc.powerState).To(Equal("On"))
+}
+
+func TestRedfishPowerOnAlreadyOn(t *testing.T) {
+	tt := newRedfishTest(t, "On")
+
+	tt.Expect(tt.client.PowerOn(tt.ctx, tt.bmcInfo)).To(Succeed())
+	tt.Expect(tt.bmc.posts()).To(BeEmpty())
+}
+
+func TestRedfishPowerOff(t *testing.T) {
+	tt := newRedfishTest(t, "On")
+
+	tt.Expect(tt.client.PowerOff(tt.ctx, tt.bmcInfo)).To(Succeed())
+	tt.Expect(tt.bmc.powerState).To(Equal("Off"))
+}
+
+func TestRedfishPowerOffAlreadyOff(t *testing.T) {
+	tt := newRedfishTest(t, "Off")
+
+	tt.Expect(tt.client.PowerOff(tt.ctx, tt.bmcInfo)).To(Succeed())
+	tt.Expect(tt.bmc.posts()).To(BeEmpty())
+}
+
+func TestRedfishSetPxeBootOnce(t *testing.T) {
+	tt := newRedfishTest(t, "Off")
+
+	tt.Expect(tt.client.SetPxeBootOnce(tt.ctx, tt.bmcInfo)).To(Succeed())
+	tt.Expect(tt.bmc.boot).To(Equal(map[string]string{
+		"BootSourceOverrideTarget":  "Pxe",
+		"BootSourceOverrideEnabled": "Once",
+	}))
+}
+
+func TestRedfishEjectVirtualMedia(t *testing.T) {
+	tt := newRedfishTest(t, "On")
+	tt.bmc.mediaInserted = true
+
+	tt.Expect(tt.client.EjectVirtualMedia(tt.ctx, tt.bmcInfo)).To(Succeed())
+	tt.Expect(tt.bmc.mediaInserted).To(BeFalse())
+}
+
+func TestRedfishEjectVirtualMediaNothingInserted(t *testing.T) {
+	tt := newRedfishTest(t, "On")
+
+	tt.Expect(tt.client.EjectVirtualMedia(tt.ctx, tt.bmcInfo)).To(Succeed())
+	tt.Expect(tt.bmc.posts()).To(BeEmpty())
+}
+
+func TestRedfishUnreachableBmc(t *testing.T) {
+	tt := newRedfishTest(t, "On")
+	tt.bmcInfo.Host = "127.0.0.1:1"
+
+	tt.Expect(tt.client.PowerOn(tt.ctx, tt.bmcInfo)).To(MatchError(ContainSubstring("failed to power on machine: 127.0.0.1:1")))
+}
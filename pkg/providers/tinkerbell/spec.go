@@ -5,13 +5,13 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 )
 
-type spec struct {
+type Spec struct {
 	*cluster.Spec
 	datacenterConfig     *anywherev1.TinkerbellDatacenterConfig
 	machineConfigsLookup map[string]*anywherev1.TinkerbellMachineConfig
 }
 
-func newSpec(clusterSpec *cluster.Spec, machineConfigs map[string]*anywherev1.TinkerbellMachineConfig, datacenterConfig *anywherev1.TinkerbellDatacenterConfig) *spec {
+func NewSpec(clusterSpec *cluster.Spec, machineConfigs map[string]*anywherev1.TinkerbellMachineConfig, datacenterConfig *anywherev1.TinkerbellDatacenterConfig) *Spec {
 	machineConfigsInCluster := map[string]*anywherev1.TinkerbellMachineConfig{}
 	for _, m := range clusterSpec.Cluster.MachineConfigRefs() {
 		machineConfig, ok := machineConfigs[m.Name]
@@ -21,17 +21,17 @@ func newSpec(clusterSpec *cluster.Spec, machineConfigs map[string]*anywherev1.Ti
 		machineConfigsInCluster[m.Name] = machineConfig
 	}
 
-	return &spec{
+	return &Spec{
 		Spec:                 clusterSpec,
 		datacenterConfig:     datacenterConfig,
 		machineConfigsLookup: machineConfigsInCluster,
 	}
 }
 
-func (s *spec) controlPlaneMachineConfig() *anywherev1.TinkerbellMachineConfig {
+func (s *Spec) controlPlaneMachineConfig() *anywherev1.TinkerbellMachineConfig {
 	return s.machineConfigsLookup[s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
 }
 
-func (s *spec) firstWorkerMachineConfig() *anywherev1.TinkerbellMachineConfig {
+func (s *Spec) firstWorkerMachineConfig() *anywherev1.TinkerbellMachineConfig {
 	return s.machineConfigsLookup[s.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name]
 }
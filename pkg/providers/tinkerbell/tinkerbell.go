@@ -60,6 +60,7 @@ type tinkerbellProvider struct {
 	machineConfigs        map[string]*v1alpha1.TinkerbellMachineConfig
 	providerKubectlClient ProviderKubectlClient
 	providerTinkClient    ProviderTinkClient
+	bmcClient             ProviderBmcClient
 	templateBuilder       *TinkerbellTemplateBuilder
 	skipIpCheck           bool
 	hardwareConfigFile    string
@@ -72,7 +73,7 @@ type tinkerbellProvider struct {
 
 type TinkerbellClients struct {
 	ProviderTinkClient ProviderTinkClient
-	ProviderPbnjClient ProviderPbnjClient
+	ProviderBmcClient  ProviderBmcClient
 }
 
 // TODO: Add necessary kubectl functions here
@@ -88,8 +89,12 @@ type ProviderTinkClient interface {
 	GetWorkflow(ctx context.Context) ([]*tinkworkflow.Workflow, error)
 }
 
-type ProviderPbnjClient interface {
+// ProviderBmcClient checks the power state of machines and prepares them to be provisioned through their BMC,
+// either with PBnJ or Redfish.
+type ProviderBmcClient interface {
 	GetPowerState(ctx context.Context, bmc pbnj.BmcSecretConfig) (pbnj.PowerState, error)
+	SetPxeBootOnce(ctx context.Context, bmc pbnj.BmcSecretConfig) error
+	EjectVirtualMedia(ctx context.Context, bmc pbnj.BmcSecretConfig) error
 }
 
 // KeyGenerator generates ssh keys and writes them to a FileWriter.
@@ -116,7 +121,7 @@ func NewProvider(
 		writer,
 		providerKubectlClient,
 		providerTinkbellClient.ProviderTinkClient,
-		providerTinkbellClient.ProviderPbnjClient,
+		providerTinkbellClient.ProviderBmcClient,
 		&networkutils.DefaultNetClient{},
 		now,
 		skipIpCheck,
@@ -132,7 +137,7 @@ func NewProviderCustomDep(
 	writer filewriter.FileWriter,
 	providerKubectlClient ProviderKubectlClient,
 	providerTinkClient ProviderTinkClient,
-	pbnjClient ProviderPbnjClient,
+	bmcClient ProviderBmcClient,
 	netClient networkutils.NetClient,
 	now types.NowFunc,
 	skipIpCheck bool,
@@ -161,6 +166,7 @@ func NewProviderCustomDep(
 		machineConfigs:        machineConfigs,
 		providerKubectlClient: providerKubectlClient,
 		providerTinkClient:    providerTinkClient,
+		bmcClient:             bmcClient,
 		templateBuilder: &TinkerbellTemplateBuilder{
 			datacenterSpec:              &datacenterConfig.Spec,
 			controlPlaneMachineSpec:     controlPlaneMachineSpec,
@@ -169,7 +175,7 @@ func NewProviderCustomDep(
 			now:                         now,
 		},
 		hardwareConfigFile: hardwareConfigFile,
-		validator:          NewValidator(providerTinkClient, netClient, hardware.HardwareConfig{}, pbnjClient),
+		validator:          NewValidator(providerTinkClient, netClient, hardware.HardwareConfig{}, bmcClient),
 		skipIpCheck:        skipIpCheck,
 		skipPowerActions:   skipPowerActions,
 		writer:             writer,
@@ -204,6 +210,29 @@ func (p *tinkerbellProvider) PostBootstrapSetup(ctx context.Context, clusterConf
 	if err != nil {
 		return fmt.Errorf("error applying hardware yaml: %v", err)
 	}
+
+	if p.skipPowerActions {
+		return nil
+	}
+	return p.prepareHardwareForProvisioning(ctx)
+}
+
+// prepareHardwareForProvisioning ejects the virtual media of the hardware and makes it boot from the network once,
+// so the machines boot into the Tinkerbell provisioning environment when they are powered on. It only changes the
+// hardware loaded by ValidateHardwareConfig when validating a create, which isn't bound to any workflow.
+func (p *tinkerbellProvider) prepareHardwareForProvisioning(ctx context.Context) error {
+	for _, bmcInfo := range bmcSecretConfigs(p.validator.hardwareConfig) {
+		if err := p.bmcClient.EjectVirtualMedia(ctx, bmcInfo); err != nil {
+			if !errors.Is(err, pbnj.ErrNotSupported) {
+				return err
+			}
+			logger.V(4).Info("Skipping virtual media eject", "machine", bmcInfo.Host, "reason", err)
+		}
+
+		if err := p.bmcClient.SetPxeBootOnce(ctx, bmcInfo); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -297,7 +326,7 @@ func (p *tinkerbellProvider) SetupAndValidateCreateCluster(ctx context.Context,
 		return fmt.Errorf("failed setup and validations: %v", err)
 	}
 
-	tinkerbellClusterSpec := newSpec(clusterSpec, p.machineConfigs, p.datacenterConfig)
+	tinkerbellClusterSpec := NewSpec(clusterSpec, p.machineConfigs, p.datacenterConfig)
 
 	if err := p.configureSshKeys(); err != nil {
 		return err
@@ -442,8 +471,14 @@ func (vs *TinkerbellTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluste
 		} else {
 			values["workloadTemplateName"] = vs.WorkerMachineTemplateName(clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name)
 		}
+		if kubeadmconfigTemplateName, ok := kubeadmconfigTemplateNames[workerNodeGroupConfiguration.Name]; ok {
+			values["workloadkubeadmconfigTemplateName"] = kubeadmconfigTemplateName
+		} else {
+			values["workloadkubeadmconfigTemplateName"] = vs.KubeadmConfigTemplateName(clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name)
+		}
 		values["workerSshAuthorizedKey"] = vs.workerNodeGroupMachineSpecs[workerNodeGroupConfiguration.MachineGroupRef.Name].Users[0].SshAuthorizedKeys[0]
 		values["workerReplicas"] = workerNodeGroupConfiguration.Count
+		values["autoscalingConfig"] = workerNodeGroupConfiguration.AutoScalingConfiguration
 
 		bytes, err := templater.Execute(defaultClusterConfigMD, values)
 		if err != nil {
@@ -2,13 +2,18 @@ package tinkerbell
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	pbnjv1alpha1 "github.com/tinkerbell/cluster-api-provider-tinkerbell/pbnj/api/v1alpha1"
 	tinkhardware "github.com/tinkerbell/tink/protos/hardware"
 	tinkworkflow "github.com/tinkerbell/tink/protos/workflow"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -16,6 +21,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	filewritermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/pbnj"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -145,9 +151,9 @@ func TestTinkerbellProviderGenerateDeploymentFileWithExternalEtcd(t *testing.T)
 	mockCtrl := gomock.NewController(t)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	tink := mocks.NewMockProviderTinkClient(mockCtrl)
-	pbnjClient := mocks.NewMockProviderPbnjClient(mockCtrl)
+	bmcClient := mocks.NewMockProviderBmcClient(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	tinkerbellClients := TinkerbellClients{tink, pbnjClient}
+	tinkerbellClients := TinkerbellClients{tink, bmcClient}
 	cluster := &types.Cluster{Name: "test"}
 	hardwares := setupHardware()
 
@@ -159,7 +165,7 @@ func TestTinkerbellProviderGenerateDeploymentFileWithExternalEtcd(t *testing.T)
 	tink.EXPECT().GetHardware(ctx).Return(hardwares, nil)
 	tink.EXPECT().GetWorkflow(ctx).Return([]*tinkworkflow.Workflow{}, nil)
 
-	pbnjClient.EXPECT().GetPowerState(ctx, gomock.Any()).Return(pbnj.PowerStateOff, nil).Times(4)
+	bmcClient.EXPECT().GetPowerState(ctx, gomock.Any()).Return(pbnj.PowerStateOff, nil).Times(4)
 
 	provider := newProviderWithKubectlWithTink(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, kubectl, tinkerbellClients)
 	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
@@ -181,10 +187,10 @@ func TestTinkerbellProviderMachineConfigsMissingUserSshKeys(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	tink := mocks.NewMockProviderTinkClient(mockCtrl)
-	pbnjClient := mocks.NewMockProviderPbnjClient(mockCtrl)
+	bmcClient := mocks.NewMockProviderBmcClient(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
 	keyGenerator := mocks.NewMockSSHAuthKeyGenerator(mockCtrl)
-	tinkerbellClients := TinkerbellClients{tink, pbnjClient}
+	tinkerbellClients := TinkerbellClients{tink, bmcClient}
 	cluster := &types.Cluster{Name: "test"}
 	hardwares := setupHardware()
 
@@ -196,7 +202,7 @@ func TestTinkerbellProviderMachineConfigsMissingUserSshKeys(t *testing.T) {
 	tink.EXPECT().GetHardware(ctx).Return(hardwares, nil)
 	tink.EXPECT().GetWorkflow(ctx).Return([]*tinkworkflow.Workflow{}, nil)
 
-	pbnjClient.EXPECT().GetPowerState(ctx, gomock.Any()).Return(pbnj.PowerStateOff, nil).Times(4)
+	bmcClient.EXPECT().GetPowerState(ctx, gomock.Any()).Return(pbnj.PowerStateOff, nil).Times(4)
 
 	const sshKey = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAACAQC1BK73XhIzjX+meUr7pIYh6RHbvI3tmHeQIXY5lv7aztN1UoX+bhPo3dwo2sfSQn5kuxgQdnxIZ/CTzy0p0GkEYVv3gwspCeurjmu0XmrdmaSGcGxCEWT/65NtvYrQtUE5ELxJ+N/aeZNlK2B7IWANnw/82913asXH4VksV1NYNduP0o1/G4XcwLLSyVFB078q/oEnmvdNIoS61j4/o36HVtENJgYr0idcBvwJdvcGxGnPaqOhx477t+kfJAa5n5dSA5wilIaoXH5i1Tf/HsTCM52L+iNCARvQzJYZhzbWI1MDQwzILtIBEQCJsl2XSqIupleY8CxqQ6jCXt2mhae+wPc3YmbO5rFvr2/EvC57kh3yDs1Nsuj8KOvD78KeeujbR8n8pScm3WDp62HFQ8lEKNdeRNj6kB8WnuaJvPnyZfvzOhwG65/9w13IBl7B1sWxbFnq2rMpm5uHVK7mAmjL0Tt8zoDhcE1YJEnp9xte3/pvmKPkST5Q/9ZtR9P5sI+02jY0fvPkPyC03j2gsPixG7rpOCwpOdbny4dcj0TDeeXJX8er+oVfJuLYz0pNWJcT2raDdFfcqvYA0B0IyNYlj5nWX4RuEcyT3qocLReWPnZojetvAG/H8XwOh7fEVGqHAKOVSnPXCSQJPl6s0H12jPJBDJMTydtYPEszl4/CeQ=="
 	keyGenerator.EXPECT().GenerateSSHAuthKey(gomock.Any()).Return(sshKey, nil)
@@ -224,9 +230,9 @@ func TestTinkerbellProviderGenerateDeploymentFileWithStackedEtcd(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	tink := mocks.NewMockProviderTinkClient(mockCtrl)
-	pbnjClient := mocks.NewMockProviderPbnjClient(mockCtrl)
+	bmcClient := mocks.NewMockProviderBmcClient(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	tinkerbellClients := TinkerbellClients{tink, pbnjClient}
+	tinkerbellClients := TinkerbellClients{tink, bmcClient}
 	cluster := &types.Cluster{Name: "test"}
 	hardwares := setupHardware()
 
@@ -237,7 +243,7 @@ func TestTinkerbellProviderGenerateDeploymentFileWithStackedEtcd(t *testing.T) {
 
 	tink.EXPECT().GetHardware(ctx).Return(hardwares, nil)
 	tink.EXPECT().GetWorkflow(ctx).Return([]*tinkworkflow.Workflow{}, nil)
-	pbnjClient.EXPECT().GetPowerState(ctx, gomock.Any()).Return(pbnj.PowerStateOff, nil).Times(4)
+	bmcClient.EXPECT().GetPowerState(ctx, gomock.Any()).Return(pbnj.PowerStateOff, nil).Times(4)
 
 	provider := newProviderWithKubectlWithTink(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, kubectl, tinkerbellClients)
 	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
@@ -259,9 +265,9 @@ func TestTinkerbellProviderGenerateDeploymentFileMultipleWorkerNodeGroups(t *tes
 	mockCtrl := gomock.NewController(t)
 	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
 	tink := mocks.NewMockProviderTinkClient(mockCtrl)
-	pbnjClient := mocks.NewMockProviderPbnjClient(mockCtrl)
+	bmcClient := mocks.NewMockProviderBmcClient(mockCtrl)
 	writer := filewritermocks.NewMockFileWriter(mockCtrl)
-	tinkerbellClients := TinkerbellClients{tink, pbnjClient}
+	tinkerbellClients := TinkerbellClients{tink, bmcClient}
 	cluster := &types.Cluster{Name: "test"}
 	hardwares := setupHardware()
 
@@ -273,7 +279,7 @@ func TestTinkerbellProviderGenerateDeploymentFileMultipleWorkerNodeGroups(t *tes
 	tink.EXPECT().GetHardware(ctx).Return(hardwares, nil)
 	tink.EXPECT().GetWorkflow(ctx).Return([]*tinkworkflow.Workflow{}, nil)
 
-	pbnjClient.EXPECT().GetPowerState(ctx, gomock.Any()).Return(pbnj.PowerStateOff, nil).Times(4)
+	bmcClient.EXPECT().GetPowerState(ctx, gomock.Any()).Return(pbnj.PowerStateOff, nil).Times(4)
 	provider := newProviderWithKubectlWithTink(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, kubectl, tinkerbellClients)
 	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
 		t.Fatalf("failed to setup and validate: %v", err)
@@ -287,3 +293,91 @@ func TestTinkerbellProviderGenerateDeploymentFileMultipleWorkerNodeGroups(t *tes
 	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_external_etcd.yaml")
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_tinkerbell_md_multiple_node_groups.yaml")
 }
+
+func newHardwareConfigWithBmcs(vendors ...string) hardware.HardwareConfig {
+	var hc hardware.HardwareConfig
+	for i, vendor := range vendors {
+		name := fmt.Sprintf("bmc-worker%d", i)
+		hc.Bmcs = append(hc.Bmcs, pbnjv1alpha1.BMC{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: pbnjv1alpha1.BMCSpec{
+				Host:          fmt.Sprintf("192.168.0.%d", 10+i),
+				Vendor:        vendor,
+				AuthSecretRef: corev1.SecretReference{Name: name + "-auth"},
+			},
+		})
+		hc.Secrets = append(hc.Secrets, corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-auth"},
+			Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("password")},
+		})
+	}
+	return hc
+}
+
+func TestTinkerbellProviderPostBootstrapSetupPreparesHardware(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	bmcClient := mocks.NewMockProviderBmcClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	clusterConfig := &v1alpha1.Cluster{}
+	ctx := context.Background()
+
+	provider := newProvider(givenDatacenterConfig(t, clusterSpecManifest), nil, clusterConfig, nil, kubectl, TinkerbellClients{nil, bmcClient})
+	provider.validator.hardwareConfig = newHardwareConfigWithBmcs("supermicro", "redfish")
+	pbnjMachine := pbnj.BmcSecretConfig{Host: "192.168.0.10", Username: "admin", Password: "password", Vendor: "supermicro"}
+	redfishMachine := pbnj.BmcSecretConfig{Host: "192.168.0.11", Username: "admin", Password: "password", Vendor: "redfish"}
+
+	kubectl.EXPECT().ApplyHardware(ctx, "testdata/hardware_config.yaml", cluster.KubeconfigFile)
+	gomock.InOrder(
+		bmcClient.EXPECT().EjectVirtualMedia(ctx, pbnjMachine).Return(fmt.Errorf("ejecting: %w", pbnj.ErrNotSupported)),
+		bmcClient.EXPECT().SetPxeBootOnce(ctx, pbnjMachine),
+		bmcClient.EXPECT().EjectVirtualMedia(ctx, redfishMachine),
+		bmcClient.EXPECT().SetPxeBootOnce(ctx, redfishMachine),
+	)
+
+	if err := provider.PostBootstrapSetup(ctx, clusterConfig, cluster); err != nil {
+		t.Fatalf("failed post bootstrap setup: %v", err)
+	}
+}
+
+func TestTinkerbellProviderPostBootstrapSetupErrorSettingPxeBoot(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	bmcClient := mocks.NewMockProviderBmcClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	clusterConfig := &v1alpha1.Cluster{}
+	ctx := context.Background()
+
+	provider := newProvider(givenDatacenterConfig(t, clusterSpecManifest), nil, clusterConfig, nil, kubectl, TinkerbellClients{nil, bmcClient})
+	provider.validator.hardwareConfig = newHardwareConfigWithBmcs("redfish")
+
+	kubectl.EXPECT().ApplyHardware(ctx, "testdata/hardware_config.yaml", cluster.KubeconfigFile)
+	bmcClient.EXPECT().EjectVirtualMedia(ctx, gomock.Any())
+	bmcClient.EXPECT().SetPxeBootOnce(ctx, gomock.Any()).Return(errors.New("boot override failed"))
+
+	if err := provider.PostBootstrapSetup(ctx, clusterConfig, cluster); err == nil || err.Error() != "boot override failed" {
+		t.Fatalf("PostBootstrapSetup() error = %v, want boot override failed", err)
+	}
+}
+
+func TestTinkerbellProviderPostBootstrapSetupSkipPowerActions(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	bmcClient := mocks.NewMockProviderBmcClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	clusterConfig := &v1alpha1.Cluster{}
+	ctx := context.Background()
+
+	provider := newProvider(givenDatacenterConfig(t, clusterSpecManifest), nil, clusterConfig, nil, kubectl, TinkerbellClients{nil, bmcClient})
+	provider.validator.hardwareConfig = newHardwareConfigWithBmcs("redfish")
+	provider.skipPowerActions = true
+
+	kubectl.EXPECT().ApplyHardware(ctx, "testdata/hardware_config.yaml", cluster.KubeconfigFile)
+
+	if err := provider.PostBootstrapSetup(ctx, clusterConfig, cluster); err != nil {
+		t.Fatalf("failed post bootstrap setup: %v", err)
+	}
+}
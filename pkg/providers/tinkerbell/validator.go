@@ -22,15 +22,15 @@ type Validator struct {
 	tink           ProviderTinkClient
 	netClient      networkutils.NetClient
 	hardwareConfig hardware.HardwareConfig
-	pbnj           ProviderPbnjClient
+	bmc            ProviderBmcClient
 }
 
-func NewValidator(tink ProviderTinkClient, netClient networkutils.NetClient, hardwareConfig hardware.HardwareConfig, pbnjClient ProviderPbnjClient) *Validator {
+func NewValidator(tink ProviderTinkClient, netClient networkutils.NetClient, hardwareConfig hardware.HardwareConfig, bmcClient ProviderBmcClient) *Validator {
 	return &Validator{
 		tink:           tink,
 		netClient:      netClient,
 		hardwareConfig: hardwareConfig,
-		pbnj:           pbnjClient,
+		bmc:            bmcClient,
 	}
 }
 
@@ -56,7 +56,7 @@ func (v *Validator) ValidateTinkerbellConfig(ctx context.Context, datacenterConf
 }
 
 // TODO: dry out machine configs validations
-func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, tinkerbellClusterSpec *spec) error {
+func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, tinkerbellClusterSpec *Spec) error {
 	// TODO: move this to api Cluster validations
 	if len(tinkerbellClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host) <= 0 {
 		return errors.New("cluster controlPlaneConfiguration.Endpoint.Host is not set or is empty")
@@ -166,19 +166,28 @@ func (v *Validator) ValidateHardwareConfig(ctx context.Context, hardwareConfigFi
 }
 
 func (v *Validator) ValidateBMCSecretCreds(ctx context.Context, hc hardware.HardwareConfig) error {
+	for _, bmcInfo := range bmcSecretConfigs(hc) {
+		if _, err := v.bmc.GetPowerState(ctx, bmcInfo); err != nil {
+			return fmt.Errorf("failed to connect to BMC (address=%s): %v", bmcInfo.Host, err)
+		}
+	}
+
+	return nil
+}
+
+// bmcSecretConfigs returns the BMC configuration of the hardware in hc, with the credentials in the secret at the
+// same index as the BMC
+func bmcSecretConfigs(hc hardware.HardwareConfig) []pbnj.BmcSecretConfig {
+	configs := make([]pbnj.BmcSecretConfig, 0, len(hc.Bmcs))
 	for index, bmc := range hc.Bmcs {
-		bmcInfo := pbnj.BmcSecretConfig{
+		configs = append(configs, pbnj.BmcSecretConfig{
 			Host:     bmc.Spec.Host,
 			Username: string(hc.Secrets[index].Data["username"]),
 			Password: string(hc.Secrets[index].Data["password"]),
 			Vendor:   bmc.Spec.Vendor,
-		}
-		if _, err := v.pbnj.GetPowerState(ctx, bmcInfo); err != nil {
-			return fmt.Errorf("failed to connect to BMC (address=%s): %v", bmcInfo.Host, err)
-		}
+		})
 	}
-
-	return nil
+	return configs
 }
 
 func (v *Validator) ValidateTinkerbellTemplate(ctx context.Context, tinkerbellIp string, templateConfig *v1alpha1.TinkerbellTemplateConfig) error {
@@ -227,7 +236,7 @@ func (v *Validator) ValidateMinimumRequiredTinkerbellHardwareAvailable(spec v1al
 	return nil
 }
 
-func (v *Validator) validateControlPlaneIpUniqueness(tinkerBellClusterSpec *spec) error {
+func (v *Validator) validateControlPlaneIpUniqueness(tinkerBellClusterSpec *Spec) error {
 	ip := tinkerBellClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
 	if !networkutils.NewIPGenerator(v.netClient).IsIPUnique(ip) {
 		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host <%s> is already in use, please provide a unique IP", ip)
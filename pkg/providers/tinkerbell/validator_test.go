@@ -62,14 +62,14 @@ func TestValidateMinimumRequiredTinkerbellHardware_EtcdUnspecified(t *testing.T)
 
 func TestValidateTinkerbellConfig_ValidAuthorities(t *testing.T) {
 	ctrl := gomock.NewController(t)
-	pbnj := tinkerbellmocks.NewMockProviderPbnjClient(ctrl)
+	bmc := tinkerbellmocks.NewMockProviderBmcClient(ctrl)
 	tink := tinkerbellmocks.NewMockProviderTinkClient(ctrl)
 	net := networkutilsmocks.NewMockNetClient(ctrl)
 
 	var hardware hardware.HardwareConfig
 	datacenter := newValidTinkerbellDatacenterConfig()
 
-	validator := tinkerbell.NewValidator(tink, net, hardware, pbnj)
+	validator := tinkerbell.NewValidator(tink, net, hardware, bmc)
 	err := validator.ValidateTinkerbellConfig(context.Background(), datacenter)
 
 	assert.NoError(t, err)
@@ -86,7 +86,7 @@ func TestValidateTinkerbellConfig_InvalidGrpcAuthority(t *testing.T) {
 	for name, address := range cases {
 		t.Run(name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
-			pbnj := tinkerbellmocks.NewMockProviderPbnjClient(ctrl)
+			bmc := tinkerbellmocks.NewMockProviderBmcClient(ctrl)
 			tink := tinkerbellmocks.NewMockProviderTinkClient(ctrl)
 			net := networkutilsmocks.NewMockNetClient(ctrl)
 
@@ -94,7 +94,7 @@ func TestValidateTinkerbellConfig_InvalidGrpcAuthority(t *testing.T) {
 			datacenter := newValidTinkerbellDatacenterConfig()
 			datacenter.Spec.TinkerbellGRPCAuth = address
 
-			validator := tinkerbell.NewValidator(tink, net, hardware, pbnj)
+			validator := tinkerbell.NewValidator(tink, net, hardware, bmc)
 			err := validator.ValidateTinkerbellConfig(context.Background(), datacenter)
 
 			assert.Error(t, err)
@@ -113,7 +113,7 @@ func TestValidateTinkerbellConfig_InvalidPbnjAuthority(t *testing.T) {
 	for name, address := range cases {
 		t.Run(name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
-			pbnj := tinkerbellmocks.NewMockProviderPbnjClient(ctrl)
+			bmc := tinkerbellmocks.NewMockProviderBmcClient(ctrl)
 			tink := tinkerbellmocks.NewMockProviderTinkClient(ctrl)
 			net := networkutilsmocks.NewMockNetClient(ctrl)
 
@@ -121,7 +121,7 @@ func TestValidateTinkerbellConfig_InvalidPbnjAuthority(t *testing.T) {
 			datacenter := newValidTinkerbellDatacenterConfig()
 			datacenter.Spec.TinkerbellPBnJGRPCAuth = address
 
-			validator := tinkerbell.NewValidator(tink, net, hardware, pbnj)
+			validator := tinkerbell.NewValidator(tink, net, hardware, bmc)
 			err := validator.ValidateTinkerbellConfig(context.Background(), datacenter)
 
 			assert.Error(t, err)
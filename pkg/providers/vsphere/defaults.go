@@ -113,7 +113,7 @@ func (d *Defaulter) setDefaultTemplateIfMissing(ctx context.Context, spec *Spec,
 
 func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machineConfig *anywherev1.VSphereMachineConfig) error {
 	osFamily := machineConfig.Spec.OSFamily
-	eksd := spec.VersionsBundle.EksD
+	eksd := machineConfigVersionsBundle(spec.Spec, machineConfig).EksD
 	var ova releasev1.OSImage
 	switch osFamily {
 	case anywherev1.Bottlerocket:
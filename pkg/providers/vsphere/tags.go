@@ -21,7 +21,18 @@ func requiredTemplateTags(clusterSpec *cluster.Spec, machineConfig *v1alpha1.VSp
 func requiredTemplateTagsByCategory(clusterSpec *cluster.Spec, machineConfig *v1alpha1.VSphereMachineConfig) map[string][]string {
 	osFamily := machineConfig.Spec.OSFamily
 	return map[string][]string{
-		"eksdRelease": {fmt.Sprintf("eksdRelease:%s", clusterSpec.VersionsBundle.EksD.Name)},
+		"eksdRelease": {fmt.Sprintf("eksdRelease:%s", machineConfigVersionsBundle(clusterSpec, machineConfig).EksD.Name)},
 		"os":          {fmt.Sprintf("os:%s", strings.ToLower(string(osFamily)))},
 	}
 }
+
+// machineConfigVersionsBundle returns the VersionsBundle for the kubernetes version of the nodes using the machine config.
+// Machine configs can't be shared by nodes with different versions, so it's either a worker node group version or the Cluster one
+func machineConfigVersionsBundle(clusterSpec *cluster.Spec, machineConfig *v1alpha1.VSphereMachineConfig) *cluster.VersionsBundle {
+	for _, w := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if w.MachineGroupRef != nil && w.MachineGroupRef.Name == machineConfig.Name {
+			return clusterSpec.WorkerNodeGroupVersionsBundle(w)
+		}
+	}
+	return clusterSpec.VersionsBundle
+}
@@ -0,0 +1,140 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const checkpointFileName = "checkpoint.json"
+
+// ErrNoCheckpoint is returned when there is no saved checkpoint to resume from
+var ErrNoCheckpoint = errors.New("no task checkpoint found")
+
+// Checkpoint records the progress of a task run so a failed workflow can be resumed
+type Checkpoint struct {
+	ClusterName       string                `json:"clusterName"`
+	ClusterSpec       *v1alpha1.ClusterSpec `json:"clusterSpec,omitempty"`
+	CompletedTasks    []string              `json:"completedTasks"`
+	NextTask          string                `json:"nextTask"`
+	BootstrapCluster  *types.Cluster        `json:"bootstrapCluster,omitempty"`
+	WorkloadCluster   *types.Cluster        `json:"workloadCluster,omitempty"`
+	UpgradeChangeDiff *types.ChangeDiff     `json:"upgradeChangeDiff,omitempty"`
+}
+
+func newCheckpoint(commandContext *CommandContext, completedTasks []string, nextTask Task) *Checkpoint {
+	checkpoint := &Checkpoint{
+		CompletedTasks:    completedTasks,
+		NextTask:          nextTask.Name(),
+		BootstrapCluster:  commandContext.BootstrapCluster,
+		WorkloadCluster:   commandContext.WorkloadCluster,
+		UpgradeChangeDiff: commandContext.UpgradeChangeDiff,
+	}
+	if commandContext.ClusterSpec != nil {
+		checkpoint.ClusterName = commandContext.ClusterSpec.Cluster.Name
+		checkpoint.ClusterSpec = &commandContext.ClusterSpec.Cluster.Spec
+	}
+	return checkpoint
+}
+
+// Restore copies the saved state into the command context. It fails if the checkpoint
+// was taken for a different cluster or if the cluster spec changed since it was saved
+func (c *Checkpoint) Restore(commandContext *CommandContext) error {
+	if commandContext.ClusterSpec != nil {
+		if c.ClusterName != commandContext.ClusterSpec.Cluster.Name {
+			return fmt.Errorf("checkpoint belongs to cluster %s, not %s", c.ClusterName, commandContext.ClusterSpec.Cluster.Name)
+		}
+		changed, err := specChanged(c.ClusterSpec, &commandContext.ClusterSpec.Cluster.Spec)
+		if err != nil {
+			return err
+		}
+		if changed {
+			return fmt.Errorf("cluster spec for %s changed since the checkpoint was saved, resuming is not supported", c.ClusterName)
+		}
+	}
+
+	if c.BootstrapCluster != nil {
+		commandContext.BootstrapCluster = c.BootstrapCluster
+	}
+	if c.WorkloadCluster != nil {
+		commandContext.WorkloadCluster = c.WorkloadCluster
+	}
+	if c.UpgradeChangeDiff != nil {
+		commandContext.UpgradeChangeDiff = c.UpgradeChangeDiff
+	}
+	return nil
+}
+
+func specChanged(saved, current *v1alpha1.ClusterSpec) (bool, error) {
+	savedContent, err := json.Marshal(saved)
+	if err != nil {
+		return false, fmt.Errorf("marshalling saved cluster spec: %v", err)
+	}
+	currentContent, err := json.Marshal(current)
+	if err != nil {
+		return false, fmt.Errorf("marshalling current cluster spec: %v", err)
+	}
+	return !bytes.Equal(savedContent, currentContent), nil
+}
+
+// CheckpointStore persists the checkpoints taken by the task runner
+type CheckpointStore interface {
+	Load() (*Checkpoint, error)
+	Save(checkpoint *Checkpoint) error
+	Delete() error
+}
+
+type fileCheckpointStore struct {
+	writer filewriter.FileWriter
+}
+
+// NewFileCheckpointStore returns a CheckpointStore that keeps the checkpoint in the writer's directory
+func NewFileCheckpointStore(writer filewriter.FileWriter) CheckpointStore {
+	return &fileCheckpointStore{writer: writer}
+}
+
+func (f *fileCheckpointStore) path() string {
+	return filepath.Join(f.writer.Dir(), checkpointFileName)
+}
+
+func (f *fileCheckpointStore) Load() (*Checkpoint, error) {
+	content, err := ioutil.ReadFile(f.path())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoCheckpoint
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading task checkpoint: %v", err)
+	}
+
+	checkpoint := &Checkpoint{}
+	if err = json.Unmarshal(content, checkpoint); err != nil {
+		return nil, fmt.Errorf("parsing task checkpoint %s: %v", f.path(), err)
+	}
+	return checkpoint, nil
+}
+
+func (f *fileCheckpointStore) Save(checkpoint *Checkpoint) error {
+	content, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling task checkpoint: %v", err)
+	}
+	if _, err = f.writer.Write(checkpointFileName, content, filewriter.PersistentFile, filewriter.Permission0600); err != nil {
+		return fmt.Errorf("writing task checkpoint: %v", err)
+	}
+	return nil
+}
+
+func (f *fileCheckpointStore) Delete() error {
+	if err := os.Remove(f.path()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting task checkpoint: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package task_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestCheckpointRestore(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "cluster-name"
+		s.Cluster.Spec.KubernetesVersion = "1.21"
+	})
+	checkpoint := &task.Checkpoint{
+		ClusterName:      "cluster-name",
+		ClusterSpec:      spec.Cluster.Spec.DeepCopy(),
+		NextTask:         "taskB",
+		BootstrapCluster: &types.Cluster{Name: "bootstrap", KubeconfigFile: "bootstrap.kubeconfig"},
+		WorkloadCluster:  &types.Cluster{Name: "cluster-name", KubeconfigFile: "cluster-name.kubeconfig"},
+	}
+	commandContext := &task.CommandContext{ClusterSpec: spec}
+
+	g.Expect(checkpoint.Restore(commandContext)).To(Succeed())
+	g.Expect(commandContext.BootstrapCluster).To(Equal(checkpoint.BootstrapCluster))
+	g.Expect(commandContext.WorkloadCluster).To(Equal(checkpoint.WorkloadCluster))
+}
+
+func TestCheckpointRestoreSpecChanged(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "cluster-name"
+		s.Cluster.Spec.KubernetesVersion = "1.21"
+	})
+	checkpoint := &task.Checkpoint{
+		ClusterName: "cluster-name",
+		ClusterSpec: spec.Cluster.Spec.DeepCopy(),
+	}
+	spec.Cluster.Spec.KubernetesVersion = "1.22"
+
+	g.Expect(checkpoint.Restore(&task.CommandContext{ClusterSpec: spec})).To(MatchError(ContainSubstring("changed since the checkpoint was saved")))
+}
+
+func TestCheckpointRestoreDifferentCluster(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "cluster-name" })
+	checkpoint := &task.Checkpoint{ClusterName: "other-cluster"}
+
+	g.Expect(checkpoint.Restore(&task.CommandContext{ClusterSpec: spec})).To(MatchError(ContainSubstring("belongs to cluster other-cluster")))
+}
+
+func TestFileCheckpointStoreLoadNoCheckpoint(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+
+	_, err := task.NewFileCheckpointStore(writer).Load()
+	g.Expect(err).To(Equal(task.ErrNoCheckpoint))
+}
@@ -0,0 +1,84 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+type EventType string
+
+const (
+	TaskStarted  EventType = "task_started"
+	TaskFinished EventType = "task_finished"
+	TaskFailed   EventType = "task_failed"
+)
+
+// Event is emitted by the task runner when a task starts, finishes or fails
+type Event struct {
+	Time            time.Time          `json:"time"`
+	Type            EventType          `json:"type"`
+	Task            string             `json:"task"`
+	Cluster         string             `json:"cluster,omitempty"`
+	DurationSeconds float64            `json:"durationSeconds,omitempty"`
+	Subtasks        map[string]float64 `json:"subtasks,omitempty"`
+	Error           string             `json:"error,omitempty"`
+}
+
+// EventSink receives the task events of a run
+type EventSink interface {
+	Emit(event Event) error
+}
+
+type jsonLinesSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLinesSink returns an EventSink that writes each event to w as a single line of json
+func NewJSONLinesSink(w io.Writer) EventSink {
+	return &jsonLinesSink{w: w}
+}
+
+func (s *jsonLinesSink) Emit(event Event) error {
+	content, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshalling task event: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err = s.w.Write(append(content, '\n')); err != nil {
+		return fmt.Errorf("writing task event: %v", err)
+	}
+	return nil
+}
+
+func newTaskEvent(eventType EventType, taskName string, commandContext *CommandContext) Event {
+	event := Event{
+		Time: time.Now(),
+		Type: eventType,
+		Task: taskName,
+	}
+	if commandContext.ClusterSpec != nil {
+		event.Cluster = commandContext.ClusterSpec.Cluster.Name
+	}
+	return event
+}
+
+// subtaskDurations returns the sub task timings the profiler recorded for a task, in seconds
+func (pp *Profiler) subtaskDurations(taskName string) map[string]float64 {
+	var durations map[string]float64
+	for name, duration := range pp.metrics[taskName] {
+		if name == taskName {
+			continue
+		}
+		if durations == nil {
+			durations = map[string]float64{}
+		}
+		durations[name] = duration.Seconds()
+	}
+	return durations
+}
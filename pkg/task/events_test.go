@@ -0,0 +1,65 @@
+package task_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/task"
+	mocktasks "github.com/aws/eks-anywhere/pkg/task/mocks"
+)
+
+func TestTaskRunnerRunTaskEmitsEvents(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	cmdContext := &task.CommandContext{
+		ClusterSpec: test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "cluster-name" }),
+	}
+	taskA := mocktasks.NewMockTask(ctrl)
+	taskB := mocktasks.NewMockTask(ctrl)
+
+	taskA.EXPECT().Name().Return("taskA").AnyTimes()
+	taskB.EXPECT().Name().Return("taskB").AnyTimes()
+	taskA.EXPECT().Run(ctx, cmdContext).DoAndReturn(func(ctx context.Context, c *task.CommandContext) task.Task {
+		c.Profiler.SetStart("taskA", "subtaskA")
+		c.Profiler.MarkDone("taskA", "subtaskA")
+		return taskB
+	})
+	taskB.EXPECT().Run(ctx, cmdContext).DoAndReturn(func(ctx context.Context, c *task.CommandContext) task.Task {
+		c.SetError(errors.New("taskB failed"))
+		return nil
+	})
+
+	out := &bytes.Buffer{}
+	runner := task.NewTaskRunner(taskA, task.WithEventSink(task.NewJSONLinesSink(out)))
+	g.Expect(runner.RunTask(ctx, cmdContext)).NotTo(Succeed())
+
+	var events []task.Event
+	decoder := json.NewDecoder(out)
+	for decoder.More() {
+		event := task.Event{}
+		g.Expect(decoder.Decode(&event)).To(Succeed())
+		events = append(events, event)
+	}
+
+	g.Expect(events).To(HaveLen(4))
+	g.Expect(events[0].Type).To(Equal(task.TaskStarted))
+	g.Expect(events[0].Task).To(Equal("taskA"))
+	g.Expect(events[0].Cluster).To(Equal("cluster-name"))
+	g.Expect(events[1].Type).To(Equal(task.TaskFinished))
+	g.Expect(events[1].Task).To(Equal("taskA"))
+	g.Expect(events[1].Subtasks).To(HaveKey("subtaskA"))
+	g.Expect(events[2].Type).To(Equal(task.TaskStarted))
+	g.Expect(events[2].Task).To(Equal("taskB"))
+	g.Expect(events[3].Type).To(Equal(task.TaskFailed))
+	g.Expect(events[3].Task).To(Equal("taskB"))
+	g.Expect(events[3].Error).To(Equal("taskB failed"))
+}
@@ -0,0 +1,198 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const defaultGraphConcurrency = 4
+
+// Graph is a Task that runs a set of tasks respecting their declared dependencies,
+// running independent tasks concurrently. The task returned by each node is ignored:
+// once all nodes are done the graph continues with the task set with Then, or with the
+// one set with OnError if the CommandContext has an error.
+// After a node fails no new nodes are started, but the ones already running are waited for.
+// A graph started with an error already set, like one collecting diagnostics, runs all its nodes
+type Graph struct {
+	name        string
+	concurrency int
+	nodes       []*graphNode
+	next        Task
+	onError     Task
+}
+
+type graphNode struct {
+	task      Task
+	dependsOn []string
+}
+
+type GraphOpt func(*Graph)
+
+// WithConcurrency sets the maximum number of tasks the graph runs at the same time
+func WithConcurrency(concurrency int) GraphOpt {
+	return func(g *Graph) {
+		g.concurrency = concurrency
+	}
+}
+
+func NewGraph(name string, opts ...GraphOpt) *Graph {
+	g := &Graph{
+		name:        name,
+		concurrency: defaultGraphConcurrency,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Add adds a task to the graph that will only start after the tasks named in dependsOn have finished
+func (g *Graph) Add(task Task, dependsOn ...string) *Graph {
+	g.nodes = append(g.nodes, &graphNode{task: task, dependsOn: dependsOn})
+	return g
+}
+
+// Then sets the task to run after all the tasks in the graph succeed
+func (g *Graph) Then(next Task) *Graph {
+	g.next = next
+	return g
+}
+
+// OnError sets the task to run when any of the tasks in the graph fail
+func (g *Graph) OnError(onError Task) *Graph {
+	g.onError = onError
+	return g
+}
+
+func (g *Graph) Name() string {
+	return g.name
+}
+
+func (g *Graph) Run(ctx context.Context, commandContext *CommandContext) Task {
+	dependents, pending, err := g.build()
+	if err != nil {
+		commandContext.SetError(err)
+		return g.onError
+	}
+
+	concurrency := g.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var ready []*graphNode
+	for _, n := range g.nodes {
+		if pending[n.task.Name()] == 0 {
+			ready = append(ready, n)
+		}
+	}
+
+	previousErr := commandContext.err()
+	failed := func() bool {
+		return previousErr == nil && commandContext.err() != nil
+	}
+
+	done := make(chan *graphNode)
+	running := 0
+	for len(ready) > 0 || running > 0 {
+		for len(ready) > 0 && running < concurrency && !failed() {
+			n := ready[0]
+			ready = ready[1:]
+			running++
+			go func() {
+				defer func() { done <- n }()
+				g.runNode(ctx, commandContext, n)
+			}()
+		}
+		if running == 0 {
+			break
+		}
+
+		n := <-done
+		running--
+		for _, d := range dependents[n.task.Name()] {
+			pending[d.task.Name()]--
+			if pending[d.task.Name()] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	if commandContext.err() != nil {
+		return g.onError
+	}
+	return g.next
+}
+
+func (g *Graph) runNode(ctx context.Context, commandContext *CommandContext, n *graphNode) {
+	name := n.task.Name()
+	logger.V(4).Info("Task start", "task_name", name, "graph", g.name)
+	if commandContext.Profiler != nil {
+		commandContext.Profiler.SetStart(g.name, name)
+		defer commandContext.Profiler.MarkDone(g.name, name)
+	}
+	if next := n.task.Run(ctx, commandContext); next != nil {
+		logger.V(4).Info("Ignoring next task returned by graph node", "task_name", name, "next_task", next.Name())
+	}
+}
+
+// build indexes the nodes by name and validates every dependency exists and there are no cycles
+func (g *Graph) build() (dependents map[string][]*graphNode, pending map[string]int, err error) {
+	nodes := make(map[string]*graphNode, len(g.nodes))
+	for _, n := range g.nodes {
+		name := n.task.Name()
+		if _, ok := nodes[name]; ok {
+			return nil, nil, fmt.Errorf("task graph %s has more than one task named %s", g.name, name)
+		}
+		nodes[name] = n
+	}
+
+	dependents = make(map[string][]*graphNode, len(g.nodes))
+	pending = make(map[string]int, len(g.nodes))
+	for _, n := range g.nodes {
+		for _, dep := range n.dependsOn {
+			if _, ok := nodes[dep]; !ok {
+				return nil, nil, fmt.Errorf("task %s in graph %s depends on unknown task %s", n.task.Name(), g.name, dep)
+			}
+			dependents[dep] = append(dependents[dep], n)
+		}
+		pending[n.task.Name()] = len(n.dependsOn)
+	}
+
+	if err = g.checkCycles(dependents, pending); err != nil {
+		return nil, nil, err
+	}
+
+	return dependents, pending, nil
+}
+
+func (g *Graph) checkCycles(dependents map[string][]*graphNode, pending map[string]int) error {
+	remaining := make(map[string]int, len(pending))
+	var queue []string
+	for name, count := range pending {
+		remaining[name] = count
+		if count == 0 {
+			queue = append(queue, name)
+		}
+	}
+
+	visited := 0
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, d := range dependents[name] {
+			remaining[d.task.Name()]--
+			if remaining[d.task.Name()] == 0 {
+				queue = append(queue, d.task.Name())
+			}
+		}
+	}
+
+	if visited != len(pending) {
+		return fmt.Errorf("task graph %s has a dependency cycle", g.name)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package task_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/task"
+	mocktasks "github.com/aws/eks-anywhere/pkg/task/mocks"
+)
+
+type recordedTask struct {
+	name  string
+	err   error
+	mu    *sync.Mutex
+	order *[]string
+}
+
+func (r *recordedTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	r.mu.Lock()
+	*r.order = append(*r.order, r.name)
+	r.mu.Unlock()
+	if r.err != nil {
+		commandContext.SetError(r.err)
+	}
+	return nil
+}
+
+func (r *recordedTask) Name() string {
+	return r.name
+}
+
+type graphTest struct {
+	*WithT
+	ctrl       *gomock.Controller
+	ctx        context.Context
+	cmdContext *task.CommandContext
+	mu         *sync.Mutex
+	order      []string
+}
+
+func newGraphTest(t *testing.T) *graphTest {
+	return &graphTest{
+		WithT:      NewWithT(t),
+		ctrl:       gomock.NewController(t),
+		ctx:        context.Background(),
+		cmdContext: &task.CommandContext{},
+		mu:         &sync.Mutex{},
+	}
+}
+
+func (g *graphTest) task(name string) *recordedTask {
+	return &recordedTask{name: name, mu: g.mu, order: &g.order}
+}
+
+func (g *graphTest) failingTask(name string) *recordedTask {
+	t := g.task(name)
+	t.err = errors.New(name + " failed")
+	return t
+}
+
+func (g *graphTest) indexOf(name string) int {
+	for i, n := range g.order {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestGraphRunRespectsDependencies(t *testing.T) {
+	tt := newGraphTest(t)
+	next := mocktasks.NewMockTask(tt.ctrl)
+	graph := task.NewGraph("graph", task.WithConcurrency(2)).
+		Add(tt.task("a")).
+		Add(tt.task("b")).
+		Add(tt.task("c"), "a", "b").
+		Add(tt.task("d"), "c").
+		Then(next)
+
+	tt.Expect(graph.Run(tt.ctx, tt.cmdContext)).To(Equal(next))
+	tt.Expect(tt.cmdContext.OriginalError).To(BeNil())
+	tt.Expect(tt.order).To(ConsistOf("a", "b", "c", "d"))
+	tt.Expect(tt.indexOf("c")).To(BeNumerically(">", tt.indexOf("a")))
+	tt.Expect(tt.indexOf("c")).To(BeNumerically(">", tt.indexOf("b")))
+	tt.Expect(tt.indexOf("d")).To(Equal(3))
+}
+
+func TestGraphRunStopsAfterError(t *testing.T) {
+	tt := newGraphTest(t)
+	next := mocktasks.NewMockTask(tt.ctrl)
+	onError := mocktasks.NewMockTask(tt.ctrl)
+	graph := task.NewGraph("graph", task.WithConcurrency(1)).
+		Add(tt.failingTask("a")).
+		Add(tt.task("b"), "a").
+		Then(next).
+		OnError(onError)
+
+	tt.Expect(graph.Run(tt.ctx, tt.cmdContext)).To(Equal(onError))
+	tt.Expect(tt.cmdContext.OriginalError).To(MatchError("a failed"))
+	tt.Expect(tt.order).To(Equal([]string{"a"}))
+}
+
+func TestGraphRunWithPreviousErrorRunsAllTasks(t *testing.T) {
+	tt := newGraphTest(t)
+	tt.cmdContext.SetError(errors.New("previous error"))
+	graph := task.NewGraph("graph").
+		Add(tt.failingTask("a")).
+		Add(tt.task("b"), "a")
+
+	tt.Expect(graph.Run(tt.ctx, tt.cmdContext)).To(BeNil())
+	tt.Expect(tt.cmdContext.OriginalError).To(MatchError("previous error"))
+	tt.Expect(tt.order).To(Equal([]string{"a", "b"}))
+}
+
+func TestGraphRunInvalidGraph(t *testing.T) {
+	tests := []struct {
+		name    string
+		graph   func(tt *graphTest) *task.Graph
+		wantErr string
+	}{
+		{
+			name: "unknown dependency",
+			graph: func(tt *graphTest) *task.Graph {
+				return task.NewGraph("graph").Add(tt.task("a"), "b")
+			},
+			wantErr: "task a in graph graph depends on unknown task b",
+		},
+		{
+			name: "duplicated task",
+			graph: func(tt *graphTest) *task.Graph {
+				return task.NewGraph("graph").Add(tt.task("a")).Add(tt.task("a"))
+			},
+			wantErr: "task graph graph has more than one task named a",
+		},
+		{
+			name: "cycle",
+			graph: func(tt *graphTest) *task.Graph {
+				return task.NewGraph("graph").Add(tt.task("a"), "b").Add(tt.task("b"), "a")
+			},
+			wantErr: "task graph graph has a dependency cycle",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newGraphTest(t)
+			tt.Expect(tc.graph(tt).Run(tt.ctx, tt.cmdContext)).To(BeNil())
+			tt.Expect(tt.cmdContext.OriginalError).To(MatchError(tc.wantErr))
+			tt.Expect(tt.order).To(BeEmpty())
+		})
+	}
+}
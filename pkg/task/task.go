@@ -2,6 +2,7 @@ package task
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -34,15 +35,26 @@ type CommandContext struct {
 	WorkloadCluster    *types.Cluster
 	Profiler           *Profiler
 	OriginalError      error
+	errMu              sync.Mutex
 }
 
+// SetError records the first error of the command. It's safe to call from tasks running concurrently
 func (c *CommandContext) SetError(err error) {
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
 	if c.OriginalError == nil {
 		c.OriginalError = err
 	}
 }
 
+func (c *CommandContext) err() error {
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	return c.OriginalError
+}
+
 type Profiler struct {
+	mu      sync.Mutex
 	metrics map[string]map[string]time.Duration
 	starts  map[string]map[string]time.Time
 }
@@ -54,6 +66,8 @@ func (pp *Profiler) SetStartTask(taskName string) {
 
 // this can be used to profile sub tasks
 func (pp *Profiler) SetStart(taskName string, msg string) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
 	if _, ok := pp.starts[taskName]; !ok {
 		pp.starts[taskName] = map[string]time.Time{}
 	}
@@ -67,6 +81,8 @@ func (pp *Profiler) MarkDoneTask(taskName string) {
 
 // this can be used to profile sub tasks
 func (pp *Profiler) MarkDone(taskName string, msg string) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
 	if _, ok := pp.metrics[taskName]; !ok {
 		pp.metrics[taskName] = map[string]time.Duration{}
 	}
@@ -97,7 +113,33 @@ func (pp *Profiler) logProfileSummary(taskName string) {
 
 // Manages Task execution
 type taskRunner struct {
-	task Task
+	task           Task
+	checkpoints    CheckpointStore
+	completedTasks []string
+	events         EventSink
+}
+
+type TaskRunnerOpt func(*taskRunner)
+
+// WithCheckpoints saves a checkpoint to the store after each task that finishes without error
+func WithCheckpoints(store CheckpointStore) TaskRunnerOpt {
+	return func(r *taskRunner) {
+		r.checkpoints = store
+	}
+}
+
+// WithEventSink emits an event to the sink every time a task starts, finishes or fails
+func WithEventSink(sink EventSink) TaskRunnerOpt {
+	return func(r *taskRunner) {
+		r.events = sink
+	}
+}
+
+// ResumingFrom keeps the completed tasks of a previous run in the new checkpoints
+func ResumingFrom(checkpoint *Checkpoint) TaskRunnerOpt {
+	return func(r *taskRunner) {
+		r.completedTasks = append(r.completedTasks, checkpoint.CompletedTasks...)
+	}
 }
 
 // executes Task
@@ -111,21 +153,78 @@ func (pr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 	defer taskRunnerFinalBlock(start)
 	for task != nil {
 		logger.V(4).Info("Task start", "task_name", task.Name())
+		pr.emitTaskStart(task, commandContext)
+		failedBefore := commandContext.OriginalError != nil
 		commandContext.Profiler.SetStartTask(task.Name())
 		nextTask := task.Run(ctx, commandContext)
 		commandContext.Profiler.MarkDoneTask(task.Name())
 		commandContext.Profiler.logProfileSummary(task.Name())
+		pr.emitTaskDone(task, commandContext, !failedBefore && commandContext.OriginalError != nil)
+		pr.checkpoint(commandContext, task, nextTask)
 		task = nextTask
 	}
 	return commandContext.OriginalError
 }
 
+func (pr *taskRunner) emitTaskStart(task Task, commandContext *CommandContext) {
+	if pr.events == nil {
+		return
+	}
+	pr.emit(newTaskEvent(TaskStarted, task.Name(), commandContext))
+}
+
+// emitTaskDone reports a task as failed only if it's the one that set the command error
+func (pr *taskRunner) emitTaskDone(task Task, commandContext *CommandContext, failed bool) {
+	if pr.events == nil {
+		return
+	}
+	taskName := task.Name()
+	event := newTaskEvent(TaskFinished, taskName, commandContext)
+	if failed {
+		event.Type = TaskFailed
+		event.Error = commandContext.OriginalError.Error()
+	}
+	event.DurationSeconds = commandContext.Profiler.Metrics()[taskName][taskName].Seconds()
+	event.Subtasks = commandContext.Profiler.subtaskDurations(taskName)
+	pr.emit(event)
+}
+
+func (pr *taskRunner) emit(event Event) {
+	if err := pr.events.Emit(event); err != nil {
+		logger.Error(err, "Failed to emit task event", "task_name", event.Task)
+	}
+}
+
+// checkpoint records a task that finished successfully. Once a task fails the checkpoint
+// is left untouched so a resumed run starts again from the failed task
+func (pr *taskRunner) checkpoint(commandContext *CommandContext, task, nextTask Task) {
+	if pr.checkpoints == nil || commandContext.OriginalError != nil {
+		return
+	}
+	pr.completedTasks = append(pr.completedTasks, task.Name())
+
+	if nextTask == nil {
+		if err := pr.checkpoints.Delete(); err != nil {
+			logger.Error(err, "Failed to delete task checkpoint")
+		}
+		return
+	}
+
+	if err := pr.checkpoints.Save(newCheckpoint(commandContext, pr.completedTasks, nextTask)); err != nil {
+		logger.Error(err, "Failed to save task checkpoint", "task_name", task.Name())
+	}
+}
+
 func taskRunnerFinalBlock(startTime time.Time) {
 	logger.V(4).Info("Tasks completed", "duration", time.Since(startTime))
 }
 
-func NewTaskRunner(task Task) *taskRunner {
-	return &taskRunner{
+func NewTaskRunner(task Task, opts ...TaskRunnerOpt) *taskRunner {
+	runner := &taskRunner{
 		task: task,
 	}
+	for _, opt := range opts {
+		opt(runner)
+	}
+	return runner
 }
@@ -2,12 +2,17 @@ package task_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
 
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/task"
 	mocktasks "github.com/aws/eks-anywhere/pkg/task/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
 )
 
 func TestTaskRunnerRunTask(t *testing.T) {
@@ -55,3 +60,63 @@ func TestTaskRunnerRunTask(t *testing.T) {
 		}
 	}
 }
+
+func TestTaskRunnerRunTaskSavesCheckpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+	store := task.NewFileCheckpointStore(writer)
+	cmdContext := &task.CommandContext{
+		ClusterSpec:      test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "cluster-name" }),
+		BootstrapCluster: &types.Cluster{Name: "bootstrap"},
+	}
+	taskA := mocktasks.NewMockTask(ctrl)
+	taskB := mocktasks.NewMockTask(ctrl)
+
+	taskA.EXPECT().Name().Return("taskA").AnyTimes()
+	taskB.EXPECT().Name().Return("taskB").AnyTimes()
+	taskA.EXPECT().Run(ctx, cmdContext).Return(taskB)
+	taskB.EXPECT().Run(ctx, cmdContext).DoAndReturn(func(ctx context.Context, c *task.CommandContext) task.Task {
+		c.SetError(errors.New("taskB failed"))
+		return nil
+	})
+
+	runner := task.NewTaskRunner(taskA, task.WithCheckpoints(store))
+	if err := runner.RunTask(ctx, cmdContext); err == nil {
+		t.Fatal("RunTask() err = nil, want err not nil")
+	}
+
+	g := NewWithT(t)
+	checkpoint, err := store.Load()
+	g.Expect(err).To(BeNil())
+	g.Expect(checkpoint.ClusterName).To(Equal("cluster-name"))
+	g.Expect(checkpoint.CompletedTasks).To(Equal([]string{"taskA"}))
+	g.Expect(checkpoint.NextTask).To(Equal("taskB"))
+	g.Expect(checkpoint.BootstrapCluster).To(Equal(cmdContext.BootstrapCluster))
+}
+
+func TestTaskRunnerRunTaskDeletesCheckpointOnSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+	store := task.NewFileCheckpointStore(writer)
+	cmdContext := &task.CommandContext{
+		ClusterSpec: test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "cluster-name" }),
+	}
+	previous := &task.Checkpoint{ClusterName: "cluster-name", CompletedTasks: []string{"taskA"}, NextTask: "taskB"}
+	if err := store.Save(previous); err != nil {
+		t.Fatal(err)
+	}
+	taskB := mocktasks.NewMockTask(ctrl)
+	taskB.EXPECT().Name().Return("taskB").AnyTimes()
+	taskB.EXPECT().Run(ctx, cmdContext).Return(nil)
+
+	runner := task.NewTaskRunner(taskB, task.WithCheckpoints(store), task.ResumingFrom(previous))
+	if err := runner.RunTask(ctx, cmdContext); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Load(); err != task.ErrNoCheckpoint {
+		t.Fatalf("store.Load() err = %v, want err = %v", err, task.ErrNoCheckpoint)
+	}
+}
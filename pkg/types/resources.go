@@ -23,14 +23,21 @@ func (m *Machine) HasAnyLabel(labels []string) bool {
 }
 
 type MachineStatus struct {
-	NodeRef    *ResourceRef `json:"nodeRef,omitempty"`
+	NodeRef    *ResourceRef     `json:"nodeRef,omitempty"`
+	Addresses  []MachineAddress `json:"addresses,omitempty"`
 	Conditions Conditions
 }
 
 type MachineMetadata struct {
+	Name   string            `json:"name,omitempty"`
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+type MachineAddress struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
 type ResourceRef struct {
 	APIVersion string `json:"apiVersion"`
 	Kind       string `json:"kind"`
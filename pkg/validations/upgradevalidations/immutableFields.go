@@ -78,9 +78,29 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 	if !nSpec.ClusterNetwork.DNS.Equal(&oSpec.ClusterNetwork.DNS) {
 		return fmt.Errorf("spec.clusterNetwork.DNS is immutable")
 	}
-	if !v1alpha1.CNIPluginSame(nSpec.ClusterNetwork, oSpec.ClusterNetwork) {
+	if !v1alpha1.CNIPluginSame(nSpec.ClusterNetwork, oSpec.ClusterNetwork) && !v1alpha1.KindnetdToCiliumMigration(nSpec.ClusterNetwork, oSpec.ClusterNetwork) {
 		return fmt.Errorf("spec.clusterNetwork.CNI/CNIConfig is immutable")
 	}
+	// The migration from kindnetd doesn't install the network policies needed by the EKS Anywhere components
+	if v1alpha1.KindnetdToCiliumMigration(nSpec.ClusterNetwork, oSpec.ClusterNetwork) && nSpec.ClusterNetwork.CNIConfig != nil &&
+		nSpec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode == v1alpha1.CiliumPolicyModeAlways {
+		return fmt.Errorf("cilium policyEnforcementMode %s is not supported when migrating from kindnetd", v1alpha1.CiliumPolicyModeAlways)
+	}
+	// Calico only applies the encapsulation when it creates the IP pool of the cluster
+	if oCNI, nCNI := oSpec.ClusterNetwork.CNIConfig, nSpec.ClusterNetwork.CNIConfig; oCNI != nil && nCNI != nil && oCNI.Calico != nil && nCNI.Calico != nil {
+		if oCNI.Calico.EncapsulationMode() != nCNI.Calico.EncapsulationMode() {
+			return fmt.Errorf("spec.clusterNetwork.cniConfig.calico.encapsulation is immutable")
+		}
+	}
+	// Cilium can't change how the pod IPs of a running cluster are allocated and routed between nodes
+	if oCilium, nCilium := ciliumConfig(oSpec.ClusterNetwork), ciliumConfig(nSpec.ClusterNetwork); oCilium != nil && nCilium != nil {
+		if oCilium.EffectiveIPAMMode() != nCilium.EffectiveIPAMMode() {
+			return fmt.Errorf("spec.clusterNetwork.cniConfig.cilium.ipamMode is immutable")
+		}
+		if oCilium.EffectiveRoutingMode() != nCilium.EffectiveRoutingMode() {
+			return fmt.Errorf("spec.clusterNetwork.cniConfig.cilium.routingMode is immutable")
+		}
+	}
 
 	if !nSpec.ProxyConfiguration.Equal(oSpec.ProxyConfiguration) {
 		return fmt.Errorf("spec.proxyConfiguration is immutable")
@@ -124,3 +144,15 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 
 	return provider.ValidateNewSpec(ctx, cluster, spec)
 }
+
+// ciliumConfig returns the cilium config of a cluster network, or an empty one for clusters using cilium through
+// the deprecated cni field, which run with the default modes. It returns nil if the cluster doesn't use cilium
+func ciliumConfig(network v1alpha1.ClusterNetwork) *v1alpha1.CiliumConfig {
+	if network.CNIConfig != nil {
+		return network.CNIConfig.Cilium
+	}
+	if network.CNI == v1alpha1.Cilium || network.CNI == v1alpha1.CiliumEnterprise {
+		return &v1alpha1.CiliumConfig{}
+	}
+	return nil
+}
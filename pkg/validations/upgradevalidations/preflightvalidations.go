@@ -54,6 +54,11 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) (err erro
 			Remediation: "ensure that the cluster kubernetes version is incremented by one minor version exactly (e.g. 1.18 -> 1.19)",
 			Err:         ValidateServerVersionSkew(ctx, u.Opts.Spec.Cluster.Spec.KubernetesVersion, u.Opts.WorkloadCluster, k),
 		},
+		{
+			Name:        "upgrade worker node groups kubernetes version increment",
+			Remediation: "ensure that the kubernetes version of each worker node group is incremented by one minor version at most and is not downgraded",
+			Err:         ValidateWorkerNodeGroupsVersionSkew(ctx, k, targetCluster, u.Opts.Spec),
+		},
 		{
 			Name:        "validate immutable fields",
 			Remediation: "",
@@ -494,6 +494,49 @@ func TestPreflightValidations(t *testing.T) {
 				s.Cluster.Spec.ClusterNetwork.DNS = v1alpha1.DNS{}
 			},
 		},
+		{
+			name:               "ValidationCiliumIPAMModeImmutable",
+			clusterVersion:     "v1.19.16-eks-1-19-4",
+			upgradeVersion:     "1.19",
+			getClusterResponse: goodClusterResponse,
+			cpResponse:         nil,
+			workerResponse:     nil,
+			nodeResponse:       nil,
+			crdResponse:        nil,
+			wantErr:            composeError("spec.clusterNetwork.cniConfig.cilium.ipamMode is immutable"),
+			modifyFunc: func(s *cluster.Spec) {
+				s.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.IPAMMode = v1alpha1.CiliumIPAMModeClusterPool
+			},
+		},
+		{
+			name:               "ValidationCiliumRoutingModeImmutable",
+			clusterVersion:     "v1.19.16-eks-1-19-4",
+			upgradeVersion:     "1.19",
+			getClusterResponse: goodClusterResponse,
+			cpResponse:         nil,
+			workerResponse:     nil,
+			nodeResponse:       nil,
+			crdResponse:        nil,
+			wantErr:            composeError("spec.clusterNetwork.cniConfig.cilium.routingMode is immutable"),
+			modifyFunc: func(s *cluster.Spec) {
+				s.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.RoutingMode = v1alpha1.CiliumRoutingModeNative
+			},
+		},
+		{
+			name:               "ValidationCiliumDefaultModesUnchanged",
+			clusterVersion:     "v1.19.16-eks-1-19-4",
+			upgradeVersion:     "1.19",
+			getClusterResponse: goodClusterResponse,
+			cpResponse:         nil,
+			workerResponse:     nil,
+			nodeResponse:       nil,
+			crdResponse:        nil,
+			wantErr:            nil,
+			modifyFunc: func(s *cluster.Spec) {
+				s.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.IPAMMode = v1alpha1.CiliumIPAMModeKubernetes
+				s.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.RoutingMode = v1alpha1.CiliumRoutingModeTunnel
+			},
+		},
 		{
 			name:               "ValidationProxyConfigurationImmutable",
 			clusterVersion:     "v1.19.16-eks-1-19-4",
@@ -674,6 +717,9 @@ func TestPreflightValidations(t *testing.T) {
 			DNS: v1alpha1.DNS{
 				ResolvConf: &v1alpha1.ResolvConf{Path: "file.conf"},
 			},
+			CNIConfig: &v1alpha1.CNIConfig{
+				Cilium: &v1alpha1.CiliumConfig{},
+			},
 		}
 		s.Cluster.Spec.ProxyConfiguration = &v1alpha1.ProxyConfiguration{
 			HttpProxy:  "httpproxy",
@@ -729,7 +775,7 @@ func TestPreflightValidations(t *testing.T) {
 			k.EXPECT().ValidateNodes(ctx, kubeconfigFilePath).Return(tc.nodeResponse)
 			k.EXPECT().ValidateClustersCRD(ctx, workloadCluster).Return(tc.crdResponse)
 			k.EXPECT().GetClusters(ctx, workloadCluster).Return(tc.getClusterResponse, nil)
-			k.EXPECT().GetEksaCluster(ctx, workloadCluster, clusterSpec.Cluster.Name).Return(existingClusterSpec.Cluster, nil)
+			k.EXPECT().GetEksaCluster(ctx, workloadCluster, clusterSpec.Cluster.Name).Return(existingClusterSpec.Cluster, nil).Times(2)
 			k.EXPECT().GetEksaGitOpsConfig(ctx, clusterSpec.Cluster.Spec.GitOpsRef.Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.GitOpsConfig, nil).MaxTimes(1)
 			k.EXPECT().GetEksaOIDCConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.OIDCConfig, nil).MaxTimes(1)
 			k.EXPECT().GetEksaAWSIamConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[1].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.AWSIamConfig, nil).MaxTimes(1)
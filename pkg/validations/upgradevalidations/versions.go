@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/version"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
@@ -43,3 +44,43 @@ func ValidateServerVersionSkew(ctx context.Context, compareVersion v1alpha1.Kube
 	}
 	return nil
 }
+
+// ValidateWorkerNodeGroupsVersionSkew checks that the kubernetes version of the existing worker node groups
+// is not downgraded nor incremented by more than the supported minor version increment
+func ValidateWorkerNodeGroupsVersionSkew(ctx context.Context, k validations.KubectlClient, cluster *types.Cluster, spec *cluster.Spec) error {
+	prevCluster, err := k.GetEksaCluster(ctx, cluster, spec.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	prevWorkerNodeGroups := make(map[string]v1alpha1.WorkerNodeGroupConfiguration, len(prevCluster.Spec.WorkerNodeGroupConfigurations))
+	for _, w := range prevCluster.Spec.WorkerNodeGroupConfigurations {
+		prevWorkerNodeGroups[w.Name] = w
+	}
+
+	for _, w := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		prevWorkerNodeGroup, ok := prevWorkerNodeGroups[w.Name]
+		if !ok {
+			continue
+		}
+
+		prevVersion := prevCluster.WorkerNodeGroupKubernetesVersion(prevWorkerNodeGroup)
+		newVersion := spec.Cluster.WorkerNodeGroupKubernetesVersion(w)
+		parsedPrevVersion, err := version.ParseGeneric(string(prevVersion))
+		if err != nil {
+			return fmt.Errorf("error while parsing worker node group %s current version: %v", w.Name, err)
+		}
+		parsedNewVersion, err := version.ParseGeneric(string(newVersion))
+		if err != nil {
+			return fmt.Errorf("error while parsing worker node group %s upgrade version: %v", w.Name, err)
+		}
+
+		minorVersionDifference := int(parsedNewVersion.Minor()) - int(parsedPrevVersion.Minor())
+		if parsedNewVersion.Major() != parsedPrevVersion.Major() || minorVersionDifference < 0 || minorVersionDifference > supportedMinorVersionIncrement {
+			return fmt.Errorf("version difference between upgrade version (%s) and current version (%s) of worker node group %s do not meet the supported version increment of +%d",
+				newVersion, prevVersion, w.Name, supportedMinorVersionIncrement)
+		}
+	}
+
+	return nil
+}
@@ -2,13 +2,19 @@ package upgradevalidations_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"reflect"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
 )
 
@@ -57,3 +63,82 @@ func TestValidateVersionSkew(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateWorkerNodeGroupsVersionSkew(t *testing.T) {
+	kube119 := v1alpha1.Kube119
+	kube120 := v1alpha1.Kube120
+	kube121 := v1alpha1.Kube121
+	tests := []struct {
+		name           string
+		wantErr        error
+		currentVersion *v1alpha1.KubernetesVersion
+		newVersion     *v1alpha1.KubernetesVersion
+		newGroupName   string
+	}{
+		{
+			name:           "SuccessSameVersion",
+			wantErr:        nil,
+			currentVersion: &kube120,
+			newVersion:     &kube120,
+			newGroupName:   "md-0",
+		},
+		{
+			name:           "SuccessOneMinorVersion",
+			wantErr:        nil,
+			currentVersion: &kube119,
+			newVersion:     nil,
+			newGroupName:   "md-0",
+		},
+		{
+			name:           "SuccessNewWorkerNodeGroup",
+			wantErr:        nil,
+			currentVersion: &kube119,
+			newVersion:     &kube121,
+			newGroupName:   "md-1",
+		},
+		{
+			name:           "FailureTwoMinorVersions",
+			wantErr:        errors.New("version difference between upgrade version (1.21) and current version (1.19) of worker node group md-0 do not meet the supported version increment of +1"),
+			currentVersion: &kube119,
+			newVersion:     &kube121,
+			newGroupName:   "md-0",
+		},
+		{
+			name:           "FailureDowngrade",
+			wantErr:        errors.New("version difference between upgrade version (1.19) and current version (1.20) of worker node group md-0 do not meet the supported version increment of +1"),
+			currentVersion: nil,
+			newVersion:     &kube119,
+			newGroupName:   "md-0",
+		},
+	}
+
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{Name: "test-cluster", KubeconfigFile: "kubeconfig"}
+	for _, tc := range tests {
+		t.Run(tc.name, func(tt *testing.T) {
+			mockCtrl := gomock.NewController(tt)
+			k := mocks.NewMockKubectlClient(mockCtrl)
+			currentCluster := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					KubernetesVersion: v1alpha1.Kube120,
+					WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+						{Name: "md-0", KubernetesVersion: tc.currentVersion},
+					},
+				},
+			}
+			clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = workloadCluster.Name
+				s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube120
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+					{Name: tc.newGroupName, KubernetesVersion: tc.newVersion},
+				}
+			})
+			k.EXPECT().GetEksaCluster(ctx, workloadCluster, workloadCluster.Name).Return(currentCluster, nil)
+
+			err := upgradevalidations.ValidateWorkerNodeGroupsVersionSkew(ctx, k, workloadCluster, clusterSpec)
+			if !reflect.DeepEqual(err, tc.wantErr) {
+				tt.Errorf("%v got = %v, \nwant %v", tc.name, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+const (
+	// capiBackupDir is the folder in a management cluster backup with the CAPI objects saved by clusterctl
+	capiBackupDir = "capi"
+	// eksaResourcesBackupFile is the file in a management cluster backup with the EKS-A resources
+	eksaResourcesBackupFile = "eksa-resources.yaml"
+)
+
+type Backup struct {
+	clusterManager interfaces.ClusterManager
+	writer         filewriter.FileWriter
+}
+
+// NewBackup returns a workflow that saves a management cluster in the folder of writer
+func NewBackup(clusterManager interfaces.ClusterManager, writer filewriter.FileWriter) *Backup {
+	return &Backup{
+		clusterManager: clusterManager,
+		writer:         writer,
+	}
+}
+
+// Run saves the CAPI objects and the EKS-A resources of the management cluster, for itself and all the
+// workload clusters it manages, so it can be rebuilt with Restore if it's lost
+func (b *Backup) Run(ctx context.Context, managementCluster *types.Cluster) error {
+	commandContext := &task.CommandContext{
+		ClusterManager:  b.clusterManager,
+		Writer:          b.writer,
+		WorkloadCluster: managementCluster,
+	}
+
+	return task.NewTaskRunner(&backupCAPITask{}).RunTask(ctx, commandContext)
+}
+
+type backupCAPITask struct{}
+
+type backupEksaResourcesTask struct{}
+
+// backupCAPITask implementation
+
+func (s *backupCAPITask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Backing up cluster-api objects")
+	directory := filepath.Join(commandContext.Writer.Dir(), capiBackupDir)
+	if err := os.MkdirAll(directory, os.ModePerm); err != nil {
+		commandContext.SetError(fmt.Errorf("error creating directory %s: %v", directory, err))
+		return nil
+	}
+
+	if err := commandContext.ClusterManager.BackupCAPI(ctx, commandContext.WorkloadCluster, directory); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	return &backupEksaResourcesTask{}
+}
+
+func (s *backupCAPITask) Name() string {
+	return "capi-backup"
+}
+
+// backupEksaResourcesTask implementation
+
+func (s *backupEksaResourcesTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Backing up EKS-A resources")
+	resources, err := commandContext.ClusterManager.GetEKSAResources(ctx, commandContext.WorkloadCluster)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	if _, err = commandContext.Writer.Write(eksaResourcesBackupFile, resources, filewriter.PersistentFile); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	logger.MarkSuccess("Cluster backed up!", "directory", commandContext.Writer.Dir())
+	return nil
+}
+
+func (s *backupEksaResourcesTask) Name() string {
+	return "eksa-resources-backup"
+}
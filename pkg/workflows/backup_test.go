@@ -0,0 +1,74 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
+)
+
+type backupTestSetup struct {
+	*WithT
+	clusterManager    *mocks.MockClusterManager
+	writer            *writermocks.MockFileWriter
+	workflow          *workflows.Backup
+	ctx               context.Context
+	dir               string
+	managementCluster *types.Cluster
+}
+
+func newBackupTest(t *testing.T) *backupTestSetup {
+	mockCtrl := gomock.NewController(t)
+	clusterManager := mocks.NewMockClusterManager(mockCtrl)
+	writer := writermocks.NewMockFileWriter(mockCtrl)
+	dir := t.TempDir()
+	writer.EXPECT().Dir().Return(dir).AnyTimes()
+
+	return &backupTestSetup{
+		WithT:             NewWithT(t),
+		clusterManager:    clusterManager,
+		writer:            writer,
+		workflow:          workflows.NewBackup(clusterManager, writer),
+		ctx:               context.Background(),
+		dir:               dir,
+		managementCluster: &types.Cluster{Name: "management", KubeconfigFile: "management.kubeconfig"},
+	}
+}
+
+func TestBackupRunSuccess(t *testing.T) {
+	test := newBackupTest(t)
+	resources := []byte("resources")
+	gomock.InOrder(
+		test.clusterManager.EXPECT().BackupCAPI(test.ctx, test.managementCluster, filepath.Join(test.dir, "capi")),
+		test.clusterManager.EXPECT().GetEKSAResources(test.ctx, test.managementCluster).Return(resources, nil),
+		test.writer.EXPECT().Write("eksa-resources.yaml", resources, gomock.Any()),
+	)
+
+	test.Expect(test.workflow.Run(test.ctx, test.managementCluster)).To(Succeed())
+	test.Expect(filepath.Join(test.dir, "capi")).To(BeADirectory())
+}
+
+func TestBackupRunCAPIError(t *testing.T) {
+	test := newBackupTest(t)
+	test.clusterManager.EXPECT().BackupCAPI(test.ctx, test.managementCluster, filepath.Join(test.dir, "capi")).Return(errors.New("move failed"))
+
+	test.Expect(test.workflow.Run(test.ctx, test.managementCluster)).To(MatchError("move failed"))
+}
+
+func TestBackupRunEKSAResourcesError(t *testing.T) {
+	test := newBackupTest(t)
+	gomock.InOrder(
+		test.clusterManager.EXPECT().BackupCAPI(test.ctx, test.managementCluster, filepath.Join(test.dir, "capi")),
+		test.clusterManager.EXPECT().GetEKSAResources(test.ctx, test.managementCluster).Return(nil, errors.New("get failed")),
+	)
+
+	test.Expect(test.workflow.Run(test.ctx, test.managementCluster)).To(MatchError("get failed"))
+}
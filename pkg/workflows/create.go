@@ -21,17 +21,39 @@ type Create struct {
 	clusterManager interfaces.ClusterManager
 	addonManager   interfaces.AddonManager
 	writer         filewriter.FileWriter
+	checkpoints    task.CheckpointStore
+	events         task.EventSink
+}
+
+type CreateOpt func(*Create)
+
+// WithCreateCheckpoints saves the progress of the create tasks so a failed create can be resumed
+func WithCreateCheckpoints(store task.CheckpointStore) CreateOpt {
+	return func(c *Create) {
+		c.checkpoints = store
+	}
+}
+
+// WithCreateTaskEvents emits an event for every task the create workflow runs
+func WithCreateTaskEvents(sink task.EventSink) CreateOpt {
+	return func(c *Create) {
+		c.events = sink
+	}
 }
 
 func NewCreate(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
-	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, writer filewriter.FileWriter) *Create {
-	return &Create{
+	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, writer filewriter.FileWriter, opts ...CreateOpt) *Create {
+	create := &Create{
 		bootstrapper:   bootstrapper,
 		provider:       provider,
 		clusterManager: clusterManager,
 		addonManager:   addonManager,
 		writer:         writer,
 	}
+	for _, opt := range opts {
+		opt(create)
+	}
+	return create
 }
 
 func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator, forceCleanup bool) error {
@@ -42,6 +64,38 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 			return err
 		}
 	}
+	commandContext := c.newCommandContext(clusterSpec, validator)
+
+	return task.NewTaskRunner(&SetAndValidateTask{}, c.runnerOpts()...).RunTask(ctx, commandContext)
+}
+
+// Resume continues a failed create from the first task that didn't finish, as recorded in the last checkpoint.
+// The provider setup runs again but the create preflight validations are skipped, since the cluster already exists
+func (c *Create) Resume(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator) error {
+	if c.checkpoints == nil {
+		return fmt.Errorf("resuming cluster create requires task checkpoints")
+	}
+	checkpoint, err := c.checkpoints.Load()
+	if err != nil {
+		return err
+	}
+
+	commandContext := c.newCommandContext(clusterSpec, validator)
+	if err = checkpoint.Restore(commandContext); err != nil {
+		return err
+	}
+
+	nextTask, err := resumableTask(checkpoint.NextTask, createTasks())
+	if err != nil {
+		return err
+	}
+	logger.Info("Resuming cluster create", "task", checkpoint.NextTask)
+
+	opts := append(c.runnerOpts(), task.ResumingFrom(checkpoint))
+	return task.NewTaskRunner(&resumeCreateTask{next: nextTask}, opts...).RunTask(ctx, commandContext)
+}
+
+func (c *Create) newCommandContext(clusterSpec *cluster.Spec, validator interfaces.Validator) *task.CommandContext {
 	commandContext := &task.CommandContext{
 		Bootstrapper:   c.bootstrapper,
 		Provider:       c.provider,
@@ -56,11 +110,41 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&SetAndValidateTask{}).RunTask(ctx, commandContext)
+	return commandContext
+}
+
+func (c *Create) runnerOpts() []task.TaskRunnerOpt {
+	var opts []task.TaskRunnerOpt
+	if c.checkpoints != nil {
+		opts = append(opts, task.WithCheckpoints(c.checkpoints))
+	}
+	if c.events != nil {
+		opts = append(opts, task.WithEventSink(c.events))
+	}
+	return opts
+}
+
+// createTasks lists the create tasks a run can be resumed at
+func createTasks() []task.Task {
+	return []task.Task{
+		&CreateBootStrapClusterTask{},
+		&CreateWorkloadClusterTask{},
+		workloadClusterComponentsTask(),
+		&MoveClusterManagementTask{},
+		&InstallEksaComponentsTask{},
+		&InstallClusterAutoscalerTask{},
+		&InstallAddonManagerTask{},
+		&WriteClusterConfigTask{},
+		&DeleteBootstrapClusterTask{},
+	}
 }
 
 // task related entities
 
+type resumeCreateTask struct {
+	next task.Task
+}
+
 type CreateBootStrapClusterTask struct{}
 
 type SetAndValidateTask struct{}
@@ -69,8 +153,18 @@ type CreateWorkloadClusterTask struct{}
 
 type InstallEksaComponentsTask struct{}
 
+type InstallClusterAutoscalerTask struct{}
+
 type InstallAddonManagerTask struct{}
 
+type installAwsIamAuthTask struct{}
+
+type installStorageClassTask struct{}
+
+type installWorkloadCAPITask struct{}
+
+type installMachineHealthChecksTask struct{}
+
 type MoveClusterManagementTask struct{}
 
 type WriteClusterConfigTask struct{}
@@ -79,6 +173,21 @@ type DeleteBootstrapClusterTask struct {
 	*CollectDiagnosticsTask
 }
 
+// resumeCreateTask implementation
+
+func (s *resumeCreateTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Performing provider setup")
+	if err := commandContext.Provider.SetupAndValidateCreateCluster(ctx, commandContext.ClusterSpec); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	return s.next
+}
+
+func (s *resumeCreateTask) Name() string {
+	return "resume-setup"
+}
+
 // CreateBootStrapClusterTask implementation
 
 func (s *CreateBootStrapClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -188,50 +297,95 @@ func (s *CreateWorkloadClusterTask) Run(ctx context.Context, commandContext *tas
 		return &CollectDiagnosticsTask{}
 	}
 
-	if commandContext.ClusterSpec.AWSIamConfig != nil {
-		logger.Info("Installing aws-iam-authenticator on workload cluster")
-		err = commandContext.ClusterManager.InstallAwsIamAuth(ctx, commandContext.BootstrapCluster, workloadCluster, commandContext.ClusterSpec)
-		if err != nil {
-			commandContext.SetError(err)
-			return &CollectDiagnosticsTask{}
-		}
+	return workloadClusterComponentsTask()
+}
+
+func (s *CreateWorkloadClusterTask) Name() string {
+	return "workload-cluster-init"
+}
+
+// workloadClusterComponentsTask installs the components that don't depend on each other concurrently
+func workloadClusterComponentsTask() task.Task {
+	return task.NewGraph("workload-cluster-components").
+		Add(&installAwsIamAuthTask{}).
+		Add(&installStorageClassTask{}).
+		Add(&installWorkloadCAPITask{}).
+		Add(&installMachineHealthChecksTask{}).
+		Then(&MoveClusterManagementTask{}).
+		OnError(&CollectDiagnosticsTask{})
+}
+
+// installAwsIamAuthTask implementation
+
+func (s *installAwsIamAuthTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if commandContext.ClusterSpec.AWSIamConfig == nil {
+		return nil
 	}
+	logger.Info("Installing aws-iam-authenticator on workload cluster")
+	err := commandContext.ClusterManager.InstallAwsIamAuth(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster, commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+	}
+	return nil
+}
+
+func (s *installAwsIamAuthTask) Name() string {
+	return "aws-iam-auth-install"
+}
+
+// installStorageClassTask implementation
 
+func (s *installStorageClassTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Installing storage class on workload cluster")
-	err = commandContext.ClusterManager.InstallStorageClass(ctx, workloadCluster, commandContext.Provider)
+	err := commandContext.ClusterManager.InstallStorageClass(ctx, commandContext.WorkloadCluster, commandContext.Provider)
 	if err != nil {
 		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
 	}
+	return nil
+}
 
-	if !commandContext.BootstrapCluster.ExistingManagement {
-		logger.Info("Installing cluster-api providers on workload cluster")
-		err = commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, commandContext.WorkloadCluster, commandContext.Provider)
-		if err != nil {
-			commandContext.SetError(err)
-			return &CollectDiagnosticsTask{}
-		}
+func (s *installStorageClassTask) Name() string {
+	return "storage-class-install"
+}
 
-		logger.Info("Installing EKS-A secrets on workload cluster")
-		err := commandContext.Provider.UpdateSecrets(ctx, commandContext.WorkloadCluster)
-		if err != nil {
-			commandContext.SetError(err)
-			return &CollectDiagnosticsTask{}
-		}
+// installWorkloadCAPITask implementation
+
+func (s *installWorkloadCAPITask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if commandContext.BootstrapCluster.ExistingManagement {
+		return nil
+	}
+	logger.Info("Installing cluster-api providers on workload cluster")
+	err := commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, commandContext.WorkloadCluster, commandContext.Provider)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
 	}
 
-	logger.V(4).Info("Installing machine health checks on bootstrap cluster")
-	err = commandContext.ClusterManager.InstallMachineHealthChecks(ctx, commandContext.BootstrapCluster, commandContext.Provider)
+	logger.Info("Installing EKS-A secrets on workload cluster")
+	err = commandContext.Provider.UpdateSecrets(ctx, commandContext.WorkloadCluster)
 	if err != nil {
 		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
 	}
+	return nil
+}
 
-	return &MoveClusterManagementTask{}
+func (s *installWorkloadCAPITask) Name() string {
+	return "workload-capi-install"
 }
 
-func (s *CreateWorkloadClusterTask) Name() string {
-	return "workload-cluster-init"
+// installMachineHealthChecksTask implementation
+
+func (s *installMachineHealthChecksTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.V(4).Info("Installing machine health checks on bootstrap cluster")
+	err := commandContext.ClusterManager.InstallMachineHealthChecks(ctx, commandContext.BootstrapCluster, commandContext.Provider)
+	if err != nil {
+		commandContext.SetError(err)
+	}
+	return nil
+}
+
+func (s *installMachineHealthChecksTask) Name() string {
+	return "machine-health-checks-install"
 }
 
 // MoveClusterManagementTask implementation
@@ -288,13 +442,29 @@ func (s *InstallEksaComponentsTask) Run(ctx context.Context, commandContext *tas
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
-	return &InstallAddonManagerTask{}
+	return &InstallClusterAutoscalerTask{}
 }
 
 func (s *InstallEksaComponentsTask) Name() string {
 	return "eksa-components-install"
 }
 
+// InstallClusterAutoscalerTask implementation
+
+func (s *InstallClusterAutoscalerTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.V(4).Info("Installing cluster-autoscaler on management cluster")
+	err := commandContext.ClusterManager.InstallClusterAutoscaler(ctx, getManagementCluster(commandContext), commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+	return &InstallAddonManagerTask{}
+}
+
+func (s *InstallClusterAutoscalerTask) Name() string {
+	return "cluster-autoscaler-install"
+}
+
 // InstallAddonManagerTask implementation
 
 func (s *InstallAddonManagerTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
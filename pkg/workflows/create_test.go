@@ -13,6 +13,7 @@ import (
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
@@ -91,17 +92,20 @@ func (c *createTestSetup) expectCreateBootstrap() {
 }
 
 func (c *createTestSetup) expectCreateWorkload() {
+	installNetworking := c.clusterManager.EXPECT().InstallNetworking(
+		c.ctx, c.workloadCluster, c.clusterSpec, c.provider,
+	)
 	gomock.InOrder(
 		c.clusterManager.EXPECT().CreateWorkloadCluster(
 			c.ctx, c.bootstrapCluster, c.clusterSpec, c.provider,
 		).Return(c.workloadCluster, nil),
-
-		c.clusterManager.EXPECT().InstallNetworking(
-			c.ctx, c.workloadCluster, c.clusterSpec, c.provider,
-		),
-		c.clusterManager.EXPECT().InstallStorageClass(
-			c.ctx, c.workloadCluster, c.provider,
-		),
+		installNetworking,
+	)
+	c.clusterManager.EXPECT().InstallStorageClass(
+		c.ctx, c.workloadCluster, c.provider,
+	).After(installNetworking)
+	gomock.InOrder(
+		installNetworking,
 		c.clusterManager.EXPECT().InstallCAPI(
 			c.ctx, c.clusterSpec, c.workloadCluster, c.provider,
 		),
@@ -174,6 +178,10 @@ func (c *createTestSetup) skipInstallEksaComponents() {
 	)
 }
 
+func (c *createTestSetup) expectInstallClusterAutoscaler(managementCluster *types.Cluster) {
+	c.clusterManager.EXPECT().InstallClusterAutoscaler(c.ctx, managementCluster, c.clusterSpec)
+}
+
 func (c *createTestSetup) expectInstallAddonManager() {
 	gomock.InOrder(
 		c.provider.EXPECT().DatacenterConfig(c.clusterSpec).Return(c.datacenterConfig),
@@ -224,6 +232,7 @@ func TestCreateRunSuccess(t *testing.T) {
 	test.expectCreateWorkload()
 	test.expectMoveManagement()
 	test.expectInstallEksaComponents()
+	test.expectInstallClusterAutoscaler(test.workloadCluster)
 	test.expectInstallAddonManager()
 	test.expectWriteClusterConfig()
 	test.expectDeleteBootstrap()
@@ -245,6 +254,7 @@ func TestCreateRunSuccessForceCleanup(t *testing.T) {
 	test.expectCreateWorkload()
 	test.expectMoveManagement()
 	test.expectInstallEksaComponents()
+	test.expectInstallClusterAutoscaler(test.workloadCluster)
 	test.expectInstallAddonManager()
 	test.expectWriteClusterConfig()
 	test.expectDeleteBootstrap()
@@ -275,6 +285,7 @@ func TestCreateWorkloadClusterRunSuccess(t *testing.T) {
 	test.expectCreateWorkloadSkipCAPI()
 	test.skipMoveManagement()
 	test.skipInstallEksaComponents()
+	test.expectInstallClusterAutoscaler(test.bootstrapCluster)
 	test.expectInstallAddonManager()
 	test.expectWriteClusterConfig()
 	test.expectNotDeleteBootstrap()
@@ -285,3 +296,47 @@ func TestCreateWorkloadClusterRunSuccess(t *testing.T) {
 		t.Fatalf("Create.Run() err = %v, want err = nil", err)
 	}
 }
+
+func TestCreateResumeSuccess(t *testing.T) {
+	tt := newCreateTest(t)
+	_, writer := test.NewWriter(t)
+	checkpoints := task.NewFileCheckpointStore(writer)
+	tt.workflow = workflows.NewCreate(tt.bootstrapper, tt.provider, tt.clusterManager, tt.addonManager, tt.writer, workflows.WithCreateCheckpoints(checkpoints))
+
+	if err := checkpoints.Save(&task.Checkpoint{
+		ClusterName:      tt.clusterSpec.Cluster.Name,
+		ClusterSpec:      tt.clusterSpec.Cluster.Spec.DeepCopy(),
+		CompletedTasks:   []string{"setup-validate", "bootstrap-cluster-init", "workload-cluster-init"},
+		NextTask:         "capi-management-move",
+		BootstrapCluster: tt.bootstrapCluster,
+		WorkloadCluster:  tt.workloadCluster,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tt.provider.EXPECT().SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
+	tt.expectMoveManagement()
+	tt.expectInstallEksaComponents()
+	tt.expectInstallClusterAutoscaler(tt.workloadCluster)
+	tt.expectInstallAddonManager()
+	tt.expectWriteClusterConfig()
+	tt.expectDeleteBootstrap()
+
+	if err := tt.workflow.Resume(tt.ctx, tt.clusterSpec, tt.validator); err != nil {
+		t.Fatalf("Create.Resume() err = %v, want err = nil", err)
+	}
+
+	if _, err := checkpoints.Load(); err != task.ErrNoCheckpoint {
+		t.Fatalf("checkpoints.Load() err = %v, want err = %v", err, task.ErrNoCheckpoint)
+	}
+}
+
+func TestCreateResumeNoCheckpoint(t *testing.T) {
+	tt := newCreateTest(t)
+	_, writer := test.NewWriter(t)
+	tt.workflow = workflows.NewCreate(tt.bootstrapper, tt.provider, tt.clusterManager, tt.addonManager, tt.writer, workflows.WithCreateCheckpoints(task.NewFileCheckpointStore(writer)))
+
+	if err := tt.workflow.Resume(tt.ctx, tt.clusterSpec, tt.validator); err != task.ErrNoCheckpoint {
+		t.Fatalf("Create.Resume() err = %v, want err = %v", err, task.ErrNoCheckpoint)
+	}
+}
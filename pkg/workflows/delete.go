@@ -16,16 +16,30 @@ type Delete struct {
 	provider       providers.Provider
 	clusterManager interfaces.ClusterManager
 	addonManager   interfaces.AddonManager
+	events         task.EventSink
+}
+
+type DeleteOpt func(*Delete)
+
+// WithDeleteTaskEvents emits an event for every task the delete workflow runs
+func WithDeleteTaskEvents(sink task.EventSink) DeleteOpt {
+	return func(d *Delete) {
+		d.events = sink
+	}
 }
 
 func NewDelete(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
-	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager) *Delete {
-	return &Delete{
+	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, opts ...DeleteOpt) *Delete {
+	d := &Delete{
 		bootstrapper:   bootstrapper,
 		provider:       provider,
 		clusterManager: clusterManager,
 		addonManager:   addonManager,
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, forceCleanup bool, kubeconfig string) error {
@@ -50,7 +64,11 @@ func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, cluste
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&setupAndValidate{}).RunTask(ctx, commandContext)
+	var runnerOpts []task.TaskRunnerOpt
+	if c.events != nil {
+		runnerOpts = append(runnerOpts, task.WithEventSink(c.events))
+	}
+	return task.NewTaskRunner(&setupAndValidate{}, runnerOpts...).RunTask(ctx, commandContext)
 }
 
 type setupAndValidate struct{}
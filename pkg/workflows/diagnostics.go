@@ -20,8 +20,10 @@ type CollectMgmtClusterDiagnosticsTask struct{}
 
 func (s *CollectDiagnosticsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("collecting cluster diagnostics")
-	_ = s.CollectMgmtClusterDiagnosticsTask.Run(ctx, commandContext)
-	_ = s.CollectWorkloadClusterDiagnosticsTask.Run(ctx, commandContext)
+	_ = task.NewGraph(s.Name()).
+		Add(s.CollectMgmtClusterDiagnosticsTask).
+		Add(s.CollectWorkloadClusterDiagnosticsTask).
+		Run(ctx, commandContext)
 	return nil
 }
 
@@ -17,8 +17,15 @@ type Bootstrapper interface {
 
 type ClusterManager interface {
 	MoveCAPI(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error
+	BackupCAPI(ctx context.Context, managementCluster *types.Cluster, directory string) error
+	RestoreCAPI(ctx context.Context, cluster *types.Cluster, directory string) error
+	GetEKSAResources(ctx context.Context, managementCluster *types.Cluster) ([]byte, error)
+	RestoreEKSAResources(ctx context.Context, cluster *types.Cluster, resources []byte) error
 	CreateWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) (*types.Cluster, error)
 	UpgradeCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
+	SaveRollbackPoint(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentSpec *cluster.Spec, provider providers.Provider) error
+	ValidateRollback(ctx context.Context, managementCluster *types.Cluster, clusterName string) error
+	RollbackCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
 	DeleteCluster(ctx context.Context, managementCluster, clusterToDelete *types.Cluster, provider providers.Provider, clusterSpec *cluster.Spec) error
 	InstallCAPI(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster, provider providers.Provider) error
 	InstallNetworking(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
@@ -34,6 +41,7 @@ type ClusterManager interface {
 	ResumeEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
 	EKSAClusterSpecChanged(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) (bool, error)
 	InstallMachineHealthChecks(ctx context.Context, workloadCluster *types.Cluster, provider providers.Provider) error
+	InstallClusterAutoscaler(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error
 	GetCurrentClusterSpec(ctx context.Context, cluster *types.Cluster, clusterName string) (*cluster.Spec, error)
 	Upgrade(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
 	InstallAwsIamAuth(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error
@@ -110,6 +110,20 @@ func (mr *MockClusterManagerMockRecorder) ApplyBundles(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBundles", reflect.TypeOf((*MockClusterManager)(nil).ApplyBundles), arg0, arg1, arg2)
 }
 
+// BackupCAPI mocks base method.
+func (m *MockClusterManager) BackupCAPI(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupCAPI", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackupCAPI indicates an expected call of BackupCAPI.
+func (mr *MockClusterManagerMockRecorder) BackupCAPI(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupCAPI", reflect.TypeOf((*MockClusterManager)(nil).BackupCAPI), arg0, arg1, arg2)
+}
+
 // CreateAwsIamAuthCaSecret mocks base method.
 func (m *MockClusterManager) CreateAwsIamAuthCaSecret(arg0 context.Context, arg1 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -197,6 +211,21 @@ func (mr *MockClusterManagerMockRecorder) GetCurrentClusterSpec(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentClusterSpec", reflect.TypeOf((*MockClusterManager)(nil).GetCurrentClusterSpec), arg0, arg1, arg2)
 }
 
+// GetEKSAResources mocks base method.
+func (m *MockClusterManager) GetEKSAResources(arg0 context.Context, arg1 *types.Cluster) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEKSAResources", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEKSAResources indicates an expected call of GetEKSAResources.
+func (mr *MockClusterManagerMockRecorder) GetEKSAResources(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEKSAResources", reflect.TypeOf((*MockClusterManager)(nil).GetEKSAResources), arg0, arg1)
+}
+
 // InstallAwsIamAuth mocks base method.
 func (m *MockClusterManager) InstallAwsIamAuth(arg0 context.Context, arg1, arg2 *types.Cluster, arg3 *cluster.Spec) error {
 	m.ctrl.T.Helper()
@@ -225,6 +254,20 @@ func (mr *MockClusterManagerMockRecorder) InstallCAPI(arg0, arg1, arg2, arg3 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCAPI", reflect.TypeOf((*MockClusterManager)(nil).InstallCAPI), arg0, arg1, arg2, arg3)
 }
 
+// InstallClusterAutoscaler mocks base method.
+func (m *MockClusterManager) InstallClusterAutoscaler(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallClusterAutoscaler", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallClusterAutoscaler indicates an expected call of InstallClusterAutoscaler.
+func (mr *MockClusterManagerMockRecorder) InstallClusterAutoscaler(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallClusterAutoscaler", reflect.TypeOf((*MockClusterManager)(nil).InstallClusterAutoscaler), arg0, arg1, arg2)
+}
+
 // InstallCustomComponents mocks base method.
 func (m *MockClusterManager) InstallCustomComponents(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -328,6 +371,34 @@ func (mr *MockClusterManagerMockRecorder) PauseEKSAControllerReconcile(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseEKSAControllerReconcile", reflect.TypeOf((*MockClusterManager)(nil).PauseEKSAControllerReconcile), arg0, arg1, arg2, arg3)
 }
 
+// RestoreCAPI mocks base method.
+func (m *MockClusterManager) RestoreCAPI(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCAPI", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreCAPI indicates an expected call of RestoreCAPI.
+func (mr *MockClusterManagerMockRecorder) RestoreCAPI(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCAPI", reflect.TypeOf((*MockClusterManager)(nil).RestoreCAPI), arg0, arg1, arg2)
+}
+
+// RestoreEKSAResources mocks base method.
+func (m *MockClusterManager) RestoreEKSAResources(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreEKSAResources", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreEKSAResources indicates an expected call of RestoreEKSAResources.
+func (mr *MockClusterManagerMockRecorder) RestoreEKSAResources(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreEKSAResources", reflect.TypeOf((*MockClusterManager)(nil).RestoreEKSAResources), arg0, arg1, arg2)
+}
+
 // ResumeEKSAControllerReconcile mocks base method.
 func (m *MockClusterManager) ResumeEKSAControllerReconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) error {
 	m.ctrl.T.Helper()
@@ -342,6 +413,20 @@ func (mr *MockClusterManagerMockRecorder) ResumeEKSAControllerReconcile(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeEKSAControllerReconcile", reflect.TypeOf((*MockClusterManager)(nil).ResumeEKSAControllerReconcile), arg0, arg1, arg2, arg3)
 }
 
+// RollbackCluster mocks base method.
+func (m *MockClusterManager) RollbackCluster(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackCluster", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackCluster indicates an expected call of RollbackCluster.
+func (mr *MockClusterManagerMockRecorder) RollbackCluster(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackCluster", reflect.TypeOf((*MockClusterManager)(nil).RollbackCluster), arg0, arg1, arg2, arg3)
+}
+
 // SaveLogsManagementCluster mocks base method.
 func (m *MockClusterManager) SaveLogsManagementCluster(arg0 context.Context, arg1 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -370,6 +455,20 @@ func (mr *MockClusterManagerMockRecorder) SaveLogsWorkloadCluster(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLogsWorkloadCluster", reflect.TypeOf((*MockClusterManager)(nil).SaveLogsWorkloadCluster), arg0, arg1, arg2, arg3)
 }
 
+// SaveRollbackPoint mocks base method.
+func (m *MockClusterManager) SaveRollbackPoint(arg0 context.Context, arg1, arg2 *types.Cluster, arg3 *cluster.Spec, arg4 providers.Provider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRollbackPoint", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRollbackPoint indicates an expected call of SaveRollbackPoint.
+func (mr *MockClusterManagerMockRecorder) SaveRollbackPoint(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRollbackPoint", reflect.TypeOf((*MockClusterManager)(nil).SaveRollbackPoint), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Upgrade mocks base method.
 func (m *MockClusterManager) Upgrade(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 *cluster.Spec) (*types.ChangeDiff, error) {
 	m.ctrl.T.Helper()
@@ -414,6 +513,20 @@ func (mr *MockClusterManagerMockRecorder) UpgradeNetworking(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeNetworking", reflect.TypeOf((*MockClusterManager)(nil).UpgradeNetworking), arg0, arg1, arg2, arg3)
 }
 
+// ValidateRollback mocks base method.
+func (m *MockClusterManager) ValidateRollback(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateRollback", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateRollback indicates an expected call of ValidateRollback.
+func (mr *MockClusterManagerMockRecorder) ValidateRollback(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateRollback", reflect.TypeOf((*MockClusterManager)(nil).ValidateRollback), arg0, arg1, arg2)
+}
+
 // MockAddonManager is a mock of AddonManager interface.
 type MockAddonManager struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,176 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+type Restore struct {
+	bootstrapper   interfaces.Bootstrapper
+	provider       providers.Provider
+	clusterManager interfaces.ClusterManager
+}
+
+func NewRestore(bootstrapper interfaces.Bootstrapper, provider providers.Provider, clusterManager interfaces.ClusterManager) *Restore {
+	return &Restore{
+		bootstrapper:   bootstrapper,
+		provider:       provider,
+		clusterManager: clusterManager,
+	}
+}
+
+// Run rebuilds the management cluster saved by Backup in directory on a new bootstrap cluster, which takes over
+// the management of the clusters that were managed by it. The bootstrap cluster is not deleted
+func (r *Restore) Run(ctx context.Context, clusterSpec *cluster.Spec, directory string) error {
+	commandContext := &task.CommandContext{
+		Bootstrapper:   r.bootstrapper,
+		Provider:       r.provider,
+		ClusterManager: r.clusterManager,
+		ClusterSpec:    clusterSpec,
+	}
+
+	return task.NewTaskRunner(&restoreSetupTask{directory: directory}).RunTask(ctx, commandContext)
+}
+
+type restoreSetupTask struct {
+	directory string
+}
+
+type restoreBootstrapClusterTask struct {
+	directory string
+}
+
+type restoreCAPITask struct {
+	directory string
+}
+
+type restoreEksaResourcesTask struct {
+	directory string
+}
+
+// restoreSetupTask implementation
+
+func (s *restoreSetupTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Performing provider setup and validations")
+	// The create validations are skipped since the endpoints of the restored clusters are already in use,
+	// only the provider credentials the cluster-api providers need are set up
+	if err := commandContext.Provider.SetupAndValidateDeleteCluster(ctx); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	for _, path := range []string{filepath.Join(s.directory, capiBackupDir), filepath.Join(s.directory, eksaResourcesBackupFile)} {
+		if !validations.FileExists(path) {
+			commandContext.SetError(fmt.Errorf("backup directory %s is missing %s", s.directory, filepath.Base(path)))
+			return nil
+		}
+	}
+
+	return &restoreBootstrapClusterTask{directory: s.directory}
+}
+
+func (s *restoreSetupTask) Name() string {
+	return "restore-setup"
+}
+
+// restoreBootstrapClusterTask implementation
+
+func (s *restoreBootstrapClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Creating new bootstrap cluster")
+	bootstrapOptions, err := commandContext.Provider.BootstrapClusterOpts()
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	bootstrapCluster, err := commandContext.Bootstrapper.CreateBootstrapCluster(ctx, commandContext.ClusterSpec, bootstrapOptions...)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	commandContext.BootstrapCluster = bootstrapCluster
+
+	logger.Info("Installing cluster-api providers on bootstrap cluster")
+	if err = commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, bootstrapCluster, commandContext.Provider); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	logger.Info("Provider specific post-setup")
+	if err = commandContext.Provider.PostBootstrapSetup(ctx, commandContext.ClusterSpec.Cluster, bootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	return &restoreCAPITask{directory: s.directory}
+}
+
+func (s *restoreBootstrapClusterTask) Name() string {
+	return "bootstrap-cluster-init"
+}
+
+// restoreCAPITask implementation
+
+func (s *restoreCAPITask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Restoring cluster-api objects on bootstrap cluster")
+	err := commandContext.ClusterManager.RestoreCAPI(ctx, commandContext.BootstrapCluster, filepath.Join(s.directory, capiBackupDir))
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+	return &restoreEksaResourcesTask{directory: s.directory}
+}
+
+func (s *restoreCAPITask) Name() string {
+	return "capi-restore"
+}
+
+// restoreEksaResourcesTask implementation
+
+func (s *restoreEksaResourcesTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Installing EKS-A custom components (CRD and controller) on bootstrap cluster")
+	err := commandContext.ClusterManager.InstallCustomComponents(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	if err = commandContext.ClusterManager.InstallEksdComponents(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	logger.Info("Installing EKS-A secrets on bootstrap cluster")
+	if err = commandContext.Provider.UpdateSecrets(ctx, commandContext.BootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	logger.Info("Restoring EKS-A resources on bootstrap cluster")
+	resources, err := ioutil.ReadFile(filepath.Join(s.directory, eksaResourcesBackupFile))
+	if err != nil {
+		commandContext.SetError(fmt.Errorf("error reading EKS-A resources backup: %v", err))
+		return nil
+	}
+
+	if err = commandContext.ClusterManager.RestoreEKSAResources(ctx, commandContext.BootstrapCluster, resources); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	logger.MarkSuccess("Management cluster restored!", "kubeconfig", commandContext.BootstrapCluster.KubeconfigFile)
+	return nil
+}
+
+func (s *restoreEksaResourcesTask) Name() string {
+	return "eksa-resources-restore"
+}
@@ -0,0 +1,102 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
+)
+
+type restoreTestSetup struct {
+	*WithT
+	bootstrapper     *mocks.MockBootstrapper
+	clusterManager   *mocks.MockClusterManager
+	provider         *providermocks.MockProvider
+	workflow         *workflows.Restore
+	ctx              context.Context
+	clusterSpec      *cluster.Spec
+	dir              string
+	bootstrapCluster *types.Cluster
+}
+
+func newRestoreTest(t *testing.T) *restoreTestSetup {
+	mockCtrl := gomock.NewController(t)
+	bootstrapper := mocks.NewMockBootstrapper(mockCtrl)
+	clusterManager := mocks.NewMockClusterManager(mockCtrl)
+	provider := providermocks.NewMockProvider(mockCtrl)
+
+	return &restoreTestSetup{
+		WithT:            NewWithT(t),
+		bootstrapper:     bootstrapper,
+		clusterManager:   clusterManager,
+		provider:         provider,
+		workflow:         workflows.NewRestore(bootstrapper, provider, clusterManager),
+		ctx:              context.Background(),
+		clusterSpec:      test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "cluster-name" }),
+		dir:              t.TempDir(),
+		bootstrapCluster: &types.Cluster{Name: "bootstrap", KubeconfigFile: "bootstrap.kubeconfig"},
+	}
+}
+
+func (c *restoreTestSetup) writeBackup() {
+	c.Expect(os.Mkdir(filepath.Join(c.dir, "capi"), os.ModePerm)).To(Succeed())
+	c.Expect(ioutil.WriteFile(filepath.Join(c.dir, "eksa-resources.yaml"), []byte("resources"), 0o644)).To(Succeed())
+}
+
+func (c *restoreTestSetup) expectCreateBootstrap() {
+	opts := []bootstrapper.BootstrapClusterOption{bootstrapper.WithExtraDockerMounts()}
+	gomock.InOrder(
+		c.provider.EXPECT().SetupAndValidateDeleteCluster(c.ctx),
+		c.provider.EXPECT().BootstrapClusterOpts().Return(opts, nil),
+		c.bootstrapper.EXPECT().CreateBootstrapCluster(c.ctx, c.clusterSpec, gomock.Not(gomock.Nil())).Return(c.bootstrapCluster, nil),
+		c.clusterManager.EXPECT().InstallCAPI(c.ctx, c.clusterSpec, c.bootstrapCluster, c.provider),
+		c.provider.EXPECT().PostBootstrapSetup(c.ctx, c.clusterSpec.Cluster, c.bootstrapCluster),
+	)
+}
+
+func TestRestoreRunSuccess(t *testing.T) {
+	test := newRestoreTest(t)
+	test.writeBackup()
+	test.expectCreateBootstrap()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().RestoreCAPI(test.ctx, test.bootstrapCluster, filepath.Join(test.dir, "capi")),
+		test.clusterManager.EXPECT().InstallCustomComponents(test.ctx, test.clusterSpec, test.bootstrapCluster),
+		test.clusterManager.EXPECT().InstallEksdComponents(test.ctx, test.clusterSpec, test.bootstrapCluster),
+		test.provider.EXPECT().UpdateSecrets(test.ctx, test.bootstrapCluster),
+		test.clusterManager.EXPECT().RestoreEKSAResources(test.ctx, test.bootstrapCluster, []byte("resources")),
+	)
+
+	test.Expect(test.workflow.Run(test.ctx, test.clusterSpec, test.dir)).To(Succeed())
+}
+
+func TestRestoreRunMissingBackup(t *testing.T) {
+	test := newRestoreTest(t)
+	test.provider.EXPECT().SetupAndValidateDeleteCluster(test.ctx)
+
+	test.Expect(test.workflow.Run(test.ctx, test.clusterSpec, test.dir)).To(MatchError(ContainSubstring("is missing capi")))
+}
+
+func TestRestoreRunRestoreCAPIError(t *testing.T) {
+	test := newRestoreTest(t)
+	test.writeBackup()
+	test.expectCreateBootstrap()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().RestoreCAPI(test.ctx, test.bootstrapCluster, filepath.Join(test.dir, "capi")).Return(errors.New("move failed")),
+		test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, test.bootstrapCluster),
+	)
+
+	test.Expect(test.workflow.Run(test.ctx, test.clusterSpec, test.dir)).To(MatchError("move failed"))
+}
@@ -0,0 +1,16 @@
+package workflows
+
+import (
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/task"
+)
+
+func resumableTask(name string, tasks []task.Task) (task.Task, error) {
+	for _, t := range tasks {
+		if t.Name() == name {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("task %s from checkpoint can't be resumed", name)
+}
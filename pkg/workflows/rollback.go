@@ -0,0 +1,115 @@
+package workflows
+
+import (
+	"context"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+type Rollback struct {
+	provider       providers.Provider
+	clusterManager interfaces.ClusterManager
+	addonManager   interfaces.AddonManager
+}
+
+func NewRollback(provider providers.Provider, clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager) *Rollback {
+	return &Rollback{
+		provider:       provider,
+		clusterManager: clusterManager,
+		addonManager:   addonManager,
+	}
+}
+
+// Run takes the cluster back to the state saved before its last upgrade and resumes the reconciliation the
+// upgrade paused. clusterSpec is the spec of the failed upgrade, it's used to find the worker node groups
+// the upgrade added
+func (r *Rollback) Run(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster) error {
+	commandContext := &task.CommandContext{
+		Provider:        r.provider,
+		ClusterManager:  r.clusterManager,
+		AddonManager:    r.addonManager,
+		WorkloadCluster: workloadCluster,
+		ClusterSpec:     clusterSpec,
+	}
+
+	if clusterSpec.ManagementCluster != nil {
+		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
+	}
+
+	return task.NewTaskRunner(&validateRollbackTask{}).RunTask(ctx, commandContext)
+}
+
+type validateRollbackTask struct{}
+
+type rollbackClusterTask struct{}
+
+type resumeReconcileAfterRollbackTask struct{}
+
+// validateRollbackTask implementation
+
+func (s *validateRollbackTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Validating cluster can be rolled back")
+	target := getManagementCluster(commandContext)
+	if err := commandContext.ClusterManager.ValidateRollback(ctx, target, commandContext.ClusterSpec.Cluster.Name); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	return &rollbackClusterTask{}
+}
+
+func (s *validateRollbackTask) Name() string {
+	return "rollback-validate"
+}
+
+// rollbackClusterTask implementation
+
+func (s *rollbackClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Rolling back cluster to its pre-upgrade state")
+	target := getManagementCluster(commandContext)
+	if err := commandContext.ClusterManager.RollbackCluster(ctx, target, commandContext.ClusterSpec, commandContext.Provider); err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+	return &resumeReconcileAfterRollbackTask{}
+}
+
+func (s *rollbackClusterTask) Name() string {
+	return "rollback-cluster"
+}
+
+// resumeReconcileAfterRollbackTask implementation
+
+func (s *resumeReconcileAfterRollbackTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	target := getManagementCluster(commandContext)
+
+	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, target, commandContext.ClusterSpec.Cluster.Name)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+	commandContext.CurrentClusterSpec = currentSpec
+
+	logger.Info("Resuming EKS-A controller reconciliation")
+	if err = commandContext.ClusterManager.ResumeEKSAControllerReconcile(ctx, target, currentSpec, commandContext.Provider); err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
+	logger.Info("Resuming Flux kustomization")
+	if err = commandContext.AddonManager.ResumeGitOpsKustomization(ctx, target, currentSpec); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	logger.MarkSuccess("Cluster rolled back!")
+	return nil
+}
+
+func (s *resumeReconcileAfterRollbackTask) Name() string {
+	return "rollback-resume-reconcile"
+}
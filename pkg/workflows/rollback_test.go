@@ -0,0 +1,99 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
+)
+
+type rollbackTestSetup struct {
+	*WithT
+	clusterManager *mocks.MockClusterManager
+	addonManager   *mocks.MockAddonManager
+	provider       *providermocks.MockProvider
+	workflow       *workflows.Rollback
+	ctx            context.Context
+	clusterSpec    *cluster.Spec
+	currentSpec    *cluster.Spec
+	cluster        *types.Cluster
+}
+
+func newRollbackTest(t *testing.T) *rollbackTestSetup {
+	mockCtrl := gomock.NewController(t)
+	clusterManager := mocks.NewMockClusterManager(mockCtrl)
+	addonManager := mocks.NewMockAddonManager(mockCtrl)
+	provider := providermocks.NewMockProvider(mockCtrl)
+
+	return &rollbackTestSetup{
+		WithT:          NewWithT(t),
+		clusterManager: clusterManager,
+		addonManager:   addonManager,
+		provider:       provider,
+		workflow:       workflows.NewRollback(provider, clusterManager, addonManager),
+		ctx:            context.Background(),
+		clusterSpec:    test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "cluster-name" }),
+		currentSpec:    test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "cluster-name" }),
+		cluster:        &types.Cluster{Name: "cluster-name", KubeconfigFile: "cluster-name.kubeconfig"},
+	}
+}
+
+func (c *rollbackTestSetup) expectDiagnostics() {
+	c.clusterManager.EXPECT().SaveLogsManagementCluster(c.ctx, gomock.Any()).AnyTimes()
+	c.clusterManager.EXPECT().SaveLogsWorkloadCluster(c.ctx, c.provider, c.clusterSpec, c.cluster).AnyTimes()
+}
+
+func TestRollbackRunSuccess(t *testing.T) {
+	test := newRollbackTest(t)
+	gomock.InOrder(
+		test.clusterManager.EXPECT().ValidateRollback(test.ctx, test.cluster, "cluster-name"),
+		test.clusterManager.EXPECT().RollbackCluster(test.ctx, test.cluster, test.clusterSpec, test.provider),
+		test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, test.cluster, "cluster-name").Return(test.currentSpec, nil),
+		test.clusterManager.EXPECT().ResumeEKSAControllerReconcile(test.ctx, test.cluster, test.currentSpec, test.provider),
+		test.addonManager.EXPECT().ResumeGitOpsKustomization(test.ctx, test.cluster, test.currentSpec),
+	)
+
+	test.Expect(test.workflow.Run(test.ctx, test.clusterSpec, test.cluster)).To(Succeed())
+}
+
+func TestRollbackRunManagedClusterSuccess(t *testing.T) {
+	test := newRollbackTest(t)
+	management := &types.Cluster{Name: "management", KubeconfigFile: "management.kubeconfig", ExistingManagement: true}
+	test.clusterSpec.ManagementCluster = management
+	gomock.InOrder(
+		test.clusterManager.EXPECT().ValidateRollback(test.ctx, management, "cluster-name"),
+		test.clusterManager.EXPECT().RollbackCluster(test.ctx, management, test.clusterSpec, test.provider),
+		test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, management, "cluster-name").Return(test.currentSpec, nil),
+		test.clusterManager.EXPECT().ResumeEKSAControllerReconcile(test.ctx, management, test.currentSpec, test.provider),
+		test.addonManager.EXPECT().ResumeGitOpsKustomization(test.ctx, management, test.currentSpec),
+	)
+
+	test.Expect(test.workflow.Run(test.ctx, test.clusterSpec, test.cluster)).To(Succeed())
+}
+
+func TestRollbackRunValidationError(t *testing.T) {
+	test := newRollbackTest(t)
+	test.clusterManager.EXPECT().ValidateRollback(test.ctx, test.cluster, "cluster-name").Return(errors.New("etcd was already upgraded"))
+
+	test.Expect(test.workflow.Run(test.ctx, test.clusterSpec, test.cluster)).To(MatchError(ContainSubstring("etcd was already upgraded")))
+}
+
+func TestRollbackRunRollbackClusterError(t *testing.T) {
+	test := newRollbackTest(t)
+	test.expectDiagnostics()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().ValidateRollback(test.ctx, test.cluster, "cluster-name"),
+		test.clusterManager.EXPECT().RollbackCluster(test.ctx, test.cluster, test.clusterSpec, test.provider).Return(errors.New("timed out")),
+	)
+
+	test.Expect(test.workflow.Run(test.ctx, test.clusterSpec, test.cluster)).To(MatchError(ContainSubstring("timed out")))
+}
@@ -23,13 +23,31 @@ type Upgrade struct {
 	writer            filewriter.FileWriter
 	capiManager       interfaces.CAPIManager
 	upgradeChangeDiff *types.ChangeDiff
+	checkpoints       task.CheckpointStore
+	events            task.EventSink
+}
+
+type UpgradeOpt func(*Upgrade)
+
+// WithUpgradeCheckpoints saves the progress of the upgrade tasks so a failed upgrade can be resumed
+func WithUpgradeCheckpoints(store task.CheckpointStore) UpgradeOpt {
+	return func(u *Upgrade) {
+		u.checkpoints = store
+	}
+}
+
+// WithUpgradeTaskEvents emits an event for every task the upgrade workflow runs
+func WithUpgradeTaskEvents(sink task.EventSink) UpgradeOpt {
+	return func(u *Upgrade) {
+		u.events = sink
+	}
 }
 
 func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
 	capiManager interfaces.CAPIManager,
-	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, writer filewriter.FileWriter) *Upgrade {
+	clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, writer filewriter.FileWriter, opts ...UpgradeOpt) *Upgrade {
 	upgradeChangeDiff := types.NewChangeDiff()
-	return &Upgrade{
+	upgrade := &Upgrade{
 		bootstrapper:      bootstrapper,
 		provider:          provider,
 		clusterManager:    clusterManager,
@@ -38,6 +56,10 @@ func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provide
 		capiManager:       capiManager,
 		upgradeChangeDiff: upgradeChangeDiff,
 	}
+	for _, opt := range opts {
+		opt(upgrade)
+	}
+	return upgrade
 }
 
 func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, validator interfaces.Validator, forceCleanup bool) error {
@@ -49,6 +71,39 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, workloadCl
 		}
 	}
 
+	commandContext := c.newCommandContext(clusterSpec, workloadCluster, validator)
+
+	return task.NewTaskRunner(&setupAndValidateTasks{}, c.runnerOpts()...).RunTask(ctx, commandContext)
+}
+
+// Resume continues a failed upgrade from the first task that didn't finish, as recorded in the last checkpoint.
+// The provider setup runs again and the current cluster spec is read back from the cluster, but the upgrade
+// preflight validations are skipped since the cluster is expected to be mid-upgrade
+func (c *Upgrade) Resume(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, validator interfaces.Validator) error {
+	if c.checkpoints == nil {
+		return fmt.Errorf("resuming cluster upgrade requires task checkpoints")
+	}
+	checkpoint, err := c.checkpoints.Load()
+	if err != nil {
+		return err
+	}
+
+	commandContext := c.newCommandContext(clusterSpec, workloadCluster, validator)
+	if err = checkpoint.Restore(commandContext); err != nil {
+		return err
+	}
+
+	nextTask, err := resumableTask(checkpoint.NextTask, upgradeTasks())
+	if err != nil {
+		return err
+	}
+	logger.Info("Resuming cluster upgrade", "task", checkpoint.NextTask)
+
+	opts := append(c.runnerOpts(), task.ResumingFrom(checkpoint))
+	return task.NewTaskRunner(&resumeUpgradeTask{next: nextTask}, opts...).RunTask(ctx, commandContext)
+}
+
+func (c *Upgrade) newCommandContext(clusterSpec *cluster.Spec, workloadCluster *types.Cluster, validator interfaces.Validator) *task.CommandContext {
 	commandContext := &task.CommandContext{
 		Bootstrapper:      c.bootstrapper,
 		Provider:          c.provider,
@@ -66,7 +121,43 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, workloadCl
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&setupAndValidateTasks{}).RunTask(ctx, commandContext)
+	return commandContext
+}
+
+func (c *Upgrade) runnerOpts() []task.TaskRunnerOpt {
+	var opts []task.TaskRunnerOpt
+	if c.checkpoints != nil {
+		opts = append(opts, task.WithCheckpoints(c.checkpoints))
+	}
+	if c.events != nil {
+		opts = append(opts, task.WithEventSink(c.events))
+	}
+	return opts
+}
+
+// upgradeTasks lists the upgrade tasks a run can be resumed at
+func upgradeTasks() []task.Task {
+	return []task.Task{
+		&updateSecrets{},
+		&ensureEtcdCAPIComponentsExistTask{},
+		&upgradeCoreComponents{},
+		&upgradeNeeded{},
+		&pauseEksaAndFluxReconcile{},
+		&createBootstrapClusterTask{},
+		&installCAPITask{},
+		&moveManagementToBootstrapTask{},
+		&upgradeWorkloadClusterTask{},
+		&moveManagementToWorkloadTask{},
+		&upgradeClusterAutoscalerTask{},
+		&updateClusterAndGitResources{},
+		&resumeFluxReconcile{},
+		&writeClusterConfigTask{},
+		&deleteBootstrapClusterTask{},
+	}
+}
+
+type resumeUpgradeTask struct {
+	next task.Task
 }
 
 type setupAndValidateTasks struct{}
@@ -99,12 +190,37 @@ type deleteBootstrapClusterTask struct {
 	*CollectDiagnosticsTask
 }
 
+type upgradeClusterAutoscalerTask struct{}
+
 type updateClusterAndGitResources struct{}
 
 type resumeFluxReconcile struct{}
 
 type writeClusterConfigTask struct{}
 
+func (s *resumeUpgradeTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	target := getManagementCluster(commandContext)
+
+	logger.Info("Performing provider setup")
+	if err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, target, commandContext.ClusterSpec); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, target, commandContext.ClusterSpec.Cluster.Name)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	commandContext.CurrentClusterSpec = currentSpec
+
+	return s.next
+}
+
+func (s *resumeUpgradeTask) Name() string {
+	return "resume-setup"
+}
+
 func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Performing setup and validations")
 	runner := validations.NewRunner()
@@ -334,8 +450,18 @@ func (s *moveManagementToBootstrapTask) Name() string {
 func (s *upgradeWorkloadClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
+	logger.V(3).Info("Saving rollback point")
+	err := commandContext.ClusterManager.SaveRollbackPoint(ctx, commandContext.BootstrapCluster, target, commandContext.CurrentClusterSpec, commandContext.Provider)
+	if err != nil {
+		commandContext.SetError(err)
+		if commandContext.BootstrapCluster.ExistingManagement {
+			return &CollectDiagnosticsTask{}
+		}
+		return &moveManagementToWorkloadTaskAndExit{}
+	}
+
 	logger.Info("Upgrading workload cluster")
-	err := commandContext.ClusterManager.UpgradeCluster(ctx, commandContext.BootstrapCluster, target, commandContext.ClusterSpec, commandContext.Provider)
+	err = commandContext.ClusterManager.UpgradeCluster(ctx, commandContext.BootstrapCluster, target, commandContext.ClusterSpec, commandContext.Provider)
 	if err != nil {
 		commandContext.SetError(err)
 		if commandContext.BootstrapCluster.ExistingManagement {
@@ -360,7 +486,7 @@ func (s *upgradeWorkloadClusterTask) Name() string {
 
 func (s *moveManagementToWorkloadTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.BootstrapCluster.ExistingManagement {
-		return &updateClusterAndGitResources{}
+		return &upgradeClusterAutoscalerTask{}
 	}
 	logger.Info("Moving cluster management from bootstrap to workload cluster")
 	err := commandContext.ClusterManager.MoveCAPI(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef(), types.WithNodeHealthy())
@@ -368,7 +494,7 @@ func (s *moveManagementToWorkloadTask) Run(ctx context.Context, commandContext *
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
-	return &updateClusterAndGitResources{}
+	return &upgradeClusterAutoscalerTask{}
 }
 
 func (s *moveManagementToWorkloadTaskAndExit) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -380,6 +506,20 @@ func (s *moveManagementToWorkloadTask) Name() string {
 	return "capi-management-move-to-workload"
 }
 
+func (s *upgradeClusterAutoscalerTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.V(4).Info("Upgrading cluster-autoscaler on management cluster")
+	err := commandContext.ClusterManager.InstallClusterAutoscaler(ctx, getManagementCluster(commandContext), commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+	return &updateClusterAndGitResources{}
+}
+
+func (s *upgradeClusterAutoscalerTask) Name() string {
+	return "cluster-autoscaler-upgrade"
+}
+
 func (s *updateClusterAndGitResources) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
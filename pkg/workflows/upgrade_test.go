@@ -186,6 +186,9 @@ func (c *upgradeTestSetup) expectUpgradeWorkload(expectedCluster *types.Cluster)
 
 func (c *upgradeTestSetup) expectUpgradeWorkloadToReturn(expectedCluster *types.Cluster, err error) {
 	gomock.InOrder(
+		c.clusterManager.EXPECT().SaveRollbackPoint(
+			c.ctx, c.bootstrapCluster, expectedCluster, c.currentClusterSpec, c.provider,
+		),
 		c.clusterManager.EXPECT().UpgradeCluster(
 			c.ctx, c.bootstrapCluster, expectedCluster, c.newClusterSpec, c.provider,
 		).Return(err),
@@ -216,6 +219,10 @@ func (c *upgradeTestSetup) expectNotToMoveManagementToWorkload() {
 	c.clusterManager.EXPECT().MoveCAPI(c.ctx, c.bootstrapCluster, c.workloadCluster, gomock.Any(), c.newClusterSpec, gomock.Any()).Times(0)
 }
 
+func (c *upgradeTestSetup) expectInstallClusterAutoscaler(expectedCluster *types.Cluster) {
+	c.clusterManager.EXPECT().InstallClusterAutoscaler(c.ctx, expectedCluster, c.newClusterSpec)
+}
+
 func (c *upgradeTestSetup) expectPauseEKSAControllerReconcile(expectedCluster *types.Cluster) {
 	gomock.InOrder(
 		c.clusterManager.EXPECT().PauseEKSAControllerReconcile(
@@ -293,11 +300,8 @@ func (c *upgradeTestSetup) expectVerifyClusterSpecChanged(expectedCluster *types
 }
 
 func (c *upgradeTestSetup) expectSaveLogs(expectedWorkloadCluster *types.Cluster) {
-	gomock.InOrder(
-
-		c.clusterManager.EXPECT().SaveLogsManagementCluster(c.ctx, c.bootstrapCluster).Return(nil),
-		c.clusterManager.EXPECT().SaveLogsWorkloadCluster(c.ctx, c.provider, c.newClusterSpec, expectedWorkloadCluster),
-	)
+	c.clusterManager.EXPECT().SaveLogsManagementCluster(c.ctx, c.bootstrapCluster).Return(nil)
+	c.clusterManager.EXPECT().SaveLogsWorkloadCluster(c.ctx, c.provider, c.newClusterSpec, expectedWorkloadCluster)
 }
 
 func (c *upgradeTestSetup) run() error {
@@ -373,6 +377,7 @@ func TestUpgradeRunSuccess(t *testing.T) {
 	test.expectMoveManagementToBootstrap()
 	test.expectUpgradeWorkload(test.workloadCluster)
 	test.expectMoveManagementToWorkload()
+	test.expectInstallClusterAutoscaler(test.workloadCluster)
 	test.expectWriteClusterConfig()
 	test.expectDeleteBootstrap()
 	test.expectDatacenterConfig()
@@ -403,6 +408,7 @@ func TestUpgradeRunProviderNeedsUpgradeSuccess(t *testing.T) {
 	test.expectMoveManagementToBootstrap()
 	test.expectUpgradeWorkload(test.workloadCluster)
 	test.expectMoveManagementToWorkload()
+	test.expectInstallClusterAutoscaler(test.workloadCluster)
 	test.expectWriteClusterConfig()
 	test.expectDeleteBootstrap()
 	test.expectDatacenterConfig()
@@ -470,6 +476,7 @@ func TestUpgradeWorkloadRunSuccess(t *testing.T) {
 	test.expectNotToCreateBootstrap()
 	test.expectNotToMoveManagementToBootstrap()
 	test.expectNotToMoveManagementToWorkload()
+	test.expectInstallClusterAutoscaler(test.bootstrapCluster)
 	test.expectWriteClusterConfig()
 	test.expectNotToDeleteBootstrap()
 	test.expectDatacenterConfig()
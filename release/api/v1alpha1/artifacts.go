@@ -47,6 +47,9 @@ func (vb *VersionsBundle) Manifests() map[string][]*string {
 		"kindnetd": {
 			&vb.Kindnetd.Manifest.URI,
 		},
+		"calico": {
+			&vb.Calico.Manifest.URI,
+		},
 		"eks-anywhere-cluster-controller": {
 			&vb.Eksa.Components.URI,
 		},
@@ -78,6 +81,14 @@ func (vb *VersionsBundle) CloudStackImages() []Image {
 	}
 }
 
+func (vb *VersionsBundle) CalicoImages() []Image {
+	return []Image{
+		vb.Calico.Cni,
+		vb.Calico.KubeControllers,
+		vb.Calico.Node,
+	}
+}
+
 func (vb *VersionsBundle) VsphereImages() []Image {
 	return []Image{
 		vb.VSphere.ClusterAPIController,
@@ -124,6 +135,7 @@ func (vb *VersionsBundle) SharedImages() []Image {
 		vb.ExternalEtcdController.Controller,
 		vb.ExternalEtcdController.KubeProxy,
 		vb.Haproxy.Image,
+		vb.ClusterAutoscaler.Image,
 	}
 }
 
@@ -132,12 +144,14 @@ func (vb *VersionsBundle) Images() []Image {
 	docker := vb.DockerImages()
 	vsphere := vb.VsphereImages()
 	cloudstack := vb.CloudStackImages()
+	calico := vb.CalicoImages()
 
-	images := make([]Image, 0, len(shared)+len(docker)+len(vsphere)+len(cloudstack))
+	images := make([]Image, 0, len(shared)+len(docker)+len(vsphere)+len(cloudstack)+len(calico))
 	images = append(images, shared...)
 	images = append(images, docker...)
 	images = append(images, vsphere...)
 	images = append(images, cloudstack...)
+	images = append(images, calico...)
 
 	return images
 }
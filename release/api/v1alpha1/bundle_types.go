@@ -71,6 +71,7 @@ type VersionsBundle struct {
 	Eksa                   EksaBundle                  `json:"eksa"`
 	Cilium                 CiliumBundle                `json:"cilium"`
 	Kindnetd               KindnetdBundle              `json:"kindnetd"`
+	Calico                 CalicoBundle                `json:"calico,omitempty"`
 	Flux                   FluxBundle                  `json:"flux"`
 	BottleRocketBootstrap  BottlerocketBootstrapBundle `json:"bottlerocketBootstrap"`
 	BottleRocketAdmin      BottlerocketAdminBundle     `json:"bottlerocketAdmin"`
@@ -79,6 +80,7 @@ type VersionsBundle struct {
 	Tinkerbell             TinkerbellBundle            `json:"tinkerbell,omitempty"`
 	Haproxy                HaproxyBundle               `json:"haproxy,omitempty"`
 	Snow                   SnowBundle                  `json:"snow,omitempty"`
+	ClusterAutoscaler      ClusterAutoscalerBundle     `json:"clusterAutoscaler,omitempty"`
 }
 
 type EksDRelease struct {
@@ -218,6 +220,14 @@ type KindnetdBundle struct {
 	Manifest Manifest `json:"manifest"`
 }
 
+type CalicoBundle struct {
+	Version         string   `json:"version,omitempty"`
+	Node            Image    `json:"node"`
+	Cni             Image    `json:"cni"`
+	KubeControllers Image    `json:"kubeControllers"`
+	Manifest        Manifest `json:"manifest"`
+}
+
 type FluxBundle struct {
 	Version                string `json:"version,omitempty"`
 	SourceController       Image  `json:"sourceController"`
@@ -295,10 +305,17 @@ type HaproxyBundle struct {
 	Image Image `json:"image"`
 }
 
+type ClusterAutoscalerBundle struct {
+	Version string `json:"version,omitempty"`
+	Image   Image  `json:"image"`
+}
+
 type SnowBundle struct {
 	Version    string   `json:"version"`
 	Manager    Image    `json:"manager"`
 	KubeVip    Image    `json:"kubeVip"`
 	Components Manifest `json:"components"`
 	Metadata   Manifest `json:"metadata"`
+	// LocalPathProvisioner is the image of the provisioner installed for the storage of snow clusters
+	LocalPathProvisioner Image `json:"localPathProvisioner,omitempty"`
 }
@@ -227,6 +227,25 @@ func (in *BundlesStatus) DeepCopy() *BundlesStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalicoBundle) DeepCopyInto(out *CalicoBundle) {
+	*out = *in
+	in.Node.DeepCopyInto(&out.Node)
+	in.Cni.DeepCopyInto(&out.Cni)
+	in.KubeControllers.DeepCopyInto(&out.KubeControllers)
+	out.Manifest = in.Manifest
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoBundle.
+func (in *CalicoBundle) DeepCopy() *CalicoBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(CalicoBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertManagerBundle) DeepCopyInto(out *CertManagerBundle) {
 	*out = *in
@@ -284,6 +303,22 @@ func (in *CloudStackBundle) DeepCopy() *CloudStackBundle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalerBundle) DeepCopyInto(out *ClusterAutoscalerBundle) {
+	*out = *in
+	in.Image.DeepCopyInto(&out.Image)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerBundle.
+func (in *ClusterAutoscalerBundle) DeepCopy() *ClusterAutoscalerBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscalerBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoreClusterAPI) DeepCopyInto(out *CoreClusterAPI) {
 	*out = *in
@@ -713,6 +748,7 @@ func (in *SnowBundle) DeepCopyInto(out *SnowBundle) {
 	in.KubeVip.DeepCopyInto(&out.KubeVip)
 	out.Components = in.Components
 	out.Metadata = in.Metadata
+	in.LocalPathProvisioner.DeepCopyInto(&out.LocalPathProvisioner)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowBundle.
@@ -793,6 +829,7 @@ func (in *VersionsBundle) DeepCopyInto(out *VersionsBundle) {
 	in.Eksa.DeepCopyInto(&out.Eksa)
 	in.Cilium.DeepCopyInto(&out.Cilium)
 	out.Kindnetd = in.Kindnetd
+	in.Calico.DeepCopyInto(&out.Calico)
 	in.Flux.DeepCopyInto(&out.Flux)
 	in.BottleRocketBootstrap.DeepCopyInto(&out.BottleRocketBootstrap)
 	in.BottleRocketAdmin.DeepCopyInto(&out.BottleRocketAdmin)
@@ -801,6 +838,7 @@ func (in *VersionsBundle) DeepCopyInto(out *VersionsBundle) {
 	in.Tinkerbell.DeepCopyInto(&out.Tinkerbell)
 	in.Haproxy.DeepCopyInto(&out.Haproxy)
 	in.Snow.DeepCopyInto(&out.Snow)
+	in.ClusterAutoscaler.DeepCopyInto(&out.ClusterAutoscaler)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionsBundle.
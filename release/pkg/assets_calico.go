@@ -0,0 +1,183 @@
+// Copyright 2021 Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	anywherev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const calicoProjectPath = "projects/projectcalico/calico"
+
+// GetCalicoAssets returns the eks-a artifacts for Calico
+func (r *ReleaseConfig) GetCalicoAssets() ([]Artifact, error) {
+	gitTag, err := r.readGitTag(calicoProjectPath, r.BuildRepoBranchName)
+	if err != nil {
+		return nil, errors.Cause(err)
+	}
+
+	calicoImages := []string{
+		"cni",
+		"kube-controllers",
+		"node",
+	}
+
+	artifacts := []Artifact{}
+	for _, component := range calicoImages {
+		image := fmt.Sprintf("calico-%s", component)
+		repoName := fmt.Sprintf("projectcalico/%s", component)
+		tagOptions := map[string]string{
+			"gitTag":      gitTag,
+			"projectPath": calicoProjectPath,
+		}
+
+		sourceImageUri, sourcedFromBranch, err := r.GetSourceImageURI(image, repoName, tagOptions)
+		if err != nil {
+			return nil, errors.Cause(err)
+		}
+		if sourcedFromBranch != r.BuildRepoBranchName {
+			gitTag, err = r.readGitTag(calicoProjectPath, sourcedFromBranch)
+			if err != nil {
+				return nil, errors.Cause(err)
+			}
+			tagOptions["gitTag"] = gitTag
+		}
+		releaseImageUri, err := r.GetReleaseImageURI(image, repoName, tagOptions)
+		if err != nil {
+			return nil, errors.Cause(err)
+		}
+
+		imageArtifact := &ImageArtifact{
+			AssetName:         image,
+			SourceImageURI:    sourceImageUri,
+			ReleaseImageURI:   releaseImageUri,
+			Arch:              []string{"amd64"},
+			OS:                "linux",
+			GitTag:            gitTag,
+			ProjectPath:       calicoProjectPath,
+			SourcedFromBranch: sourcedFromBranch,
+		}
+		artifacts = append(artifacts, Artifact{Image: imageArtifact})
+	}
+
+	manifestName := "calico.yaml"
+
+	var sourceS3Prefix string
+	var releaseS3Path string
+	sourcedFromBranch := r.BuildRepoBranchName
+	latestPath := getLatestUploadDestination(sourcedFromBranch)
+
+	if r.DevRelease || r.ReleaseEnvironment == "development" {
+		sourceS3Prefix = fmt.Sprintf("%s/%s/manifests/calico/%s", calicoProjectPath, latestPath, gitTag)
+	} else {
+		sourceS3Prefix = fmt.Sprintf("releases/bundles/%d/artifacts/calico/manifests/calico/%s", r.BundleNumber, gitTag)
+	}
+
+	if r.DevRelease {
+		releaseS3Path = fmt.Sprintf("artifacts/%s/calico/manifests/calico/%s", r.DevReleaseUriVersion, gitTag)
+	} else {
+		releaseS3Path = fmt.Sprintf("releases/bundles/%d/artifacts/calico/manifests/calico/%s", r.BundleNumber, gitTag)
+	}
+
+	cdnURI, err := r.GetURI(filepath.Join(
+		releaseS3Path,
+		manifestName))
+	if err != nil {
+		return nil, errors.Cause(err)
+	}
+
+	manifestArtifact := &ManifestArtifact{
+		SourceS3Key:       manifestName,
+		SourceS3Prefix:    sourceS3Prefix,
+		ArtifactPath:      filepath.Join(r.ArtifactDir, "calico-manifests", r.BuildRepoHead),
+		ReleaseName:       manifestName,
+		ReleaseS3Path:     releaseS3Path,
+		ReleaseCdnURI:     cdnURI,
+		ImageTagOverrides: []ImageTagOverride{},
+		GitTag:            gitTag,
+		ProjectPath:       calicoProjectPath,
+		SourcedFromBranch: sourcedFromBranch,
+	}
+	artifacts = append(artifacts, Artifact{Manifest: manifestArtifact})
+
+	return artifacts, nil
+}
+
+func (r *ReleaseConfig) GetCalicoBundle(imageDigests map[string]string) (anywherev1alpha1.CalicoBundle, error) {
+	artifacts := r.BundleArtifactsTable["calico"]
+
+	var sourceBranch string
+	bundleImageArtifacts := map[string]anywherev1alpha1.Image{}
+	bundleManifestArtifacts := map[string]anywherev1alpha1.Manifest{}
+	artifactHashes := []string{}
+
+	for _, artifact := range artifacts {
+		if artifact.Image != nil {
+			imageArtifact := artifact.Image
+			sourceBranch = imageArtifact.SourcedFromBranch
+
+			bundleArtifact := anywherev1alpha1.Image{
+				Name:        imageArtifact.AssetName,
+				Description: fmt.Sprintf("Container image for %s image", imageArtifact.AssetName),
+				OS:          imageArtifact.OS,
+				Arch:        imageArtifact.Arch,
+				URI:         imageArtifact.ReleaseImageURI,
+				ImageDigest: imageDigests[imageArtifact.ReleaseImageURI],
+			}
+
+			bundleImageArtifacts[imageArtifact.AssetName] = bundleArtifact
+			artifactHashes = append(artifactHashes, bundleArtifact.ImageDigest)
+		}
+		if artifact.Manifest != nil {
+			manifestArtifact := artifact.Manifest
+			bundleManifestArtifact := anywherev1alpha1.Manifest{
+				URI: manifestArtifact.ReleaseCdnURI,
+			}
+
+			bundleManifestArtifacts[manifestArtifact.ReleaseName] = bundleManifestArtifact
+
+			manifestContents, err := ioutil.ReadFile(filepath.Join(manifestArtifact.ArtifactPath, manifestArtifact.ReleaseName))
+			if err != nil {
+				return anywherev1alpha1.CalicoBundle{}, err
+			}
+			manifestHash := generateManifestHash(manifestContents)
+			artifactHashes = append(artifactHashes, manifestHash)
+		}
+	}
+
+	componentChecksum := generateComponentHash(artifactHashes)
+	version, err := BuildComponentVersion(
+		newVersionerWithGITTAG(r.BuildRepoSource, calicoProjectPath, sourceBranch, r),
+		componentChecksum,
+	)
+	if err != nil {
+		return anywherev1alpha1.CalicoBundle{}, errors.Wrapf(err, "Error getting version for calico")
+	}
+
+	bundle := anywherev1alpha1.CalicoBundle{
+		Version:         version,
+		Cni:             bundleImageArtifacts["calico-cni"],
+		KubeControllers: bundleImageArtifacts["calico-kube-controllers"],
+		Node:            bundleImageArtifacts["calico-node"],
+		Manifest:        bundleManifestArtifacts["calico.yaml"],
+	}
+
+	return bundle, nil
+}
@@ -129,6 +129,7 @@ func (r *ReleaseConfig) GetSnowBundle(imageDigests map[string]string) (anywherev
 		"cluster-api-provider-aws-snow": r.BundleArtifactsTable["cluster-api-provider-aws-snow"],
 		"kube-rbac-proxy":               r.BundleArtifactsTable["kube-rbac-proxy"],
 		"kube-vip":                      r.BundleArtifactsTable["kube-vip"],
+		"local-path-provisioner":        r.BundleArtifactsTable["local-path-provisioner"],
 	}
 	sortedComponentNames := sortArtifactsMap(capasBundleArtifacts)
 
@@ -185,11 +186,12 @@ func (r *ReleaseConfig) GetSnowBundle(imageDigests map[string]string) (anywherev
 	}
 
 	bundle := anywherev1alpha1.SnowBundle{
-		Version:    version,
-		Manager:    bundleImageArtifacts["cluster-api-snow-controller"],
-		KubeVip:    bundleImageArtifacts["kube-vip"],
-		Components: bundleManifestArtifacts["infrastructure-components.yaml"],
-		Metadata:   bundleManifestArtifacts["metadata.yaml"],
+		Version:              version,
+		Manager:              bundleImageArtifacts["cluster-api-snow-controller"],
+		KubeVip:              bundleImageArtifacts["kube-vip"],
+		Components:           bundleManifestArtifacts["infrastructure-components.yaml"],
+		Metadata:             bundleManifestArtifacts["metadata.yaml"],
+		LocalPathProvisioner: bundleImageArtifacts["local-path-provisioner"],
 	}
 
 	return bundle, nil
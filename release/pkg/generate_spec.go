@@ -161,11 +161,17 @@ func (r *ReleaseConfig) GetVersionsBundles(imageDigests map[string]string) ([]an
 	}
 
 	var cloudStackBundle anywherev1alpha1.CloudStackBundle
+	var calicoBundle anywherev1alpha1.CalicoBundle
 	if r.DevRelease && r.BuildRepoBranchName == "main" {
 		cloudStackBundle, err = r.GetCloudStackBundle(imageDigests)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Error getting bundle for CloudStack infrastructure provider")
 		}
+
+		calicoBundle, err = r.GetCalicoBundle(imageDigests)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error getting bundle for Calico")
+		}
 	}
 
 	eksDReleaseMap, err := readEksDReleases(r)
@@ -204,6 +210,11 @@ func (r *ReleaseConfig) GetVersionsBundles(imageDigests map[string]string) ([]an
 			return nil, errors.Wrapf(err, "Error getting bundle for bottlerocket bootstrap")
 		}
 
+		clusterAutoscalerBundle, err := r.GetClusterAutoscalerBundle(channel, imageDigests)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error getting bundle for cluster-autoscaler")
+		}
+
 		versionsBundle := anywherev1alpha1.VersionsBundle{
 			KubeVersion:            shortKubeVersion,
 			EksD:                   eksDReleaseBundle,
@@ -218,6 +229,7 @@ func (r *ReleaseConfig) GetVersionsBundles(imageDigests map[string]string) ([]an
 			Eksa:                   eksaBundle,
 			Cilium:                 ciliumBundle,
 			Kindnetd:               kindnetdBundle,
+			Calico:                 calicoBundle,
 			Flux:                   fluxBundle,
 			ExternalEtcdBootstrap:  etcdadmBootstrapBundle,
 			ExternalEtcdController: etcdadmControllerBundle,
@@ -226,6 +238,7 @@ func (r *ReleaseConfig) GetVersionsBundles(imageDigests map[string]string) ([]an
 			Tinkerbell:             tinkerbellBundle,
 			Haproxy:                haproxyBundle,
 			Snow:                   snowBundle,
+			ClusterAutoscaler:      clusterAutoscalerBundle,
 		}
 		versionsBundles = append(versionsBundles, versionsBundle)
 	}
@@ -288,6 +301,7 @@ func (r *ReleaseConfig) GenerateBundleArtifactsTable() (map[string][]Artifact, e
 		eksAArtifactsFuncs["hub"] = r.GetHubAssets
 		eksAArtifactsFuncs["cluster-api-provider-aws-snow"] = r.GetCapasAssets
 		eksAArtifactsFuncs["hook"] = r.GetHookAssets
+		eksAArtifactsFuncs["calico"] = r.GetCalicoAssets
 	}
 
 	for componentName, artifactFunc := range eksAArtifactsFuncs {
@@ -333,6 +347,11 @@ func (r *ReleaseConfig) GenerateBundleArtifactsTable() (map[string][]Artifact, e
 			return nil, errors.Wrapf(err, "Error getting artifact information for %s", channel)
 		}
 
+		clusterAutoscalerArtifacts, err := r.GetClusterAutoscalerAssets(channel)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error getting artifact information for %s", channel)
+		}
+
 		eksDComponentName := fmt.Sprintf("eks-d-%s", channel)
 		artifactsTable[eksDComponentName] = eksDChannelArtifacts
 
@@ -341,6 +360,9 @@ func (r *ReleaseConfig) GenerateBundleArtifactsTable() (map[string][]Artifact, e
 
 		bottlerocketBootstrapComponentName := fmt.Sprintf("bottlerocket-bootstrap-%s-%s", channel, number)
 		artifactsTable[bottlerocketBootstrapComponentName] = bottlerocketBootstrapArtifacts
+
+		clusterAutoscalerComponentName := fmt.Sprintf("cluster-autoscaler-%s", channel)
+		artifactsTable[clusterAutoscalerComponentName] = clusterAutoscalerArtifacts
 	}
 
 	fmt.Printf("%s Successfully generated bundle artifacts table\n", SuccessIcon)
@@ -389,7 +411,7 @@ func (r *ReleaseConfig) GetSourceImageURI(name, repoName string, tagOptions map[
 				tagOptions["eksDReleaseNumber"],
 				latestTag,
 			)
-		} else if name == "cloud-provider-vsphere" {
+		} else if name == "cloud-provider-vsphere" || name == "cluster-autoscaler" {
 			sourceImageUri = fmt.Sprintf("%s/%s:%s-%s",
 				r.SourceContainerRegistry,
 				repoName,
@@ -443,7 +465,7 @@ func (r *ReleaseConfig) GetSourceImageURI(name, repoName string, tagOptions map[
 				tagOptions["eksDReleaseNumber"],
 				r.BundleNumber,
 			)
-		} else if name == "cloud-provider-vsphere" {
+		} else if name == "cloud-provider-vsphere" || name == "cluster-autoscaler" {
 			sourceImageUri = fmt.Sprintf("%s/%s:%s-eks-d-%s-eks-a-%d",
 				r.SourceContainerRegistry,
 				repoName,
@@ -497,7 +519,7 @@ func (r *ReleaseConfig) GetReleaseImageURI(name, repoName string, tagOptions map
 			tagOptions["eksDReleaseChannel"],
 			tagOptions["eksDReleaseNumber"],
 		)
-	} else if name == "cloud-provider-vsphere" {
+	} else if name == "cloud-provider-vsphere" || name == "cluster-autoscaler" {
 		releaseImageUri = fmt.Sprintf("%s/%s:%s-eks-d-%s-eks-a",
 			r.ReleaseContainerRegistry,
 			repoName,
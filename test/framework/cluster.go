@@ -22,7 +22,9 @@ import (
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/pbnj"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/redfish"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/semver"
 	"github.com/aws/eks-anywhere/pkg/templater"
@@ -216,12 +218,13 @@ func (e *ClusterE2ETest) PowerOffHardware() {
 	if err != nil {
 		e.T.Fatalf("failed to create pbnj client: %v", err)
 	}
+	bmcClient := bmc.NewVendorClient(pbnjClient, redfish.NewRedfishClient())
 
 	ctx := context.Background()
 
 	for _, h := range e.TestHardware {
 		bmcInfo := api.NewBmcSecretConfig(h)
-		err := pbnjClient.PowerOff(ctx, bmcInfo)
+		err := bmcClient.PowerOff(ctx, bmcInfo)
 		if err != nil {
 			e.T.Fatalf("failed to power off hardware: %v", err)
 		}
@@ -234,6 +237,7 @@ func (e *ClusterE2ETest) ValidateHardwareDecommissioned() {
 	if err != nil {
 		e.T.Fatalf("failed to create pbnj client: %v", err)
 	}
+	bmcClient := bmc.NewVendorClient(pbnjClient, redfish.NewRedfishClient())
 
 	ctx := context.Background()
 
@@ -241,7 +245,7 @@ func (e *ClusterE2ETest) ValidateHardwareDecommissioned() {
 	for _, h := range e.TestHardware {
 		bmcInfo := api.NewBmcSecretConfig(h)
 
-		powerState, err := pbnjClient.GetPowerState(ctx, bmcInfo)
+		powerState, err := bmcClient.GetPowerState(ctx, bmcInfo)
 		if err != nil {
 			e.T.Logf("failed to get power state for hardware (%v): %v", h, err)
 		}